@@ -0,0 +1,53 @@
+package game
+
+// Accessibility holds the options for players who are sensitive to motion and
+// flashing, or who need clearer outlines. Effects should ask it whether they
+// are allowed rather than hard-coding their visuals, so new effects
+// automatically respect the player's choices.
+type Accessibility struct {
+	// ReducedMotion disables trails, screen shake, knockback and flashing
+	// color fades
+	ReducedMotion bool `json:"reduced_motion"`
+	// HighContrast draws every line 1px wider
+	HighContrast bool `json:"high_contrast"`
+}
+
+// Trails reports whether ghosting trails may be drawn
+func (a Accessibility) Trails() bool {
+	return !a.ReducedMotion
+}
+
+// ScreenShake reports whether the screen may be shaken
+func (a Accessibility) ScreenShake() bool {
+	return !a.ReducedMotion
+}
+
+// Knockback reports whether explosions may push the ship about
+func (a Accessibility) Knockback() bool {
+	return !a.ReducedMotion
+}
+
+// Flashing reports whether objects may flash or pulse between colors
+func (a Accessibility) Flashing() bool {
+	return !a.ReducedMotion
+}
+
+// LineWidthBoost returns the extra width to add to every drawn line
+func (a Accessibility) LineWidthBoost() float32 {
+	if a.HighContrast {
+		return 1
+	}
+	return 0
+}
+
+// applyAccessibility updates the long-lived drawing state, such as the fonts,
+// to match the current accessibility settings
+func (g *Game) applyAccessibility() {
+	boost := g.config.Accessibility.LineWidthBoost()
+	g.vectorFont.SetLineWidth(3 + boost)
+	g.titleFont.SetLineWidth(4 + boost)
+	g.feedFont.SetLineWidth(1.5 + boost)
+	if !g.config.Accessibility.Trails() {
+		g.dropPhosphorGhost()
+	}
+}
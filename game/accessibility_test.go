@@ -0,0 +1,69 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestAccessibilityReducedMotionFragments(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Accessibility.ReducedMotion = true
+	g := New(WithConfig(cfg))
+	g.entities = []Entity{g.player}
+
+	g.splitAsteroid(placeAsteroid(g, 40, 100, 100), OwnerPlayer)
+
+	for _, e := range g.entities {
+		fragment, ok := e.(*Asteroid)
+		if !ok || fragment.Dead() {
+			continue
+		}
+		if fragment.polygon.IsFading {
+			t.Errorf("Expected no color pulse on fragments with reduced motion")
+		}
+		if fragment.freshTicks == 0 {
+			t.Errorf("Expected fragments to be marked fresh with a thicker outline")
+		}
+	}
+}
+
+func TestAccessibilityDefaultFragmentsPulse(t *testing.T) {
+	g := New()
+	g.entities = []Entity{g.player}
+
+	g.splitAsteroid(placeAsteroid(g, 40, 100, 100), OwnerPlayer)
+
+	for _, e := range g.entities {
+		fragment, ok := e.(*Asteroid)
+		if !ok || fragment.Dead() {
+			continue
+		}
+		if !fragment.polygon.IsFading {
+			t.Errorf("Expected fragments to pulse by default")
+		}
+		if fragment.freshTicks != 0 {
+			t.Errorf("Expected no outline change by default")
+		}
+	}
+}
+
+func TestAccessibilityReducedMotionNoDeathFlash(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Accessibility.ReducedMotion = true
+	g := New(WithConfig(cfg))
+
+	g.emit(PlayerHit{})
+	g.dispatchEvents()
+
+	if g.player.polygon.IsFading {
+		t.Errorf("Expected no death flash with reduced motion")
+	}
+}
+
+func TestAccessibilityLineWidthBoost(t *testing.T) {
+	if got := (Accessibility{}).LineWidthBoost(); got != 0 {
+		t.Errorf("Expected no boost by default, got %v", got)
+	}
+	if got := (Accessibility{HighContrast: true}).LineWidthBoost(); got != 1 {
+		t.Errorf("Expected 1px boost with high contrast, got %v", got)
+	}
+}
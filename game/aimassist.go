@@ -0,0 +1,56 @@
+package game
+
+import (
+	"math"
+	"slices"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// aimAssistLevels are the aim assist strengths offered on the settings
+// screen, as the largest angle in degrees a shot is snapped by. 0 is off.
+var aimAssistLevels = []int{0, 3, 6, 10}
+
+// nextAimAssist returns the aim assist strength after current on the
+// settings screen
+func nextAimAssist(current int) int {
+	i := slices.Index(aimAssistLevels, current)
+	return aimAssistLevels[(i+1)%len(aimAssistLevels)]
+}
+
+// aimAssist returns the largest angle, in radians, shots are snapped by this
+// run. Both players of a network game have to agree, so it is off there.
+func (g *Game) aimAssist() float64 {
+	if g.net != nil {
+		return 0
+	}
+	return float64(g.config.AimAssist) * math.Pi / 180
+}
+
+// assistAim returns the direction to fire a shot from origin that the ship
+// is aiming along angle. With aim assist on, a shot within the assist angle
+// of the shortest line to an asteroid's centroid, which may cross a screen
+// edge, is snapped onto the closest such line.
+func (g *Game) assistAim(origin geometry.Vector2, angle float64) float64 {
+	limit := g.aimAssist()
+	if limit == 0 {
+		return angle
+	}
+	aim, closest := angle, math.Inf(1)
+	for _, e := range g.entities {
+		a, ok := e.(*Asteroid)
+		if !ok || a.Dead() {
+			continue
+		}
+		delta := geometry.WrapDelta(origin, centerOf(a.polygon), g.screenWidth, g.screenHeight)
+		if delta == (geometry.Vector2{}) {
+			continue
+		}
+		// Angles are measured clockwise from straight up the screen
+		target := math.Atan2(delta.X, -delta.Y)
+		if off := math.Abs(geometry.AngleDifference(angle, target)); off <= limit && off < closest {
+			aim, closest = target, off
+		}
+	}
+	return aim
+}
@@ -0,0 +1,113 @@
+package game
+
+import (
+	"math"
+	"testing"
+)
+
+// newAimAssistGame creates a game with aim assist of the given strength and
+// the ship alone in the middle of the screen, facing straight up
+func newAimAssistGame(degrees int) *Game {
+	cfg := DefaultConfig()
+	cfg.AimAssist = degrees
+	g := New(WithConfig(cfg))
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+	g.player.polygon.SetRotation(0)
+	return g
+}
+
+// firedAngle fires the ship's gun and returns the direction the bullet
+// set off in, measured clockwise from straight up
+func firedAngle(t *testing.T, g *Game) float64 {
+	t.Helper()
+	g.createBullet(g.player)
+	for _, e := range g.entities {
+		if b, ok := e.(*Bullet); ok {
+			return math.Atan2(b.polygon.Velocity.X, -b.polygon.Velocity.Y)
+		}
+	}
+	t.Fatalf("Expected a bullet to be fired")
+	return 0
+}
+
+// placeAsteroidAt places an asteroid distance pixels from the ship's nose,
+// angle degrees clockwise from straight up
+func placeAsteroidAt(g *Game, angle, distance float64) *Asteroid {
+	nose := g.player.nose()
+	sin, cos := math.Sincos(angle * math.Pi / 180)
+	return placeAsteroid(g, 10, nose.X+sin*distance, nose.Y-cos*distance)
+}
+
+func TestAimAssistSnapsToAsteroid(t *testing.T) {
+	g := newAimAssistGame(6)
+	placeAsteroidAt(g, 4, 200)
+
+	if got := firedAngle(t, g); math.Abs(got-4*math.Pi/180) > 1e-6 {
+		t.Errorf("Expected the shot to be snapped onto the asteroid at 4 degrees, got %v", got*180/math.Pi)
+	}
+	if g.player.polygon.Rotation != 0 {
+		t.Errorf("Expected the ship to keep facing up, got %v", g.player.polygon.Rotation)
+	}
+}
+
+func TestAimAssistPicksClosestLine(t *testing.T) {
+	g := newAimAssistGame(6)
+	placeAsteroidAt(g, -5, 150)
+	placeAsteroidAt(g, 2, 250)
+
+	if got := firedAngle(t, g); math.Abs(got-2*math.Pi/180) > 1e-6 {
+		t.Errorf("Expected the shot to be snapped onto the nearest line, 2 degrees, got %v", got*180/math.Pi)
+	}
+}
+
+func TestAimAssistLeavesWideShots(t *testing.T) {
+	for _, degrees := range []int{0, 6} {
+		g := newAimAssistGame(degrees)
+		placeAsteroidAt(g, 8, 200)
+		if degrees == 0 {
+			// With assist off even a near miss isn't snapped
+			placeAsteroidAt(g, 1, 200)
+		}
+		if got := firedAngle(t, g); got != 0 {
+			t.Errorf("Expected an unassisted shot with assist at %d, got %v degrees", degrees, got*180/math.Pi)
+		}
+	}
+}
+
+func TestAimAssistAcrossScreenEdge(t *testing.T) {
+	g := newAimAssistGame(6)
+	// Near the top of the screen, with the asteroid just over the edge at
+	// the bottom. Straight at it on screen would be a shot downwards.
+	g.player.polygon.SetPosition(400, 30)
+	nose := g.player.nose()
+	placeAsteroid(g, 10, nose.X+10, g.screenHeight-120)
+
+	want := math.Atan2(10, nose.Y+120)
+	if got := firedAngle(t, g); math.Abs(got-want) > 1e-6 {
+		t.Errorf("Expected the shot to be snapped across the top edge at %v degrees, got %v", want*180/math.Pi, got*180/math.Pi)
+	}
+}
+
+func TestAimAssistOffInNetworkGames(t *testing.T) {
+	g := newAimAssistGame(6)
+	g.net = &NetSession{}
+	if g.aimAssist() != 0 {
+		t.Errorf("Expected aim assist to be off in a network game")
+	}
+}
+
+func TestNextAimAssist(t *testing.T) {
+	level := 0
+	for range aimAssistLevels {
+		level = nextAimAssist(level)
+	}
+	if level != 0 {
+		t.Errorf("Expected the levels to cycle back to off, got %d", level)
+	}
+	// An unknown strength from a hand edited config goes back to off
+	if nextAimAssist(7) != 0 {
+		t.Errorf("Expected an unknown strength to cycle to off")
+	}
+}
@@ -0,0 +1,119 @@
+package game
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// asteroidArchetype is a family of asteroid outlines
+type asteroidArchetype struct {
+	name string
+	// weight is how often the archetype is picked for a new asteroid on the
+	// given wave, relative to the others
+	weight func(wave int) float64
+	// generate returns an outline of about the given radius around the
+	// origin. Every outline is a simple polygon, with each vertex further
+	// round the origin than the one before.
+	generate func(rng *rand.Rand, radius float64) []geometry.Vector2
+}
+
+// asteroidArchetypes are the families of generated asteroids. The rounded
+// potatoes give way to more awkward shapes as the waves go on.
+var asteroidArchetypes = []asteroidArchetype{
+	{
+		name:     "potato",
+		weight:   func(wave int) float64 { return 4 },
+		generate: potatoOutline,
+	},
+	{
+		name:     "shard",
+		weight:   func(wave int) float64 { return math.Min(3, 1+0.25*float64(wave-1)) },
+		generate: shardOutline,
+	},
+	{
+		name:     "ring-broken",
+		weight:   func(wave int) float64 { return math.Min(2, 0.5*float64(wave-1)) },
+		generate: brokenRingOutline,
+	},
+	{
+		name:     "slab",
+		weight:   func(wave int) float64 { return math.Min(2, 1+0.2*float64(wave-1)) },
+		generate: slabOutline,
+	},
+}
+
+// pickArchetype chooses the archetype of a new asteroid on the given wave,
+// by the archetypes' weights
+func pickArchetype(rng *rand.Rand, wave int) asteroidArchetype {
+	total := 0.0
+	for _, a := range asteroidArchetypes {
+		total += a.weight(wave)
+	}
+	pick := rng.Float64() * total
+	for _, a := range asteroidArchetypes {
+		pick -= a.weight(wave)
+		if pick < 0 {
+			return a
+		}
+	}
+	return asteroidArchetypes[0]
+}
+
+// radialOutline returns n vertices evenly spaced around the origin, the
+// i'th at the distance radius(i, angle) along its angle
+func radialOutline(n int, radius func(i int, angle float64) float64) []geometry.Vector2 {
+	vertices := make([]geometry.Vector2, n)
+	for i := range vertices {
+		angle := 2 * math.Pi * float64(i) / float64(n)
+		r := radius(i, angle)
+		vertices[i] = geometry.Vector2{X: math.Cos(angle) * r, Y: math.Sin(angle) * r}
+	}
+	return vertices
+}
+
+// potatoOutline is a lumpy, rounded rock
+func potatoOutline(rng *rand.Rand, radius float64) []geometry.Vector2 {
+	return radialOutline(8+rng.Intn(5), func(int, float64) float64 {
+		return radius * (0.85 + 0.3*rng.Float64())
+	})
+}
+
+// shardOutline is a spiky rock with few vertices, alternating between
+// points and deep valleys
+func shardOutline(rng *rand.Rand, radius float64) []geometry.Vector2 {
+	return radialOutline(2*(3+rng.Intn(2)), func(i int, _ float64) float64 {
+		if i%2 == 0 {
+			return radius * (1 + 0.2*rng.Float64())
+		}
+		return radius * (0.4 + 0.2*rng.Float64())
+	})
+}
+
+// brokenRingBite is how far either side of the bite's middle, in radians,
+// a broken ring's bite reaches
+const brokenRingBite = math.Pi / 4
+
+// brokenRingOutline is a round rock with a deep bite taken out of it, which
+// makes it concave. The bite is centered on the positive X axis.
+func brokenRingOutline(rng *rand.Rand, radius float64) []geometry.Vector2 {
+	depth := 0.3 + 0.15*rng.Float64()
+	return radialOutline(12, func(_ int, angle float64) float64 {
+		if math.Abs(geometry.AngleDifference(0, angle)) < brokenRingBite {
+			return radius * depth
+		}
+		return radius * (0.9 + 0.2*rng.Float64())
+	})
+}
+
+// slabOutline is a long, flat rock, roughly an ellipse
+func slabOutline(rng *rand.Rand, radius float64) []geometry.Vector2 {
+	aspect := 2 + 0.8*rng.Float64()
+	return radialOutline(8+rng.Intn(3), func(_ int, angle float64) float64 {
+		// The distance to the edge of the ellipse along angle, roughened
+		a, b := radius*math.Sqrt(aspect), radius/math.Sqrt(aspect)
+		edge := a * b / math.Hypot(b*math.Cos(angle), a*math.Sin(angle))
+		return edge * (0.9 + 0.2*rng.Float64())
+	})
+}
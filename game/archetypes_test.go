@@ -0,0 +1,85 @@
+package game
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+func TestArchetypesAreSimple(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, archetype := range asteroidArchetypes {
+		for i := range 500 {
+			radius := 5 + float64(i%50)
+			vertices := archetype.generate(rng, radius)
+			if !geometry.IsSimplePolygon(vertices) {
+				t.Fatalf("%s: expected a simple polygon, got %v", archetype.name, vertices)
+			}
+		}
+	}
+}
+
+func TestArchetypeWeightsShiftWithWaves(t *testing.T) {
+	count := func(wave int) map[string]int {
+		rng := rand.New(rand.NewSource(1))
+		counts := map[string]int{}
+		for range 1000 {
+			counts[pickArchetype(rng, wave).name]++
+		}
+		return counts
+	}
+
+	first := count(1)
+	if first["ring-broken"] != 0 {
+		t.Errorf("Expected no broken rings on the first wave, got %d", first["ring-broken"])
+	}
+	later := count(6)
+	for _, archetype := range asteroidArchetypes {
+		if later[archetype.name] == 0 {
+			t.Errorf("Expected some %s asteroids by wave 6", archetype.name)
+		}
+	}
+	if later["potato"] >= first["potato"] {
+		t.Errorf("Expected fewer potatoes later on, got %d then %d", first["potato"], later["potato"])
+	}
+}
+
+// newBrokenRing returns a broken ring asteroid of radius 40 at (400, 300),
+// with its bite facing right
+func newBrokenRing(g *Game) *Asteroid {
+	polygon := geometry.CreatePolygon(brokenRingOutline(rand.New(rand.NewSource(1)), 40))
+	polygon.SetPosition(400, 300)
+	a := newAsteroid(polygon)
+	g.addEntity(a)
+	return a
+}
+
+func TestBulletInBiteMisses(t *testing.T) {
+	g := New()
+	a := newBrokenRing(g)
+
+	// The bite is never more than 18 deep, and the rest of the rock is at
+	// least 36 out
+	inBite := newBullet(geometry.Vector2{X: 428, Y: 300}, geometry.Vector2{})
+	if geometry.PolygonsCollide(a.polygon, inBite.polygon) {
+		t.Errorf("Expected a bullet in the bite's notch not to touch the rock")
+	}
+	inRock := newBullet(geometry.Vector2{X: 372, Y: 300}, geometry.Vector2{})
+	if !geometry.PolygonsCollide(a.polygon, inRock.polygon) {
+		t.Errorf("Expected a bullet in the solid side of the rock to touch it")
+	}
+}
+
+func TestBulletFliesThroughBite(t *testing.T) {
+	g := newWeaponsGame()
+	g.player.polygon.SetPosition(100, 100)
+	a := newBrokenRing(g)
+	// Straight down through the middle of the notch
+	g.addEntity(newBullet(geometry.Vector2{X: 428, Y: 250}, geometry.Vector2{Y: 4}))
+
+	stepTicks(t, g, 25)
+	if a.Dead() {
+		t.Errorf("Expected the bullet to pass through the bite without hitting the rock")
+	}
+}
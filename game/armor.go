@@ -0,0 +1,67 @@
+package game
+
+import (
+	"image/color"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+const (
+	// armoredFirstWave is the first wave with armored asteroids in it
+	armoredFirstWave = 3
+	// armoredChance is the chance of an asteroid in a later wave being
+	// armored
+	armoredChance = 0.15
+	// armoredHP is how many hits an armored asteroid takes to split
+	armoredHP = 3
+	// healthBarTicks is how long an armored asteroid's health bar shows
+	// after it was last hit
+	healthBarTicks = 2 * ticksPerSecond
+	// healthBarWidth is the length of a full health bar, and healthBarGap
+	// how far above the asteroid it is drawn
+	healthBarWidth = 24
+	healthBarGap   = 6
+)
+
+var (
+	healthFull = color.RGBA{0, 220, 0, 255}
+	healthHalf = color.RGBA{230, 220, 0, 255}
+	healthLow  = color.RGBA{230, 0, 0, 255}
+)
+
+// rollArmored decides whether an asteroid in the current wave should be
+// armored. Early waves never have armored asteroids.
+func (g *Game) rollArmored() bool {
+	return g.wave >= armoredFirstWave && g.rng.Float64() < armoredChance
+}
+
+// makeArmored gives an asteroid the hit points to take several shots. Only
+// the asteroid itself is armored, its fragments split as usual.
+func (a *Asteroid) makeArmored() {
+	a.hp = armoredHP
+	a.maxHP = armoredHP
+}
+
+// armored reports whether the asteroid has hits left to take before it
+// splits
+func (a *Asteroid) armored() bool {
+	return a.hp > 1
+}
+
+// damageAsteroid takes a hit point off an armored asteroid hit at position,
+// showing its health bar and cracking it
+func (g *Game) damageAsteroid(a *Asteroid, position geometry.Vector2) {
+	a.hp--
+	a.damagedTicks = healthBarTicks
+	g.crackAsteroid(a, position)
+	g.emit(AsteroidDamaged{Position: a.polygon.Position, HP: a.hp})
+}
+
+// healthColor returns the color of a health bar with the given fraction of
+// its hit points left, from green when full through yellow to red
+func healthColor(fraction float64) color.Color {
+	if fraction > 0.5 {
+		return geometry.InterpolateColor(healthHalf, healthFull, (fraction-0.5)*2)
+	}
+	return geometry.InterpolateColor(healthLow, healthHalf, fraction*2)
+}
@@ -0,0 +1,36 @@
+//go:build !headless
+
+package game
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// DrawOverlay draws the health bar of an armored asteroid hit recently,
+// above it, where it is drawn this frame. It is kept out of the phosphor
+// trail, and drawn again across any edge the asteroid is wrapping over.
+func (a *Asteroid) DrawOverlay(screen *ebiten.Image, ctx DrawContext) {
+	if a.damagedTicks <= 0 || a.maxHP == 0 {
+		return
+	}
+	position, _ := a.polygon.InterpolatedPose(1 - ctx.Rewind)
+	// A wrap since the last tick would drag the bar across the screen
+	if math.Hypot(position.X-a.polygon.Position.X, position.Y-a.polygon.Position.Y) > healthBarWidth {
+		position = a.polygon.Position
+	}
+	bbox := a.polygon.GetBoundingBox()
+	x := float32(position.X) - healthBarWidth/2
+	y := float32(bbox.MinY-a.polygon.Position.Y+position.Y) - healthBarGap
+	length := healthBarWidth * float32(a.hp) / float32(a.maxHP)
+	c := healthColor(float64(a.hp) / float64(a.maxHP))
+
+	width, height := float32(screen.Bounds().Dx()), float32(screen.Bounds().Dy())
+	for _, dx := range []float32{-width, 0, width} {
+		for _, dy := range []float32{-height, 0, height} {
+			vector.StrokeLine(screen, x+dx, y+dy, x+dx+length, y+dy, 2+ctx.LineWidthBoost, c, false)
+		}
+	}
+}
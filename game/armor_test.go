@@ -0,0 +1,97 @@
+package game
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// shootAt fires a bullet that hits the asteroid straight away
+func shootAt(g *Game, a *Asteroid) *Bullet {
+	b := newBullet(a.polygon.Position, geometry.Vector2{})
+	g.addEntity(b)
+	g.checkCollisions()
+	g.dispatchEvents()
+	return b
+}
+
+func TestArmoredAsteroidTakesSeveralHits(t *testing.T) {
+	g := New()
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+	a := placeAsteroid(g, 30, 100, 100)
+	a.makeArmored()
+	events := recordEvents(g)
+
+	for hp := armoredHP - 1; hp > 0; hp-- {
+		b := shootAt(g, a)
+		if a.Dead() || !b.Dead() {
+			t.Fatalf("Expected the armor to stop the bullet with %d hits left", hp)
+		}
+		if a.hp != hp || a.damagedTicks != healthBarTicks {
+			t.Errorf("Expected %d hit points and the health bar showing, got %d and %d", hp, a.hp, a.damagedTicks)
+		}
+	}
+	damaged := 0
+	for _, e := range *events {
+		if _, ok := e.(AsteroidDamaged); ok {
+			damaged++
+		}
+	}
+	if damaged != armoredHP-1 || g.score != 0 {
+		t.Errorf("Expected %d damage events and no score, got %d and %d", armoredHP-1, damaged, g.score)
+	}
+
+	shootAt(g, a)
+	if !a.Dead() {
+		t.Errorf("Expected the last hit to split the asteroid")
+	}
+}
+
+func TestChargedShotIgnoresArmor(t *testing.T) {
+	g := New()
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+	a := placeAsteroid(g, 30, 100, 100)
+	a.makeArmored()
+	b := newBullet(a.polygon.Position, geometry.Vector2{})
+	b.makeCharged()
+	g.addEntity(b)
+	g.checkCollisions()
+	if !a.Dead() {
+		t.Errorf("Expected a charged shot to destroy an armored asteroid")
+	}
+}
+
+func TestHealthBarFades(t *testing.T) {
+	a := newAsteroid(geometry.CreateAsteroid(30, 0, 8))
+	a.makeArmored()
+	a.damagedTicks = healthBarTicks
+	ctx := UpdateContext{ScreenWidth: 800, ScreenHeight: 600, TimeScale: 1}
+	for i := 0; i < healthBarTicks; i++ {
+		a.Update(ctx)
+	}
+	if a.damagedTicks != 0 {
+		t.Errorf("Expected the health bar to hide after %d ticks, %d left", healthBarTicks, a.damagedTicks)
+	}
+}
+
+func TestHealthColor(t *testing.T) {
+	for _, test := range []struct {
+		fraction float64
+		want     color.RGBA
+	}{
+		{1, healthFull},
+		{0.5, healthHalf},
+		{0, healthLow},
+	} {
+		r, g, b, a := healthColor(test.fraction).RGBA()
+		got := color.RGBA{uint8(r >> 8), uint8(g >> 8), uint8(b >> 8), uint8(a >> 8)}
+		if got != test.want {
+			t.Errorf("Expected %v at %v health, got %v", test.want, test.fraction, got)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package game
+
+import (
+	"image/color"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// largeAsteroidSize is the smallest asteroid whose destruction is big enough
+// for the dramatic effects, such as hit-stop and shockwaves
+const largeAsteroidSize = 40.0
+
+// asteroidSpawnTicks is how long a new asteroid takes to materialize,
+// during which it can't hit or be hit by anything
+const asteroidSpawnTicks = ticksPerSecond
+
+// Asteroid is a drifting rock that splits when shot
+type Asteroid struct {
+	entityBase
+	// entering is set for asteroids spawned outside the screen. They don't
+	// wrap until they are fully visible, otherwise they would immediately
+	// jump to the opposite edge.
+	entering bool
+	// freshTicks counts down while a new fragment is drawn with a thicker
+	// outline, which replaces the red pulse when flashing is disabled
+	freshTicks int
+	// dilated is set while the asteroid is slowed by time dilation
+	dilated bool
+	// spawnTick is the tick a fragment was split off on, so it can't be hit
+	// again by the same bullet within that tick
+	spawnTick int
+	// spawning counts down while the asteroid materializes
+	spawning float64
+	// orbit is the moonlet's path around its parent, or nil for an asteroid
+	// flying free
+	orbit *orbit
+	// history is where the asteroid has been over the last few ticks
+	history positionHistory
+	// hp is how many hits an armored asteroid has left, out of maxHP, with
+	// both 0 for an ordinary one. damagedTicks counts down while its health
+	// bar shows after a hit.
+	hp           int
+	maxHP        int
+	damagedTicks int
+	// cracks are left across an armored asteroid by the hits it has taken
+	cracks []crack
+	// turret is the gun on a turret asteroid, or nil for an ordinary one
+	turret *turret
+	// impact is the energy of the last hit the asteroid took, which is
+	// passed on to the effects of destroying it
+	impact  float64
+	palette *Palette
+}
+
+// newAsteroid wraps an asteroid polygon as an entity
+func newAsteroid(polygon *geometry.PolygonObject) *Asteroid {
+	return &Asteroid{
+		entityBase: entityBase{polygon: polygon, tag: TagAsteroid, layer: LayerAsteroid},
+		spawnTick:  -1,
+	}
+}
+
+// ApplyPalette colors the asteroid, keeping any fresh fragment fade or
+// materialization going
+func (a *Asteroid) ApplyPalette(p *Palette) {
+	a.palette = p
+	fadeFrom := p.FreshFragment
+	if a.spawning > 0 {
+		fadeFrom = color.RGBA{A: 0xff}
+	}
+	recolor(a.polygon, fadeFrom, a.restingColor())
+}
+
+// materialize fades and grows the asteroid into existence, rather than it
+// appearing at full size. Until it has finished it is left out of every
+// collision, so it can't ambush the ship. It must be called once the
+// asteroid has been colored.
+func (a *Asteroid) materialize() {
+	a.spawning = asteroidSpawnTicks
+	scale := a.polygon.Scale
+	a.polygon.TweenScale(0.1*scale, scale, ticksDuration(asteroidSpawnTicks), geometry.EaseOut, nil)
+	a.polygon.SetColor(color.RGBA{A: 0xff})
+	a.polygon.StartFade(a.restingColor(), asteroidSpawnTicks)
+}
+
+// CollisionLayers leaves the asteroid out of collisions while it
+// materializes
+func (a *Asteroid) CollisionLayers() (layer, collidesWith CollisionLayer) {
+	if a.spawning > 0 {
+		return 0, 0
+	}
+	return a.entityBase.CollisionLayers()
+}
+
+// restingColor returns the color the asteroid settles on once any fade has
+// finished
+func (a *Asteroid) restingColor() color.Color {
+	if a.dilated {
+		return a.palette.Dilated
+	}
+	if a.maxHP > 0 {
+		return a.palette.Armored
+	}
+	return a.palette.Asteroid
+}
+
+// setDilated slows the asteroid down, or returns it to normal speed, fading
+// it to or from the time dilation tint
+func (a *Asteroid) setDilated(dilated bool) {
+	if dilated == a.dilated {
+		return
+	}
+	a.dilated = dilated
+	a.polygon.TimeScale = 1
+	if dilated {
+		a.polygon.TimeScale = timeDilationFactor
+	}
+
+	// A fade already in progress, such as a fresh fragment's, just ends on
+	// the new color instead
+	if a.polygon.IsFading {
+		a.polygon.FadeEndColor = a.restingColor()
+		return
+	}
+	const tintFadeTicks = 20
+	a.polygon.StartFade(a.restingColor(), tintFadeTicks)
+}
+
+// Update moves the asteroid, wrapping around the screen edges once it has
+// entered the screen
+func (a *Asteroid) Update(ctx UpdateContext) {
+	a.setDilated(ctx.TimeDilated)
+	// Entering asteroids don't wrap until they are fully on the screen, and
+	// are removed if the wind blows them back out
+	a.polygon.Boundary = geometry.BoundaryWrap
+	if a.entering {
+		a.polygon.Boundary = geometry.BoundaryDespawn
+	}
+	if a.polygon.Step(ctx.TimeScale, ctx.ScreenWidth, ctx.ScreenHeight) == geometry.EdgeLeft && a.orbit == nil {
+		a.Kill()
+		return
+	}
+
+	if a.freshTicks > 0 {
+		a.freshTicks--
+	}
+	if a.damagedTicks > 0 {
+		a.damagedTicks--
+	}
+	a.spawning = max(0, a.spawning-ctx.TimeScale)
+
+	if a.entering {
+		bbox := a.polygon.GetBoundingBox()
+		if bbox.MinX >= 0 && bbox.MaxX <= ctx.ScreenWidth &&
+			bbox.MinY >= 0 && bbox.MaxY <= ctx.ScreenHeight {
+			a.entering = false
+		}
+	}
+
+	// A moonlet goes wherever its orbit takes it, slowed down along with
+	// everything else by time dilation
+	if a.orbit != nil {
+		motion := ctx.TimeScale
+		if a.dilated {
+			motion *= timeDilationFactor
+		}
+		a.orbit.angle += a.orbit.speed * motion
+		a.followOrbit(ctx.ScreenWidth, ctx.ScreenHeight)
+	}
+	a.history.record(a.polygon.Position)
+}
@@ -0,0 +1,22 @@
+//go:build !headless
+
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Draw renders the asteroid. While entering it is drawn without the wrapped
+// copies on the far side of the screen.
+func (a *Asteroid) Draw(screen *ebiten.Image, ctx DrawContext) {
+	opts := ctx.polygonOptions()
+	opts.NoWrap = a.entering
+	if a.freshTicks > 0 {
+		opts.ExtraLineWidth++
+	}
+	a.polygon.DrawWithOptions(screen, opts)
+	a.drawCracks(screen, ctx, !opts.NoWrap)
+	if a.turret != nil {
+		a.drawBarrel(screen, ctx)
+	}
+}
@@ -0,0 +1,101 @@
+package game
+
+import "fmt"
+
+// AudioChannel is one of the groups of sounds the game mixes, each with its
+// own volume, so gameplay and the menus can be balanced against each other
+type AudioChannel int
+
+const (
+	// ChannelSFX is the sound of the game being played, such as explosions
+	ChannelSFX AudioChannel = iota
+	// ChannelUI is the blips and buzzes of the menus and controls
+	ChannelUI
+	// ChannelMusic is background music
+	ChannelMusic
+)
+
+// String returns the channel's name as shown on the settings screen
+func (c AudioChannel) String() string {
+	switch c {
+	case ChannelSFX:
+		return "SFX"
+	case ChannelUI:
+		return "UI"
+	case ChannelMusic:
+		return "MUSIC"
+	}
+	return "UNKNOWN"
+}
+
+// volumeSteps is how many steps down from full volume each volume can be
+// turned, the last of them silent
+const volumeSteps = 4
+
+// Volumes is how loud the game plays, overall and on each channel. Each is
+// the number of steps it is turned down from full volume, so a config
+// without them plays everything at full volume.
+type Volumes struct {
+	Master int `json:"master,omitempty"`
+	SFX    int `json:"sfx,omitempty"`
+	UI     int `json:"ui,omitempty"`
+	Music  int `json:"music,omitempty"`
+}
+
+// channel returns the steps down of the given channel
+func (v *Volumes) channel(c AudioChannel) *int {
+	switch c {
+	case ChannelUI:
+		return &v.UI
+	case ChannelMusic:
+		return &v.Music
+	}
+	return &v.SFX
+}
+
+// volumeLevel returns the gain of a volume turned down the given number of
+// steps, from 1 at full volume to 0 when silent
+func volumeLevel(steps int) float64 {
+	return float64(volumeSteps-max(0, min(volumeSteps, steps))) / volumeSteps
+}
+
+// volumeLabel returns how a volume is shown on the settings screen
+func volumeLabel(steps int) string {
+	return fmt.Sprintf("%d%%", int(volumeLevel(steps)*100))
+}
+
+// nextVolume returns the volume after steps on the settings screen, one
+// step quieter, or back to full from silent
+func nextVolume(steps int) int {
+	return (max(0, min(volumeSteps, steps)) + 1) % (volumeSteps + 1)
+}
+
+// Gain returns how loud a sound on the channel plays: the master volume
+// scaled by the channel's own
+func (v Volumes) Gain(c AudioChannel) float64 {
+	return volumeLevel(v.Master) * volumeLevel(*v.channel(c))
+}
+
+// volumeSetting creates a setting on the settings screen for the volume
+// picked out by field
+func volumeSetting(name string, field func(v *Volumes) *int) setting {
+	return setting{
+		name:   name,
+		value:  func(c *Config) string { return volumeLabel(*field(&c.Volume)) },
+		change: func(c *Config) { *field(&c.Volume) = nextVolume(*field(&c.Volume)) },
+	}
+}
+
+// audible reports whether a sound on the channel would be heard: there is
+// a speaker, sound isn't muted and the channel isn't turned all the way down
+func (g *Game) audible(c AudioChannel) bool {
+	return g.speaker != nil && !g.replaying && !g.config.Muted && g.config.Volume.Gain(c) > 0
+}
+
+// play plays pcm through the speaker at the channel's volume, if it would
+// be heard
+func (g *Game) play(c AudioChannel, pcm []byte) {
+	if g.audible(c) {
+		g.speaker.Play(pcm, g.config.Volume.Gain(c))
+	}
+}
@@ -0,0 +1,99 @@
+package game
+
+import (
+	"math"
+	"testing"
+)
+
+func TestVolumeGain(t *testing.T) {
+	tests := []struct {
+		volumes Volumes
+		channel AudioChannel
+		want    float64
+	}{
+		{Volumes{}, ChannelSFX, 1},
+		{Volumes{Master: 1}, ChannelUI, 0.75},
+		{Volumes{UI: 2}, ChannelUI, 0.5},
+		{Volumes{Master: 1, UI: 2}, ChannelUI, 0.375},
+		// Only the channel asked about counts, besides the master volume
+		{Volumes{Master: 2, SFX: 3, Music: 4}, ChannelSFX, 0.125},
+		{Volumes{SFX: 4}, ChannelUI, 1},
+		{Volumes{Master: volumeSteps}, ChannelMusic, 0},
+		// Volumes out of range are held to it
+		{Volumes{Master: -1, SFX: 9}, ChannelSFX, 0},
+	}
+	for _, tt := range tests {
+		if got := tt.volumes.Gain(tt.channel); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%+v on %v: expected a gain of %v, got %v", tt.volumes, tt.channel, tt.want, got)
+		}
+	}
+}
+
+func TestVolumeSettingCycles(t *testing.T) {
+	var labels []string
+	steps := 0
+	for range volumeSteps + 2 {
+		labels = append(labels, volumeLabel(steps))
+		steps = nextVolume(steps)
+	}
+	want := []string{"100%", "75%", "50%", "25%", "0%", "100%"}
+	for i := range want {
+		if labels[i] != want[i] {
+			t.Fatalf("Expected the volume to step through %v, got %v", want, labels)
+		}
+	}
+}
+
+func TestSoundsPlayAtChannelVolume(t *testing.T) {
+	speaker := &countingSpeaker{}
+	g := New(WithSpeaker(speaker), WithConfig(Config{Volume: Volumes{Master: 1, SFX: 2, UI: volumeSteps}}))
+	g.handleExplosionSounds(AsteroidDestroyed{Size: 20})
+	g.playUISound(uiSelect)
+	if len(speaker.played) != 1 {
+		t.Fatalf("Expected only the explosion to play with the UI turned down, got %d sounds", len(speaker.played))
+	}
+	if speaker.volumes[0] != 0.375 {
+		t.Errorf("Expected the explosion at a volume of 0.375, got %v", speaker.volumes[0])
+	}
+
+	// A silent channel doesn't take up a voice
+	g.config.Volume.SFX = volumeSteps
+	g.handleExplosionSounds(AsteroidDestroyed{Size: 20})
+	if len(speaker.played) != 1 || len(g.explosionVoices) != 1 {
+		t.Errorf("Expected nothing played on a silent channel")
+	}
+}
+
+func TestEmptyWeaponBuzzes(t *testing.T) {
+	speaker := &countingSpeaker{}
+	g := newWeaponsGame()
+	g.speaker = speaker
+	p := g.player
+	p.weapons.selected = WeaponBomb
+	g.fireSecondary(p)
+	g.dispatchEvents()
+	if len(speaker.played) != 0 {
+		t.Fatalf("Expected the bomb to drop without a buzz")
+	}
+	g.fireSecondary(p)
+	g.dispatchEvents()
+	if len(speaker.played) != 1 || &speaker.played[0][0] != &g.uiSounds[uiError][0] {
+		t.Errorf("Expected a buzz firing with no bombs left, got %d sounds", len(speaker.played))
+	}
+}
+
+func TestSettingsScroll(t *testing.T) {
+	rows := settingsVisible + 5
+	for selected := range rows {
+		first := settingsScroll(selected, rows)
+		if selected < first || selected >= first+settingsVisible {
+			t.Errorf("Expected row %d to be shown, showing from %d", selected, first)
+		}
+		if first < 0 || first+settingsVisible > rows {
+			t.Errorf("Expected to show rows within the list, showing from %d", first)
+		}
+	}
+	if first := settingsScroll(3, settingsVisible-1); first != 0 {
+		t.Errorf("Expected a short list not to scroll, got %d", first)
+	}
+}
@@ -0,0 +1,67 @@
+package game
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// bannerSlideTicks is how long a banner takes to slide in, and out again
+	bannerSlideTicks = ticksPerSecond / 2
+	// bannerHoldTicks is how long a banner stays in the middle of the screen
+	bannerHoldTicks = ticksPerSecond
+	// bannerTicks is how long a banner is shown for in total
+	bannerTicks = 2*bannerSlideTicks + bannerHoldTicks
+	// bannerFlashTicks is how long each flash of the warning border lasts
+	bannerFlashTicks = ticksPerSecond / 4
+	// bannerFlashes is how many times the warning border flashes
+	bannerFlashes = 2
+)
+
+// banner is a message that slides across the middle of the screen, such as
+// the number of a new wave
+type banner struct {
+	text string
+	// warning adds a flashing red border and a WARNING line, for bosses
+	warning bool
+	// age counts the ticks since the banner started showing
+	age int
+}
+
+// handleBanners queues a banner for each new wave. Banners are shown one at
+// a time, so a wave that starts while another's banner is showing waits its
+// turn.
+func (g *Game) handleBanners(e Event) {
+	if e, ok := e.(WaveStarted); ok {
+		g.banners = append(g.banners, banner{text: fmt.Sprintf("WAVE %d", e.Wave), warning: e.Boss})
+	}
+}
+
+// updateBanners ages the current banner, moving on to the next once it has
+// slid off the screen
+func (g *Game) updateBanners() {
+	if len(g.banners) == 0 {
+		return
+	}
+	g.banners[0].age++
+	if g.banners[0].age >= bannerTicks {
+		g.banners = g.banners[1:]
+	}
+}
+
+// bannerOffset returns how far left (negative) or right (positive) of the
+// center of the screen a banner is, as a fraction of the distance it slides
+func bannerOffset(age int) float64 {
+	switch {
+	case age < bannerSlideTicks:
+		// Decelerate in from the left
+		t := float64(age) / bannerSlideTicks
+		return -math.Pow(1-t, 3)
+	case age < bannerSlideTicks+bannerHoldTicks:
+		return 0
+	default:
+		// Accelerate out to the right
+		t := float64(age-bannerSlideTicks-bannerHoldTicks) / bannerSlideTicks
+		return math.Pow(t, 3)
+	}
+}
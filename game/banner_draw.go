@@ -0,0 +1,38 @@
+//go:build !headless
+
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// drawBanner draws the current banner, if there is one
+func (g *Game) drawBanner(screen *ebiten.Image) {
+	if len(g.banners) == 0 {
+		return
+	}
+	b := g.banners[0]
+	width := g.titleFont.GetWidth(b.text)
+	// Slide from just off the left edge to just off the right edge
+	distance := (g.screenWidth + float64(width)) / 2
+	x := float32(g.screenWidth)/2 - width/2 + float32(bannerOffset(b.age)*distance)
+	y := float32(g.screenHeight)/2 - 100
+	g.titleFont.DrawString(screen, b.text, x, y)
+
+	if !b.warning {
+		return
+	}
+	g.vectorFont.SetColor(g.palette.Hit)
+	warningX := x + width/2 - g.vectorFont.GetWidth("WARNING")/2
+	g.vectorFont.DrawString(screen, "WARNING", warningX, y+60)
+	g.vectorFont.SetColor(g.palette.UI)
+
+	// Flash the border on and off, or hold it steady if flashing is turned off
+	flash := b.age / bannerFlashTicks
+	if g.config.Accessibility.Flashing() && (flash >= 2*bannerFlashes || flash%2 == 1) {
+		return
+	}
+	const inset = 4
+	vector.StrokeRect(screen, inset, inset, float32(g.screenWidth)-2*inset, float32(g.screenHeight)-2*inset, 4, g.palette.Hit, false)
+}
@@ -0,0 +1,53 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestBannersQueue(t *testing.T) {
+	g := New()
+	g.handleBanners(WaveStarted{Wave: 1})
+	g.handleBanners(WaveStarted{Wave: 2, Boss: true})
+
+	if len(g.banners) != 2 || g.banners[0].text != "WAVE 1" {
+		t.Fatalf("Expected both banners queued in order, got %+v", g.banners)
+	}
+	for i := 0; i < bannerTicks; i++ {
+		g.updateBanners()
+	}
+	if len(g.banners) != 1 || g.banners[0].text != "WAVE 2" || !g.banners[0].warning || g.banners[0].age != 0 {
+		t.Fatalf("Expected the boss banner to start once the first finished, got %+v", g.banners)
+	}
+
+	g.Restart(RestartOptions{})
+	if len(g.banners) != 0 {
+		t.Errorf("Expected a restart to clear the banners")
+	}
+}
+
+func TestBannerSlides(t *testing.T) {
+	if got := bannerOffset(0); got != -1 {
+		t.Errorf("Expected the banner to start off the left edge, got %v", got)
+	}
+	if got := bannerOffset(bannerSlideTicks); got != 0 {
+		t.Errorf("Expected the banner centered after sliding in, got %v", got)
+	}
+	if got := bannerOffset(bannerSlideTicks + bannerHoldTicks - 1); got != 0 {
+		t.Errorf("Expected the banner to hold in the center, got %v", got)
+	}
+	if got := bannerOffset(bannerTicks - 1); got <= 0.8 {
+		t.Errorf("Expected the banner to be nearly off the right edge, got %v", got)
+	}
+}
+
+func TestBannerShownAtRunStart(t *testing.T) {
+	g := New()
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	if err := Simulate(g, nil, 1); err != nil {
+		t.Fatal(err)
+	}
+	if len(g.banners) != 1 || g.banners[0].text != "WAVE 1" {
+		t.Errorf("Expected a banner for the first wave, got %+v", g.banners)
+	}
+}
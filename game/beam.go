@@ -0,0 +1,101 @@
+package game
+
+import (
+	"math"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+const (
+	// beamRange is how far from the ship the repulsor beam reaches
+	beamRange = 200.0
+	// beamHalfAngle is how far either side of the ship's nose the beam's
+	// cone spreads, in radians
+	beamHalfAngle = math.Pi / 8
+	// beamStrength is the push, in pixels per tick squared, on an asteroid
+	// right at the ship. It falls off to nothing at the end of the beam.
+	beamStrength = 0.15
+	// beamMaxEnergy is how many ticks the beam can be held from full
+	beamMaxEnergy = 2 * ticksPerSecond
+	// beamRecharge is how much energy comes back each tick the beam is off,
+	// so it takes twice as long to recharge as it does to drain
+	beamRecharge = 0.5
+	// beamBarWidth and beamBarHeight are the size of the energy bar
+	beamBarWidth, beamBarHeight = 80, 6
+)
+
+// inBeamCone reports whether target is inside a beam cone projected from
+// origin in the direction facing (0 being up the screen), taking the
+// shortest way around the wrapped screen. It also returns how far away
+// target is.
+func inBeamCone(origin geometry.Vector2, facing float64, target geometry.Vector2, width, height float64) (distance float64, inside bool) {
+	delta := geometry.WrapDelta(origin, target, width, height)
+	distance = math.Hypot(delta.X, delta.Y)
+	if distance > beamRange {
+		return distance, false
+	}
+	if distance == 0 {
+		return 0, true
+	}
+	angle := math.Atan2(delta.X, -delta.Y)
+	return distance, math.Abs(math.Remainder(angle-facing, 2*math.Pi)) <= beamHalfAngle
+}
+
+// beamForce returns the push on something distance away along delta from
+// the ship, strongest up close and fading out at the end of the beam
+func beamForce(delta geometry.Vector2, distance float64) geometry.Vector2 {
+	if distance == 0 {
+		return geometry.Vector2{}
+	}
+	strength := beamStrength * (1 - distance/beamRange)
+	return geometry.Vector2{X: delta.X / distance * strength, Y: delta.Y / distance * strength}
+}
+
+// updateBeam runs a ship's repulsor beam while it is held and there is energy
+// left, pushing every asteroid in the cone away from the ship. Energy only
+// recharges once the beam is released, so it can't sputter on and off.
+func (g *Game) updateBeam(p *Player, held bool, timeScale float64) {
+	if !held {
+		p.beaming = false
+		p.beamEnergy = math.Min(p.beamEnergy+beamRecharge*timeScale, beamMaxEnergy)
+		return
+	}
+	p.beaming = p.beamEnergy > 0
+	if !p.beaming {
+		return
+	}
+	p.beamEnergy = math.Max(p.beamEnergy-timeScale, 0)
+
+	ship := p.polygon
+	nose := p.nose()
+	for _, e := range g.entities {
+		a, ok := e.(*Asteroid)
+		if !ok || a.Dead() {
+			continue
+		}
+		centroid := a.polygon.ToWorld(a.polygon.Centroid())
+		distance, inside := inBeamCone(nose, ship.Rotation, centroid, g.screenWidth, g.screenHeight)
+		if !inside {
+			continue
+		}
+		delta := geometry.WrapDelta(nose, centroid, g.screenWidth, g.screenHeight)
+		force := beamForce(delta, distance)
+		a.polygon.ApplyForce(force.X, force.Y)
+	}
+}
+
+// muzzleClearance is how far ahead of the ship's nose vertex its shots start,
+// so they come out clear of the hull
+const muzzleClearance = 2.0
+
+// nose returns the point just ahead of the tip of the ship, where bullets
+// and the beam come from. Every ship design has its nose at its first
+// vertex.
+func (p *Player) nose() geometry.Vector2 {
+	ship := p.polygon
+	tip := ship.ToWorld(ship.Vertices[0])
+	return geometry.Vector2{
+		X: tip.X + math.Sin(ship.Rotation)*muzzleClearance,
+		Y: tip.Y - math.Cos(ship.Rotation)*muzzleClearance,
+	}
+}
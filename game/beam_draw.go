@@ -0,0 +1,37 @@
+//go:build !headless
+
+package game
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// DrawOverlay draws the edges of the beam's cone, while it is on, as two
+// lines diverging from the ship's nose
+func (p *Player) DrawOverlay(screen *ebiten.Image, ctx DrawContext) {
+	if !p.beaming {
+		return
+	}
+	nose := p.nose()
+	c := fadeColor(p.beamColor, 0.6)
+	for _, side := range []float64{-beamHalfAngle, beamHalfAngle} {
+		angle := p.polygon.Rotation + side
+		vector.StrokeLine(screen,
+			float32(nose.X), float32(nose.Y),
+			float32(nose.X+math.Sin(angle)*beamRange), float32(nose.Y-math.Cos(angle)*beamRange),
+			1+ctx.LineWidthBoost, c, true)
+	}
+}
+
+// drawBeamBar draws the beam's energy as a small bar in the bottom-right
+// corner
+func (g *Game) drawBeamBar(screen *ebiten.Image) {
+	x := float32(g.screenWidth) - 20 - beamBarWidth
+	y := float32(g.screenHeight) - 20 - beamBarHeight
+	fraction := float32(g.localShip().beamEnergy / beamMaxEnergy)
+	vector.StrokeRect(screen, x, y, beamBarWidth, beamBarHeight, 1, g.palette.UI, false)
+	vector.FillRect(screen, x, y, beamBarWidth*fraction, beamBarHeight, g.palette.Pickup, false)
+}
@@ -0,0 +1,137 @@
+package game
+
+import (
+	"math"
+	"testing"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+func TestInBeamCone(t *testing.T) {
+	origin := geometry.Vector2{X: 400, Y: 300}
+	tests := []struct {
+		name   string
+		facing float64
+		target geometry.Vector2
+		inside bool
+	}{
+		{"straight ahead", 0, geometry.Vector2{X: 400, Y: 200}, true},
+		{"just inside the edge", 0, geometry.Vector2{X: 400 + 100*math.Tan(beamHalfAngle*0.9), Y: 200}, true},
+		{"just outside the edge", 0, geometry.Vector2{X: 400 + 100*math.Tan(beamHalfAngle*1.1), Y: 200}, false},
+		{"behind", 0, geometry.Vector2{X: 400, Y: 400}, false},
+		{"out of range", 0, geometry.Vector2{X: 400, Y: 300 - beamRange - 1}, false},
+		{"facing right", math.Pi / 2, geometry.Vector2{X: 550, Y: 300}, true},
+		// Facing just short of a full turn still points up the screen
+		{"across zero", 2*math.Pi - 0.1, geometry.Vector2{X: 400, Y: 200}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, inside := inBeamCone(origin, test.facing, test.target, 800, 600)
+			if inside != test.inside {
+				t.Errorf("Expected inside %v, got %v", test.inside, inside)
+			}
+		})
+	}
+}
+
+func TestInBeamConeWraps(t *testing.T) {
+	// Near the top edge, pointing up at something just inside the bottom
+	origin := geometry.Vector2{X: 400, Y: 20}
+	distance, inside := inBeamCone(origin, 0, geometry.Vector2{X: 400, Y: 570}, 800, 600)
+	if !inside {
+		t.Errorf("Expected the cone to reach across the top edge")
+	}
+	if math.Abs(distance-50) > 1e-9 {
+		t.Errorf("Expected the wrapped distance of 50, got %v", distance)
+	}
+}
+
+func TestBeamForce(t *testing.T) {
+	near := beamForce(geometry.Vector2{X: 0, Y: -50}, 50)
+	far := beamForce(geometry.Vector2{X: 0, Y: -150}, 150)
+	if near.X != 0 || near.Y >= 0 {
+		t.Errorf("Expected a push straight away from the ship, got %v", near)
+	}
+	if math.Abs(near.Y) <= math.Abs(far.Y) {
+		t.Errorf("Expected a stronger push up close, got %v near and %v far", near, far)
+	}
+	if end := beamForce(geometry.Vector2{X: beamRange}, beamRange); end != (geometry.Vector2{}) {
+		t.Errorf("Expected no push at the end of the beam, got %v", end)
+	}
+}
+
+func TestBeamPushesAsteroids(t *testing.T) {
+	g := New()
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+	// Still air, so only the beam moves the asteroids
+	g.wind = geometry.Vector2{}
+	ship := g.player.polygon
+	ahead := placeAsteroid(g, 10, ship.Position.X, ship.Position.Y-100)
+	behind := placeAsteroid(g, 10, ship.Position.X, ship.Position.Y+100)
+
+	g.input = InputState{Beam: true}
+	if err := g.updatePlaying(); err != nil {
+		t.Fatal(err)
+	}
+	if v := ahead.polygon.Velocity; v.Y >= 0 || math.Abs(v.X) > 1e-9 {
+		t.Errorf("Expected the asteroid ahead to be pushed away, velocity %v", v)
+	}
+	if v := behind.polygon.Velocity; v != (geometry.Vector2{}) {
+		t.Errorf("Expected the asteroid behind to be left alone, velocity %v", v)
+	}
+	if g.player.beamEnergy >= beamMaxEnergy {
+		t.Errorf("Expected the beam to use energy")
+	}
+}
+
+func TestBeamEnergy(t *testing.T) {
+	g := New()
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	p := g.player
+
+	for i := 0; i < beamMaxEnergy; i++ {
+		g.updateBeam(g.player, true, 1)
+	}
+	if p.beamEnergy != 0 {
+		t.Fatalf("Expected the beam to drain, %v left", p.beamEnergy)
+	}
+	g.updateBeam(g.player, true, 1)
+	if p.beaming {
+		t.Errorf("Expected the beam to stay off without energy")
+	}
+
+	g.updateBeam(g.player, false, 1)
+	if p.beamEnergy != beamRecharge {
+		t.Errorf("Expected the beam to recharge when released, got %v", p.beamEnergy)
+	}
+	for i := 0; i < 10*beamMaxEnergy; i++ {
+		g.updateBeam(g.player, false, 1)
+	}
+	if p.beamEnergy != beamMaxEnergy {
+		t.Errorf("Expected the recharge to stop when full, got %v", p.beamEnergy)
+	}
+}
+
+func TestNoseClearOfHull(t *testing.T) {
+	arrow := Shape{Name: "arrow", Role: ShapeRolePlayer, Vertices: [][2]float64{{0, -12}, {8, 10}, {0, 4}, {-8, 10}}, Scale: 1.5}
+	designs := map[string]*geometry.PolygonObject{
+		"built-in": New().newShipPolygon(),
+		"custom":   (&Game{shapes: Shapes{Shapes: []Shape{arrow}}}).newShipPolygon(),
+	}
+	for name, ship := range designs {
+		p := newPlayer(ship, 400, 300)
+		for step := range 16 {
+			ship.SetRotation(float64(step) * math.Pi / 8)
+			nose := p.nose()
+			if geometry.PointInPolygon(nose, ship.TransformedCollisionVertices()) {
+				t.Errorf("%s ship at rotation %d/16: shots start inside the collision hull, at %v", name, step, nose)
+			}
+			if geometry.PointInPolygon(nose, ship.TransformedVertices()) {
+				t.Errorf("%s ship at rotation %d/16: shots start inside the outline, at %v", name, step, nose)
+			}
+		}
+	}
+}
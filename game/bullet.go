@@ -0,0 +1,181 @@
+package game
+
+import (
+	"image/color"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+const (
+	// piercingHits is how many asteroids a piercing bullet can split
+	piercingHits = 3
+	// ricochetBounces is how many times a bullet can bounce off the screen
+	// edges with the ricochet mutator
+	ricochetBounces = 2
+	// bulletLifetime is how many ticks a bullet flies for before it fizzles
+	// out, if it hasn't left the screen first. Ricochet bullets last long
+	// enough to use up their bounces.
+	bulletLifetime   = 80
+	ricochetLifetime = bulletLifetime * (ricochetBounces + 1)
+	// enemyBulletLifetime is longer, as turret bullets are much slower
+	enemyBulletLifetime = 200
+	// selfHazardGraceTicks is how long a bullet flies before it can hit the
+	// ship that fired it with the self hazard mutator, so it can't hit it on
+	// the way out of the gun
+	selfHazardGraceTicks = 15
+)
+
+// BulletKind is the sort of shot a bullet is
+type BulletKind int
+
+const (
+	// BulletNormal is a shot from a tap of the fire button
+	BulletNormal BulletKind = iota
+	// BulletCharged is a large shot released after holding fire, which
+	// destroys asteroids without splitting them and passes through one
+	BulletCharged
+)
+
+// Bullet represents a projectile fired by the player
+type Bullet struct {
+	entityBase
+	kind BulletKind
+	// landed is set once the bullet has hit a target, so it only counts
+	// towards accuracy once
+	landed bool
+	// previous is where the bullet was before its last update, and age how
+	// many ticks it has flown for
+	previous    geometry.Vector2
+	age         float64
+	tracerColor color.Color
+	// hitsLeft is how many more asteroids the bullet can hit. Normal bullets
+	// are used up by their first hit.
+	hitsLeft int
+	piercing bool
+	// Bouncing bullets go back to despawning once they have used up
+	// bouncesLeft
+	bouncesLeft int
+	bounces     int
+	// owner is who fired the bullet
+	owner   BulletOwner
+	palette *Palette
+}
+
+// newBullet creates a small square bullet at the given position and
+// velocity, fired by the first ship
+func newBullet(position, velocity geometry.Vector2) *Bullet {
+	// Create a small rectangle for the bullet (2x2)
+	polygon := &geometry.PolygonObject{
+		Vertices: []geometry.Vector2{
+			{X: -1, Y: -1}, // Top left
+			{X: 1, Y: -1},  // Top right
+			{X: 1, Y: 1},   // Bottom right
+			{X: -1, Y: 1},  // Bottom left
+		},
+		Position:      position,
+		Velocity:      velocity,
+		Rotation:      0,
+		RotationSpeed: 0,
+		Scale:         1.0,
+		LineWidth:     1.0,
+		Boundary:      geometry.BoundaryDespawn,
+		Margin:        despawnMargin,
+	}
+	return &Bullet{
+		entityBase: entityBase{
+			polygon:      polygon,
+			tag:          TagBullet,
+			layer:        LayerBullet,
+			collidesWith: LayerAsteroid | LayerComet | LayerDebris | LayerEnemyBullet,
+			lifetime:     newLifetime(bulletLifetime),
+		},
+		previous: position,
+		hitsLeft: 1,
+		owner:    OwnerPlayer,
+	}
+}
+
+// newEnemyBullet creates a bullet fired at the player by owner
+func newEnemyBullet(position, velocity geometry.Vector2, owner BulletOwner) *Bullet {
+	b := newBullet(position, velocity)
+	b.tag = TagEnemyBullet
+	b.layer = LayerEnemyBullet
+	b.collidesWith = 0
+	b.owner = owner
+	b.lifetime.Remaining = enemyBulletLifetime
+	return b
+}
+
+// makePiercing lets the bullet pass through asteroids until it has split
+// piercingHits of them
+func (b *Bullet) makePiercing() {
+	b.piercing = true
+	b.hitsLeft = piercingHits
+}
+
+// makeCharged turns the bullet into a charged shot, bigger and able to
+// pass through an extra target
+func (b *Bullet) makeCharged() {
+	b.kind = BulletCharged
+	// Bullets are 2x2 to start with
+	b.polygon.SetScale(chargedBulletSize / 2)
+	b.hitsLeft = max(b.hitsLeft, chargedBulletHits)
+}
+
+// makeRicochet makes the bullet bounce off the screen edges
+func (b *Bullet) makeRicochet() {
+	b.polygon.Boundary = geometry.BoundaryBounce
+	b.bouncesLeft = ricochetBounces
+	b.lifetime.Remaining = ricochetLifetime
+}
+
+// color returns the bullet's color. Piercing bullets dim as they use up
+// their remaining hits, and every bullet dims a little with each bounce.
+func (b *Bullet) color() color.RGBA {
+	c := b.palette.Bullet
+	if b.owner.enemy() {
+		c = b.palette.Hit
+	}
+	if b.piercing {
+		c = fadeColor(b.palette.PowerUp, 0.4+0.6*float64(b.hitsLeft)/piercingHits)
+	}
+	return fadeColor(c, 1-0.2*float64(b.bounces))
+}
+
+// ApplyPalette colors the bullet
+func (b *Bullet) ApplyPalette(p *Palette) {
+	b.palette = p
+	b.polygon.SetColor(b.color())
+	b.tracerColor = p.Tracer
+}
+
+// hit uses up one of the bullet's hits, killing it once they have all been
+// used, or fading it to show how many remain
+func (b *Bullet) hit() {
+	b.hitsLeft--
+	if b.hitsLeft <= 0 {
+		b.Kill()
+		return
+	}
+	const fadeTicks = 10
+	b.polygon.StartFade(b.color(), fadeTicks)
+}
+
+// Update moves the bullet and kills it once it has left the screen, or
+// its lifetime runs out. Ricochet bullets bounce off the edges until they
+// run out of bounces.
+func (b *Bullet) Update(ctx UpdateContext) {
+	b.previous = b.polygon.Position
+	b.age += ctx.TimeScale
+	switch b.polygon.Step(ctx.TimeScale, ctx.ScreenWidth, ctx.ScreenHeight) {
+	case geometry.EdgeBounced:
+		b.bouncesLeft--
+		b.bounces++
+		b.polygon.SetColor(b.color())
+		if b.bouncesLeft <= 0 {
+			b.polygon.Boundary = geometry.BoundaryDespawn
+		}
+	case geometry.EdgeLeft:
+		b.Kill()
+	}
+}
@@ -0,0 +1,23 @@
+//go:build !headless
+
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// DrawOverlay draws a dim streak from where the bullet was last tick to where
+// it is now. If the bullet wrapped around the screen the streak trails off
+// the edge it came in from, rather than crossing the whole screen.
+func (b *Bullet) DrawOverlay(screen *ebiten.Image, ctx DrawContext) {
+	pos := b.polygon.Position
+	bounds := screen.Bounds()
+	delta := geometry.WrapDelta(b.previous, pos, float64(bounds.Dx()), float64(bounds.Dy()))
+	vector.StrokeLine(screen,
+		float32(pos.X-delta.X), float32(pos.Y-delta.Y),
+		float32(pos.X), float32(pos.Y),
+		1+ctx.LineWidthBoost, b.tracerColor, true)
+}
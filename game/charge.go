@@ -0,0 +1,55 @@
+package game
+
+const (
+	// chargeDelayTicks is how long fire has to be held in semi-automatic
+	// mode before the ship starts charging a shot
+	chargeDelayTicks = 0.5 * ticksPerSecond
+	// chargeFullTicks is how long fire has to be held for the shot to be
+	// fully charged
+	chargeFullTicks = 1.5 * ticksPerSecond
+	// chargedBulletHits is how many targets a charged shot passes through,
+	// one more than a normal bullet
+	chargedBulletHits = 2
+	// chargedBulletSize is the width and height of a charged shot
+	chargedBulletSize = 6
+)
+
+// updateCharge builds up a ship's charge while fire is held in
+// semi-automatic mode, and fires a charged shot when fire is let go with
+// the charge full. Letting go sooner wastes the charge, the press having
+// already fired a normal bullet.
+func (g *Game) updateCharge(p *Player, in InputState, timeScale float64) {
+	if g.fireMode() != FireSemi {
+		p.charge = 0
+		return
+	}
+	if in.Fire {
+		wasFull := p.charged()
+		p.charge += timeScale
+		if !wasFull && p.charged() {
+			g.emit(ShotCharged{Partner: p.partner})
+		}
+		return
+	}
+	if p.lastInput.Fire && p.charged() {
+		g.createChargedBullet(p)
+	}
+	p.charge = 0
+}
+
+// charged reports whether the ship's shot is fully charged
+func (p *Player) charged() bool {
+	return p.charge >= chargeFullTicks
+}
+
+// createChargedBullet fires a charged shot from the tip of a ship
+func (g *Game) createChargedBullet(p *Player) {
+	tip := p.nose()
+	bullet := g.spawnBullet(p, tip, g.assistAim(tip, p.polygon.Rotation))
+	if bullet == nil {
+		return
+	}
+	bullet.makeCharged()
+	g.addEntity(newMuzzleFlash(tip, p.polygon.Rotation))
+	p.lastShotTick = g.survivalTicks
+}
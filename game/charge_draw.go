@@ -0,0 +1,46 @@
+//go:build !headless
+
+package game
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// chargeProgress returns how far the ship is through charging its shot,
+// from 0 before charging starts to 1 once it is full
+func (p *Player) chargeProgress() float64 {
+	return math.Max(0, math.Min(1, (p.charge-chargeDelayTicks)/(chargeFullTicks-chargeDelayTicks)))
+}
+
+// drawChargeGlow draws a glow on the ship's nose while it charges, growing
+// brighter as the charge builds and pulsing once it is full
+func (p *Player) drawChargeGlow(screen *ebiten.Image, ctx DrawContext) {
+	progress := p.chargeProgress()
+	if progress == 0 {
+		return
+	}
+	brightness := 0.3 + 0.5*progress
+	if p.charged() && ctx.Flashing {
+		brightness = 0.8 + 0.2*math.Sin(p.charge*0.4)
+	}
+	tip := p.nose()
+	radius := float32(2 + 2*progress)
+	vector.FillCircle(screen, float32(tip.X), float32(tip.Y), radius, fadeColor(p.chargeColor, brightness), true)
+}
+
+// drawChargeIndicator shows on the HUD when the local ship's shot is fully
+// charged
+func (g *Game) drawChargeIndicator(screen *ebiten.Image) {
+	if !g.localShip().charged() {
+		return
+	}
+	const text = "CHARGED"
+	x := float32(g.screenWidth) - 20 - g.vectorFont.GetWidth(text)
+	y := float32(g.screenHeight) - 20 - beamBarHeight - 40
+	g.vectorFont.SetColor(g.palette.PowerUp)
+	g.vectorFont.DrawString(screen, text, x, y)
+	g.vectorFont.SetColor(g.palette.UI)
+}
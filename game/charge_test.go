@@ -0,0 +1,112 @@
+package game
+
+import "testing"
+
+// holdFire holds fire down on a ship for the given number of ticks, then
+// lets go
+func holdFire(t *testing.T, g *Game, ticks int) {
+	t.Helper()
+	for i := 0; i < ticks; i++ {
+		if err := g.Step(InputState{Fire: true}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := g.Step(InputState{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// chargedBullets counts the charged shots in flight
+func chargedBullets(g *Game) int {
+	n := 0
+	for _, e := range g.entities {
+		if b, ok := e.(*Bullet); ok && !b.Dead() && b.kind == BulletCharged {
+			n++
+		}
+	}
+	return n
+}
+
+func TestChargeShot(t *testing.T) {
+	g := New()
+	g.config.FireMode = FireSemi
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+	placeAsteroid(g, 10, 50, 50)
+	events := recordEvents(g)
+
+	// Letting go before the charge is full only fires the normal shot
+	holdFire(t, g, int(chargeDelayTicks)+5)
+	if got := chargedBullets(g); got != 0 {
+		t.Errorf("Expected no charged shot from a short hold, got %d", got)
+	}
+	if g.player.charge != 0 {
+		t.Errorf("Expected letting go to waste the charge, got %v", g.player.charge)
+	}
+
+	holdFire(t, g, int(chargeFullTicks))
+	if got := chargedBullets(g); got != 1 {
+		t.Errorf("Expected a charged shot, got %d", got)
+	}
+	charged := 0
+	for _, e := range *events {
+		if _, ok := e.(ShotCharged); ok {
+			charged++
+		}
+	}
+	if charged != 1 {
+		t.Errorf("Expected the full charge to be announced once, got %d", charged)
+	}
+}
+
+func TestNoChargeInAutoMode(t *testing.T) {
+	g := New()
+	g.config.FireMode = FireAuto
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+	placeAsteroid(g, 10, 50, 50)
+	holdFire(t, g, int(chargeFullTicks))
+	if got := chargedBullets(g); got != 0 || g.player.charge != 0 {
+		t.Errorf("Expected automatic fire not to charge, got %d shots", got)
+	}
+}
+
+func TestChargedShotDestroysOutright(t *testing.T) {
+	g := New()
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+	events := recordEvents(g)
+	first := placeAsteroid(g, 40, 400, 200)
+	second := placeAsteroid(g, 40, 400, 100)
+
+	bullet := newBullet(first.polygon.Position, first.polygon.Velocity)
+	bullet.makeCharged()
+	g.addEntity(bullet)
+	g.checkCollisions()
+	g.dispatchEvents()
+	if !first.Dead() || g.countEntities(TagAsteroid) != 1 {
+		t.Fatalf("Expected the asteroid to be destroyed without splitting, %d left", g.countEntities(TagAsteroid))
+	}
+	if bullet.Dead() {
+		t.Fatalf("Expected the charged shot to pass through its first target")
+	}
+
+	bullet.polygon.SetPosition(second.polygon.Position.X, second.polygon.Position.Y)
+	g.checkCollisions()
+	g.dispatchEvents()
+	if !second.Dead() || !bullet.Dead() {
+		t.Errorf("Expected the second hit to use up the charged shot")
+	}
+	destroyed := 0
+	for _, e := range *events {
+		if _, ok := e.(AsteroidDestroyed); ok {
+			destroyed++
+		}
+	}
+	if destroyed != 2 {
+		t.Errorf("Expected two asteroids destroyed, got %d", destroyed)
+	}
+}
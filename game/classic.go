@@ -0,0 +1,63 @@
+package game
+
+// classicWaves is how many waves a classic run has. Clearing the last one
+// wins the run.
+const classicWaves = 5
+
+// startClassicWave fills the field with the current wave's asteroids, one
+// more than the wave before, along with its debris
+func (g *Game) startClassicWave() {
+	g.emit(WaveStarted{Wave: g.wave})
+	g.startWaveStats()
+	for i := 0; i < g.tuning.AsteroidCount+g.wave-1; i++ {
+		asteroid := g.randomAsteroidShape()
+		a := newAsteroid(asteroid)
+
+		// Place it again while it would reach the ship too soon to dodge
+		for try := 0; try < fairSpawnTries; try++ {
+			// Random position within the screen bounds (with some margin)
+			asteroid.SetPosition(
+				50+g.rng.Float64()*(g.screenWidth-100),  // X between 50 and 750
+				50+g.rng.Float64()*(g.screenHeight-100), // Y between 50 and 550
+			)
+
+			// Random rotation
+			asteroid.SetRotation(g.rng.Float64() * 6.28) // 0 to 2π radians
+
+			// Random velocity (pixels per frame)
+			vx := (g.rng.Float64() - 0.5) * 4 // -2 to 2 pixels per frame
+			vy := (g.rng.Float64() - 0.5) * 4 // -2 to 2 pixels per frame
+			asteroid.SetVelocity(vx*g.rules.asteroidSpeed, vy*g.rules.asteroidSpeed)
+			if g.fairFor(a) {
+				break
+			}
+		}
+
+		// Random rotation speed (radians per frame)
+		rotSpeed := (g.rng.Float64() - 0.5) * 0.1 // -0.05 to 0.05 radians per frame
+		asteroid.SetRotationSpeed(rotSpeed)
+
+		if g.rollTurret() {
+			g.makeTurret(a)
+		}
+		if g.rollArmored() {
+			a.makeArmored()
+		}
+		g.spawnAsteroid(a)
+		a.materialize()
+		g.addMoonlets(a)
+	}
+	g.spawnDebris()
+}
+
+// nextClassicWave starts the next wave once the tally for the last one is
+// done, or wins the run if that was the final wave
+func (g *Game) nextClassicWave() {
+	if g.wave >= classicWaves {
+		g.endRun("YOU WIN!")
+		return
+	}
+	g.wave++
+	g.rollWind()
+	g.startClassicWave()
+}
@@ -0,0 +1,78 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestClassicWavesGrow(t *testing.T) {
+	g := New()
+	g.Restart(RestartOptions{})
+	first := g.countEntities(TagAsteroid)
+
+	g.entities = []Entity{g.player}
+	g.nextClassicWave()
+	if g.wave != 2 || g.countEntities(TagAsteroid) <= first {
+		t.Errorf("Expected wave 2 to bring more than %d asteroids, got %d on wave %d", first, g.countEntities(TagAsteroid), g.wave)
+	}
+
+	g.wave = classicWaves
+	g.nextClassicWave()
+	if g.state != GameStateGameOver || g.gameOverReason != "YOU WIN!" {
+		t.Errorf("Expected clearing the last wave to win, got %v %q", g.state, g.gameOverReason)
+	}
+}
+
+// clearWave destroys the asteroids and comets in g's field and plays on,
+// as a run does once the player has cleared a wave, until the next wave
+// has started
+func clearWave(t *testing.T, g *Game) {
+	t.Helper()
+	wave := g.wave
+	for tick := 0; g.wave == wave; tick++ {
+		if !g.InRun() || tick > 30*ticksPerSecond {
+			t.Fatalf("Expected wave %d to follow wave %d, got %q after %d ticks", wave+1, wave, g.gameOverReason, tick)
+		}
+		for _, e := range g.entities {
+			if e.Tag() == TagAsteroid || e.Tag() == TagComet {
+				e.Kill()
+			}
+		}
+		if err := g.Step(InputState{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// countTurrets returns how many turrets are in g's field
+func countTurrets(g *Game) int {
+	turrets := 0
+	for _, e := range g.entities {
+		if a, ok := e.(*Asteroid); ok && !a.Dead() && a.turret != nil {
+			turrets++
+		}
+	}
+	return turrets
+}
+
+func TestClassicRunReachesTurrets(t *testing.T) {
+	// Turrets are rolled for, so play the first waves of a run for each of
+	// several seeds. None should have a turret too early, and at least one
+	// should have one once turrets can turn up.
+	withTurrets := 0
+	for seed := int64(1); seed <= 20; seed++ {
+		g := New(WithSeed(seed))
+		g.Restart(RestartOptions{})
+		for g.wave < turretFirstWave {
+			if turrets := countTurrets(g); turrets > 0 {
+				t.Fatalf("Seed %d: expected no turrets before wave %d, got %d on wave %d", seed, turretFirstWave, turrets, g.wave)
+			}
+			clearWave(t, g)
+		}
+		if countTurrets(g) > 0 {
+			withTurrets++
+		}
+	}
+	if withTurrets == 0 {
+		t.Errorf("Expected turrets on wave %d of some runs, got none in 20", turretFirstWave)
+	}
+}
@@ -0,0 +1,75 @@
+package game
+
+import (
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+const (
+	// closeCallDistance is how near, in pixels, a ship has to be to the edge
+	// of an asteroid it shoots for the kill to count as a close call
+	closeCallDistance = 80
+	// closeCallMultiplier multiplies the points for a close call kill
+	closeCallMultiplier = 2
+	// popupTicks is how long a popup floats above where it was shown
+	popupTicks = ticksPerSecond
+	// popupRise is how far, in pixels, a popup floats up over its life
+	popupRise = 30
+)
+
+// popup is a short message shown where something happened, which floats
+// up and fades out
+type popup struct {
+	text     string
+	position geometry.Vector2
+	// ticks counts down the popup's remaining life
+	ticks int
+}
+
+// closeRange returns how far the nearer edge of an asteroid, approximated
+// as a circle around its centroid, is from a ship's centroid, going the
+// short way around the screen. It is negative if the ship is inside that
+// circle.
+func closeRange(ship, asteroid *geometry.PolygonObject, screenWidth, screenHeight float64) float64 {
+	distance := geometry.WrapDistance(centerOf(ship), centerOf(asteroid), screenWidth, screenHeight)
+	return distance - approximateRadius(asteroid)
+}
+
+// closeCall reports whether any live ship is within closeCallDistance of
+// the asteroid
+func (g *Game) closeCall(asteroid *geometry.PolygonObject) bool {
+	for _, ship := range []*Player{g.player, g.partner} {
+		if ship == nil || ship.Dead() {
+			continue
+		}
+		if closeRange(ship.Collider(), asteroid, g.screenWidth, g.screenHeight) < closeCallDistance {
+			return true
+		}
+	}
+	return false
+}
+
+// handleCloseCalls counts the close call kills and marks each with a popup
+func (g *Game) handleCloseCalls(e Event) {
+	if e, ok := e.(AsteroidDestroyed); ok && e.CloseCall {
+		g.waveStats.closeCalls++
+		g.runTotals.closeCalls++
+		g.showPopup("CLOSE!", e.Position)
+	}
+}
+
+// showPopup shows text floating up from position
+func (g *Game) showPopup(text string, position geometry.Vector2) {
+	g.popups = append(g.popups, popup{text: text, position: position, ticks: popupTicks})
+}
+
+// updatePopups ages the popups, dropping those that have finished
+func (g *Game) updatePopups() {
+	live := g.popups[:0]
+	for _, p := range g.popups {
+		p.ticks--
+		if p.ticks > 0 {
+			live = append(live, p)
+		}
+	}
+	g.popups = live
+}
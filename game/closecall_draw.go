@@ -0,0 +1,20 @@
+//go:build !headless
+
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// drawPopups draws each popup centered over where it was shown, rising and
+// fading as it ages
+func (g *Game) drawPopups(screen *ebiten.Image) {
+	for _, p := range g.popups {
+		progress := 1 - float64(p.ticks)/popupTicks
+		x := float32(p.position.X) - g.vectorFont.GetWidth(p.text)/2
+		y := float32(p.position.Y - progress*popupRise)
+		g.vectorFont.SetColor(fadeColor(g.palette.Score, 1-progress))
+		g.vectorFont.DrawString(screen, p.text, x, y)
+	}
+	g.vectorFont.SetColor(g.palette.UI)
+}
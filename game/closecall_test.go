@@ -0,0 +1,101 @@
+package game
+
+import (
+	"math"
+	"testing"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+func TestCloseRange(t *testing.T) {
+	const width, height = 800, 600
+	ship := geometry.CreateCircle(10, 12)
+	asteroid := geometry.CreateCircle(40, 12)
+	radius := approximateRadius(asteroid)
+
+	tests := []struct {
+		name         string
+		ship, target geometry.Vector2
+		want         float64
+	}{
+		{"straight across", geometry.Vector2{X: 100, Y: 300}, geometry.Vector2{X: 200, Y: 300}, 100 - radius},
+		{"across the wrap", geometry.Vector2{X: 10, Y: 300}, geometry.Vector2{X: 760, Y: 300}, 50 - radius},
+		{"diagonally across the corner", geometry.Vector2{X: 5, Y: 5}, geometry.Vector2{X: 785, Y: 585}, math.Hypot(20, 20) - radius},
+		{"inside the asteroid", geometry.Vector2{X: 300, Y: 300}, geometry.Vector2{X: 310, Y: 300}, 10 - radius},
+	}
+	for _, tt := range tests {
+		ship.SetPosition(tt.ship.X, tt.ship.Y)
+		asteroid.SetPosition(tt.target.X, tt.target.Y)
+		if got := closeRange(ship, asteroid, width, height); math.Abs(got-tt.want) > 1e-6 {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestCloseCallLargeAsteroidMeasuredFromEdge(t *testing.T) {
+	g := New()
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+	center := centerOf(g.player.polygon)
+
+	// A huge rock whose middle is well beyond the threshold still counts if
+	// its edge is close, and a small one the same distance away doesn't
+	big := placeAsteroid(g, 100, center.X+150, center.Y)
+	small := placeAsteroid(g, 10, center.X+150, center.Y)
+	if !g.closeCall(big.polygon) {
+		t.Errorf("Expected the edge of a large asteroid to be a close call, at %v", closeRange(g.player.polygon, big.polygon, g.screenWidth, g.screenHeight))
+	}
+	if g.closeCall(small.polygon) {
+		t.Errorf("Expected a small asteroid as far away not to be a close call, at %v", closeRange(g.player.polygon, small.polygon, g.screenWidth, g.screenHeight))
+	}
+
+	// A dead ship can't have a close call
+	g.player.Kill()
+	if g.closeCall(big.polygon) {
+		t.Errorf("Expected no close call without a live ship")
+	}
+}
+
+func TestCloseCallDoublesPoints(t *testing.T) {
+	g := New()
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+	events := recordEvents(g)
+	center := centerOf(g.player.polygon)
+
+	g.splitAsteroid(placeAsteroid(g, 40, center.X+60, center.Y), OwnerPlayer)
+	g.dispatchEvents()
+	if g.score != closeCallMultiplier {
+		t.Errorf("Expected a close call to score %d, got %d", closeCallMultiplier, g.score)
+	}
+	if len(g.popups) != 1 || g.popups[0].text != "CLOSE!" {
+		t.Errorf("Expected a CLOSE! popup, got %+v", g.popups)
+	}
+
+	// Far away, and rammed rather than shot, score as before
+	g.splitAsteroid(placeAsteroid(g, 40, center.X+300, center.Y), OwnerPlayer)
+	g.splitAsteroid(placeAsteroid(g, 40, center.X+60, center.Y+60), OwnerNone)
+	g.dispatchEvents()
+	if g.score != closeCallMultiplier+1 {
+		t.Errorf("Expected a distant kill to score 1, score is %d", g.score)
+	}
+	closeCalls := 0
+	for _, e := range *events {
+		if d, ok := e.(AsteroidDestroyed); ok && d.CloseCall {
+			closeCalls++
+		}
+	}
+	if closeCalls != 1 || g.waveStats.closeCalls != 1 || g.runTotals.closeCalls != 1 {
+		t.Errorf("Expected one close call counted, got %d events, %d in the wave and %d in the run", closeCalls, g.waveStats.closeCalls, g.runTotals.closeCalls)
+	}
+
+	// The popup floats away after a second
+	for range popupTicks {
+		g.updatePopups()
+	}
+	if len(g.popups) != 0 {
+		t.Errorf("Expected the popup to have gone, got %+v", g.popups)
+	}
+}
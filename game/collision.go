@@ -0,0 +1,186 @@
+package game
+
+import (
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// CollisionLayer is a bitmask of the collision layers an entity is on, or
+// that it collides with
+type CollisionLayer uint32
+
+const (
+	LayerPlayer CollisionLayer = 1 << iota
+	LayerAsteroid
+	LayerBullet
+	LayerPickup
+	LayerEnemyBullet
+	LayerComet
+	LayerDebris
+	LayerStation
+)
+
+// collisionRule handles collisions between an entity on layer a, which must
+// collide with layer b, and an entity on layer b
+type collisionRule struct {
+	a, b CollisionLayer
+	// handle is called for each colliding pair, and returns true if no more
+	// pairs should be handled by this rule during the tick
+	handle func(g *Game, a, b Entity) bool
+	// collide tests whether a pair touches. The colliders' outlines are
+	// compared if it is nil.
+	collide func(g *Game, a, b Entity) bool
+}
+
+// collisionRules lists how each pair of layers interacts. Pairs of layers
+// without a rule never collide.
+var collisionRules = []collisionRule{
+	{a: LayerBullet, b: LayerAsteroid, handle: (*Game).bulletHitsAsteroid},
+	{a: LayerPlayer, b: LayerAsteroid, handle: (*Game).playerHitsAsteroid},
+	{a: LayerPlayer, b: LayerPickup, handle: (*Game).playerCollectsPickup},
+	{a: LayerPlayer, b: LayerEnemyBullet, handle: (*Game).playerHitByBullet, collide: (*Game).shipHitByBullet},
+	{a: LayerPlayer, b: LayerBullet, handle: (*Game).playerHitByBullet, collide: (*Game).shipHitByBullet},
+	{a: LayerBullet, b: LayerComet, handle: (*Game).bulletHitsComet},
+	{a: LayerPlayer, b: LayerComet, handle: (*Game).playerHitsComet},
+	{a: LayerBullet, b: LayerDebris, handle: (*Game).bulletHitsDebris},
+	{a: LayerPlayer, b: LayerDebris, handle: (*Game).playerHitsDebris},
+	{a: LayerStation, b: LayerAsteroid, handle: (*Game).stationHitByAsteroid},
+	{a: LayerPlayer, b: LayerStation, handle: (*Game).playerHitsStation},
+	{a: LayerBullet, b: LayerEnemyBullet, handle: (*Game).bulletHitsEnemyBullet, collide: (*Game).bulletsCross},
+}
+
+// collisionRuleFor returns the index of the rule for an entity on layer a
+// colliding with one on layer b, or -1 if there isn't one
+func collisionRuleFor(a, b CollisionLayer) int {
+	for i, rule := range collisionRules {
+		if rule.a == a && rule.b == b {
+			return i
+		}
+	}
+	return -1
+}
+
+// collisionPairs calls visit with every pair of live entities that should be
+// tested for a collision, along with the index of the rule that handles
+// them. Pairs are only considered when a's mask includes b's layer, and
+// entities killed by visit are skipped from then on.
+func (g *Game) collisionPairs(visit func(a, b Entity, rule int)) {
+	for i := len(g.entities) - 1; i >= 0; i-- {
+		a := g.entities[i]
+		layer, mask := a.CollisionLayers()
+		if mask == 0 {
+			continue
+		}
+		for j := len(g.entities) - 1; j >= 0 && !a.Dead(); j-- {
+			b := g.entities[j]
+			other, _ := b.CollisionLayers()
+			if i == j || mask&other == 0 || b.Dead() {
+				continue
+			}
+			if rule := collisionRuleFor(layer, other); rule >= 0 {
+				visit(a, b, rule)
+			}
+		}
+	}
+}
+
+// checkCollisions tests every pair of entities whose collision masks
+// intersect, and dispatches the colliding ones to their rule
+func (g *Game) checkCollisions() {
+	done := make([]bool, len(collisionRules))
+	g.collisionPairs(func(a, b Entity, rule int) {
+		if done[rule] {
+			return
+		}
+		if collide := collisionRules[rule].collide; collide != nil {
+			if !collide(g, a, b) {
+				return
+			}
+		} else if !geometry.PolygonsCollide(a.Collider(), b.Collider()) {
+			return
+		}
+		g.emit(Collision{A: a.Tag(), B: b.Tag(), Position: b.Collider().Position})
+		done[rule] = collisionRules[rule].handle(g, a, b)
+		// Remember the asteroid that ended the run, to show in the death review
+		if killer, ok := b.(*Asteroid); ok && a.Tag() == TagPlayer && !g.InRun() && g.killer == nil {
+			g.killer = killer
+		}
+	})
+}
+
+// bulletHitsAsteroid uses up the bullet and splits the asteroid. Only one
+// bullet hit is handled per tick.
+func (g *Game) bulletHitsAsteroid(bullet, asteroid Entity) bool {
+	// Don't let a piercing bullet immediately hit the fragments of the
+	// asteroid it just split
+	if asteroid.(*Asteroid).spawnTick == g.survivalTicks {
+		return false
+	}
+
+	// Use up the bullet, which removes it unless it is piercing
+	b := bullet.(*Bullet)
+	b.hit()
+	g.bulletLanded(b)
+	a := asteroid.(*Asteroid)
+	a.impact = impactEnergy(b.polygon, a.polygon)
+	g.showImpact(b.polygon.Position, a.impact)
+
+	// Split the asteroid along the bullet's path or remove it if too small.
+	// Armored asteroids take several shots, charged shots destroy it
+	// whatever its size, and moonlets are worth more.
+	switch {
+	case a.armored() && b.kind != BulletCharged:
+		g.damageAsteroid(a, b.polygon.Position)
+	case a.orbit != nil:
+		g.shootMoonlet(a, b.owner)
+	case b.kind == BulletCharged:
+		g.destroyAsteroid(asteroid, b.owner)
+	default:
+		g.splitAsteroidAlong(asteroid, b.owner, b)
+	}
+	return true
+}
+
+// playerHitsAsteroid damages or destroys the ship. Only the first asteroid
+// touching the ship counts each tick.
+func (g *Game) playerHitsAsteroid(ship, asteroid Entity) bool {
+	g.showImpact(ship.Collider().Position, impactEnergy(ship.Collider(), asteroid.Collider()))
+	g.collideWithAsteroid(ship, asteroid)
+	return true
+}
+
+// playerCollectsPickup removes the pickup and lets subscribers apply it.
+// Bullets pass straight through pickups.
+func (g *Game) playerCollectsPickup(ship, pickup Entity) bool {
+	pickup.Kill()
+	p := pickup.(*Pickup)
+	g.emit(PickupCollected{Kind: p.kind, Points: p.points(), Position: p.polygon.Position, Partner: ship == g.partner})
+	return false
+}
+
+// playerHitByBullet uses up the bullet and damages or destroys the ship
+func (g *Game) playerHitByBullet(ship, bullet Entity) bool {
+	bullet.Kill()
+	reason := "GAME OVER"
+	if bullet.(*Bullet).owner == ship.(*Player).owner() {
+		reason = "SHOT YOURSELF"
+	}
+	g.collideWithBullet(ship, reason)
+	return true
+}
+
+// shipHitByBullet tests whether a bullet touches a ship it can hurt, which
+// depends on who fired it and the run's fire policy, or on the self hazard
+// mutator for the ship's own bullets
+func (g *Game) shipHitByBullet(ship, bullet Entity) bool {
+	b, p := bullet.(*Bullet), ship.(*Player)
+	if !shouldCollide(b.owner, p.owner(), g.firePolicy()) && !g.hitsOwnShip(b, p) {
+		return false
+	}
+	return geometry.PolygonsCollide(ship.Collider(), bullet.Collider())
+}
+
+// hitsOwnShip reports whether a bullet can hit the ship that fired it: only
+// with the self hazard mutator, once it is clear of the gun
+func (g *Game) hitsOwnShip(b *Bullet, p *Player) bool {
+	return g.rules.selfHazard && b.owner == p.owner() && b.age >= selfHazardGraceTicks
+}
@@ -0,0 +1,87 @@
+package game
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// tagPair is a pair of entity tags tested against each other
+type tagPair struct {
+	a, b EntityTag
+}
+
+func TestCollisionPairsForMixedEntities(t *testing.T) {
+	g := New()
+	g.entities = []Entity{g.player}
+
+	placeAsteroid(g, 30, 100, 100)
+	placeAsteroid(g, 30, 300, 300)
+	g.addEntity(newBullet(geometry.Vector2{X: 500, Y: 500}, geometry.Vector2{}))
+	g.addEntity(newPickup(PickupScore, geometry.Vector2{X: 600, Y: 100}))
+	g.addEntity(newMuzzleFlash(geometry.Vector2{X: 500, Y: 500}, 0))
+	g.addEntity(newShockwave(geometry.Vector2{X: 100, Y: 100}, 40))
+
+	var pairs []tagPair
+	g.collisionPairs(func(a, b Entity, rule int) {
+		pairs = append(pairs, tagPair{a.Tag(), b.Tag()})
+	})
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].a != pairs[j].a {
+			return pairs[i].a < pairs[j].a
+		}
+		return pairs[i].b < pairs[j].b
+	})
+
+	// Asteroids never test against each other, and bullets, pickups and
+	// effects ignore each other. The ship is tested against bullets, which
+	// only hurt it if the fire policy allows.
+	want := []tagPair{
+		{TagPlayer, TagAsteroid},
+		{TagPlayer, TagAsteroid},
+		{TagPlayer, TagBullet},
+		{TagPlayer, TagPickup},
+		{TagBullet, TagAsteroid},
+		{TagBullet, TagAsteroid},
+	}
+	if !reflect.DeepEqual(pairs, want) {
+		t.Errorf("Expected pairs %v, got %v", want, pairs)
+	}
+}
+
+func TestCollisionPairsSkipDeadEntities(t *testing.T) {
+	g := New()
+	g.entities = []Entity{g.player}
+	asteroid := placeAsteroid(g, 30, 100, 100)
+	asteroid.Kill()
+	bullet := newBullet(geometry.Vector2{X: 100, Y: 100}, geometry.Vector2{})
+	g.addEntity(bullet)
+
+	count := 0
+	g.collisionPairs(func(a, b Entity, rule int) {
+		if a == asteroid || b == asteroid {
+			count++
+		}
+	})
+	if count != 0 {
+		t.Errorf("Expected no pairs with a dead asteroid, got %d", count)
+	}
+}
+
+func TestCollisionOneBulletHitPerTick(t *testing.T) {
+	g := New()
+	g.entities = []Entity{g.player}
+
+	placeAsteroid(g, 10, 100, 100)
+	placeAsteroid(g, 10, 300, 300)
+	g.addEntity(newBullet(geometry.Vector2{X: 100, Y: 100}, geometry.Vector2{}))
+	g.addEntity(newBullet(geometry.Vector2{X: 300, Y: 300}, geometry.Vector2{}))
+
+	g.checkCollisions()
+
+	if got := g.countEntities(TagBullet); got != 1 {
+		t.Errorf("Expected only one bullet to hit this tick, %d left", got)
+	}
+}
@@ -0,0 +1,152 @@
+package game
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+const (
+	// cometPoints is the score for shooting down a comet
+	cometPoints = 250
+	// cometMinInterval and cometMaxInterval bound the random time between
+	// comets
+	cometMinInterval = 20 * ticksPerSecond
+	cometMaxInterval = 40 * ticksPerSecond
+	// cometMinSpeed and cometMaxSpeed bound a comet's speed, in pixels per
+	// tick, which gets it across the screen in a couple of seconds
+	cometMinSpeed = 6.0
+	cometMaxSpeed = 9.0
+	// cometTrailLength is how many past positions a comet's trail covers
+	cometTrailLength = 20
+	// despawnMargin is how far past the screen edge entities that don't wrap
+	// can go before they are removed
+	despawnMargin = 50.0
+)
+
+// trail remembers an entity's recent positions, so a streak can be drawn
+// behind it
+type trail struct {
+	points []geometry.Vector2
+	// length is how many positions are kept, and interval how many ticks
+	// apart they are sampled
+	length   int
+	interval float64
+	// sinceSample counts the ticks since the last position was kept
+	sinceSample float64
+}
+
+// newTrail creates a trail keeping length positions, sampled interval ticks
+// apart
+func newTrail(length int, interval float64) *trail {
+	return &trail{length: length, interval: interval}
+}
+
+// update samples position if enough time has passed since the last sample
+func (t *trail) update(position geometry.Vector2, timeScale float64) {
+	t.sinceSample += timeScale
+	if t.sinceSample < t.interval && len(t.points) > 0 {
+		return
+	}
+	t.sinceSample = 0
+	if len(t.points) == t.length {
+		copy(t.points, t.points[1:])
+		t.points = t.points[:t.length-1]
+	}
+	t.points = append(t.points, position)
+}
+
+// Comet is a small, fast rock that streaks across the screen once, trailing
+// a long tail. It doesn't wrap, and it doesn't count towards clearing a wave.
+type Comet struct {
+	entityBase
+	trail *trail
+	color color.RGBA
+}
+
+// newComet creates a comet at position with the given velocity
+func newComet(position, velocity geometry.Vector2) *Comet {
+	polygon := geometry.CreateAsteroid(6, 1.5, 6)
+	polygon.SetPosition(position.X, position.Y)
+	polygon.SetVelocity(velocity.X, velocity.Y)
+	polygon.SetRotationSpeed(0.2)
+	polygon.Boundary = geometry.BoundaryDespawn
+	polygon.Margin = despawnMargin
+	return &Comet{
+		entityBase: entityBase{polygon: polygon, tag: TagComet, layer: LayerComet},
+		// Sampled every tick, for a tighter, longer tail than the bullets'
+		trail: newTrail(cometTrailLength, 1),
+	}
+}
+
+// Update moves the comet, removing it once it has left the screen
+func (c *Comet) Update(ctx UpdateContext) {
+	c.trail.update(c.polygon.Position, ctx.TimeScale)
+	if c.polygon.Step(ctx.TimeScale, ctx.ScreenWidth, ctx.ScreenHeight) == geometry.EdgeLeft {
+		c.Kill()
+	}
+}
+
+// ApplyPalette colors the comet
+func (c *Comet) ApplyPalette(p *Palette) {
+	c.color = p.Comet
+	c.polygon.SetColor(p.Comet)
+}
+
+// randomCometInterval returns the number of ticks until the next comet
+func (g *Game) randomCometInterval() int {
+	return cometMinInterval + g.rng.Intn(cometMaxInterval-cometMinInterval)
+}
+
+// updateComets launches a comet whenever the comet timer runs out
+func (g *Game) updateComets() {
+	g.cometTimer--
+	if g.cometTimer > 0 {
+		return
+	}
+	g.cometTimer = g.randomCometInterval()
+	g.spawnComet()
+}
+
+// spawnComet launches a comet from just outside a random edge, across the
+// screen through a random point in its middle
+func (g *Game) spawnComet() *Comet {
+	const offset = 10.0
+	var x, y float64
+	switch g.rng.Intn(4) {
+	case 0: // Top
+		x, y = g.rng.Float64()*g.screenWidth, -offset
+	case 1: // Bottom
+		x, y = g.rng.Float64()*g.screenWidth, g.screenHeight+offset
+	case 2: // Left
+		x, y = -offset, g.rng.Float64()*g.screenHeight
+	default: // Right
+		x, y = g.screenWidth+offset, g.rng.Float64()*g.screenHeight
+	}
+	targetX := g.screenWidth * (0.25 + g.rng.Float64()*0.5)
+	targetY := g.screenHeight * (0.25 + g.rng.Float64()*0.5)
+	dx, dy := targetX-x, targetY-y
+	distance := math.Hypot(dx, dy)
+	speed := cometMinSpeed + g.rng.Float64()*(cometMaxSpeed-cometMinSpeed)
+
+	comet := newComet(geometry.Vector2{X: x, Y: y}, geometry.Vector2{X: dx / distance * speed, Y: dy / distance * speed})
+	g.addEntity(comet)
+	return comet
+}
+
+// bulletHitsComet uses up the bullet and destroys the comet
+func (g *Game) bulletHitsComet(bullet, comet Entity) bool {
+	b := bullet.(*Bullet)
+	b.hit()
+	g.bulletLanded(b)
+	comet.Kill()
+	g.emit(CometDestroyed{Position: comet.Collider().Position, Points: cometPoints, Owner: b.owner})
+	return true
+}
+
+// playerHitsComet treats a comet like any other rock the ship flies into
+func (g *Game) playerHitsComet(ship, comet Entity) bool {
+	g.collideWithAsteroid(ship, comet)
+	return true
+}
@@ -0,0 +1,38 @@
+//go:build !headless
+
+package game
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// draw strokes the trail from head, fading it towards its oldest end
+func (t *trail) draw(screen *ebiten.Image, head geometry.Vector2, width float32, c color.RGBA) {
+	from := head
+	for i := len(t.points) - 1; i >= 0; i-- {
+		to := t.points[i]
+		alpha := float64(i+1) / float64(t.length+1)
+		vector.StrokeLine(screen,
+			float32(from.X), float32(from.Y),
+			float32(to.X), float32(to.Y),
+			width, fadeColor(c, alpha), true)
+		from = to
+	}
+}
+
+// DrawOverlay draws the comet's tail, which is kept out of the phosphor trail
+func (c *Comet) DrawOverlay(screen *ebiten.Image, ctx DrawContext) {
+	c.trail.draw(screen, c.polygon.Position, 2+ctx.LineWidthBoost, c.color)
+}
+
+// Draw renders the comet without wrapped copies, as it never wraps
+func (c *Comet) Draw(screen *ebiten.Image, ctx DrawContext) {
+	opts := ctx.polygonOptions()
+	opts.NoWrap = true
+	c.polygon.DrawWithOptions(screen, opts)
+}
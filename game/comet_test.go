@@ -0,0 +1,100 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+func TestCometCrossesAndLeaves(t *testing.T) {
+	g := New()
+	g.Restart(RestartOptions{})
+	comet := g.spawnComet()
+	ctx := UpdateContext{ScreenWidth: g.screenWidth, ScreenHeight: g.screenHeight, TimeScale: 1}
+
+	ticks := 0
+	seen := false
+	for ; !comet.Dead() && ticks < 1000; ticks++ {
+		comet.Update(ctx)
+		seen = seen || onScreen(comet.Bounds(), g.screenWidth, g.screenHeight)
+	}
+	if !seen {
+		t.Errorf("Expected the comet to cross the screen")
+	}
+	if ticks < 60 || ticks > 4*60 {
+		t.Errorf("Expected the comet to take a couple of seconds to cross, took %d ticks", ticks)
+	}
+	if len(comet.trail.points) != cometTrailLength {
+		t.Errorf("Expected a %d point trail, got %d", cometTrailLength, len(comet.trail.points))
+	}
+}
+
+func TestTrailSampling(t *testing.T) {
+	tr := newTrail(3, 2)
+	for i := 0; i < 10; i++ {
+		tr.update(geometry.Vector2{X: float64(i)}, 1)
+	}
+	// Sampled on ticks 0, 2, 4, 6 and 8, keeping the last three
+	want := []geometry.Vector2{{X: 4}, {X: 6}, {X: 8}}
+	if len(tr.points) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, tr.points)
+	}
+	for i := range want {
+		if tr.points[i] != want[i] {
+			t.Errorf("Expected %v, got %v", want, tr.points)
+			break
+		}
+	}
+}
+
+func TestShootingCometScores(t *testing.T) {
+	g := New()
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+	placeAsteroid(g, 10, 50, 50)
+	comet := newComet(geometry.Vector2{X: 300, Y: 100}, geometry.Vector2{})
+	g.addEntity(comet)
+	g.addEntity(newBullet(geometry.Vector2{X: 300, Y: 100}, geometry.Vector2{}))
+
+	if err := g.updatePlaying(); err != nil {
+		t.Fatal(err)
+	}
+	if !comet.Dead() {
+		t.Errorf("Expected the comet to be shot down")
+	}
+	if g.score != cometPoints {
+		t.Errorf("Expected %d points, got %d", cometPoints, g.score)
+	}
+}
+
+func TestCometDoesNotHoldUpWave(t *testing.T) {
+	g := New()
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+	g.addEntity(newComet(geometry.Vector2{X: 100, Y: 100}, geometry.Vector2{X: 5}))
+
+	if err := g.updatePlaying(); err != nil {
+		t.Fatal(err)
+	}
+	if g.tally.running() || g.wave != 2 {
+		t.Errorf("Expected the wave to be cleared with only a comet left, on wave %d", g.wave)
+	}
+}
+
+func TestCometHitIsFatal(t *testing.T) {
+	g := New()
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+	placeAsteroid(g, 10, 50, 50)
+	g.addEntity(newComet(g.player.polygon.Position, geometry.Vector2{}))
+
+	if err := g.updatePlaying(); err != nil {
+		t.Fatal(err)
+	}
+	if g.state != GameStateGameOver {
+		t.Errorf("Expected flying into a comet to end the run")
+	}
+}
@@ -0,0 +1,118 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Config holds the settings that persist between runs of the game
+type Config struct {
+	Accessibility Accessibility `json:"accessibility"`
+	// Mutators remembers the mutators last chosen on the mutator screen
+	Mutators   Mutators    `json:"mutators"`
+	HighScores []HighScore `json:"high_scores,omitempty"`
+	// Palette is the name of the color palette to draw with
+	Palette string `json:"palette,omitempty"`
+	// Themes are drawn over the palette, changing every few waves. Empty
+	// uses the built in themes.
+	Themes []Palette `json:"themes,omitempty"`
+	// FixedPalette keeps the palette the same for every wave, rather than
+	// changing theme
+	FixedPalette bool `json:"fixed_palette,omitempty"`
+	// Difficulty is how hard the game plays. Empty means normal.
+	Difficulty Difficulty `json:"difficulty,omitempty"`
+	// Muted turns off sound, whatever the volumes
+	Muted bool `json:"muted,omitempty"`
+	// Volume is how loud the game plays, overall and on each channel
+	Volume Volumes `json:"volume"`
+	// FireMode is how holding fire behaves. Empty leaves it to the game
+	// mode.
+	FireMode FireMode `json:"fire_mode,omitempty"`
+	// AimAssist is the largest angle, in degrees, a shot is snapped by to
+	// line up with an asteroid. 0 turns it off.
+	AimAssist int `json:"aim_assist,omitempty"`
+	// FriendlyFire lets the ships of a network game hurt each other with
+	// their bullets. The host's choice is used for the run.
+	FriendlyFire bool `json:"friendly_fire,omitempty"`
+	// PlainWindowTitle keeps the window title fixed, rather than showing
+	// the run's progress in it
+	PlainWindowTitle bool `json:"plain_window_title,omitempty"`
+	// MomentumIndicator draws a line from the ship the way it is drifting
+	MomentumIndicator bool `json:"momentum_indicator,omitempty"`
+	// TickRate is how many times a second the game updates and polls
+	// input. The world is still stepped ticksPerSecond times a second,
+	// whatever the rate. 0 uses ebiten's default.
+	TickRate int `json:"tick_rate,omitempty"`
+	// Window is how the window was left last time, if it has been saved
+	Window *WindowState `json:"window,omitempty"`
+}
+
+// DefaultConfig returns the settings used when no config file exists
+func DefaultConfig() Config {
+	return Config{}
+}
+
+// DefaultConfigPath returns where the config file lives in the user's config
+// directory, or "" if there is no such directory (eg: in a browser)
+func DefaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "SpaceDebris", "config.json")
+}
+
+// LoadConfig reads the config file at path. A missing file is not an error,
+// and results in the default config.
+func LoadConfig(path string) (Config, error) {
+	cfg := DefaultConfig()
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, err
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return DefaultConfig(), fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Save writes the config to path, creating its directory if needed
+func (c Config) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// WithConfig starts the game with the given settings
+func WithConfig(cfg Config) Option {
+	return func(g *Game) {
+		g.config = cfg
+	}
+}
+
+// WithConfigPath sets where changes made on the settings screen are saved
+func WithConfigPath(path string) Option {
+	return func(g *Game) {
+		g.configPath = path
+	}
+}
+
+// saveConfig writes the current settings back to the config file, if there is one
+func (g *Game) saveConfig() error {
+	if g.configPath == "" {
+		return nil
+	}
+	return g.config.Save(g.configPath)
+}
@@ -0,0 +1,66 @@
+package game
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestConfigRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "config.json")
+
+	cfg := DefaultConfig()
+	cfg.Accessibility.ReducedMotion = true
+	if err := cfg.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded, cfg) {
+		t.Errorf("Expected %+v, got %+v", cfg, loaded)
+	}
+}
+
+func TestLoadConfigMissingFile(t *testing.T) {
+	cfg, err := LoadConfig(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Fatalf("Expected no error for a missing file, got %v", err)
+	}
+	if !reflect.DeepEqual(cfg, DefaultConfig()) {
+		t.Errorf("Expected default config, got %+v", cfg)
+	}
+}
+
+func TestLoadConfigInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadConfig(path)
+	if err == nil {
+		t.Errorf("Expected an error for invalid JSON")
+	}
+	if !reflect.DeepEqual(cfg, DefaultConfig()) {
+		t.Errorf("Expected default config on error, got %+v", cfg)
+	}
+}
+
+func TestConfigWindowRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	cfg := DefaultConfig()
+	cfg.Window = &WindowState{Width: 1024, Height: 768, X: 40, Y: 30, HasPosition: true, Fullscreen: true}
+	if err := cfg.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Window == nil || *loaded.Window != *cfg.Window {
+		t.Errorf("Expected the window %+v to be remembered, got %+v", *cfg.Window, loaded.Window)
+	}
+}
@@ -0,0 +1,73 @@
+package game
+
+import (
+	"math"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+const (
+	// crackSpread is how far either side of straight through the middle, in
+	// radians, a crack can run from the hit
+	crackSpread = 0.6
+	// crackMinReach and crackMaxReach bound how far a crack runs towards
+	// the far side, as a fraction of the way there
+	crackMinReach = 0.4
+	crackMaxReach = 0.9
+)
+
+// crack is a line across an asteroid left by a hit, in the asteroid's own
+// coordinates so it moves and turns with it
+type crack struct {
+	from, to geometry.Vector2
+}
+
+// crackAsteroid adds one or two cracks to an asteroid that has taken a hit
+// at position, running from the hit across the rock towards its far side
+func (g *Game) crackAsteroid(a *Asteroid, position geometry.Vector2) {
+	outline := geometry.DrawablePolygon(a.polygon.Vertices)
+	center := a.polygon.Centroid()
+	from := a.polygon.ToLocal(position)
+	// A hit on the outside of the rock cracks it from where the shot met
+	// its edge
+	if !geometry.PointInPolygon(from, outline) {
+		hits := geometry.SegmentPolygonIntersections(from, center, outline)
+		if len(hits) == 0 {
+			return
+		}
+		from = hits[0].Point
+	}
+
+	count := 1 + g.rng.Intn(2)
+	for range count {
+		angle := math.Atan2(center.Y-from.Y, center.X-from.X) + (g.rng.Float64()*2-1)*crackSpread
+		reach := crackMinReach + g.rng.Float64()*(crackMaxReach-crackMinReach)
+		if c, ok := crackAcross(outline, from, angle, reach); ok {
+			a.cracks = append(a.cracks, c)
+		}
+	}
+}
+
+// crackAcross returns a crack starting at from, inside or on the edge of
+// outline, and running along angle the given fraction of the way to where
+// it would first leave the outline
+func crackAcross(outline geometry.DrawablePolygon, from geometry.Vector2, angle, reach float64) (crack, bool) {
+	radius := 0.0
+	for _, v := range outline {
+		radius = math.Max(radius, math.Hypot(v.X-from.X, v.Y-from.Y))
+	}
+	far := geometry.Vector2{X: from.X + math.Cos(angle)*radius*2, Y: from.Y + math.Sin(angle)*radius*2}
+	// The edge the crack starts on doesn't count as where it leaves
+	for _, hit := range geometry.SegmentPolygonIntersections(from, far, outline) {
+		if hit.T*radius*2 < 1e-6 {
+			continue
+		}
+		to := geometry.Vector2{X: from.X + (hit.Point.X-from.X)*reach, Y: from.Y + (hit.Point.Y-from.Y)*reach}
+		if !geometry.PointInPolygon(geometry.Vector2{X: (from.X + to.X) / 2, Y: (from.Y + to.Y) / 2}, outline) {
+			// It runs along the outside of a concave outline
+			return crack{}, false
+		}
+		return crack{from: from, to: to}, true
+	}
+	return crack{}, false
+}
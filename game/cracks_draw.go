@@ -0,0 +1,41 @@
+//go:build !headless
+
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// drawCracks draws the asteroid's cracks, thinner than its outline, and
+// again across any edge it is wrapping over
+func (a *Asteroid) drawCracks(screen *ebiten.Image, ctx DrawContext, wrap bool) {
+	if len(a.cracks) == 0 {
+		return
+	}
+	points := make([]geometry.Vector2, 0, 2*len(a.cracks))
+	for _, c := range a.cracks {
+		points = append(points, c.from, c.to)
+	}
+	world := a.polygon.TransformedPoints(points, ctx.Rewind)
+	lineWidth := a.polygon.LineWidth/2 + ctx.LineWidthBoost
+
+	xs, ys := []float32{0}, []float32{0}
+	if wrap {
+		width, height := float32(screen.Bounds().Dx()), float32(screen.Bounds().Dy())
+		xs, ys = []float32{-width, 0, width}, []float32{-height, 0, height}
+	}
+	for i := 0; i < len(world); i += 2 {
+		from, to := world[i], world[i+1]
+		for _, dx := range xs {
+			for _, dy := range ys {
+				vector.StrokeLine(screen,
+					float32(from.X)+dx, float32(from.Y)+dy,
+					float32(to.X)+dx, float32(to.Y)+dy,
+					lineWidth, a.polygon.Color, true)
+			}
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package game
+
+import (
+	"math"
+	"testing"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// insideOrOn reports whether p is inside outline, or within a hair of its
+// edge
+func insideOrOn(p geometry.Vector2, outline geometry.DrawablePolygon) bool {
+	if geometry.PointInPolygon(p, outline) {
+		return true
+	}
+	for i, v := range outline {
+		w := outline[(i+1)%len(outline)]
+		if geometry.ClosestApproach(p, p, v, w) < 1e-6 {
+			return true
+		}
+	}
+	return false
+}
+
+func TestHitsCrackArmoredAsteroid(t *testing.T) {
+	g := newWeaponsGame()
+	a := placeAsteroid(g, 30, 400, 300)
+	a.makeArmored()
+	outline := geometry.DrawablePolygon(a.polygon.Vertices)
+
+	for hits := 1; hits < armoredHP; hits++ {
+		// Hit near the top edge rather than the middle
+		g.addEntity(newBullet(geometry.Vector2{X: 400, Y: 300 - 30}, geometry.Vector2{}))
+		g.checkCollisions()
+		if a.Dead() {
+			t.Fatalf("Expected the armor to hold on hit %d", hits)
+		}
+		if len(a.cracks) < hits || len(a.cracks) > 2*hits {
+			t.Fatalf("Expected one or two cracks a hit, got %d after %d hits", len(a.cracks), hits)
+		}
+	}
+	for _, c := range a.cracks {
+		middle := geometry.Vector2{X: (c.from.X + c.to.X) / 2, Y: (c.from.Y + c.to.Y) / 2}
+		if !insideOrOn(c.from, outline) || !insideOrOn(c.to, outline) || !geometry.PointInPolygon(middle, outline) {
+			t.Errorf("Expected the crack %v to lie inside the rock", c)
+		}
+	}
+
+	g.addEntity(newBullet(a.polygon.Position, geometry.Vector2{}))
+	g.checkCollisions()
+	if !a.Dead() {
+		t.Fatalf("Expected the last hit to split the asteroid")
+	}
+	for _, f := range fragmentsOf(g) {
+		if len(f.cracks) != 0 {
+			t.Errorf("Expected the fragments to start without cracks, got %v", f.cracks)
+		}
+	}
+}
+
+func TestCracksMoveWithAsteroid(t *testing.T) {
+	g := newWeaponsGame()
+	a := placeAsteroid(g, 30, 400, 300)
+	a.cracks = []crack{{from: geometry.Vector2{X: -10}, to: geometry.Vector2{X: 10, Y: 5}}}
+	a.polygon.SetRotation(math.Pi / 2)
+	a.polygon.SetScale(2)
+	a.polygon.SetPosition(100, 200)
+
+	world := a.polygon.TransformedPoints([]geometry.Vector2{a.cracks[0].from, a.cracks[0].to}, 0)
+	want := []geometry.Vector2{{X: 100, Y: 180}, {X: 90, Y: 220}}
+	for i := range want {
+		if math.Abs(world[i].X-want[i].X) > 1e-9 || math.Abs(world[i].Y-want[i].Y) > 1e-9 {
+			t.Errorf("Expected the crack's end %d at %v, got %v", i, want[i], world[i])
+		}
+	}
+}
+
+func TestCrackAcrossConcaveRock(t *testing.T) {
+	// A U shape, cracked from the top of one arm straight across the gap
+	u := geometry.DrawablePolygon{{X: 0, Y: 0}, {X: 30, Y: 0}, {X: 30, Y: 30}, {X: 20, Y: 30}, {X: 20, Y: 10}, {X: 10, Y: 10}, {X: 10, Y: 30}, {X: 0, Y: 30}}
+	c, ok := crackAcross(u, geometry.Vector2{X: 5, Y: 20}, 0, 1)
+	if !ok {
+		t.Fatalf("Expected a crack")
+	}
+	if math.Abs(c.to.X-10) > 1e-9 || math.Abs(c.to.Y-20) > 1e-9 {
+		t.Errorf("Expected the crack to stop at the inside of the arm, got %v", c.to)
+	}
+}
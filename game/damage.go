@@ -0,0 +1,180 @@
+package game
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+const (
+	// wingFlickerHull is the fraction of its hull a ship is left with below
+	// which a wing flickers, and sputterHull the fraction below which its
+	// engine sputters as well
+	wingFlickerHull = 0.6
+	sputterHull     = 0.3
+	// sputterThrust is how much of its usual thrust a sputtering engine
+	// gives
+	sputterThrust = 0.7
+	// sputterDropoutChance is the chance each tick of thrusting that a
+	// sputtering engine cuts out, for sputterDropoutTicks
+	sputterDropoutChance = 0.05
+	sputterDropoutTicks  = 8
+	// smokeInterval is the ticks between puffs of smoke from a sputtering
+	// engine, and smokeTicks how long each lasts
+	smokeInterval = 6
+	smokeTicks    = 40
+)
+
+// hullFraction returns how much of its hull a ship has left, from 1 for
+// undamaged, or for a ship without a hull, to 0
+func (p *Player) hullFraction() float64 {
+	if p.maxHull == 0 {
+		return 1
+	}
+	return float64(p.hull) / float64(p.maxHull)
+}
+
+// sputtering reports whether the ship is damaged badly enough for its
+// engine to sputter
+func (p *Player) sputtering() bool {
+	return p.hullFraction() < sputterHull
+}
+
+// wingFlickers reports whether the ship's wing is left out this tick. A
+// damaged ship's wing goes out on seemingly random ticks, picked by hashing
+// the tick rather than from the game's random numbers, as it is only for
+// show.
+func (p *Player) wingFlickers(tick int) bool {
+	if p.hullFraction() >= wingFlickerHull {
+		return false
+	}
+	return uint32(tick)*2654435761>>28 < 5
+}
+
+// wingEdge returns the edge of an outline that runs into its leftmost
+// vertex, which is a ship's left wing tip
+func wingEdge(vertices []geometry.Vector2) int {
+	tip := 0
+	for i, v := range vertices {
+		if v.X < vertices[tip].X {
+			tip = i
+		}
+	}
+	return (tip + len(vertices) - 1) % len(vertices)
+}
+
+// updateEngine works out how much thrust a ship's engine gives this tick.
+// A badly damaged engine gives less, cuts out now and then while thrusting,
+// and trails smoke.
+func (g *Game) updateEngine(p *Player, thrusting bool) {
+	p.thrust = 1
+	p.wingOut = p.wingFlickers(g.survivalTicks)
+	if !p.sputtering() {
+		p.dropoutTicks = 0
+		return
+	}
+	p.thrust = sputterThrust
+	if p.dropoutTicks > 0 {
+		p.dropoutTicks--
+		p.thrust = 0
+	} else if thrusting && g.rng.Float64() < sputterDropoutChance {
+		p.dropoutTicks = sputterDropoutTicks
+		p.thrust = 0
+	}
+	if g.survivalTicks%smokeInterval == 0 {
+		g.puffSmoke(p)
+	}
+}
+
+// puffSmoke sends a puff of smoke drifting off the back of a ship
+func (g *Game) puffSmoke(p *Player) {
+	ship := p.polygon
+	back := 0.0
+	for _, v := range ship.Vertices {
+		back = math.Max(back, v.Y)
+	}
+	rear := ship.ToWorld(geometry.Vector2{Y: back})
+	heading := g.rng.Float64() * 2 * math.Pi
+	drift := 0.2 + 0.2*g.rng.Float64()
+	velocity := geometry.Vector2{
+		X: ship.Velocity.X*0.5 + math.Cos(heading)*drift,
+		Y: ship.Velocity.Y*0.5 + math.Sin(heading)*drift,
+	}
+	g.addEntity(&Smoke{position: rear, velocity: velocity})
+}
+
+// Smoke is a small gray puff trailing from a damaged ship, drifting and
+// fading away
+type Smoke struct {
+	position geometry.Vector2
+	velocity geometry.Vector2
+	// age counts the ticks since the puff was made
+	age   float64
+	color color.RGBA
+	dead  bool
+}
+
+// Update drifts the puff, removing it once it has faded
+func (s *Smoke) Update(ctx UpdateContext) {
+	s.position.X += s.velocity.X * ctx.TimeScale
+	s.position.Y += s.velocity.Y * ctx.TimeScale
+	s.age += ctx.TimeScale
+	if s.age >= smokeTicks {
+		s.dead = true
+	}
+}
+
+// Bounds returns where the puff is
+func (s *Smoke) Bounds() geometry.BoundingBox {
+	return geometry.BoundingBox{MinX: s.position.X, MinY: s.position.Y, MaxX: s.position.X, MaxY: s.position.Y}
+}
+
+// Collider returns nil, as effects never collide
+func (s *Smoke) Collider() *geometry.PolygonObject {
+	return nil
+}
+
+// Tag returns TagEffect
+func (s *Smoke) Tag() EntityTag {
+	return TagEffect
+}
+
+// CollisionLayers puts the puff on no layers
+func (s *Smoke) CollisionLayers() (layer, collidesWith CollisionLayer) {
+	return 0, 0
+}
+
+// Kill removes the puff early
+func (s *Smoke) Kill() {
+	s.dead = true
+}
+
+// Dead reports whether the puff has faded
+func (s *Smoke) Dead() bool {
+	return s.dead
+}
+
+// ApplyPalette colors the puff like a bullet's tracer
+func (s *Smoke) ApplyPalette(p *Palette) {
+	s.color = p.Tracer
+}
+
+// handleRepairs restores the hull of a ship that collects a repair pickup
+func (g *Game) handleRepairs(e Event) {
+	if e, ok := e.(PickupCollected); ok && e.Kind == PickupRepair {
+		p := g.ship(e.Partner)
+		p.hull = p.maxHull
+		p.regenTicks = 0
+	}
+}
+
+// hullDamaged reports whether either ship has lost any of its hull
+func (g *Game) hullDamaged() bool {
+	for _, p := range []*Player{g.player, g.partner} {
+		if p != nil && p.hull < p.maxHull {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,15 @@
+//go:build !headless
+
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Draw renders the puff as a ring that grows as it fades
+func (s *Smoke) Draw(screen *ebiten.Image, ctx DrawContext) {
+	life := 1 - s.age/smokeTicks
+	radius := float32(1.5 + 2.5*s.age/smokeTicks)
+	vector.StrokeCircle(screen, float32(s.position.X), float32(s.position.Y), radius, 1+ctx.LineWidthBoost, fadeColor(s.color, 0.6*life), true)
+}
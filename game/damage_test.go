@@ -0,0 +1,106 @@
+package game
+
+import (
+	"math"
+	"testing"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// thrustFor gives the player the hull, thrusts for a tick, and returns the
+// ship's acceleration. The engine isn't told of the thrust, so
+// it doesn't roll for a dropout.
+func thrustFor(g *Game, hull int) float64 {
+	p := g.player
+	p.setHull(5)
+	p.hull = hull
+	p.polygon.Acceleration = geometry.Vector2{}
+	g.updateEngine(p, false)
+	g.handlePlayerInput(p, InputState{Thrust: true})
+	return math.Hypot(p.polygon.Acceleration.X, p.polygon.Acceleration.Y)
+}
+
+func TestDamagedEngineThrust(t *testing.T) {
+	g := newWeaponsGame()
+	full := thrustFor(g, 5)
+	if math.Abs(full-g.tuning.Thrust) > 1e-9 {
+		t.Fatalf("Expected an undamaged ship to get full thrust %v, got %v", g.tuning.Thrust, full)
+	}
+	// Flickering wings alone don't weaken the engine
+	if got := thrustFor(g, 2); math.Abs(got-full) > 1e-9 {
+		t.Errorf("Expected full thrust at 2/5 hull, got %v", got)
+	}
+
+	if got := thrustFor(g, 1); math.Abs(got-sputterThrust*full) > 1e-9 {
+		t.Errorf("Expected a sputtering engine to give %v of its thrust, got %v", sputterThrust, got/full)
+	}
+}
+
+func TestSputteringEngineCutsOut(t *testing.T) {
+	g := newWeaponsGame()
+	p := g.player
+	p.setHull(5)
+	p.hull = 1
+	p.dropoutTicks = sputterDropoutTicks
+	for range sputterDropoutTicks {
+		if got := thrustFor(g, 1); got != 0 {
+			t.Fatalf("Expected no thrust while the engine is out, got %v", got)
+		}
+		if p.accelerating {
+			t.Fatalf("Expected no flame while the engine is out")
+		}
+	}
+	if p.dropoutTicks != 0 {
+		t.Errorf("Expected the dropout to be over, %d ticks left", p.dropoutTicks)
+	}
+}
+
+func TestSputteringEngineSmokes(t *testing.T) {
+	g := newWeaponsGame()
+	g.player.setHull(5)
+	g.player.hull = 1
+	stepTicks(t, g, 2*smokeInterval)
+	smoke := 0
+	for _, e := range g.entities {
+		if _, ok := e.(*Smoke); ok {
+			smoke++
+		}
+	}
+	if smoke == 0 {
+		t.Errorf("Expected a sputtering engine to trail smoke")
+	}
+}
+
+func TestWingEdge(t *testing.T) {
+	vertices := newWeaponsGame().player.polygon.Vertices
+	edge := wingEdge(vertices)
+	tip := vertices[(edge+1)%len(vertices)]
+	for _, v := range vertices {
+		if v.X < tip.X {
+			t.Fatalf("Expected edge %d to end at the leftmost vertex, %v is further left than %v", edge, v, tip)
+		}
+	}
+}
+
+func TestRepairPickupRestoresHull(t *testing.T) {
+	g := newWeaponsGame()
+	p := g.player
+	p.setHull(5)
+	p.hull = 1
+	if !g.hullDamaged() {
+		t.Fatalf("Expected the hull to count as damaged")
+	}
+	pickup := newPickup(PickupRepair, p.polygon.Position)
+	g.addEntity(pickup)
+	g.checkCollisions()
+	g.dispatchEvents()
+	if !pickup.Dead() {
+		t.Fatalf("Expected the ship to collect the pickup")
+	}
+	if p.hull != p.maxHull {
+		t.Errorf("Expected the hull to be repaired to %d, got %d", p.maxHull, p.hull)
+	}
+	if g.hullDamaged() {
+		t.Errorf("Expected the hull to no longer count as damaged")
+	}
+}
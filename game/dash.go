@@ -0,0 +1,76 @@
+package game
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+const (
+	// dashDistance is how far the ship jumps along its facing when it dashes
+	dashDistance = 80.0
+	// dashCooldownTicks is the time between dashes
+	dashCooldownTicks = 3 * ticksPerSecond
+	// afterimageTicks is how long the ghost left behind by a dash takes to
+	// fade away
+	afterimageTicks = 0.4 * ticksPerSecond
+)
+
+// updateDash counts down a ship's dash cooldown, and jumps it forward if a
+// dash was asked for and is ready. The ship is moved in one go, so it passes
+// straight through anything in between.
+func (g *Game) updateDash(p *Player, dash bool, timeScale float64) {
+	p.dashCooldown = math.Max(p.dashCooldown-timeScale, 0)
+	if !dash || p.dashCooldown > 0 {
+		return
+	}
+	p.dashCooldown = dashCooldownTicks
+
+	ship := p.polygon
+	g.addEntity(newAfterimage(ship))
+	destination := geometry.Vector2{
+		X: ship.Position.X + math.Sin(ship.Rotation)*dashDistance,
+		Y: ship.Position.Y - math.Cos(ship.Rotation)*dashDistance,
+	}
+	destination = geometry.WrapPosition(destination, g.screenWidth, g.screenHeight)
+	ship.SetPosition(destination.X, destination.Y)
+	p.flame.SetPosition(destination.X, destination.Y)
+
+	// Drop the phosphor trail, so no streak is drawn across the jump
+	g.dropPhosphorGhost()
+}
+
+// Afterimage is a fading copy of the ship left where it dashed from
+type Afterimage struct {
+	entityBase
+	// age counts the ticks since the afterimage appeared
+	age   float64
+	color color.RGBA
+}
+
+// newAfterimage copies the outline of ship where it is now
+func newAfterimage(ship *geometry.PolygonObject) *Afterimage {
+	ghost := geometry.CreatePolygon(ship.Vertices)
+	ghost.SetPosition(ship.Position.X, ship.Position.Y)
+	ghost.SetRotation(ship.Rotation)
+	ghost.SetScale(ship.Scale)
+	return &Afterimage{entityBase: entityBase{polygon: ghost, tag: TagEffect}}
+}
+
+// Update fades the afterimage, removing it once it is invisible
+func (a *Afterimage) Update(ctx UpdateContext) {
+	a.age += ctx.TimeScale
+	progress := a.age / afterimageTicks
+	if progress >= 1 {
+		a.Kill()
+		return
+	}
+	a.polygon.SetColor(fadeColor(a.color, 0.6*(1-progress)))
+}
+
+// ApplyPalette colors the afterimage like the ship
+func (a *Afterimage) ApplyPalette(p *Palette) {
+	a.color = p.Player
+	a.polygon.SetColor(fadeColor(a.color, 0.6*(1-a.age/afterimageTicks)))
+}
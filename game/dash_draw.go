@@ -0,0 +1,19 @@
+//go:build !headless
+
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// drawDashPip draws a small circle next to the beam bar, filled when a dash
+// is ready and filling up as the cooldown runs down
+func (g *Game) drawDashPip(screen *ebiten.Image) {
+	const radius = 5
+	x := float32(g.screenWidth) - 20 - beamBarWidth - 10 - radius
+	y := float32(g.screenHeight) - 20 - beamBarHeight/2
+	ready := 1 - float32(g.localShip().dashCooldown/dashCooldownTicks)
+	vector.StrokeCircle(screen, x, y, radius, 1, g.palette.UI, true)
+	vector.FillCircle(screen, x, y, radius*ready, g.palette.UI, true)
+}
@@ -0,0 +1,123 @@
+package game
+
+import (
+	"math"
+	"testing"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// newDashGame returns a game in play holding the player and one far away
+// asteroid, so the wave isn't cleared
+func newDashGame(t *testing.T) *Game {
+	t.Helper()
+	g := New()
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+	placeAsteroid(g, 10, 50, 50)
+	return g
+}
+
+func TestDashJumpsForward(t *testing.T) {
+	g := newDashGame(t)
+	ship := g.player.polygon
+	ship.SetRotation(math.Pi / 2)
+	start := ship.Position
+
+	g.updateDash(g.player, true, 1)
+	want := geometry.Vector2{X: start.X + dashDistance, Y: start.Y}
+	if math.Abs(ship.Position.X-want.X) > 1e-9 || math.Abs(ship.Position.Y-want.Y) > 1e-9 {
+		t.Errorf("Expected the ship at %v, got %v", want, ship.Position)
+	}
+	if g.countEntities(TagEffect) != 1 {
+		t.Errorf("Expected an afterimage to be left behind")
+	}
+}
+
+func TestDashWrapsAroundEdges(t *testing.T) {
+	g := newDashGame(t)
+	ship := g.player.polygon
+	ship.SetPosition(400, 30)
+
+	g.updateDash(g.player, true, 1)
+	if want := 30 - dashDistance + g.screenHeight; math.Abs(ship.Position.Y-want) > 1e-9 {
+		t.Errorf("Expected the ship to wrap to y %v, got %v", want, ship.Position.Y)
+	}
+}
+
+func TestDashPassesThroughAsteroids(t *testing.T) {
+	g := newDashGame(t)
+	ship := g.player.polygon
+	// An asteroid right in the ship's path, but clear of where it lands
+	placeAsteroid(g, 15, ship.Position.X, ship.Position.Y-40)
+
+	g.input = InputState{Dash: true}
+	if err := g.updatePlaying(); err != nil {
+		t.Fatal(err)
+	}
+	if g.state != GameStatePlaying {
+		t.Errorf("Expected the ship to dash through the asteroid unharmed")
+	}
+}
+
+func TestDashCooldown(t *testing.T) {
+	g := newDashGame(t)
+	ship := g.player.polygon
+	g.updateDash(g.player, true, 1)
+	after := ship.Position
+
+	g.updateDash(g.player, true, 1)
+	if ship.Position != after {
+		t.Errorf("Expected no second dash during the cooldown")
+	}
+	for i := 0; i < dashCooldownTicks; i++ {
+		g.updateDash(g.player, false, 1)
+	}
+	g.updateDash(g.player, true, 1)
+	if ship.Position == after {
+		t.Errorf("Expected to dash again once the cooldown is over")
+	}
+}
+
+func TestAfterimageFades(t *testing.T) {
+	a := newAfterimage(geometry.CreatePlayer(20))
+	ctx := UpdateContext{ScreenWidth: 800, ScreenHeight: 600, TimeScale: 1}
+	for i := 0; i < afterimageTicks-1; i++ {
+		a.Update(ctx)
+	}
+	if a.Dead() {
+		t.Fatalf("Expected the afterimage to last %v ticks", afterimageTicks)
+	}
+	a.Update(ctx)
+	if !a.Dead() {
+		t.Errorf("Expected the afterimage to be gone after %v ticks", afterimageTicks)
+	}
+}
+
+func TestDoubleTap(t *testing.T) {
+	var d doubleTap
+	// A lone press doesn't count
+	if d.update(true) {
+		t.Errorf("Expected a single press not to be a double tap")
+	}
+	for i := 0; i < doubleTapTicks-1; i++ {
+		d.update(false)
+	}
+	if !d.update(true) {
+		t.Errorf("Expected a quick second press to be a double tap")
+	}
+	// The second press of a double tap can't start another one
+	if d.update(true) {
+		t.Errorf("Expected a third press not to be a double tap")
+	}
+
+	d = doubleTap{}
+	d.update(true)
+	for i := 0; i < doubleTapTicks; i++ {
+		d.update(false)
+	}
+	if d.update(true) {
+		t.Errorf("Expected a slow second press not to be a double tap")
+	}
+}
@@ -0,0 +1,33 @@
+package game
+
+const (
+	// deathReviewTicks is how long the field is held still after a death,
+	// while the path of the asteroid that hit the ship fades away
+	deathReviewTicks = ticksPerSecond
+	// deathReviewPulseTicks is how long each pulse of the highlight around
+	// the asteroid takes
+	deathReviewPulseTicks = 20
+)
+
+// startDeathReview holds the field still to show what hit the ship, if the
+// run has just ended with an asteroid flying into it. Network games go
+// straight to the game over screen, as the other player's game may not
+// have stopped yet.
+func (g *Game) startDeathReview() {
+	if g.state != GameStateGameOver || g.killer == nil || g.net != nil {
+		return
+	}
+	g.state = GameStateDeathReview
+	g.deathReviewTicks = deathReviewTicks
+}
+
+// updateDeathReview counts down the death review, with nothing in the
+// world moving, then moves on to the game over screen. Pressing fire skips
+// the rest of it.
+func (g *Game) updateDeathReview() error {
+	g.deathReviewTicks -= g.steps
+	if g.deathReviewTicks <= 0 || keyJustPressed(keySpace) {
+		g.state = GameStateGameOver
+	}
+	return nil
+}
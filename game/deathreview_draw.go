@@ -0,0 +1,30 @@
+//go:build !headless
+
+package game
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// drawDeathReview draws the path the asteroid that hit the ship took to it,
+// with a pulsing ring around the asteroid, fading out over the review
+func (g *Game) drawDeathReview(screen *ebiten.Image) {
+	if g.state != GameStateDeathReview {
+		return
+	}
+	alpha := float64(g.deathReviewTicks) / deathReviewTicks
+	g.killer.history.draw(screen, g.palette.Hit, 2, alpha)
+
+	// The ring holds steady when flashing is turned off
+	pulse := 1.0
+	if g.config.Accessibility.Flashing() {
+		phase := float64(deathReviewTicks-g.deathReviewTicks) / deathReviewPulseTicks
+		pulse = 0.5 + 0.5*math.Sin(2*math.Pi*phase)
+	}
+	center := centerOf(g.killer.polygon)
+	radius := approximateRadius(g.killer.polygon) + 4 + 6*pulse
+	vector.StrokeCircle(screen, float32(center.X), float32(center.Y), float32(radius), 2, fadeColor(g.palette.Hit, alpha), true)
+}
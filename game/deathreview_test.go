@@ -0,0 +1,52 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestCollisionRemembersKiller(t *testing.T) {
+	g := newWeaponsGame()
+	ship := g.player.polygon
+	a := placeAsteroid(g, 30, ship.Position.X, ship.Position.Y)
+
+	stepTicks(t, g, 1)
+	if g.killer != a {
+		t.Fatalf("Expected the asteroid to be remembered as the killer")
+	}
+
+	g.startDeathReview()
+	if g.state != GameStateDeathReview {
+		t.Fatalf("Expected a death review, got state %v", g.state)
+	}
+	position := a.polygon.Position
+	// Each tick at the default rate is a single step
+	g.steps = 1
+	for range deathReviewTicks - 1 {
+		if err := g.updateDeathReview(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if g.state != GameStateDeathReview || a.polygon.Position != position {
+		t.Errorf("Expected the field to stay still for the review")
+	}
+	if err := g.updateDeathReview(); err != nil {
+		t.Fatal(err)
+	}
+	if g.state != GameStateGameOver {
+		t.Errorf("Expected the game over screen after the review, got state %v", g.state)
+	}
+}
+
+func TestNoDeathReviewWithoutKiller(t *testing.T) {
+	g := newWeaponsGame()
+	g.endRun("GAME OVER")
+	g.startDeathReview()
+	if g.state != GameStateGameOver {
+		t.Errorf("Expected nothing to review, got state %v", g.state)
+	}
+
+	g.Restart(RestartOptions{})
+	if g.killer != nil {
+		t.Errorf("Expected a restart to forget the killer")
+	}
+}
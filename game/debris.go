@@ -0,0 +1,82 @@
+package game
+
+import (
+	"math"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+const (
+	// debrisFirstWave is the first wave with debris in it
+	debrisFirstWave = 2
+	// maxDebris is the most debris that can be on the screen at once
+	maxDebris = 3
+	// debrisMaxSpeed is the fastest debris drifts, in pixels per tick
+	debrisMaxSpeed = 0.5
+)
+
+// Debris is a small, dense chunk that can't be destroyed. It soaks up
+// bullets, and the ship bounces off it unharmed.
+type Debris struct {
+	entityBase
+}
+
+// newDebris creates a chunk of debris at position, drifting with velocity
+func newDebris(position, velocity geometry.Vector2, rotationSpeed float64) *Debris {
+	polygon := geometry.CreateAsteroid(10, 2, 5)
+	polygon.SetPosition(position.X, position.Y)
+	polygon.SetVelocity(velocity.X, velocity.Y)
+	polygon.SetRotationSpeed(rotationSpeed)
+	// Drawn heavier than the asteroids, to look denser
+	polygon.LineWidth = 3
+	return &Debris{entityBase: entityBase{polygon: polygon, tag: TagDebris, layer: LayerDebris}}
+}
+
+// Update drifts the debris, wrapping around the screen edges
+func (d *Debris) Update(ctx UpdateContext) {
+	d.polygon.Step(ctx.TimeScale, ctx.ScreenWidth, ctx.ScreenHeight)
+}
+
+// ApplyPalette colors the debris as armored
+func (d *Debris) ApplyPalette(p *Palette) {
+	d.polygon.SetColor(p.Armored)
+}
+
+// debrisCount returns how many chunks of debris a wave starts with
+func debrisCount(wave int) int {
+	if wave < debrisFirstWave {
+		return 0
+	}
+	return min(wave-debrisFirstWave+1, maxDebris)
+}
+
+// spawnDebris scatters the wave's debris across the screen, away from the
+// ship, without going over the cap
+func (g *Game) spawnDebris() {
+	room := maxDebris - g.countEntities(TagDebris)
+	for i := 0; i < min(debrisCount(g.wave), room); i++ {
+		// Keep clear of the middle of the screen, where the ship starts
+		angle := g.rng.Float64() * 2 * math.Pi
+		distance := 150 + g.rng.Float64()*100
+		position := geometry.Vector2{
+			X: g.screenWidth/2 + math.Sin(angle)*distance,
+			Y: g.screenHeight/2 - math.Cos(angle)*distance,
+		}
+		heading := g.rng.Float64() * 2 * math.Pi
+		speed := g.rng.Float64() * debrisMaxSpeed
+		velocity := geometry.Vector2{X: math.Sin(heading) * speed, Y: -math.Cos(heading) * speed}
+		g.addEntity(newDebris(position, velocity, (g.rng.Float64()-0.5)*0.05))
+	}
+}
+
+// bulletHitsDebris absorbs the bullet, which does nothing to the debris
+func (g *Game) bulletHitsDebris(bullet, _ Entity) bool {
+	bullet.Kill()
+	return false
+}
+
+// playerHitsDebris bounces the ship off the debris without harming it
+func (g *Game) playerHitsDebris(ship, debris Entity) bool {
+	ship.(*Player).reflectOff(debris.Collider())
+	return true
+}
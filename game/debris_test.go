@@ -0,0 +1,98 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// newDebrisGame returns a game in play holding the player, one far away
+// asteroid so the wave isn't cleared, and a piece of debris at position
+func newDebrisGame(t *testing.T, position geometry.Vector2) (*Game, *Debris) {
+	t.Helper()
+	g := New()
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+	placeAsteroid(g, 10, 50, 50)
+	debris := newDebris(position, geometry.Vector2{}, 0)
+	g.addEntity(debris)
+	return g, debris
+}
+
+func TestDebrisAbsorbsBullets(t *testing.T) {
+	g, debris := newDebrisGame(t, geometry.Vector2{X: 300, Y: 100})
+	g.addEntity(newBullet(geometry.Vector2{X: 300, Y: 100}, geometry.Vector2{}))
+	piercing := newBullet(geometry.Vector2{X: 302, Y: 100}, geometry.Vector2{})
+	piercing.makePiercing()
+	g.addEntity(piercing)
+
+	if err := g.updatePlaying(); err != nil {
+		t.Fatal(err)
+	}
+	if debris.Dead() {
+		t.Errorf("Expected the debris to survive")
+	}
+	if got := g.countEntities(TagBullet); got != 0 {
+		t.Errorf("Expected every bullet to be absorbed, %d left", got)
+	}
+	if g.score != 0 {
+		t.Errorf("Expected no score for hitting debris, got %d", g.score)
+	}
+}
+
+func TestPlayerBouncesOffDebris(t *testing.T) {
+	// The ship starts in the middle of the screen
+	g, _ := newDebrisGame(t, geometry.Vector2{X: 420, Y: 300})
+	g.player.polygon.SetVelocity(3, 0)
+
+	if err := g.updatePlaying(); err != nil {
+		t.Fatal(err)
+	}
+	if g.state != GameStatePlaying {
+		t.Fatalf("Expected the ship to survive hitting debris")
+	}
+	if g.player.polygon.Velocity.X >= 0 {
+		t.Errorf("Expected the ship to bounce back, velocity %v", g.player.polygon.Velocity)
+	}
+}
+
+func TestDebrisInLaterWaves(t *testing.T) {
+	for _, tt := range []struct{ wave, want int }{{1, 0}, {2, 1}, {3, 2}, {4, 3}, {10, 3}} {
+		if got := debrisCount(tt.wave); got != tt.want {
+			t.Errorf("Wave %d: expected %d debris, got %d", tt.wave, tt.want, got)
+		}
+	}
+
+	g := New()
+	g.wave = 10
+	g.spawnDebris()
+	g.spawnDebris()
+	if got := g.countEntities(TagDebris); got != maxDebris {
+		t.Errorf("Expected debris to be capped at %d, got %d", maxDebris, got)
+	}
+}
+
+func TestDebrisDoesNotHoldUpWave(t *testing.T) {
+	g, _ := newDebrisGame(t, geometry.Vector2{X: 300, Y: 100})
+	g.entities = []Entity{g.player, g.entities[2]}
+
+	if err := g.updatePlaying(); err != nil {
+		t.Fatal(err)
+	}
+	if g.tally.running() || g.wave != 2 {
+		t.Errorf("Expected the wave to be cleared with only debris left, on wave %d", g.wave)
+	}
+}
+
+func TestClassicRunReachesDebris(t *testing.T) {
+	g := New()
+	g.Restart(RestartOptions{})
+	if got := g.countEntities(TagDebris); got != 0 {
+		t.Fatalf("Expected no debris on wave 1, got %d", got)
+	}
+	clearWave(t, g)
+	if got := g.countEntities(TagDebris); g.wave != 2 || got != debrisCount(2) {
+		t.Errorf("Expected %d debris once wave 2 starts, got %d on wave %d", debrisCount(2), got, g.wave)
+	}
+}
@@ -0,0 +1,75 @@
+package game
+
+import (
+	"image/color"
+	"time"
+)
+
+var (
+	// debugVisualColor and debugHullColor are the colors the debug overlay
+	// draws drawn outlines and collision hulls in
+	debugVisualColor = color.RGBA{0, 255, 0, 255}
+	debugHullColor   = color.RGBA{255, 0, 255, 255}
+	// debugHeatColor is the color of the busiest cells of the heatmap
+	debugHeatColor = color.RGBA{255, 96, 0, 255}
+	// debugPathColor is the color of the paths asteroids have taken
+	debugPathColor = color.RGBA{0, 160, 255, 255}
+)
+
+const (
+	// heatmapColumns and heatmapRows divide the screen into the cells the
+	// debug heatmap counts entities in
+	heatmapColumns = 20
+	heatmapRows    = 15
+	// heatmapSaturation is how many entities a cell holds before it is
+	// drawn at its brightest
+	heatmapSaturation = 6
+	// heatmapMaxAlpha is how opaque the busiest cells are drawn, so what is
+	// under them still shows
+	heatmapMaxAlpha = 0.5
+)
+
+// toggleDebugOverlay turns the debug overlay on and off when F3 is pressed
+func (g *Game) toggleDebugOverlay() {
+	if keyJustPressed(keyF3) {
+		g.debugOverlay = !g.debugOverlay
+	}
+}
+
+// timeCollisions runs the collision pass, timing it while the debug overlay
+// is on to show there. With the overlay off it just runs the pass.
+func (g *Game) timeCollisions() {
+	if !g.debugOverlay {
+		g.checkCollisions()
+		return
+	}
+	start := time.Now()
+	g.checkCollisions()
+	rollingAverage(&g.perf.collisions, time.Since(start))
+}
+
+// heatmap counts, for each cell of a grid over the screen, the live
+// entities whose bounding boxes overlap it. Cells are indexed by row, then
+// column.
+func (g *Game) heatmap() [heatmapRows][heatmapColumns]int {
+	var counts [heatmapRows][heatmapColumns]int
+	cellWidth := g.screenWidth / heatmapColumns
+	cellHeight := g.screenHeight / heatmapRows
+	for _, e := range g.entities {
+		if e.Collider() == nil || e.Dead() {
+			continue
+		}
+		box := e.Bounds()
+		// Anything hanging off the screen only counts where it is on it
+		minCol := max(0, int(box.MinX/cellWidth))
+		maxCol := min(heatmapColumns-1, int(box.MaxX/cellWidth))
+		minRow := max(0, int(box.MinY/cellHeight))
+		maxRow := min(heatmapRows-1, int(box.MaxY/cellHeight))
+		for row := minRow; row <= maxRow; row++ {
+			for col := minCol; col <= maxCol; col++ {
+				counts[row][col]++
+			}
+		}
+	}
+	return counts
+}
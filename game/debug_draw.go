@@ -0,0 +1,52 @@
+//go:build !headless
+
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// drawDebugOverlay outlines every entity that can collide, with its drawn
+// outline and, where it has a separate one, its collision hull in different
+// colors, and shows the solar wind, where entities are clustering, the
+// paths the asteroids have recently taken and how soon the ship could be hit
+func (g *Game) drawDebugOverlay(screen *ebiten.Image) {
+	if !g.debugOverlay {
+		return
+	}
+	g.drawHeatmap(screen)
+	g.drawWindArrow(screen)
+	g.drawImpactReadout(screen)
+	for _, e := range g.entities {
+		p := e.Collider()
+		if p == nil || e.Dead() {
+			continue
+		}
+		p.TransformedVertices().Draw(screen, 1, debugVisualColor)
+		if p.CollisionVertices != nil {
+			p.TransformedCollisionVertices().Draw(screen, 1, debugHullColor)
+		}
+		if a, ok := e.(*Asteroid); ok {
+			a.history.draw(screen, debugPathColor, 1, 1)
+		}
+	}
+}
+
+// drawHeatmap fills each cell of the heatmap that has anything in it,
+// brighter the more it holds
+func (g *Game) drawHeatmap(screen *ebiten.Image) {
+	cellWidth := float32(g.screenWidth / heatmapColumns)
+	cellHeight := float32(g.screenHeight / heatmapRows)
+	counts := g.heatmap()
+	for row := range counts {
+		for col, count := range counts[row] {
+			if count == 0 {
+				continue
+			}
+			heat := min(1, float64(count)/heatmapSaturation)
+			vector.FillRect(screen, float32(col)*cellWidth, float32(row)*cellHeight, cellWidth, cellHeight,
+				fadeColor(debugHeatColor, heat*heatmapMaxAlpha), false)
+		}
+	}
+}
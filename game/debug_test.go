@@ -0,0 +1,67 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestHeatmapCountsOverlaps(t *testing.T) {
+	g := New()
+	g.entities = nil
+	cellWidth := g.screenWidth / heatmapColumns
+	cellHeight := g.screenHeight / heatmapRows
+
+	// A small rock in the middle of one cell, and a big one across the
+	// corner where four cells meet
+	placeAsteroid(g, 2, cellWidth*2.5, cellHeight*3.5)
+	placeAsteroid(g, 5, cellWidth*6, cellHeight*6)
+	// Dead entities don't count
+	placeAsteroid(g, 2, cellWidth*10.5, cellHeight*10.5).Kill()
+
+	counts := g.heatmap()
+	total := 0
+	for row := range counts {
+		for _, count := range counts[row] {
+			total += count
+		}
+	}
+	if total != 5 {
+		t.Errorf("Expected 5 cell overlaps, got %d", total)
+	}
+	if counts[3][2] != 1 {
+		t.Errorf("Expected the small rock in its cell, got %d", counts[3][2])
+	}
+	for _, cell := range [][2]int{{5, 5}, {5, 6}, {6, 5}, {6, 6}} {
+		if counts[cell[0]][cell[1]] != 1 {
+			t.Errorf("Expected the big rock in cell %v, got %d", cell, counts[cell[0]][cell[1]])
+		}
+	}
+}
+
+func TestHeatmapClipsToScreen(t *testing.T) {
+	g := New()
+	g.entities = nil
+	// Hanging off the top-left corner
+	placeAsteroid(g, 5, 0, 0)
+
+	counts := g.heatmap()
+	if counts[0][0] != 1 {
+		t.Errorf("Expected the corner cell to count the rock, got %d", counts[0][0])
+	}
+}
+
+func TestCollisionsOnlyTimedWithOverlay(t *testing.T) {
+	g := New()
+	g.timeCollisions()
+	if g.perf.collisions != 0 {
+		t.Errorf("Expected no timing with the overlay off, got %v", g.perf.collisions)
+	}
+
+	g.debugOverlay = true
+	for range 100 {
+		placeAsteroid(g, 10, 400, 300)
+	}
+	g.timeCollisions()
+	if g.perf.collisions <= 0 {
+		t.Errorf("Expected the collision pass to be timed with the overlay on")
+	}
+}
@@ -0,0 +1,51 @@
+package game
+
+import (
+	"math"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// DemoInput returns controls that play the game without a player, by turning
+// towards the closest asteroid and firing once it is lined up
+func (g *Game) DemoInput() InputState {
+	const aimTolerance = 0.1 // radians either side of the target to stop turning
+	const fireTolerance = 0.2
+
+	ship := g.player.polygon
+	var target *geometry.PolygonObject
+	closest := math.Inf(1)
+	for _, e := range g.entities {
+		if e.Tag() != TagAsteroid || e.Dead() {
+			continue
+		}
+		// The closest threat may be just across a screen edge
+		distance := geometry.WrapDistance(ship.Position, e.Collider().Position, g.screenWidth, g.screenHeight)
+		if distance < closest {
+			closest = distance
+			target = e.Collider()
+		}
+	}
+	if target == nil {
+		return InputState{}
+	}
+
+	// Bullets don't wrap, so aim straight at it. A rotation of 0 faces up
+	// the screen.
+	dx := target.Position.X - ship.Position.X
+	dy := target.Position.Y - ship.Position.Y
+	turn := geometry.NormalizeAngle(math.Atan2(dx, -dy) - ship.Rotation)
+	if turn > math.Pi {
+		turn -= 2 * math.Pi
+	}
+	// Semi-automatic fire needs the trigger let go between shots
+	fire := math.Abs(turn) < fireTolerance
+	if g.fireMode() == FireSemi && g.player.lastInput.Fire {
+		fire = false
+	}
+	return InputState{
+		Left:  turn < -aimTolerance,
+		Right: turn > aimTolerance,
+		Fire:  fire,
+	}
+}
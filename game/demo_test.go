@@ -0,0 +1,42 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestDemoInputAims(t *testing.T) {
+	g := New()
+	g.entities = []Entity{g.player}
+	ship := g.player.polygon.Position
+
+	// Straight ahead, so it should fire without turning
+	placeAsteroid(g, 10, ship.X, ship.Y-100)
+	if in := g.DemoInput(); !in.Fire || in.Left || in.Right {
+		t.Errorf("Expected to fire straight ahead, got %+v", in)
+	}
+
+	// A closer one to the right takes priority
+	placeAsteroid(g, 10, ship.X+50, ship.Y)
+	if in := g.DemoInput(); in.Fire || !in.Right {
+		t.Errorf("Expected to turn right towards the closest asteroid, got %+v", in)
+	}
+}
+
+func TestDifficultyScalesAsteroidSpeed(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Difficulty = DifficultyHard
+	g := New(WithConfig(cfg))
+	if g.rules.asteroidSpeed != 1.4 {
+		t.Errorf("Expected hard asteroids to be faster, got %v", g.rules.asteroidSpeed)
+	}
+
+	g.mutators = Mutators{DoubleSpeed: true}
+	g.Restart(RestartOptions{})
+	if g.rules.asteroidSpeed != 2.8 {
+		t.Errorf("Expected double speed on top of the difficulty, got %v", g.rules.asteroidSpeed)
+	}
+
+	if _, err := ParseDifficulty("impossible"); err == nil {
+		t.Errorf("Expected an error for an unknown difficulty")
+	}
+}
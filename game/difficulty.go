@@ -0,0 +1,39 @@
+package game
+
+import (
+	"fmt"
+)
+
+// Difficulty scales how hard the asteroids are to deal with
+type Difficulty string
+
+const (
+	DifficultyEasy   Difficulty = "easy"
+	DifficultyNormal Difficulty = "normal"
+	DifficultyHard   Difficulty = "hard"
+)
+
+// difficulties lists every difficulty, from easiest to hardest
+var difficulties = []Difficulty{DifficultyEasy, DifficultyNormal, DifficultyHard}
+
+// ParseDifficulty returns the difficulty with the given name
+func ParseDifficulty(name string) (Difficulty, error) {
+	for _, d := range difficulties {
+		if string(d) == name {
+			return d, nil
+		}
+	}
+	return "", fmt.Errorf("unknown difficulty %q, expected one of %v", name, difficulties)
+}
+
+// asteroidSpeed returns how much faster than normal asteroids move. An
+// unset difficulty plays as normal.
+func (d Difficulty) asteroidSpeed() float64 {
+	switch d {
+	case DifficultyEasy:
+		return 0.7
+	case DifficultyHard:
+		return 1.4
+	}
+	return 1
+}
@@ -0,0 +1,119 @@
+package game
+
+import (
+	"encoding/binary"
+	"math"
+	"math/rand"
+)
+
+// soundSampleRate is the rate, in samples per second, sounds are
+// synthesized at
+const soundSampleRate = 44100
+
+// whiteNoise returns n samples of noise spread evenly between -1 and 1
+func whiteNoise(rng *rand.Rand, n int) []float64 {
+	samples := make([]float64, n)
+	for i := range samples {
+		samples[i] = rng.Float64()*2 - 1
+	}
+	return samples
+}
+
+// glide returns duration seconds of a sine tone whose pitch slides evenly
+// from one frequency to another, in hertz
+func glide(from, to, duration float64) []float64 {
+	samples := make([]float64, int(duration*soundSampleRate))
+	var phase float64
+	for i := range samples {
+		frequency := from + (to-from)*float64(i)/float64(len(samples))
+		samples[i] = math.Sin(2 * math.Pi * phase)
+		phase += frequency / soundSampleRate
+	}
+	return samples
+}
+
+// squareWave returns duration seconds of a square wave at the given
+// frequency, in hertz, a harsher tone than a sine
+func squareWave(frequency, duration float64) []float64 {
+	samples := make([]float64, int(duration*soundSampleRate))
+	for i := range samples {
+		if math.Mod(float64(i)*frequency/soundSampleRate, 1) < 0.5 {
+			samples[i] = 1
+		} else {
+			samples[i] = -1
+		}
+	}
+	return samples
+}
+
+// decayEnvelope scales the samples by an exponential decay, starting at
+// full level and falling by a factor of e every tau seconds
+func decayEnvelope(samples []float64, tau float64) {
+	for i := range samples {
+		t := float64(i) / soundSampleRate
+		samples[i] *= math.Exp(-t / tau)
+	}
+}
+
+// lowPass runs the samples through a one-pole low-pass filter with the
+// given cutoff frequency, in hertz
+func lowPass(samples []float64, cutoff float64) {
+	alpha := 1 - math.Exp(-2*math.Pi*cutoff/soundSampleRate)
+	var y float64
+	for i, x := range samples {
+		y += alpha * (x - y)
+		samples[i] = y
+	}
+}
+
+// resample returns the samples played back rate times as fast, which
+// raises their pitch and shortens them by the same factor. It interpolates
+// linearly between the original samples.
+func resample(samples []float64, rate float64) []float64 {
+	if len(samples) == 0 {
+		return nil
+	}
+	out := make([]float64, int(float64(len(samples))/rate))
+	last := len(samples) - 1
+	for i := range out {
+		pos := float64(i) * rate
+		j := min(int(pos), last)
+		next := min(j+1, last)
+		frac := pos - float64(j)
+		out[i] = samples[j] + (samples[next]-samples[j])*frac
+	}
+	return out
+}
+
+// peakLevel returns the largest magnitude of any sample
+func peakLevel(samples []float64) float64 {
+	var peak float64
+	for _, s := range samples {
+		peak = math.Max(peak, math.Abs(s))
+	}
+	return peak
+}
+
+// normalize scales the samples so the loudest is at level. Silence is left
+// alone.
+func normalize(samples []float64, level float64) {
+	peak := peakLevel(samples)
+	if peak == 0 {
+		return
+	}
+	for i := range samples {
+		samples[i] *= level / peak
+	}
+}
+
+// toPCM converts samples between -1 and 1 into 16 bit little-endian stereo
+// PCM, the same sample in both channels
+func toPCM(samples []float64) []byte {
+	pcm := make([]byte, 0, len(samples)*4)
+	for _, s := range samples {
+		v := uint16(int16(math.Round(math.Max(-1, math.Min(1, s)) * math.MaxInt16)))
+		pcm = binary.LittleEndian.AppendUint16(pcm, v)
+		pcm = binary.LittleEndian.AppendUint16(pcm, v)
+	}
+	return pcm
+}
@@ -0,0 +1,115 @@
+package game
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestWhiteNoiseRange(t *testing.T) {
+	samples := whiteNoise(rand.New(rand.NewSource(1)), 1000)
+	if len(samples) != 1000 {
+		t.Fatalf("Expected 1000 samples, got %d", len(samples))
+	}
+	if peak := peakLevel(samples); peak > 1 || peak < 0.9 {
+		t.Errorf("Expected noise filling -1 to 1, peak was %v", peak)
+	}
+}
+
+func TestDecayEnvelope(t *testing.T) {
+	samples := make([]float64, soundSampleRate)
+	for i := range samples {
+		samples[i] = 1
+	}
+	decayEnvelope(samples, 0.1)
+	if samples[0] != 1 {
+		t.Errorf("Expected the envelope to start at full level, got %v", samples[0])
+	}
+	// One tau in, it has fallen by a factor of e
+	if got := samples[soundSampleRate/10]; math.Abs(got-1/math.E) > 1e-9 {
+		t.Errorf("Expected %v after one tau, got %v", 1/math.E, got)
+	}
+}
+
+func TestLowPassSmoothsNoise(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	bright := whiteNoise(rng, soundSampleRate/10)
+	dull := append([]float64(nil), bright...)
+	lowPass(bright, 8000)
+	lowPass(dull, 300)
+	if peakLevel(dull) >= peakLevel(bright) {
+		t.Errorf("Expected a lower cutoff to take out more, peaks %v and %v", peakLevel(dull), peakLevel(bright))
+	}
+
+	// A steady level passes straight through, once the filter settles
+	steady := make([]float64, soundSampleRate/10)
+	for i := range steady {
+		steady[i] = 0.5
+	}
+	lowPass(steady, 300)
+	if got := steady[len(steady)-1]; math.Abs(got-0.5) > 1e-6 {
+		t.Errorf("Expected a steady level to settle at 0.5, got %v", got)
+	}
+}
+
+func TestToPCM(t *testing.T) {
+	pcm := toPCM([]float64{0, 1, -1, 2})
+	if len(pcm) != 16 {
+		t.Fatalf("Expected 4 bytes per sample, got %d bytes", len(pcm))
+	}
+	want := []int16{0, 0, math.MaxInt16, math.MaxInt16, -math.MaxInt16, -math.MaxInt16, math.MaxInt16, math.MaxInt16}
+	for i, w := range want {
+		if got := int16(uint16(pcm[2*i]) | uint16(pcm[2*i+1])<<8); got != w {
+			t.Errorf("Sample %d: expected %d, got %d", i, w, got)
+		}
+	}
+}
+
+func TestResample(t *testing.T) {
+	samples := []float64{0, 1, 2, 3, 4, 5, 6, 7}
+	fast := resample(samples, 2)
+	if len(fast) != 4 || fast[1] != 2 || fast[3] != 6 {
+		t.Errorf("Expected every other sample played twice as fast, got %v", fast)
+	}
+	slow := resample(samples, 0.5)
+	if len(slow) != 16 || slow[1] != 0.5 || slow[15] != 7 {
+		t.Errorf("Expected samples interpolated between at half speed, got %v", slow)
+	}
+}
+
+// crossings counts the times the samples go from negative to not
+func crossings(samples []float64) int {
+	count := 0
+	for i := 1; i < len(samples); i++ {
+		if samples[i-1] < 0 && samples[i] >= 0 {
+			count++
+		}
+	}
+	return count
+}
+
+func TestGlide(t *testing.T) {
+	steady := glide(440, 440, 0.5)
+	if len(steady) != soundSampleRate/2 {
+		t.Fatalf("Expected %d samples, got %d", soundSampleRate/2, len(steady))
+	}
+	if n := crossings(steady); n < 219 || n > 220 {
+		t.Errorf("Expected 220 cycles of 440Hz in half a second, got %d", n)
+	}
+	// Averaging 660Hz over the slide
+	if n := crossings(glide(440, 880, 0.5)); n < 329 || n > 330 {
+		t.Errorf("Expected 330 cycles sliding from 440Hz to 880Hz, got %d", n)
+	}
+}
+
+func TestSquareWave(t *testing.T) {
+	samples := squareWave(100, 1)
+	if n := crossings(samples); n < 99 || n > 100 {
+		t.Errorf("Expected 100 cycles, got %d", n)
+	}
+	for _, s := range samples {
+		if s != 1 && s != -1 {
+			t.Fatalf("Expected only full scale samples, got %v", s)
+		}
+	}
+}
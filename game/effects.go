@@ -0,0 +1,135 @@
+package game
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// muzzleFlashTicks is how long the flash at the ship's nose lasts when firing
+const muzzleFlashTicks = 3
+
+// MuzzleFlash is a small V of lines that briefly opens out from the ship's
+// nose when it fires
+type MuzzleFlash struct {
+	position geometry.Vector2
+	rotation float64
+	// age counts the ticks since the flash appeared
+	age   float64
+	color color.Color
+	dead  bool
+}
+
+// newMuzzleFlash creates a flash at position, facing along rotation
+func newMuzzleFlash(position geometry.Vector2, rotation float64) *MuzzleFlash {
+	return &MuzzleFlash{position: position, rotation: rotation}
+}
+
+// Update ages the flash, removing it once it has finished
+func (m *MuzzleFlash) Update(ctx UpdateContext) {
+	m.age += ctx.TimeScale
+	if m.age >= muzzleFlashTicks {
+		m.dead = true
+	}
+}
+
+// Bounds returns the point the flash starts from
+func (m *MuzzleFlash) Bounds() geometry.BoundingBox {
+	return geometry.BoundingBox{MinX: m.position.X, MinY: m.position.Y, MaxX: m.position.X, MaxY: m.position.Y}
+}
+
+// Collider returns nil, as effects never collide
+func (m *MuzzleFlash) Collider() *geometry.PolygonObject {
+	return nil
+}
+
+// Tag returns TagEffect
+func (m *MuzzleFlash) Tag() EntityTag {
+	return TagEffect
+}
+
+// CollisionLayers puts the flash on no layers
+func (m *MuzzleFlash) CollisionLayers() (layer, collidesWith CollisionLayer) {
+	return 0, 0
+}
+
+// Kill removes the flash early
+func (m *MuzzleFlash) Kill() {
+	m.dead = true
+}
+
+// Dead reports whether the flash has finished
+func (m *MuzzleFlash) Dead() bool {
+	return m.dead
+}
+
+// ApplyPalette colors the flash like the engine flame
+func (m *MuzzleFlash) ApplyPalette(p *Palette) {
+	m.color = p.Flame
+}
+
+const (
+	// shockwaveTicks is how long a shockwave takes to expand and fade out
+	shockwaveTicks = 30
+	// shockwaveStartScale and shockwaveEndScale are the ring's size relative
+	// to the destroyed asteroid as it expands
+	shockwaveStartScale = 0.2
+	shockwaveEndScale   = 3.0
+)
+
+// Shockwave is an expanding ring left behind when a large asteroid is destroyed
+type Shockwave struct {
+	entityBase
+}
+
+// newShockwave creates a ring at position, sized relative to the radius of
+// the asteroid that was destroyed. It grows with a tween, and is removed
+// once the tween finishes.
+func newShockwave(position geometry.Vector2, radius float64) *Shockwave {
+	ring := geometry.CreateCircle(radius, 24)
+	ring.SetPosition(position.X, position.Y)
+	// It stays where the asteroid was, even off the screen, until the tween
+	// removes it
+	ring.Boundary = geometry.BoundaryDespawn
+	ring.Margin = math.Inf(1)
+	s := &Shockwave{entityBase: entityBase{polygon: ring, tag: TagEffect}}
+	ring.TweenScale(shockwaveStartScale, shockwaveEndScale, ticksDuration(shockwaveTicks), geometry.EaseOut, s.Kill)
+	return s
+}
+
+// Update grows and fades the ring
+func (s *Shockwave) Update(ctx UpdateContext) {
+	s.polygon.Step(ctx.TimeScale, ctx.ScreenWidth, ctx.ScreenHeight)
+}
+
+// ApplyPalette colors the ring like the asteroids, fading out to nothing
+// over the life of the shockwave. A fade in progress carries on from the
+// new color.
+func (s *Shockwave) ApplyPalette(p *Palette) {
+	if s.polygon.IsFading {
+		s.polygon.FadeStartColor = p.Asteroid
+		return
+	}
+	s.polygon.SetColor(p.Asteroid)
+	s.polygon.StartFade(color.RGBA{}, shockwaveTicks)
+}
+
+// fadeColor returns c with its opacity multiplied by alpha
+func fadeColor(c color.RGBA, alpha float64) color.RGBA {
+	alpha = math.Max(0, math.Min(1, alpha))
+	// color.RGBA is alpha-premultiplied, so every channel is scaled
+	return color.RGBA{
+		R: uint8(float64(c.R) * alpha),
+		G: uint8(float64(c.G) * alpha),
+		B: uint8(float64(c.B) * alpha),
+		A: uint8(float64(c.A) * alpha),
+	}
+}
+
+// handleShockwaves spawns a shockwave where a large asteroid was destroyed
+func (g *Game) handleShockwaves(e Event) {
+	if e, ok := e.(AsteroidDestroyed); ok && e.Size >= largeAsteroidSize {
+		g.addEntity(newShockwave(e.Position, e.Size))
+	}
+}
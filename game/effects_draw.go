@@ -0,0 +1,38 @@
+//go:build !headless
+
+package game
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Draw renders the two arms of the V, which grow as the flash ages
+func (m *MuzzleFlash) Draw(screen *ebiten.Image, ctx DrawContext) {
+	const spread = 0.5 // radians either side of the ship's heading
+	length := 4 + 3*m.age
+	for _, side := range []float64{-1, 1} {
+		angle := m.rotation + side*spread
+		endX := m.position.X + math.Sin(angle)*length
+		endY := m.position.Y - math.Cos(angle)*length
+		vector.StrokeLine(screen,
+			float32(m.position.X), float32(m.position.Y),
+			float32(endX), float32(endY),
+			1+ctx.LineWidthBoost, m.color, true)
+	}
+}
+
+// drawOverlays draws the effects and cosmetic extras that are kept out of
+// the phosphor trails
+func (g *Game) drawOverlays(screen *ebiten.Image, ctx DrawContext) {
+	for _, e := range g.entities {
+		if e.Tag() == TagEffect {
+			e.Draw(screen, ctx)
+		}
+		if o, ok := e.(overlayDrawer); ok {
+			o.DrawOverlay(screen, ctx)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestEffectsMuzzleFlashExpires(t *testing.T) {
+	g := New()
+	g.state = GameStatePlaying
+	g.entities = []Entity{g.player}
+
+	g.createBullet(g.player)
+	if g.countEntities(TagEffect) != 1 {
+		t.Fatalf("Expected a muzzle flash when firing, got %d effects", g.countEntities(TagEffect))
+	}
+
+	for i := 0; i < muzzleFlashTicks; i++ {
+		g.updatePlaying()
+	}
+	if g.countEntities(TagEffect) != 0 {
+		t.Errorf("Expected the muzzle flash to be gone after %d ticks", muzzleFlashTicks)
+	}
+}
+
+func TestEffectsBulletRemembersPreviousPosition(t *testing.T) {
+	g := New()
+	g.state = GameStatePlaying
+	g.entities = []Entity{g.player}
+
+	g.createBullet(g.player)
+	var bullet *Bullet
+	for _, e := range g.entities {
+		if b, ok := e.(*Bullet); ok {
+			bullet = b
+		}
+	}
+	start := bullet.polygon.Position
+
+	g.updatePlaying()
+	if bullet.previous != start {
+		t.Errorf("Expected previous position %v, got %v", start, bullet.previous)
+	}
+	if bullet.polygon.Position == start {
+		t.Errorf("Expected the bullet to have moved")
+	}
+}
+
+func TestEffectsShockwaveOnLargeAsteroid(t *testing.T) {
+	g := New()
+	g.state = GameStatePlaying
+	g.entities = []Entity{g.player}
+
+	g.emit(AsteroidDestroyed{Size: largeAsteroidSize})
+	g.emit(AsteroidDestroyed{Size: largeAsteroidSize * 2})
+	g.emit(AsteroidDestroyed{Size: largeAsteroidSize - 1})
+	g.dispatchEvents()
+
+	if g.countEntities(TagEffect) != 2 {
+		t.Fatalf("Expected a shockwave for each large asteroid, got %d", g.countEntities(TagEffect))
+	}
+
+	// Let the hit-stop pass, then the shockwaves run to completion
+	for i := 0; i < hitStopTicks+shockwaveTicks; i++ {
+		g.updatePlaying()
+	}
+	if g.countEntities(TagEffect) != 0 {
+		t.Errorf("Expected shockwaves to be removed once faded, got %d", g.countEntities(TagEffect))
+	}
+}
@@ -0,0 +1,145 @@
+package game
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// GameMode selects the rules a run is played with
+type GameMode int
+
+const (
+	// ModeClassic spawns a fixed field of asteroids that must be cleared
+	ModeClassic GameMode = iota
+	// ModeEndless continuously spawns asteroids from the screen edges and
+	// is played for survival time
+	ModeEndless
+	// ModeStation sends waves of asteroids at a space station in the middle
+	// of the screen, which the player has to defend
+	ModeStation
+	// ModePractice keeps a classic field topped up and shows where shots
+	// and asteroids are going, for practising without losing the run
+	ModePractice
+)
+
+// String returns the name of the mode as shown on the title screen
+func (m GameMode) String() string {
+	switch m {
+	case ModeClassic:
+		return "CLASSIC"
+	case ModeEndless:
+		return "ENDLESS"
+	case ModeStation:
+		return "STATION"
+	case ModePractice:
+		return "PRACTICE"
+	}
+	return "UNKNOWN"
+}
+
+// ParseGameMode returns the mode with the given name, ignoring case
+func ParseGameMode(name string) (GameMode, error) {
+	for _, m := range gameModes {
+		if strings.EqualFold(m.String(), name) {
+			return m, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown mode %q, expected classic, endless, station or practice", name)
+}
+
+const (
+	// endlessStartInterval is the initial number of ticks between spawns
+	endlessStartInterval = 180
+	// endlessMinInterval is the shortest the spawn interval will get
+	endlessMinInterval = 30
+	// endlessRampTicks is how many ticks of survival shave one tick off the interval
+	endlessRampTicks = 120
+	// endlessMaxAsteroids pauses spawning while the field is this full
+	endlessMaxAsteroids = 20
+)
+
+// endlessSpawnInterval returns the number of ticks between spawns after
+// surviving for the given number of ticks
+func endlessSpawnInterval(survivalTicks int) int {
+	interval := endlessStartInterval - survivalTicks/endlessRampTicks
+	if interval < endlessMinInterval {
+		interval = endlessMinInterval
+	}
+	return interval
+}
+
+// updateEndless runs the endless mode spawner
+func (g *Game) updateEndless() {
+	// The spawner pauses, rather than queueing up spawns, while the field is saturated
+	if g.countEntities(TagAsteroid) >= endlessMaxAsteroids {
+		return
+	}
+
+	g.spawnTimer--
+	if g.spawnTimer > 0 {
+		return
+	}
+	g.spawnTimer = endlessSpawnInterval(g.survivalTicks)
+	g.spawnAsteroidAtEdge()
+}
+
+// spawnAsteroidAtEdge places a new random asteroid just outside a random
+// screen edge, drifting in towards the middle of the screen
+func (g *Game) spawnAsteroidAtEdge() *Asteroid {
+	return g.spawnAsteroidTowardsCenter(0.5)
+}
+
+// spawnAsteroidTowardsCenter places a new random asteroid just outside a
+// random screen edge, aimed at a random point in the middle of the screen.
+// spread is the size of the area aimed at, as a fraction of the screen.
+func (g *Game) spawnAsteroidTowardsCenter(spread float64) *Asteroid {
+	polygon := g.randomAsteroidShape()
+
+	// Find how far the outline extends from its origin so it starts fully
+	// hidden whatever its rotation
+	radius := outlineRadius(polygon)
+
+	var x, y float64
+	switch g.rng.Intn(4) {
+	case 0: // Top
+		x, y = g.rng.Float64()*g.screenWidth, -radius
+	case 1: // Bottom
+		x, y = g.rng.Float64()*g.screenWidth, g.screenHeight+radius
+	case 2: // Left
+		x, y = -radius, g.rng.Float64()*g.screenHeight
+	default: // Right
+		x, y = g.screenWidth+radius, g.rng.Float64()*g.screenHeight
+	}
+	polygon.SetPosition(x, y)
+	// It is only removed if it goes back out further than it started
+	polygon.Margin = radius + despawnMargin
+
+	// Aim at a random point in the middle of the screen
+	targetX := g.screenWidth * (0.5 + (g.rng.Float64()-0.5)*spread)
+	targetY := g.screenHeight * (0.5 + (g.rng.Float64()-0.5)*spread)
+	dx, dy := targetX-x, targetY-y
+	distance := math.Hypot(dx, dy)
+	speed := 0.5 + g.rng.Float64()*1.5 // 0.5 to 2 pixels per frame
+	speed *= g.rules.asteroidSpeed
+	polygon.SetVelocity(dx/distance*speed, dy/distance*speed)
+
+	polygon.SetRotation(g.rng.Float64() * 2 * math.Pi)
+	polygon.SetRotationSpeed((g.rng.Float64() - 0.5) * 0.1)
+
+	asteroid := newAsteroid(polygon)
+	asteroid.entering = true
+	if g.rollArmored() {
+		asteroid.makeArmored()
+	}
+	g.spawnAsteroid(asteroid)
+	asteroid.materialize()
+	g.addMoonlets(asteroid)
+	return asteroid
+}
+
+// formatSurvivalTime formats a tick count as minutes and seconds
+func formatSurvivalTime(ticks int) string {
+	seconds := ticks / ticksPerSecond
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
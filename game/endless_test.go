@@ -0,0 +1,129 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestSpawnAsteroidAtEdge(t *testing.T) {
+	g := New()
+	g.entities = []Entity{g.player}
+
+	for i := 0; i < 50; i++ {
+		asteroid := g.spawnAsteroidAtEdge()
+		bbox := asteroid.Bounds()
+		offscreen := bbox.MaxX <= 0 || bbox.MinX >= g.screenWidth ||
+			bbox.MaxY <= 0 || bbox.MinY >= g.screenHeight
+		if !offscreen {
+			t.Fatalf("Expected asteroid to spawn fully off-screen, got %+v", bbox)
+		}
+
+		// Moving along the velocity must bring it towards the middle of the screen
+		pos := asteroid.polygon.Position
+		vel := asteroid.polygon.Velocity
+		toCenterX := g.screenWidth/2 - pos.X
+		toCenterY := g.screenHeight/2 - pos.Y
+		if vel.X*toCenterX+vel.Y*toCenterY <= 0 {
+			t.Fatalf("Expected inward velocity, got %+v at %+v", vel, pos)
+		}
+	}
+}
+
+func TestEnteringAsteroidDoesNotWrap(t *testing.T) {
+	g := New()
+	g.entities = []Entity{g.player}
+	asteroid := g.spawnAsteroidAtEdge()
+	ctx := UpdateContext{ScreenWidth: g.screenWidth, ScreenHeight: g.screenHeight, TimeScale: 1}
+
+	// Track the distance to the center, which only shrinks until the
+	// asteroid is fully on-screen. A wrap would make it jump.
+	for i := 0; asteroid.entering; i++ {
+		if i > 10000 {
+			t.Fatalf("Asteroid never finished entering the screen")
+		}
+		before := asteroid.polygon.Position
+		asteroid.Update(ctx)
+		after := asteroid.polygon.Position
+		dx := after.X - before.X
+		dy := after.Y - before.Y
+		if dx*dx+dy*dy > 5*5 {
+			t.Fatalf("Asteroid jumped from %+v to %+v while entering", before, after)
+		}
+	}
+
+	bbox := asteroid.Bounds()
+	if bbox.MinX < 0 || bbox.MaxX > g.screenWidth || bbox.MinY < 0 || bbox.MaxY > g.screenHeight {
+		t.Errorf("Expected asteroid fully on-screen once entered, got %+v", bbox)
+	}
+}
+
+func TestEnteringAsteroidSurvivesSpawn(t *testing.T) {
+	// Big rocks start further out than the despawn margin, while still
+	// scaled down as they materialize
+	for seed := int64(0); seed < 100; seed++ {
+		g := New(WithSeed(seed))
+		g.entities = []Entity{g.player}
+		asteroid := g.spawnAsteroidAtEdge()
+		asteroid.Update(UpdateContext{ScreenWidth: g.screenWidth, ScreenHeight: g.screenHeight, TimeScale: 1})
+		if asteroid.Dead() {
+			t.Fatalf("Seed %d: asteroid removed as it spawned at %v", seed, asteroid.polygon.Position)
+		}
+	}
+}
+
+func TestEnteringAsteroidBlownAwayDespawns(t *testing.T) {
+	g := New()
+	g.entities = []Entity{g.player}
+	asteroid := g.spawnAsteroidAtEdge()
+	ctx := UpdateContext{ScreenWidth: g.screenWidth, ScreenHeight: g.screenHeight, TimeScale: 1}
+
+	// Turn it around before it makes it onto the screen
+	v := asteroid.polygon.Velocity
+	asteroid.polygon.SetVelocity(-v.X*10, -v.Y*10)
+	for i := 0; !asteroid.Dead(); i++ {
+		if i > 1000 {
+			t.Fatalf("Expected the asteroid to be removed, still at %+v", asteroid.polygon.Position)
+		}
+		asteroid.Update(ctx)
+	}
+	if !asteroid.entering {
+		t.Errorf("Expected the asteroid to be removed without entering")
+	}
+}
+
+func TestEndlessSpawnerPausesWhenSaturated(t *testing.T) {
+	g := New()
+	g.mode = ModeEndless
+	g.Restart(RestartOptions{})
+
+	for i := 0; i < endlessMaxAsteroids; i++ {
+		g.spawnAsteroidAtEdge()
+	}
+	g.spawnTimer = 0
+	for i := 0; i < 1000; i++ {
+		g.updateEndless()
+	}
+	if got := g.countEntities(TagAsteroid); got != endlessMaxAsteroids {
+		t.Errorf("Expected spawner to stop at %d asteroids, got %d", endlessMaxAsteroids, got)
+	}
+}
+
+func TestEndlessSpawnInterval(t *testing.T) {
+	if got := endlessSpawnInterval(0); got != endlessStartInterval {
+		t.Errorf("Expected initial interval %d, got %d", endlessStartInterval, got)
+	}
+	if endlessSpawnInterval(60*60) >= endlessSpawnInterval(0) {
+		t.Errorf("Expected the interval to shorten over time")
+	}
+	if got := endlessSpawnInterval(1000000); got != endlessMinInterval {
+		t.Errorf("Expected interval to bottom out at %d, got %d", endlessMinInterval, got)
+	}
+}
+
+func TestFormatSurvivalTime(t *testing.T) {
+	if got := formatSurvivalTime(0); got != "0:00" {
+		t.Errorf("Expected 0:00, got %s", got)
+	}
+	if got := formatSurvivalTime(60 * 125); got != "2:05" {
+		t.Errorf("Expected 2:05, got %s", got)
+	}
+}
@@ -0,0 +1,243 @@
+package game
+
+import (
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// EntityTag identifies what kind of object an entity is, and is used to
+// decide which pairs of entities are checked for collisions
+type EntityTag int
+
+const (
+	TagPlayer EntityTag = iota
+	TagAsteroid
+	TagBullet
+	TagPickup
+	// TagEnemyBullet is for bullets fired at the player
+	TagEnemyBullet
+	TagComet
+	TagDebris
+	TagStation
+	// TagEffect is for purely cosmetic entities, which never collide and are
+	// drawn after the phosphor trail snapshot so they leave no ghosts
+	TagEffect
+)
+
+// String returns the name of the tag
+func (t EntityTag) String() string {
+	switch t {
+	case TagPlayer:
+		return "player"
+	case TagAsteroid:
+		return "asteroid"
+	case TagBullet:
+		return "bullet"
+	case TagPickup:
+		return "pickup"
+	case TagEnemyBullet:
+		return "enemy bullet"
+	case TagComet:
+		return "comet"
+	case TagDebris:
+		return "debris"
+	case TagStation:
+		return "station"
+	case TagEffect:
+		return "effect"
+	}
+	return "unknown"
+}
+
+// MarshalText writes the tag by name, so it reads well in the event log
+func (t EntityTag) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UpdateContext carries the per-tick information an entity needs to update itself
+type UpdateContext struct {
+	ScreenWidth  float64
+	ScreenHeight float64
+	// TimeScale is how much of a normal tick passes in this update
+	TimeScale float64
+	// TimeDilated is set while the time dilation power-up slows asteroids
+	TimeDilated bool
+}
+
+// DrawContext carries the per-frame information an entity needs to draw itself
+type DrawContext struct {
+	// LineWidthBoost is added to the width of every outline
+	LineWidthBoost float32
+	// Flashing is false if objects must not blink or flash
+	Flashing bool
+	// Rewind is how far back towards the previous tick polygons are drawn,
+	// to smooth motion when frames are drawn between ticks
+	Rewind float64
+}
+
+// Entity is any object that lives in the game world
+type Entity interface {
+	// drawer renders the entity to the screen, in builds that have one
+	drawer
+	// Update advances the entity by one tick
+	Update(ctx UpdateContext)
+	// Bounds returns the entity's world-space bounding box
+	Bounds() geometry.BoundingBox
+	// Collider returns the polygon used for collision detection, or nil for
+	// entities that never collide
+	Collider() *geometry.PolygonObject
+	// Tag returns the kind of entity this is
+	Tag() EntityTag
+	// CollisionLayers returns the layers the entity is on and the layers it
+	// collides with. Entities with no mask are only ever hit by others.
+	CollisionLayers() (layer, collidesWith CollisionLayer)
+	// Kill marks the entity for removal at the end of the tick
+	Kill()
+	// Dead reports whether the entity should be removed
+	Dead() bool
+	// ApplyPalette colors the entity according to its role in the palette
+	ApplyPalette(p *Palette)
+}
+
+// Lifetime limits how long an entity lives. The game counts it down after
+// each update, and kills the entity once it runs out.
+type Lifetime struct {
+	// Remaining is how many more ticks the entity lives for, or -1 for it to
+	// live until it is killed
+	Remaining float64
+}
+
+// newLifetime creates a lifetime of the given number of ticks
+func newLifetime(ticks float64) *Lifetime {
+	return &Lifetime{Remaining: ticks}
+}
+
+// tick counts the lifetime down by dt ticks, and reports whether it has run
+// out
+func (l *Lifetime) tick(dt float64) bool {
+	if l.Remaining < 0 {
+		return false
+	}
+	l.Remaining = max(0, l.Remaining-dt)
+	return l.Remaining == 0
+}
+
+// entityBase holds the state shared by all polygon-backed entities
+type entityBase struct {
+	polygon      *geometry.PolygonObject
+	tag          EntityTag
+	layer        CollisionLayer
+	collidesWith CollisionLayer
+	dead         bool
+	// lifetime, if set, removes the entity once it runs out
+	lifetime *Lifetime
+	// onUpdate, if set, is run every tick after the entity's own Update, for
+	// simple behaviors that don't need a type of their own
+	onUpdate func(g *Game)
+}
+
+// components gives the game access to the entity's optional lifetime and
+// update hook
+func (e *entityBase) components() *entityBase {
+	return e
+}
+
+// composed is implemented by the entities built on entityBase
+type composed interface {
+	components() *entityBase
+}
+
+// updateEntity updates an entity, then runs its update hook and counts down
+// its lifetime, killing it if that has run out
+func (g *Game) updateEntity(e Entity, ctx UpdateContext) {
+	e.Update(ctx)
+	c, ok := e.(composed)
+	if !ok || e.Dead() {
+		return
+	}
+	base := c.components()
+	if base.onUpdate != nil {
+		base.onUpdate(g)
+	}
+	if base.lifetime != nil && base.lifetime.tick(ctx.TimeScale) {
+		e.Kill()
+	}
+}
+
+// Bounds returns the bounding box of the entity's polygon
+func (e *entityBase) Bounds() geometry.BoundingBox {
+	return e.polygon.GetBoundingBox()
+}
+
+// Collider returns the entity's polygon
+func (e *entityBase) Collider() *geometry.PolygonObject {
+	return e.polygon
+}
+
+// Tag returns the kind of entity this is
+func (e *entityBase) Tag() EntityTag {
+	return e.tag
+}
+
+// CollisionLayers returns the entity's collision layer and mask
+func (e *entityBase) CollisionLayers() (layer, collidesWith CollisionLayer) {
+	return e.layer, e.collidesWith
+}
+
+// Kill marks the entity for removal
+func (e *entityBase) Kill() {
+	e.dead = true
+}
+
+// Dead reports whether the entity has been killed
+func (e *entityBase) Dead() bool {
+	return e.dead
+}
+
+// spawnAsteroid adds an asteroid to the world and reports it
+func (g *Game) spawnAsteroid(a *Asteroid) {
+	g.addEntity(a)
+	g.emit(AsteroidSpawned{
+		Size:     approximateRadius(a.polygon),
+		Position: a.polygon.Position,
+		Velocity: a.polygon.Velocity,
+	})
+}
+
+// approximateRadius returns the average of a polygon's half width and half
+// height
+func approximateRadius(p *geometry.PolygonObject) float64 {
+	bbox := p.GetBoundingBox()
+	return (bbox.MaxX - bbox.MinX + bbox.MaxY - bbox.MinY) / 4
+}
+
+// addEntity adds a new entity to the world, colored with the current palette
+func (g *Game) addEntity(e Entity) {
+	e.ApplyPalette(&g.palette)
+	g.entities = append(g.entities, e)
+}
+
+// removeDeadEntities compacts the entity list, dropping everything killed this tick
+func (g *Game) removeDeadEntities() {
+	live := g.entities[:0]
+	for _, e := range g.entities {
+		if !e.Dead() {
+			live = append(live, e)
+		}
+	}
+	// Clear the tail so removed entities can be garbage collected
+	for i := len(live); i < len(g.entities); i++ {
+		g.entities[i] = nil
+	}
+	g.entities = live
+}
+
+// countEntities returns the number of live entities with the given tag
+func (g *Game) countEntities(tag EntityTag) int {
+	count := 0
+	for _, e := range g.entities {
+		if e.Tag() == tag && !e.Dead() {
+			count++
+		}
+	}
+	return count
+}
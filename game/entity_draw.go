@@ -0,0 +1,30 @@
+//go:build !headless
+
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// drawer is implemented by every entity, to render itself to the screen
+type drawer interface {
+	Draw(screen *ebiten.Image, ctx DrawContext)
+}
+
+// overlayDrawer is implemented by entities with cosmetic extras that, like
+// effects, are drawn after the phosphor trail snapshot
+type overlayDrawer interface {
+	DrawOverlay(screen *ebiten.Image, ctx DrawContext)
+}
+
+// polygonOptions returns the polygon drawing options for this frame
+func (ctx DrawContext) polygonOptions() geometry.DrawOptions {
+	return geometry.DrawOptions{ExtraLineWidth: ctx.LineWidthBoost, Rewind: ctx.Rewind}
+}
+
+// Draw renders the entity's polygon
+func (e *entityBase) Draw(screen *ebiten.Image, ctx DrawContext) {
+	e.polygon.DrawWithOptions(screen, ctx.polygonOptions())
+}
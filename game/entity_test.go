@@ -0,0 +1,129 @@
+package game
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// newTestEntity creates a small stationary entity in the middle of g's
+// screen, with no collisions
+func newTestEntity(g *Game) *entityBase {
+	polygon := geometry.CreateCircle(2, 6)
+	polygon.SetPosition(g.screenWidth/2, g.screenHeight/2)
+	return &entityBase{polygon: polygon, tag: TagEffect}
+}
+
+// stepTicks runs the playing state for the given number of ticks
+func stepTicks(t *testing.T, g *Game, ticks int) {
+	t.Helper()
+	for range ticks {
+		if err := g.updatePlaying(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// entityWithBase is an entity built directly on entityBase, for testing the
+// components the game runs
+type entityWithBase struct {
+	*entityBase
+}
+
+func (e entityWithBase) Update(ctx UpdateContext) {}
+func (e entityWithBase) ApplyPalette(p *Palette)  {}
+
+func TestLifetimeExpiresOnTick(t *testing.T) {
+	g := New()
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+
+	e := entityWithBase{newTestEntity(g)}
+	e.lifetime = newLifetime(5)
+	g.addEntity(e)
+
+	stepTicks(t, g, 4)
+	if e.Dead() || g.countEntities(TagEffect) != 1 {
+		t.Fatalf("Expected the entity to live for 5 ticks, gone after 4")
+	}
+	stepTicks(t, g, 1)
+	if !e.Dead() || slices.Contains(g.entities, Entity(e)) {
+		t.Errorf("Expected the entity to be removed on its 5th tick")
+	}
+}
+
+func TestLifetimeInfinite(t *testing.T) {
+	l := newLifetime(-1)
+	for range 1000 {
+		if l.tick(1) {
+			t.Fatalf("Expected an infinite lifetime never to run out")
+		}
+	}
+	if l.Remaining != -1 {
+		t.Errorf("Expected an infinite lifetime to be left alone, got %v", l.Remaining)
+	}
+
+	// Slow motion counts down in fractions of a tick, and never overshoots
+	// into looking infinite
+	l = newLifetime(1)
+	if l.tick(0.75) || !l.tick(0.75) || l.Remaining != 0 {
+		t.Errorf("Expected the lifetime to run out on the second step, at %v", l.Remaining)
+	}
+}
+
+func TestOnUpdateHook(t *testing.T) {
+	g := New()
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+
+	e := entityWithBase{newTestEntity(g)}
+	calls := 0
+	e.onUpdate = func(game *Game) {
+		if game != g {
+			t.Errorf("Expected the hook to be given the game")
+		}
+		calls++
+		if calls == 3 {
+			e.Kill()
+		}
+	}
+	g.addEntity(e)
+
+	stepTicks(t, g, 10)
+	if calls != 3 || slices.Contains(g.entities, Entity(e)) {
+		t.Errorf("Expected the hook to run until it killed the entity, ran %d times", calls)
+	}
+}
+
+func TestBulletLifetime(t *testing.T) {
+	g := New()
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+	g.wind = geometry.Vector2{}
+	g.wave = classicWaves
+
+	// A slow bullet stays on the screen until its lifetime runs out
+	bullet := newBullet(geometry.Vector2{X: 100, Y: 100}, geometry.Vector2{X: 1})
+	g.addEntity(bullet)
+	stepTicks(t, g, bulletLifetime-1)
+	if bullet.Dead() {
+		t.Fatalf("Expected the bullet to fly until its lifetime runs out")
+	}
+	stepTicks(t, g, 1)
+	if !bullet.Dead() || g.countEntities(TagBullet) != 0 {
+		t.Errorf("Expected the bullet to fizzle out after %d ticks", bulletLifetime)
+	}
+
+	// A fast one leaves the screen first, and is removed rather than
+	// wrapping around
+	bullet = newBullet(geometry.Vector2{X: 10, Y: 20}, geometry.Vector2{X: -8})
+	g.addEntity(bullet)
+	stepTicks(t, g, 10)
+	if !bullet.Dead() || bullet.lifetime.Remaining <= 0 {
+		t.Errorf("Expected the bullet to despawn past the edge at %v", bullet.polygon.Position)
+	}
+}
@@ -0,0 +1,111 @@
+package game
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// eventLogBuffer is how many lines can be waiting to be written before new
+// ones are dropped
+const eventLogBuffer = 4096
+
+// EventLog writes the game's events to a file as JSON lines, one per event,
+// for working out what happened in a run after the fact. Lines are written
+// on a separate goroutine so logging never holds up a frame.
+type EventLog struct {
+	// lines carries encoded lines to the writer. A nil line asks it to flush.
+	lines   chan []byte
+	done    chan error
+	dropped int
+}
+
+// logLine is a single line of the event log
+type logLine struct {
+	Tick  int    `json:"tick"`
+	Score int    `json:"score"`
+	Event string `json:"event"`
+	Data  Event  `json:"data"`
+}
+
+// NewEventLog starts a log writing to w. Close must be called to write out
+// anything still buffered.
+func NewEventLog(w io.Writer) *EventLog {
+	l := &EventLog{
+		lines: make(chan []byte, eventLogBuffer),
+		done:  make(chan error, 1),
+	}
+	go l.write(bufio.NewWriter(w))
+	return l
+}
+
+// WithEventLog records every event the game emits to l. The log is flushed
+// at the end of each run.
+func WithEventLog(l *EventLog) Option {
+	return func(g *Game) {
+		g.Subscribe(func(e Event) {
+			l.record(logLine{Tick: g.survivalTicks, Score: g.score, Data: e})
+			if _, ok := e.(GameEnded); ok {
+				l.send(nil)
+			}
+		})
+	}
+}
+
+// record encodes a line and queues it for writing
+func (l *EventLog) record(line logLine) {
+	line.Event = strings.TrimPrefix(fmt.Sprintf("%T", line.Data), "game.")
+	// Encoding here, rather than on the writer, means the event can't
+	// change underneath it
+	data, err := json.Marshal(line)
+	if err != nil {
+		l.dropped++
+		return
+	}
+	l.send(append(data, '\n'))
+}
+
+// send queues a line for the writer without waiting. If the writer has
+// fallen too far behind the line is dropped.
+func (l *EventLog) send(data []byte) {
+	select {
+	case l.lines <- data:
+	default:
+		l.dropped++
+	}
+}
+
+// write runs on its own goroutine, writing out lines until the log is
+// closed. Once a write fails the rest are discarded.
+func (l *EventLog) write(w *bufio.Writer) {
+	var err error
+	for data := range l.lines {
+		if err != nil {
+			continue
+		}
+		if data == nil {
+			err = w.Flush()
+			continue
+		}
+		_, err = w.Write(data)
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	l.done <- err
+}
+
+// Close writes out everything logged so far and stops the log. It returns
+// the first error writing the log, or how many lines were dropped.
+func (l *EventLog) Close() error {
+	close(l.lines)
+	if err := <-l.done; err != nil {
+		return err
+	}
+	if l.dropped > 0 {
+		return fmt.Errorf("event log fell behind and dropped %d lines", l.dropped)
+	}
+	return nil
+}
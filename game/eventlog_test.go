@@ -0,0 +1,77 @@
+package game
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// failingWriter fails every write
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestEventLogWritesJSONLines(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewEventLog(&buf)
+	g := New(WithSeed(9), WithEventLog(l))
+	g.Restart(RestartOptions{})
+	g.entities = []Entity{g.player}
+	ship := g.player.polygon
+	placeAsteroid(g, 30, ship.Position.X, ship.Position.Y)
+	if err := g.updatePlaying(); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var line struct {
+			Tick  int
+			Event string
+			Data  map[string]any
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("Bad line %q: %v", scanner.Text(), err)
+		}
+		if line.Tick != 1 {
+			t.Errorf("Expected every event on tick 1, got %d", line.Tick)
+		}
+		names = append(names, line.Event)
+		switch line.Event {
+		case "GameStarted":
+			if line.Data["Seed"] != 9.0 {
+				t.Errorf("Expected the seed to be logged, got %v", line.Data)
+			}
+		case "Collision":
+			if line.Data["A"] != "player" || line.Data["B"] != "asteroid" {
+				t.Errorf("Expected the participants by name, got %v", line.Data)
+			}
+		}
+	}
+	want := []string{"GameStarted", "WaveStarted", "AsteroidSpawned", "AsteroidSpawned", "AsteroidSpawned", "Collision", "PlayerHit", "GameEnded"}
+	if len(names) != len(want) {
+		t.Fatalf("Expected events %v, got %v", want, names)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Errorf("Expected events %v, got %v", want, names)
+			break
+		}
+	}
+}
+
+func TestEventLogReportsWriteErrors(t *testing.T) {
+	l := NewEventLog(failingWriter{})
+	l.record(logLine{Data: PlayerHit{}})
+	if err := l.Close(); err == nil {
+		t.Errorf("Expected the write error to be reported")
+	}
+}
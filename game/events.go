@@ -0,0 +1,244 @@
+package game
+
+import (
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// Event is something notable that happened during a tick. Events are queued
+// as they occur and delivered to subscribers at the end of the same tick.
+type Event interface {
+	isEvent()
+}
+
+// GameStarted is emitted when a run begins, before anything else in it
+type GameStarted struct {
+	// Seed is the seed the game's random numbers were generated from
+	Seed     int64
+	Mode     string
+	Mutators Mutators
+	Config   Config
+}
+
+// WaveStarted is emitted when a wave of asteroids is laid out
+type WaveStarted struct {
+	Wave int
+	// Boss is set for a wave led by a boss. No waves have bosses yet.
+	Boss bool
+}
+
+// AsteroidSpawned is emitted whenever an asteroid enters the world, whether
+// as part of a wave, from the edge in endless mode, or as a fragment
+type AsteroidSpawned struct {
+	// Size is the approximate radius of the new asteroid
+	Size     float64
+	Position geometry.Vector2
+	Velocity geometry.Vector2
+}
+
+// AsteroidsMerged is emitted when two small fragments touching in a crowded
+// field merge into one bigger asteroid. It is worth no score.
+type AsteroidsMerged struct {
+	// Size is the approximate radius of the merged asteroid
+	Size     float64
+	Position geometry.Vector2
+}
+
+// Collision is emitted when two entities touch, before the collision is
+// handled
+type Collision struct {
+	A, B EntityTag
+	// Position is where B was when A hit it
+	Position geometry.Vector2
+}
+
+// AsteroidDestroyed is emitted whenever an asteroid is split or removed
+type AsteroidDestroyed struct {
+	// Size is the approximate radius of the asteroid that was destroyed
+	Size float64
+	// Position is where the asteroid was when it was destroyed
+	Position geometry.Vector2
+	// ByBullet is true if the player shot the asteroid, and Owner is then
+	// whose bullet it was
+	ByBullet bool
+	Owner    BulletOwner
+	// CloseCall is true if the player shot the asteroid from up close,
+	// which scores extra
+	CloseCall bool
+	// Energy is that of the impact that destroyed the asteroid, from
+	// impactEnergy, or zero if nothing hit it, as with a bomb
+	Energy float64
+}
+
+// AsteroidDamaged is emitted when a shot hits an armored asteroid without
+// splitting it
+type AsteroidDamaged struct {
+	Position geometry.Vector2
+	// HP is how many hits the asteroid can take before it splits
+	HP int
+}
+
+// CometDestroyed is emitted when the player shoots down a comet
+type CometDestroyed struct {
+	Position geometry.Vector2
+	// Points is the score the comet is worth
+	Points int
+	// Owner is whose bullet destroyed the comet
+	Owner BulletOwner
+}
+
+// MoonletDestroyed is emitted when the player shoots down a moonlet
+// orbiting an asteroid
+type MoonletDestroyed struct {
+	Position geometry.Vector2
+	// Points is the score the moonlet is worth
+	Points int
+	// Owner is whose bullet destroyed the moonlet
+	Owner BulletOwner
+}
+
+// PlayerHit is emitted when an asteroid collides with the player and
+// destroys the ship
+type PlayerHit struct {
+	// Partner is set when it was the second ship of a network game that
+	// was hit
+	Partner bool
+}
+
+// PlayerDamaged is emitted when the ship bounces off an asteroid and loses
+// hull, without being destroyed
+type PlayerDamaged struct {
+	// Hull is how much hull the ship has left
+	Hull int
+	// Partner is set when it was the second ship of a network game
+	Partner bool
+}
+
+// PickupCollected is emitted when the player touches a pickup
+type PickupCollected struct {
+	Kind PickupKind
+	// Points is the score the pickup is worth
+	Points int
+	// Position is where the pickup was collected
+	Position geometry.Vector2
+	// Partner is set if the partner's ship collected it, in a network game
+	Partner bool
+}
+
+// GameEnded is emitted when a run is over, whether the player won or lost.
+// It is emitted after the events that ended the run, so the score is final
+// by the time it is delivered.
+type GameEnded struct {
+	// Reason is what the game over screen shows, such as "GAME OVER"
+	Reason string
+}
+
+// WaveCleared is emitted when the last asteroid of a wave is destroyed
+type WaveCleared struct {
+	Wave int
+}
+
+// BonusTallied is emitted as each part of an end of wave bonus is counted
+// up into the score
+type BonusTallied struct {
+	// Label names what the bonus is for, such as HULL
+	Label  string
+	Points int
+}
+
+// WaveSummary is emitted after WaveCleared, summing up how the player did
+// during the wave
+type WaveSummary struct {
+	Wave      int
+	Destroyed int
+	Shots     int
+	// Accuracy is the percentage of shots that hit a target
+	Accuracy int
+	// Ticks is how long the wave took
+	Ticks int
+	// Bonus is the score for the wave's accuracy
+	Bonus int
+	// CloseCalls counts the asteroids shot from up close
+	CloseCalls int
+}
+
+// BulletIntercepted is emitted when the player shoots down an enemy bullet
+type BulletIntercepted struct {
+	Position geometry.Vector2
+	// Points is the score for the interception
+	Points int
+	// Owner is whose bullet made the interception
+	Owner BulletOwner
+}
+
+// ShotCharged is emitted when a ship's charged shot is ready to release
+type ShotCharged struct {
+	// Partner is set when it was the second ship of a network game
+	Partner bool
+}
+
+// WeaponEmpty is emitted when a ship tries to fire a secondary weapon it
+// has no ammo left for
+type WeaponEmpty struct {
+	Weapon SecondaryWeapon
+	// Partner is set when it was the second ship of a network game
+	Partner bool
+}
+
+// StationDamaged is emitted when an asteroid hits the station in station mode
+type StationDamaged struct {
+	// HP is how many hit points the station has left
+	HP int
+}
+
+func (GameStarted) isEvent()       {}
+func (WaveStarted) isEvent()       {}
+func (AsteroidSpawned) isEvent()   {}
+func (AsteroidsMerged) isEvent()   {}
+func (AsteroidDamaged) isEvent()   {}
+func (Collision) isEvent()         {}
+func (AsteroidDestroyed) isEvent() {}
+func (CometDestroyed) isEvent()    {}
+func (MoonletDestroyed) isEvent()  {}
+func (PlayerHit) isEvent()         {}
+func (PlayerDamaged) isEvent()     {}
+func (PickupCollected) isEvent()   {}
+func (WaveCleared) isEvent()       {}
+func (BonusTallied) isEvent()      {}
+func (WaveSummary) isEvent()       {}
+func (StationDamaged) isEvent()    {}
+func (BulletIntercepted) isEvent() {}
+func (ShotCharged) isEvent()       {}
+func (WeaponEmpty) isEvent()       {}
+func (GameEnded) isEvent()         {}
+
+// EventHandler is called for every event emitted by the game
+type EventHandler func(Event)
+
+// eventBus queues events and delivers them to subscribers in a deterministic order
+type eventBus struct {
+	handlers []EventHandler
+	queue    []Event
+}
+
+// Subscribe registers a handler to receive all events. Handlers are called in
+// the order they were subscribed.
+func (g *Game) Subscribe(handler EventHandler) {
+	g.events.handlers = append(g.events.handlers, handler)
+}
+
+// emit queues an event for delivery at the end of the current tick
+func (g *Game) emit(e Event) {
+	g.events.queue = append(g.events.queue, e)
+}
+
+// dispatchEvents delivers all queued events in the order they were emitted.
+// Events emitted by handlers are delivered within the same dispatch.
+func (g *Game) dispatchEvents() {
+	for i := 0; i < len(g.events.queue); i++ {
+		e := g.events.queue[i]
+		for _, handler := range g.events.handlers {
+			handler(e)
+		}
+	}
+	g.events.queue = g.events.queue[:0]
+}
@@ -0,0 +1,154 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// recordEvents subscribes to g and returns a pointer to the list of events it receives
+func recordEvents(g *Game) *[]Event {
+	var events []Event
+	g.Subscribe(func(e Event) {
+		events = append(events, e)
+	})
+	return &events
+}
+
+// placeAsteroid adds a stationary asteroid of the given radius to the game
+func placeAsteroid(g *Game, radius, x, y float64) *Asteroid {
+	polygon := geometry.CreateAsteroid(radius, 0, 8)
+	polygon.SetPosition(x, y)
+	asteroid := newAsteroid(polygon)
+	g.addEntity(asteroid)
+	return asteroid
+}
+
+func TestEventsBulletDestroysLastAsteroid(t *testing.T) {
+	g := New()
+	g.entities = []Entity{g.player}
+	g.wind = geometry.Vector2{}
+	// On the last wave, so clearing it ends the run
+	g.wave = classicWaves
+	events := recordEvents(g)
+
+	// A small asteroid is removed outright rather than split
+	placeAsteroid(g, 10, 100, 100)
+	g.addEntity(newBullet(geometry.Vector2{X: 100, Y: 100}, geometry.Vector2{}))
+
+	if err := g.updatePlaying(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(*events) != 5 {
+		t.Fatalf("Expected 5 events, got %d: %#v", len(*events), *events)
+	}
+	if c, ok := (*events)[0].(Collision); !ok || c.A != TagBullet || c.B != TagAsteroid {
+		t.Errorf("Expected a bullet and asteroid Collision first, got %#v", (*events)[0])
+	}
+	destroyed, ok := (*events)[1].(AsteroidDestroyed)
+	if !ok {
+		t.Fatalf("Expected AsteroidDestroyed, got %#v", (*events)[1])
+	}
+	if !destroyed.ByBullet {
+		t.Errorf("Expected asteroid to be destroyed by a bullet")
+	}
+	if destroyed.Position != (geometry.Vector2{X: 100, Y: 100}) {
+		t.Errorf("Expected position {100, 100}, got %v", destroyed.Position)
+	}
+	if destroyed.Size < 9 || destroyed.Size > 11 {
+		t.Errorf("Expected size around 10, got %v", destroyed.Size)
+	}
+	if cleared, ok := (*events)[2].(WaveCleared); !ok || cleared.Wave != classicWaves {
+		t.Errorf("Expected WaveCleared for the last wave, got %#v", (*events)[2])
+	}
+	if summary, ok := (*events)[3].(WaveSummary); !ok || summary.Destroyed != 1 {
+		t.Errorf("Expected a WaveSummary after the wave is cleared, got %#v", (*events)[3])
+	}
+	if ended, ok := (*events)[4].(GameEnded); !ok || ended.Reason != "YOU WIN!" {
+		t.Errorf("Expected GameEnded last, got %#v", (*events)[4])
+	}
+	if g.score != 1 {
+		t.Errorf("Expected score 1 from the scoring subscriber, got %d", g.score)
+	}
+}
+
+func TestEventsBulletSplitsLargeAsteroid(t *testing.T) {
+	g := New()
+	g.entities = []Entity{g.player}
+	events := recordEvents(g)
+
+	placeAsteroid(g, 40, 100, 100)
+	g.addEntity(newBullet(geometry.Vector2{X: 100, Y: 100}, geometry.Vector2{}))
+
+	if err := g.updatePlaying(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The split leaves two fragments behind, so the wave is not cleared
+	if len(*events) != 4 {
+		t.Fatalf("Expected 4 events, got %d: %#v", len(*events), *events)
+	}
+	if _, ok := (*events)[1].(AsteroidDestroyed); !ok {
+		t.Errorf("Expected AsteroidDestroyed, got %#v", (*events)[1])
+	}
+	for _, e := range (*events)[2:] {
+		if spawned, ok := e.(AsteroidSpawned); !ok || spawned.Size >= 40 {
+			t.Errorf("Expected a smaller AsteroidSpawned for each fragment, got %#v", e)
+		}
+	}
+	if got := g.countEntities(TagAsteroid); got != 2 {
+		t.Errorf("Expected 2 fragments, got %d", got)
+	}
+}
+
+func TestEventsPlayerHit(t *testing.T) {
+	g := New()
+	g.entities = []Entity{g.player}
+	events := recordEvents(g)
+
+	ship := g.player.polygon
+	placeAsteroid(g, 30, ship.Position.X, ship.Position.Y)
+
+	if err := g.updatePlaying(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(*events) != 3 {
+		t.Fatalf("Expected 3 events, got %d: %#v", len(*events), *events)
+	}
+	if _, ok := (*events)[1].(PlayerHit); !ok {
+		t.Errorf("Expected PlayerHit, got %#v", (*events)[1])
+	}
+	if _, ok := (*events)[2].(GameEnded); !ok {
+		t.Errorf("Expected GameEnded, got %#v", (*events)[2])
+	}
+	if g.state != GameStateGameOver {
+		t.Errorf("Expected game over after the player was hit")
+	}
+	if !ship.IsFading {
+		t.Errorf("Expected the death flash subscriber to start a fade")
+	}
+}
+
+func TestEventsRunStart(t *testing.T) {
+	g := New(WithSeed(42), WithAsteroidCount(2))
+	events := recordEvents(g)
+	g.Restart(RestartOptions{})
+	g.dispatchEvents()
+
+	if len(*events) != 4 {
+		t.Fatalf("Expected 4 events, got %d: %#v", len(*events), *events)
+	}
+	if started, ok := (*events)[0].(GameStarted); !ok || started.Seed != 42 || started.Mode != "CLASSIC" {
+		t.Errorf("Expected GameStarted with the seed, got %#v", (*events)[0])
+	}
+	if wave, ok := (*events)[1].(WaveStarted); !ok || wave.Wave != 1 {
+		t.Errorf("Expected WaveStarted{Wave: 1}, got %#v", (*events)[1])
+	}
+	for _, e := range (*events)[2:] {
+		if _, ok := e.(AsteroidSpawned); !ok {
+			t.Errorf("Expected AsteroidSpawned, got %#v", e)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package game
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+const (
+	// fairImpactTicks is the least time a wave may give the ship before an
+	// asteroid could reach it, if nothing changes course: a second and a
+	// half, so every hit at the start of a wave can be dodged
+	fairImpactTicks = 1.5 * ticksPerSecond
+	// fairSpawnTries is how many times a starting asteroid is placed before
+	// settling for wherever it was put last
+	fairSpawnTries = 20
+)
+
+var (
+	// debugImpactColor is the color of the line from the ship to the
+	// asteroid that would reach it first, and debugUnfairColor its color
+	// when that is sooner than a wave should allow
+	debugImpactColor = color.RGBA{255, 255, 0, 255}
+	debugUnfairColor = color.RGBA{255, 0, 0, 255}
+)
+
+// TimeToImpact returns how many ticks until the asteroid would touch the
+// player's ship, if both kept their current velocities, or +Inf if it never
+// would before either had wrapped around the screen more than once. Both
+// are treated as the circles their outlines turn within, so it errs towards
+// impacts that a glancing pass would miss. It is 0 if they already touch.
+func TimeToImpact(player *Player, asteroid *Asteroid, screenWidth, screenHeight float64) float64 {
+	ship, rock := player.polygon, asteroid.polygon
+	reach := outlineRadius(ship) + outlineRadius(rock)
+	offset := geometry.Vector2{X: rock.Position.X - ship.Position.X, Y: rock.Position.Y - ship.Position.Y}
+	velocity := geometry.Vector2{X: rock.Velocity.X - ship.Velocity.X, Y: rock.Velocity.Y - ship.Velocity.Y}
+	// Start from the nearest copy of the asteroid across the screen's
+	// edges, and try it along with the copies around it
+	offset.X -= screenWidth * math.Round(offset.X/screenWidth)
+	offset.Y -= screenHeight * math.Round(offset.Y/screenHeight)
+
+	soonest := math.Inf(1)
+	for _, dx := range []float64{-screenWidth, 0, screenWidth} {
+		for _, dy := range []float64{-screenHeight, 0, screenHeight} {
+			soonest = math.Min(soonest, timeToReach(geometry.Vector2{X: offset.X + dx, Y: offset.Y + dy}, velocity, reach))
+		}
+	}
+	return soonest
+}
+
+// timeToReach returns how long something at offset, moving at velocity,
+// takes to come within reach of the origin, or +Inf if it never does
+func timeToReach(offset, velocity geometry.Vector2, reach float64) float64 {
+	c := offset.X*offset.X + offset.Y*offset.Y - reach*reach
+	if c <= 0 {
+		return 0
+	}
+	a := velocity.X*velocity.X + velocity.Y*velocity.Y
+	b := 2 * (offset.X*velocity.X + offset.Y*velocity.Y)
+	discriminant := b*b - 4*a*c
+	// Standing still or heading away, or passing wide
+	if a == 0 || b >= 0 || discriminant < 0 {
+		return math.Inf(1)
+	}
+	return (-b - math.Sqrt(discriminant)) / (2 * a)
+}
+
+// soonestImpact returns the asteroid that would reach the ship first, and
+// when, or nil and +Inf if none would
+func (g *Game) soonestImpact(p *Player) (*Asteroid, float64) {
+	var first *Asteroid
+	soonest := math.Inf(1)
+	for _, e := range g.entities {
+		a, ok := e.(*Asteroid)
+		if !ok || a.Dead() {
+			continue
+		}
+		if t := TimeToImpact(p, a, g.screenWidth, g.screenHeight); t < soonest {
+			first, soonest = a, t
+		}
+	}
+	return first, soonest
+}
+
+// fairFor reports whether an asteroid gives every ship at least
+// fairImpactTicks before it could reach it
+func (g *Game) fairFor(a *Asteroid) bool {
+	for _, p := range []*Player{g.player, g.partner} {
+		if p != nil && TimeToImpact(p, a, g.screenWidth, g.screenHeight) < fairImpactTicks {
+			return false
+		}
+	}
+	return true
+}
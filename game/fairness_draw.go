@@ -0,0 +1,31 @@
+//go:build !headless
+
+package game
+
+import (
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// drawImpactReadout draws a line from the ship to the asteroid that would
+// reach it first, labeled with how long it would take, while the debug
+// overlay is on
+func (g *Game) drawImpactReadout(screen *ebiten.Image) {
+	if g.player == nil || !g.midRun() {
+		return
+	}
+	a, ticks := g.soonestImpact(g.player)
+	if a == nil {
+		return
+	}
+	ship, rock := g.player.polygon.Position, a.polygon.Position
+	lineColor := debugImpactColor
+	if ticks < fairImpactTicks {
+		lineColor = debugUnfairColor
+	}
+	vector.StrokeLine(screen, float32(ship.X), float32(ship.Y), float32(rock.X), float32(rock.Y), 1, lineColor, true)
+	label := fmt.Sprintf("IMPACT %.1fS", ticks/ticksPerSecond)
+	g.debugFont.DrawString(screen, label, float32(ship.X)+20, float32(ship.Y)-20)
+}
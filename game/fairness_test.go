@@ -0,0 +1,53 @@
+package game
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTimeToImpact(t *testing.T) {
+	g := newWeaponsGame()
+	p := g.player
+	p.polygon.SetPosition(400, 300)
+	a := placeAsteroid(g, 20, 0, 0)
+	reach := outlineRadius(p.polygon) + outlineRadius(a.polygon)
+
+	tests := []struct {
+		name         string
+		x, y, vx, vy float64
+		shipVX       float64
+		want         float64
+	}{
+		{"head on", 400 + reach + 100, 300, -2, 0, 0, 50},
+		// Heading away, it comes back round from the other side
+		{"heading away", 400 + reach + 100, 300, 2, 0, 0, (800 - 2*reach - 100) / 2},
+		{"passing wide", 400 + reach + 100, 300 + reach + 1, -2, 0, 0, math.Inf(1)},
+		{"standing still", 400 + reach + 100, 300, 0, 0, 0, math.Inf(1)},
+		{"touching", 400 + reach - 1, 300, 2, 0, 0, 0},
+		{"ship flying into it", 400 + reach + 100, 300, 0, 0, 4, 25},
+		// Off the left edge and heading further left, it comes back round
+		// on the right
+		{"across the edge", 400 + reach + 100 - 800, 300, -2, 0, 0, 50},
+		{"across the bottom", 400, 300 + 600 - reach - 60, 0, 3, 0, 20},
+	}
+	for _, tt := range tests {
+		a.polygon.SetPosition(tt.x, tt.y)
+		a.polygon.SetVelocity(tt.vx, tt.vy)
+		p.polygon.SetVelocity(tt.shipVX, 0)
+		got := TimeToImpact(p, a, 800, 600)
+		if math.Abs(got-tt.want) > 1e-9 && got != tt.want {
+			t.Errorf("%s: expected impact in %v ticks, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestWaveStartsFairly(t *testing.T) {
+	for seed := int64(1); seed <= 200; seed++ {
+		g := New(WithSeed(seed))
+		g.Restart(RestartOptions{})
+		a, ticks := g.soonestImpact(g.player)
+		if ticks < fairImpactTicks {
+			t.Errorf("Seed %d: an asteroid at %v moving %v could hit the ship after %.0f ticks", seed, a.polygon.Position, a.polygon.Velocity, ticks)
+		}
+	}
+}
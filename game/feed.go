@@ -0,0 +1,136 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+)
+
+const (
+	// feedLines is how many messages the feed shows at once
+	feedLines = 3
+	// feedTicks is how long a message stays in the feed, and
+	// feedFadeTicks how long it takes to fade out at the end of that
+	feedTicks     = 3 * ticksPerSecond
+	feedFadeTicks = ticksPerSecond / 2
+	// feedLineHeight is the distance between the feed's lines, in pixels
+	feedLineHeight = 18
+)
+
+// feedPriority is how much a message in the feed matters. Less important
+// messages are the first to make way for new ones.
+type feedPriority int
+
+const (
+	// feedMinor messages can come thick and fast, so a repeat of one still
+	// in the feed is counted on it rather than shown again
+	feedMinor feedPriority = iota
+	feedNormal
+	feedMajor
+)
+
+// feedMessage is a line in the feed of what has happened in the bottom-left
+// corner of the screen
+type feedMessage struct {
+	text     string
+	priority feedPriority
+	color    color.RGBA
+	// count is how many times a minor message has been repeated while
+	// shown, and ticks counts down its remaining life
+	count int
+	ticks int
+}
+
+// label returns how the message is shown, with how many times it has
+// happened if more than once
+func (m feedMessage) label() string {
+	if m.count > 1 {
+		return fmt.Sprintf("%s ×%d", m.text, m.count)
+	}
+	return m.text
+}
+
+// postFeed adds a message to the feed. A minor message already in the feed
+// is counted again and shown for longer. Once the feed is full, the oldest
+// of its least important messages makes way, unless the new one matters
+// even less.
+func (g *Game) postFeed(text string, priority feedPriority, c color.RGBA) {
+	if priority == feedMinor {
+		for i := range g.feed {
+			if m := &g.feed[i]; m.text == text {
+				m.count++
+				m.ticks = feedTicks
+				return
+			}
+		}
+	}
+	if len(g.feed) >= feedLines {
+		oldest := 0
+		for i, m := range g.feed {
+			if m.priority < g.feed[oldest].priority {
+				oldest = i
+			}
+		}
+		if g.feed[oldest].priority > priority {
+			return
+		}
+		g.feed = append(g.feed[:oldest], g.feed[oldest+1:]...)
+	}
+	g.feed = append(g.feed, feedMessage{text: text, priority: priority, color: c, count: 1, ticks: feedTicks})
+}
+
+// handleFeed posts what happened to this player to the feed. In a network
+// game that is whoever flies the ship on this side.
+func (g *Game) handleFeed(e Event) {
+	local := g.localShip()
+	switch e := e.(type) {
+	case PickupCollected:
+		if e.Partner != local.partner {
+			return
+		}
+		if e.Points > 0 {
+			g.postFeed(fmt.Sprintf("%s +%d", e.Kind, e.Points), feedNormal, g.palette.Pickup)
+			return
+		}
+		g.postFeed(e.Kind.String()+" ACQUIRED", feedNormal, g.palette.Pickup)
+	case AsteroidDestroyed:
+		if e.CloseCall && e.Owner == local.owner() {
+			g.postFeed("CLOSE CALL", feedMinor, g.palette.Score)
+		}
+	case CometDestroyed:
+		if e.Owner == local.owner() {
+			g.postFeed(fmt.Sprintf("COMET DESTROYED +%d", e.Points), feedNormal, g.palette.Score)
+		}
+	case MoonletDestroyed:
+		if e.Owner == local.owner() {
+			g.postFeed(fmt.Sprintf("MOONLET DESTROYED +%d", e.Points), feedNormal, g.palette.Score)
+		}
+	case BulletIntercepted:
+		if e.Owner == local.owner() {
+			g.postFeed("INTERCEPT", feedMinor, g.palette.Score)
+		}
+	case PlayerDamaged:
+		if e.Partner == local.partner {
+			g.postFeed("HULL DAMAGED", feedNormal, g.palette.Hit)
+		}
+	case StationDamaged:
+		g.postFeed("STATION HIT", feedNormal, g.palette.Hit)
+	case WeaponEmpty:
+		if e.Partner == local.partner {
+			g.postFeed(weaponDefs[e.Weapon].name+" EMPTY", feedMinor, g.palette.UIDim)
+		}
+	case WaveCleared:
+		g.postFeed(fmt.Sprintf("WAVE %d CLEARED", e.Wave), feedMajor, g.palette.UI)
+	}
+}
+
+// updateFeed ages the feed's messages, dropping those that have run out
+func (g *Game) updateFeed() {
+	live := g.feed[:0]
+	for _, m := range g.feed {
+		m.ticks--
+		if m.ticks > 0 {
+			live = append(live, m)
+		}
+	}
+	g.feed = live
+}
@@ -0,0 +1,19 @@
+//go:build !headless
+
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// drawFeed draws the feed in the bottom-left corner, above the hull bar,
+// newest at the bottom, each message fading out at the end of its life
+func (g *Game) drawFeed(screen *ebiten.Image) {
+	x := float32(20)
+	y := float32(g.screenHeight) - 50 - float32(len(g.feed)-1)*feedLineHeight
+	for _, m := range g.feed {
+		g.feedFont.SetColor(fadeColor(m.color, min(1, float64(m.ticks)/feedFadeTicks)))
+		g.feedFont.DrawString(screen, m.label(), x, y)
+		y += feedLineHeight
+	}
+}
@@ -0,0 +1,93 @@
+package game
+
+import (
+	"image/color"
+	"slices"
+	"testing"
+)
+
+// feedLabels returns the labels of the messages in the feed, oldest first
+func feedLabels(g *Game) []string {
+	var labels []string
+	for _, m := range g.feed {
+		labels = append(labels, m.label())
+	}
+	return labels
+}
+
+func TestFeedKeepsLatestMessages(t *testing.T) {
+	g := newWeaponsGame()
+	for _, text := range []string{"ONE", "TWO", "THREE", "FOUR"} {
+		g.postFeed(text, feedNormal, color.RGBA{})
+	}
+	if got, want := feedLabels(g), []string{"TWO", "THREE", "FOUR"}; !slices.Equal(got, want) {
+		t.Errorf("Expected the feed to show %v, got %v", want, got)
+	}
+
+	for range feedTicks - 1 {
+		g.updateFeed()
+	}
+	if len(g.feed) != feedLines {
+		t.Fatalf("Expected the messages to last %d ticks", feedTicks)
+	}
+	g.updateFeed()
+	if len(g.feed) != 0 {
+		t.Errorf("Expected the messages to be gone after %d ticks, got %v", feedTicks, feedLabels(g))
+	}
+}
+
+func TestFeedCoalescesMinorMessages(t *testing.T) {
+	g := newWeaponsGame()
+	g.postFeed("CLOSE CALL", feedMinor, color.RGBA{})
+	g.postFeed("WAVE 1 CLEARED", feedMajor, color.RGBA{})
+	for range 10 {
+		g.updateFeed()
+	}
+	g.postFeed("CLOSE CALL", feedMinor, color.RGBA{})
+	if got, want := feedLabels(g), []string{"CLOSE CALL ×2", "WAVE 1 CLEARED"}; !slices.Equal(got, want) {
+		t.Errorf("Expected the feed to show %v, got %v", want, got)
+	}
+	if g.feed[0].ticks != feedTicks {
+		t.Errorf("Expected a repeat to show the message for longer")
+	}
+
+	// Only minor messages are counted together
+	g.postFeed("WAVE 1 CLEARED", feedMajor, color.RGBA{})
+	if len(g.feed) != 3 {
+		t.Errorf("Expected a repeated major message to be shown again, got %v", feedLabels(g))
+	}
+}
+
+func TestFeedMakesWayByPriority(t *testing.T) {
+	g := newWeaponsGame()
+	g.postFeed("MAJOR", feedMajor, color.RGBA{})
+	g.postFeed("MINOR", feedMinor, color.RGBA{})
+	g.postFeed("NORMAL", feedNormal, color.RGBA{})
+	g.postFeed("NEW", feedNormal, color.RGBA{})
+	if got, want := feedLabels(g), []string{"MAJOR", "NORMAL", "NEW"}; !slices.Equal(got, want) {
+		t.Errorf("Expected the minor message to make way, got %v", got)
+	}
+	g.postFeed("SPAM", feedMinor, color.RGBA{})
+	if got, want := feedLabels(g), []string{"MAJOR", "NORMAL", "NEW"}; !slices.Equal(got, want) {
+		t.Errorf("Expected a minor message not to push out more important ones, got %v", got)
+	}
+}
+
+func TestFeedFromEvents(t *testing.T) {
+	g := newWeaponsGame()
+	g.emit(PickupCollected{Kind: PickupGhost})
+	g.emit(PickupCollected{Kind: PickupMagnet, Partner: true})
+	g.emit(AsteroidDestroyed{CloseCall: true, Owner: OwnerPlayer})
+	g.emit(AsteroidDestroyed{CloseCall: true, Owner: OwnerPlayer})
+	g.emit(WaveCleared{Wave: 5})
+	g.dispatchEvents()
+	want := []string{"GHOST ACQUIRED", "CLOSE CALL ×2", "WAVE 5 CLEARED"}
+	if got := feedLabels(g); !slices.Equal(got, want) {
+		t.Errorf("Expected the feed to show %v, got %v", want, got)
+	}
+
+	g.Restart(RestartOptions{})
+	if len(g.feed) != 0 {
+		t.Errorf("Expected a new run to start with an empty feed, got %v", feedLabels(g))
+	}
+}
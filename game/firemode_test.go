@@ -0,0 +1,168 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// shotsFired plays the inputs from the start of a run and counts the
+// shots fired. Bullets leave the screen, so it counts the ticks the ship
+// fired on rather than the bullets in flight.
+func shotsFired(t *testing.T, g *Game, inputs []InputState) int {
+	t.Helper()
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+	placeAsteroid(g, 10, 50, 50)
+	// Start with the gun ready
+	g.player.lastShotTick = -g.tuning.BulletCooldown
+	shots := 0
+	for _, in := range inputs {
+		last := g.player.lastShotTick
+		if err := g.Step(in); err != nil {
+			t.Fatal(err)
+		}
+		if g.player.lastShotTick != last {
+			shots++
+		}
+	}
+	return shots
+}
+
+func TestFireModeDefaults(t *testing.T) {
+	g := New()
+	for _, test := range []struct {
+		mode GameMode
+		want FireMode
+	}{
+		{ModeClassic, FireSemi},
+		{ModeEndless, FireAuto},
+		{ModeStation, FireAuto},
+	} {
+		g.mode = test.mode
+		if got := g.fireMode(); got != test.want {
+			t.Errorf("Expected %v to default to %q, got %q", test.mode, test.want, got)
+		}
+	}
+	g.config.FireMode = FireAuto
+	g.mode = ModeClassic
+	if got := g.fireMode(); got != FireAuto {
+		t.Errorf("Expected the config to override the default, got %q", got)
+	}
+}
+
+func TestSemiAutoFire(t *testing.T) {
+	held := make([]InputState, 60)
+	for i := range held {
+		held[i] = InputState{Fire: true}
+	}
+	// Tapping every 20 ticks, longer than the cooldown
+	tapped := make([]InputState, 60)
+	for i := range tapped {
+		tapped[i] = InputState{Fire: i%20 == 0}
+	}
+
+	g := New()
+	g.config.FireMode = FireSemi
+	if got := shotsFired(t, g, held); got != 1 {
+		t.Errorf("Expected holding fire to shoot once, got %d", got)
+	}
+	if got := shotsFired(t, g, tapped); got != 3 {
+		t.Errorf("Expected each tap to shoot, got %d", got)
+	}
+
+	g.config.FireMode = FireAuto
+	if got := shotsFired(t, g, held); got != 10 {
+		t.Errorf("Expected holding fire to keep shooting, got %d", got)
+	}
+}
+
+func TestInputJustPressed(t *testing.T) {
+	prev := InputState{Fire: true, Left: true}
+	in := InputState{Fire: true, Right: true, Thrust: true}
+	if got, want := in.JustPressed(prev), (InputState{Right: true, Thrust: true}); got != want {
+		t.Errorf("Expected %+v, got %+v", want, got)
+	}
+}
+
+// pressedAt returns inputs that tap fire for a single tick at each of the
+// given ticks
+func pressedAt(ticks int, taps ...int) []InputState {
+	inputs := make([]InputState, ticks)
+	for _, tick := range taps {
+		inputs[tick] = InputState{Fire: true}
+	}
+	return inputs
+}
+
+func TestFirePressBufferedDuringCooldown(t *testing.T) {
+	g := New()
+	g.config.FireMode = FireSemi
+	const cooldown = 20
+	g.tuning.BulletCooldown = cooldown
+
+	// The first tap fires straight away, and the second comes 3 ticks before
+	// the gun is ready again
+	early := cooldown - 3
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+	placeAsteroid(g, 10, 50, 50)
+	g.player.lastShotTick = -cooldown
+	var fired []int
+	for i, in := range pressedAt(2*cooldown, 0, early) {
+		last := g.player.lastShotTick
+		if err := g.Step(in); err != nil {
+			t.Fatal(err)
+		}
+		if g.player.lastShotTick != last {
+			fired = append(fired, i)
+		}
+	}
+	if len(fired) != 2 || fired[1] != cooldown {
+		t.Errorf("Expected the early press to fire once, on tick %d, fired on %v", cooldown, fired)
+	}
+
+	// Pressing 10 ticks early is too soon to be remembered
+	if got := shotsFired(t, g, pressedAt(2*cooldown, 0, cooldown-10)); got != 1 {
+		t.Errorf("Expected a press 10 ticks early to be dropped, got %d shots", got)
+	}
+
+	// The window can be tuned
+	g.tuning.FireBuffer = 10
+	if got := shotsFired(t, g, pressedAt(2*cooldown, 0, cooldown-10)); got != 2 {
+		t.Errorf("Expected a longer buffer to keep the press, got %d shots", got)
+	}
+}
+
+func TestFirePressBufferedAtBulletCap(t *testing.T) {
+	g := New()
+	g.config.FireMode = FireSemi
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+	placeAsteroid(g, 10, 50, 50)
+	g.player.lastShotTick = -g.tuning.BulletCooldown
+	var bullets []*Bullet
+	for range maxActiveBullets {
+		b := newBullet(geometry.Vector2{X: 10, Y: 10}, geometry.Vector2{})
+		g.addEntity(b)
+		bullets = append(bullets, b)
+	}
+
+	if err := g.Step(InputState{Fire: true}); err != nil {
+		t.Fatal(err)
+	}
+	if g.player.lastShotTick >= 0 {
+		t.Fatalf("Expected no shot with the bullet cap reached")
+	}
+	// A bullet running out frees a slot for the buffered press
+	bullets[0].Kill()
+	if err := g.Step(InputState{}); err != nil {
+		t.Fatal(err)
+	}
+	if g.player.lastShotTick != g.survivalTicks {
+		t.Errorf("Expected the buffered press to fire once there was room")
+	}
+}
@@ -0,0 +1,958 @@
+// Package game implements the SpaceDebris game loop. A *Game satisfies the
+// ebiten.Game interface, so it can be run directly with ebiten.RunGame or
+// embedded in another ebiten application.
+//
+// Built with the headless tag, the package leaves out ebiten along with
+// everything that draws or reads the keyboard. Games can then only be
+// stepped with Step, but run anywhere, without a display.
+package game
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+	"github.com/AndreRenaud/SpaceDebris/vectorfont"
+)
+
+// GameState represents the current state of the game
+type GameState int
+
+const (
+	GameStatePlaying GameState = iota
+	GameStateGameOver
+	GameStateTitle
+	GameStateSettings
+	GameStateMutators
+	// GameStateGetReady counts down to the start of a run
+	GameStateGetReady
+	// GameStateNetError shows why a network game was abandoned
+	GameStateNetError
+	// GameStatePaused freezes a run behind the pause menu
+	GameStatePaused
+	// GameStateRecords shows the lifetime records
+	GameStateRecords
+	// GameStateDeathReview holds the field still for a moment after a death,
+	// before the game over screen, to show what hit the ship
+	GameStateDeathReview
+	// GameStateSlots picks a saved run to continue
+	GameStateSlots
+)
+
+// Game implements ebiten.Game interface.
+type Game struct {
+	// entities holds everything in the world, including the player
+	entities       []Entity
+	player         *Player
+	screenWidth    float64
+	screenHeight   float64
+	score          int
+	wave           int
+	events         eventBus
+	vectorFont     *vectorfont.Font
+	titleFont      *vectorfont.Font
+	state          GameState
+	gameOverReason string
+	// killer is the asteroid that ended the run by hitting the ship, if one
+	// did, and deathReviewTicks counts down the review of its path
+	killer           *Asteroid
+	deathReviewTicks int
+
+	// notifications is the channel returned by Events, once it has been
+	// asked for, and notifiedScore the score it last reported
+	notifications chan GameEvent
+	notifiedScore int
+
+	// config holds the persistent settings, saved to configPath when changed
+	config     Config
+	configPath string
+	// records are the lifetime totals, saved to recordsPath if it is set,
+	// and runTotals what the current run will add to them
+	records     Records
+	recordsPath string
+	runTotals   runTotals
+	// recordingDir is where runs are recorded to, if they are, and
+	// recording the run being recorded
+	recordingDir string
+	recording    *RunLog
+	// savesDir is where runs in progress are saved when the game is quit,
+	// if they are. slots is what each slot held when last read, and runSlot
+	// is the slot the run in progress was resumed from, or -1.
+	savesDir string
+	slots    [saveSlots]saveSlot
+	runSlot  int
+	// slotSelection is the slot picked on the continue screen. deleteHeld
+	// counts the ticks the delete key has been held down on it, and
+	// confirmingDelete asks whether to delete it, with deleteSelection the
+	// answer picked.
+	slotSelection    int
+	deleteHeld       int
+	confirmingDelete bool
+	deleteSelection  int
+	// persistWindow saves the window's state along with the config on quit
+	persistWindow bool
+	// liveWindowTitle shows the run's progress in the window title.
+	// windowTitle is the title last set, windowTitleTicks the ticks since.
+	liveWindowTitle  bool
+	windowTitle      string
+	windowTitleTicks int
+	// palette is the resolved color palette named in config, in the current
+	// wave's theme. While the theme changes, themeTicks counts down the fade
+	// from the colors in themeFrom.
+	palette    Palette
+	themeFrom  Palette
+	themeTicks int
+	// shapes holds the user's custom ship and asteroid designs
+	shapes Shapes
+
+	// mode is the rule set for the current run
+	mode GameMode
+	// titleSelection is the index of the highlighted mode on the title screen
+	titleSelection int
+	// settingsSelection is the index of the highlighted settings entry
+	settingsSelection int
+	// mutatorSelection is the index of the highlighted mutator screen entry
+	mutatorSelection int
+	// pauseSelection is the index of the highlighted pause menu entry, and
+	// confirmingRestart is set while the menu asks to confirm a restart
+	pauseSelection    int
+	confirmingRestart bool
+	// menuTicks counts the ticks a menu has been open, to animate it
+	menuTicks int
+	// menuInput reads the menu controls for whichever menu is showing
+	menuInput MenuInput
+	// settingsReturn is the screen the settings screen goes back to
+	settingsReturn GameState
+	// confirmingQuit is set while asking whether to quit mid-run
+	confirmingQuit bool
+	// mutators are the mutators active for the current run, and rules are
+	// the tunables they have adjusted
+	mutators Mutators
+	rules    rules
+	// survivalTicks counts the ticks played in the current run
+	survivalTicks int
+	// getReadyTicks counts down the ticks until play starts
+	getReadyTicks int
+	// spawnTimer counts down the ticks until the next endless, station or
+	// practice mode spawn
+	spawnTimer int
+	// station is the station being defended in station mode
+	station *Station
+	// stationSpawnsLeft is how many more asteroids the current station mode
+	// wave will send
+	stationSpawnsLeft int
+	// cometTimer counts down the ticks until the next comet
+	cometTimer int
+	// time controls slow motion and hit-stop effects on the world
+	time timeScale
+	// scoreFlash counts down the ticks the score flashes for
+	scoreFlash int
+	// scoreCounter is the score as drawn on the HUD
+	scoreCounter scoreCounter
+	// powerUps holds the remaining ticks of each active timed power-up
+	powerUps map[PickupKind]float64
+	// input is the player's controls for the current tick
+	input InputState
+	// partner is the second ship in a network game, flown with
+	// partnerInput, and nil otherwise
+	partner      *Player
+	partnerInput InputState
+	// net is the connection to the other player in a network game, and
+	// netError explains why one was abandoned
+	net      *NetSession
+	netError string
+	// interpolate smooths motion by drawing between the last two ticks, and
+	// lastTick is when the latest tick that moved the world started, with
+	// poseSteps the number of steps it moved it by
+	interpolate bool
+	lastTick    time.Time
+	poseSteps   int
+	// debugOverlay draws the outlines used for collisions over the game
+	debugOverlay bool
+	// perf is the performance readout shown with the debug overlay, and
+	// debugFont the small font it is drawn in
+	perf      perfStats
+	debugFont *vectorfont.Font
+
+	// wind is the solar wind blowing during the current wave, and dust the
+	// specks drifting with it
+	wind geometry.Vector2
+	dust []geometry.Vector2
+	// thrustTap spots thrust being double tapped, which dashes
+	thrustTap doubleTap
+	// latched is the input held since the last step, and thrustPressed
+	// whether thrust was pressed since then, for the next step to take
+	latched       InputState
+	thrustPressed bool
+	// stepClock counts ticksPerSecond'ths of a tick towards the next step, and
+	// steps is how many steps of the world fall in the current tick
+	stepClock int
+	steps     int
+	// paused stops the game from updating while it is embedded and the host
+	// application wants it frozen
+	paused bool
+
+	// rng is the source of all the game's randomness, seeded with seed
+	rng  *rand.Rand
+	seed int64
+	// tuning holds the handling constants, and tuningWatch reloads them from
+	// a file when one is given
+	tuning      Tuning
+	tuningWatch *tuningWatch
+	// indicator is the triangle drawn to point at off-screen threats
+	indicator *geometry.PolygonObject
+	// banners are the banners waiting to be shown, the first of which is
+	// showing now
+	banners []banner
+	// toast is a brief message shown for toastTicks more ticks
+	toast      string
+	toastTicks int
+	// popups are the messages floating up from where things happened
+	popups []popup
+	// feed is the log of recent messages in the bottom-left corner, drawn
+	// in feedFont
+	feed     []feedMessage
+	feedFont *vectorfont.Font
+
+	// speaker plays the game's sounds, if it has been given one
+	speaker Speaker
+	// replaying is set while a run log is being played back to catch up
+	// with it, which is done silently
+	replaying bool
+	// explosionSounds caches the explosion for each size bucket and step of
+	// impact, synthesized the first time it is heard
+	explosionSounds map[int][]byte
+	// explosionVoices counts down the ticks left of each explosion playing
+	explosionVoices []int
+	// uiSounds caches the menu and control sounds once they have been heard
+	uiSounds [uiSoundCount][]byte
+
+	// waveStats counts the player's shots and kills during the current wave.
+	// waveSummary is the last wave's summary, shown for waveSummaryTicks
+	// more ticks, and bestWaveAccuracy the best accuracy of any wave this
+	// run, or -1 if none has been cleared.
+	waveStats        waveStats
+	waveSummary      WaveSummary
+	waveSummaryTicks int
+	bestWaveAccuracy int
+
+	// tally plays the end of wave bonus tally, with the lines shown so far
+	// in tallyShown
+	tally      sequence
+	tallyShown []*tallyLine
+
+	// shakeTicks counts down while the screen shakes by up to
+	// shakeMagnitude pixels
+	shakeTicks     int
+	shakeMagnitude float64
+
+	// layers are the images frames are drawn through
+	layers
+
+	// phosphorGhostAlpha is how strongly the last tick's world shows
+	// through, for the phosphor ghosting effect
+	phosphorGhostAlpha float32
+	// phosphorDue is set once a tick has passed since the ghost was taken,
+	// so the trail is sampled once a tick however often frames are drawn
+	phosphorDue bool
+}
+
+// Update proceeds the game state.
+// Update is called every tick (1/60 [s] by default).
+func (g *Game) Update() error {
+	if g.paused {
+		return nil
+	}
+	if g.debugOverlay {
+		defer g.timeUpdate(time.Now())
+	}
+	// Closing the window only reaches here if the application has asked to
+	// handle it, with ebiten.SetWindowClosingHandled
+	if windowClosing() {
+		if err := g.requestQuit(); err != nil {
+			return err
+		}
+	}
+	// Menus and timers go by the steps due too, not by the updates, so they
+	// run at the same speed whatever the tick rate
+	g.steps = g.dueSteps()
+	g.updateWindowTitle()
+	if g.confirmingQuit {
+		return g.updateQuitPrompt()
+	}
+	g.pollTuning()
+	g.toggleDebugOverlay()
+	if g.steps > 0 {
+		g.savePoses()
+	}
+	for range g.steps {
+		if g.toastTicks > 0 {
+			g.toastTicks--
+		}
+		g.updateSounds()
+		if !g.frozen() {
+			g.updateOverlays()
+		}
+	}
+	switch g.state {
+	case GameStatePlaying, GameStateGetReady:
+		if g.state == GameStatePlaying && pausePressed() {
+			g.pause()
+		}
+		if g.state == GameStatePaused {
+			return nil
+		}
+		g.latchInput(ReadInput(), keyJustPressed(keyArrowUp))
+		if err := g.stepRun(); err != nil {
+			return err
+		}
+		g.startDeathReview()
+		return nil
+	case GameStateDeathReview:
+		return g.updateDeathReview()
+	case GameStateGameOver:
+		return g.updateGameOver()
+	case GameStateTitle:
+		return g.updateTitle()
+	case GameStateSettings:
+		return g.updateSettings()
+	case GameStateMutators:
+		return g.updateMutators()
+	case GameStateNetError:
+		return g.updateNetError()
+	case GameStatePaused:
+		return g.updatePause()
+	case GameStateRecords:
+		return g.updateRecords()
+	case GameStateSlots:
+		return g.updateSlots()
+	}
+	return nil
+}
+
+// frozen reports whether a run is paused behind a menu, when everything
+// drawn over the world holds still along with it
+func (g *Game) frozen() bool {
+	return g.midRun() && !g.InRun()
+}
+
+// updateOverlays ages everything drawn over the world that counts down in
+// ticks: popups, the feed, banners, the wave summary, the screen shake, the score
+// counter and fades of the theme and phosphor trail
+func (g *Game) updateOverlays() {
+	if g.waveSummaryTicks > 0 {
+		g.waveSummaryTicks--
+	}
+	g.updatePopups()
+	g.updateFeed()
+	g.updateTheme()
+	if g.shakeTicks > 0 {
+		g.shakeTicks--
+	}
+	g.scoreCounter.update(g.score, g.config.Accessibility.Flashing())
+	g.updateBanners()
+	g.phosphorGhostAlpha *= 0.9
+	g.phosphorDue = true
+}
+
+// updatePlaying handles the game logic when playing
+func (g *Game) updatePlaying() error {
+	g.survivalTicks++
+
+	if g.scoreFlash > 0 {
+		g.scoreFlash--
+	}
+
+	// Nothing in the world moves during a hit-stop
+	timeScale := g.advanceTime()
+	if timeScale == 0 {
+		return nil
+	}
+
+	g.updatePowerUps(timeScale)
+	g.runMutatorTicks(timeScale)
+	g.updateTurrets(timeScale)
+	g.updateTally()
+
+	// Handle player input
+	g.updateShip(g.player, g.input, timeScale)
+	if g.partner != nil {
+		g.updateShip(g.partner, g.partnerInput, timeScale)
+	}
+
+	switch g.mode {
+	case ModeEndless:
+		g.updateEndless()
+	case ModeStation:
+		g.updateStation()
+	case ModePractice:
+		g.updatePractice()
+	}
+	g.updateComets()
+	g.applyWind()
+	g.applyMagnet()
+	g.updateDust(timeScale)
+
+	// Update all entities
+	ctx := UpdateContext{
+		ScreenWidth:  g.screenWidth,
+		ScreenHeight: g.screenHeight,
+		TimeScale:    timeScale,
+		TimeDilated:  g.powerUpActive(PickupTimeDilation),
+	}
+	for _, e := range g.entities {
+		g.updateEntity(e, ctx)
+	}
+
+	// Check collisions
+	g.timeCollisions()
+	g.mergeFragments()
+	g.releaseMoonlets()
+
+	// Check for a cleared wave (all asteroids destroyed), which moves on to
+	// the next wave or, after the last, wins the run. Endless mode has no
+	// waves, so it can only end with the player being hit, and station mode
+	// moves on to its next wave by itself.
+	if g.mode == ModeClassic && !g.tally.running() && g.countEntities(TagAsteroid) == 0 {
+		g.emit(WaveCleared{Wave: g.wave})
+		g.finishWave()
+		g.startTally(g.nextClassicWave)
+	}
+
+	// Let everything interested react to what happened this tick
+	g.dispatchEvents()
+
+	// Drop everything that died this tick
+	g.removeDeadEntities()
+
+	return nil
+}
+
+// updateGameOver handles the game logic when in game over state
+func (g *Game) updateGameOver() error {
+	// The rest of the world carries on, in slow motion after a death
+	for range g.steps {
+		g.updateBackground(g.advanceTime())
+	}
+
+	// A network game can't be restarted without the other player, so it
+	// can only be left
+	in := g.readMenu()
+	if g.net != nil {
+		if in.Back {
+			g.leaveNetGame()
+		}
+		return nil
+	}
+
+	// Check for restart input, which goes straight back into play
+	if keyPressed(keyEnter) || in.Confirm {
+		g.Restart(instantRetry)
+	}
+	// Or go back to the title screen to pick a different mode
+	if in.Back {
+		g.state = GameStateTitle
+	}
+	return nil
+}
+
+// updateShip applies the controls for this tick to a ship, along with its
+// charged shot, beam and dash
+func (g *Game) updateShip(p *Player, in InputState, timeScale float64) {
+	in = g.updateWeaponSelect(p, in)
+	g.updateEngine(p, in.Thrust)
+	g.handlePlayerInput(p, in)
+	g.updateCharge(p, in, timeScale)
+	g.updateBeam(p, in.Beam, timeScale)
+	g.updateDash(p, in.Dash, timeScale)
+	p.lastInput = in
+}
+
+// handlePlayerInput applies the controls for this tick to a ship
+func (g *Game) handlePlayerInput(p *Player, in InputState) {
+	ship := p.polygon
+	// A damaged engine gives less than full thrust, and none while it
+	// has cut out
+	acceleration := g.tuning.Thrust * p.thrust
+
+	// Rotation controls
+	if in.Left {
+		ship.SetRotation(ship.Rotation - g.tuning.RotationSpeed)
+	}
+	if in.Right {
+		ship.SetRotation(ship.Rotation + g.tuning.RotationSpeed)
+	}
+
+	// Forward thrust, in the direction the ship is facing. Friction and the
+	// speed limit are applied by the ship's polygon as it moves.
+	p.accelerating = in.Thrust && p.thrust > 0
+	if p.accelerating {
+		ship.ApplyForce(math.Sin(ship.Rotation)*acceleration, -math.Cos(ship.Rotation)*acceleration)
+	}
+	/*
+		if ebiten.IsKeyPressed(ebiten.KeyArrowDown) {
+			// Decelerate (reverse thrust)
+			thrustX := math.Sin(ship.Rotation) * acceleration * 0.5
+			thrustY := -math.Cos(ship.Rotation) * acceleration * 0.5
+			ship.Velocity.X -= thrustX
+			ship.Velocity.Y -= thrustY
+		}
+	*/
+
+	// Shooting. Semi-automatic fire needs a fresh press for every shot.
+	pressed := in.JustPressed(p.lastInput).Fire
+	fire := in.Fire
+	if g.fireMode() == FireSemi {
+		fire = pressed
+	}
+	if !g.canFire(p) {
+		// A press just before the gun is ready is remembered for a few
+		// ticks, rather than being lost
+		if pressed {
+			p.fireBufferedUntil = g.survivalTicks + g.tuning.FireBuffer
+		}
+		return
+	}
+	if fire || g.survivalTicks <= p.fireBufferedUntil {
+		g.createBullet(p)
+		p.lastShotTick = g.survivalTicks
+		p.fireBufferedUntil = 0
+	}
+}
+
+// canFire reports whether a ship's gun has cooled down since its last shot,
+// and there is room for another bullet in flight
+func (g *Game) canFire(p *Player) bool {
+	return g.survivalTicks-p.lastShotTick >= g.tuning.BulletCooldown &&
+		g.countEntities(TagBullet) < maxActiveBullets
+}
+
+// minAsteroidSize is the size below which asteroids are destroyed outright
+// rather than split
+const minAsteroidSize = 15.0
+
+// maxActiveBullets is the most bullets that can be in flight at once
+const maxActiveBullets = 24
+
+// createBullet fires from the tip of a ship, and from its tail as well while
+// the rear gun is active
+func (g *Game) createBullet(p *Player) {
+	ship := p.polygon
+
+	tip := p.nose()
+	if g.spawnBullet(p, tip, g.assistAim(tip, ship.Rotation)) != nil {
+		g.addEntity(newMuzzleFlash(tip, ship.Rotation))
+	}
+
+	if g.powerUpActive(PickupRearGun) {
+		const tailOffset = 12.0
+		tail := geometry.Vector2{
+			X: ship.Position.X - math.Sin(ship.Rotation)*tailOffset,
+			Y: ship.Position.Y + math.Cos(ship.Rotation)*tailOffset,
+		}
+		rear := ship.Rotation + math.Pi
+		if g.spawnBullet(p, tail, rear) != nil {
+			g.addEntity(newMuzzleFlash(tail, rear))
+		}
+	}
+}
+
+// spawnBullet fires a bullet from origin travelling along angle (0 is up the
+// screen), inheriting the momentum of the ship p. It returns nil if too many
+// bullets are already in flight.
+func (g *Game) spawnBullet(p *Player, origin geometry.Vector2, angle float64) *Bullet {
+	if g.countEntities(TagBullet) >= maxActiveBullets {
+		return nil
+	}
+
+	bullet := g.newShipBullet(p, origin, angle)
+	g.waveStats.shots++
+	g.runTotals.shots++
+	g.addEntity(bullet)
+	return bullet
+}
+
+// newShipBullet makes the bullet a ship fires from origin along angle,
+// without firing it, so the shot can be predicted as well as taken
+func (g *Game) newShipBullet(p *Player, origin geometry.Vector2, angle float64) *Bullet {
+	// Set bullet velocity in the direction it is fired
+	const bulletSpeed = 8.0
+	velocity := geometry.Vector2{
+		X: math.Sin(angle) * bulletSpeed,
+		Y: -math.Cos(angle) * bulletSpeed,
+	}
+
+	// Add player's velocity to bullet (inherit momentum)
+	ship := p.polygon
+	velocity.X += ship.Velocity.X
+	velocity.Y += ship.Velocity.Y
+
+	bullet := newBullet(origin, velocity)
+	bullet.owner = p.owner()
+	if g.powerUpActive(PickupPiercing) {
+		bullet.makePiercing()
+	}
+	g.runMutatorBulletHooks(bullet)
+	return bullet
+}
+
+// endRun finishes the current run, showing reason on the game over screen
+func (g *Game) endRun(reason string) {
+	g.state = GameStateGameOver
+	g.gameOverReason = reason
+	g.player.beaming = false
+	if g.partner != nil {
+		g.partner.beaming = false
+	}
+	g.emit(GameEnded{Reason: reason})
+}
+
+// splitAsteroid splits an asteroid into two smaller ones or removes it if too
+// small. by is whoever shot it, or OwnerNone if it wasn't shot.
+func (g *Game) splitAsteroid(entity Entity, by BulletOwner) {
+	g.splitAsteroidAlong(entity, by, nil)
+}
+
+// splitAsteroidAlong is splitAsteroid, cutting the asteroid's own outline in
+// two along the path of shot if it is given, and generating new fragments
+// if it isn't or the cut fails
+func (g *Game) splitAsteroidAlong(entity Entity, by BulletOwner, shot *Bullet) {
+	asteroid := entity.Collider()
+
+	currentSize := approximateRadius(asteroid)
+	if currentSize < minAsteroidSize {
+		// Remove asteroid if too small
+		g.destroyAsteroid(entity, by)
+		return
+	}
+
+	byBullet := by != OwnerNone
+	g.emit(AsteroidDestroyed{
+		Size:      currentSize,
+		Position:  asteroid.Position,
+		ByBullet:  byBullet,
+		Owner:     by,
+		CloseCall: byBullet && g.closeCall(asteroid),
+		Energy:    lastImpact(entity),
+	})
+	if byBullet {
+		g.waveStats.destroyed++
+	}
+	if a, ok := entity.(*Asteroid); ok {
+		g.destroyMoonlets(a)
+	}
+
+	var fragments []*geometry.PolygonObject
+	if shot != nil {
+		fragments = cutFragments(asteroid, shot.polygon.Position, shot.polygon.Velocity)
+	}
+	cut := fragments != nil
+	if !cut {
+		fragments = g.generateFragments(asteroid, currentSize)
+	}
+
+	// Give each some velocity based on original velocity plus some random
+	// spread. The halves of a cut already move apart from the cut, which a
+	// random spread could undo.
+	for _, fragment := range fragments {
+		if !cut {
+			vx := fragment.Velocity.X + (g.rng.Float64()-0.5)*2
+			vy := fragment.Velocity.Y + (g.rng.Float64()-0.5)*2
+			fragment.SetVelocity(vx, vy)
+		}
+		fragment.SetRotationSpeed((g.rng.Float64() - 0.5) * 0.15)
+	}
+	g.separateFragments(fragments)
+
+	// Remove the original asteroid
+	entity.Kill()
+
+	// Add the two new asteroids
+	for _, polygon := range fragments {
+		fragment := newAsteroid(polygon)
+		fragment.spawnTick = g.survivalTicks
+		g.spawnAsteroid(fragment)
+		g.markFresh(fragment)
+	}
+}
+
+// generateFragments returns two new, smaller asteroids either side of an
+// asteroid of the given size, moving with it
+func (g *Game) generateFragments(asteroid *geometry.PolygonObject, size float64) []*geometry.PolygonObject {
+	newSize := size * 0.6            // Make them 60% of original size
+	irregularity := newSize * 0.3    // Proportional irregularity
+	numVertices := 6 + g.rng.Intn(5) // 6-10 vertices
+
+	fragments := make([]*geometry.PolygonObject, 2)
+	for i, side := range []float64{-1, 1} {
+		fragment := geometry.CreateAsteroid(newSize, irregularity, numVertices)
+		fragment.SetPosition(asteroid.Position.X+side*newSize*0.5, asteroid.Position.Y+side*newSize*0.5)
+		fragment.SetVelocity(asteroid.Velocity.X, asteroid.Velocity.Y)
+		fragments[i] = fragment
+	}
+	return fragments
+}
+
+// destroyAsteroid removes an asteroid outright, without splitting it. by is
+// whoever shot it, or OwnerNone if it wasn't shot.
+func (g *Game) destroyAsteroid(entity Entity, by BulletOwner) {
+	asteroid := entity.Collider()
+	byBullet := by != OwnerNone
+	g.emit(AsteroidDestroyed{
+		Size:      approximateRadius(asteroid),
+		Position:  asteroid.Position,
+		ByBullet:  byBullet,
+		Owner:     by,
+		CloseCall: byBullet && g.closeCall(asteroid),
+		Energy:    lastImpact(entity),
+	})
+	if byBullet {
+		g.waveStats.destroyed++
+	}
+	entity.Kill()
+	g.maybeDropPickup(asteroid.Position)
+}
+
+// markFresh highlights a newly split fragment as dangerous for 2 seconds
+// (120 frames at 60 FPS), with a color pulse or, if flashing is disabled, a
+// thicker outline
+func (g *Game) markFresh(fragment *Asteroid) {
+	const freshDuration = 120
+	if !g.config.Accessibility.Flashing() {
+		fragment.freshTicks = freshDuration
+		return
+	}
+
+	// Flash the fresh fragment color, fading back to the normal asteroid
+	// color, which setDilated retargets if time dilation is active
+	fragment.polygon.Flash(g.palette.FreshFragment, ticksDuration(freshDuration))
+}
+
+// handleScoring awards points for destroyed asteroids and comets, collected
+// pickups and wave bonuses. Points for shooting something are also credited
+// to the ship that fired.
+func (g *Game) handleScoring(e Event) {
+	switch e := e.(type) {
+	case AsteroidDestroyed:
+		if e.ByBullet {
+			// Increment score for hitting an asteroid, more so from up close
+			points := 1
+			if e.CloseCall {
+				points = closeCallMultiplier
+			}
+			g.score += points
+			g.credit(e.Owner, points, 1)
+		}
+	case PickupCollected:
+		g.score += e.Points
+	case CometDestroyed:
+		g.score += e.Points
+		g.credit(e.Owner, e.Points, 1)
+	case MoonletDestroyed:
+		g.score += e.Points
+		g.credit(e.Owner, e.Points, 1)
+	case BulletIntercepted:
+		g.score += e.Points
+		g.credit(e.Owner, e.Points, 0)
+	case BonusTallied:
+		g.score += e.Points
+	case WaveSummary:
+		g.score += e.Bonus
+	}
+}
+
+// handleDeathFlash flashes the player in the hit color when they are hit,
+// or briefly when they lose some hull
+func (g *Game) handleDeathFlash(e Event) {
+	if !g.config.Accessibility.Flashing() {
+		return
+	}
+	switch e := e.(type) {
+	case PlayerHit:
+		g.ship(e.Partner).polygon.Flash(g.palette.Hit, time.Second)
+	case PlayerDamaged:
+		g.ship(e.Partner).polygon.Flash(g.palette.Hit, ticksDuration(hullImmunityTicks))
+	}
+}
+
+// Layout takes the outside size (e.g., the window size) and returns the (logical) screen size.
+// If you don't have to adjust the screen size with the outside size, just return a fixed size.
+func (g *Game) Layout(outsideWidth, outsideHeight int) (screenWidth, screenHeight int) {
+	return int(g.screenWidth), int(g.screenHeight)
+}
+
+// Option configures a Game created with New
+type Option func(*Game)
+
+// New creates a new game instance with initialized asteroids and player
+func New(opts ...Option) *Game {
+	game := &Game{
+		screenWidth:  800,
+		screenHeight: 600,
+		seed:         time.Now().UnixNano(),
+		tuning:       DefaultTuning(),
+		interpolate:  true,
+		vectorFont:   vectorfont.New(16, 24, 3, color.White), // 16x24 digit size, 2px line width, white color
+		titleFont:    vectorfont.New(32, 48, 4, color.White),
+		debugFont:    vectorfont.New(8, 12, 1, debugVisualColor),
+		feedFont:     vectorfont.New(8, 12, 1.5, color.White),
+	}
+	for _, opt := range opts {
+		opt(game)
+	}
+	if w := game.tuningWatch; w != nil {
+		// Start with the file's values, rather than waiting for the first poll
+		w.base = game.tuning
+		w.changed()
+		if err := game.loadTuning(); err != nil {
+			log.Printf("Using the default tuning: %v", err)
+			game.showToast("CONFIG ERROR")
+		}
+	}
+	game.rng = rand.New(rand.NewSource(game.seed))
+	game.newDust()
+
+	game.applyAccessibility()
+	game.applyPalette()
+	game.applyTickRate()
+	game.mutators = game.config.Mutators
+	game.refreshSlots()
+
+	game.Subscribe(game.handleScoring)
+	game.Subscribe(game.handleDeathFlash)
+	game.Subscribe(game.handleTimeEffects)
+	game.Subscribe(game.handleShockwaves)
+	game.Subscribe(game.handleScoreFlash)
+	game.Subscribe(game.handlePowerUps)
+	game.Subscribe(game.handleHighScores)
+	game.Subscribe(game.handleBanners)
+	game.Subscribe(game.handleWaveSummary)
+	game.Subscribe(game.handleRecording)
+	game.Subscribe(game.handleKnockback)
+	game.Subscribe(game.handleRecords)
+	game.Subscribe(game.handleCloseCalls)
+	game.Subscribe(game.handleExplosionSounds)
+	game.Subscribe(game.handleThemes)
+	game.Subscribe(game.handleRepairs)
+	game.Subscribe(game.handleUISounds)
+	game.Subscribe(game.handleFeed)
+	game.Subscribe(game.handleSaves)
+	game.SetTimeScale(1, 0)
+
+	// A network game starts straight away, without mutators, which the other
+	// player might not have
+	if game.net != nil {
+		game.mutators = Mutators{}
+		game.Restart(RestartOptions{})
+		return game
+	}
+
+	// Use Restart to initialize the game state, which then sits behind the
+	// title screen until a mode is chosen
+	game.Restart(RestartOptions{})
+	game.state = GameStateTitle
+	// The field behind the title screen isn't a run, so it isn't reported
+	game.events.queue = nil
+
+	return game
+}
+
+// newShip creates a ship at the given position, set up for the current run
+func (g *Game) newShip(x, y float64) *Player {
+	p := newPlayer(g.newShipPolygon(), x, y)
+	p.polygon.Drag = g.rules.shipDrag
+	p.polygon.MaxSpeed = g.tuning.MaxSpeed
+	p.setHull(g.rules.hull)
+	return p
+}
+
+// Restart resets the game state to initial conditions, keeping what opts
+// say to from the game before
+func (g *Game) Restart(opts RestartOptions) {
+	g.reseed(opts.Seed)
+	if opts.ResetMutators {
+		g.mutators = g.config.Mutators
+	}
+	g.startRecording(opts.SkipGetReady)
+
+	// Reset game state, counting down to the start of play unless told not
+	// to
+	g.state = GameStateGetReady
+	g.getReadyTicks = getReadyTicks
+	if opts.SkipGetReady {
+		g.startPlaying()
+	}
+	g.gameOverReason = ""
+	g.killer = nil
+	g.runSlot = -1
+
+	// Reset score
+	g.score = 0
+	g.wave = 1
+	g.applyPalette()
+	g.survivalTicks = 0
+	g.scoreFlash = 0
+	g.powerUps = nil
+	g.applyMutatorRules()
+	g.spawnTimer = 0
+	g.station = nil
+	g.stationSpawnsLeft = 0
+	g.cometTimer = g.randomCometInterval()
+	g.events.queue = nil
+	g.banners = nil
+	g.popups = nil
+	g.feed = nil
+	g.startWaveStats()
+	g.runTotals = runTotals{}
+	g.bestWaveAccuracy = -1
+	g.waveSummaryTicks = 0
+	g.tally.clear()
+	g.tallyShown = nil
+
+	// Bring time back up to speed if the last run ended in slow motion
+	g.time.frozenTicks = 0
+	g.SetTimeScale(1, respawnRampTicks)
+
+	// Clear all bullets and asteroids
+	g.entities = nil
+	g.rollWind()
+
+	g.emit(GameStarted{Seed: g.seed, Mode: g.mode.String(), Mutators: g.mutators, Config: g.config})
+
+	// Create player ship in the center of the screen, with the partner's
+	// ship alongside in a network game
+	g.player = g.newShip(g.screenWidth/2, g.screenHeight/2)
+	g.partner = nil
+	if g.net != nil {
+		g.partner = g.newShip(g.screenWidth/2+partnerOffset, g.screenHeight/2)
+		g.partner.partner = true
+	}
+	g.addEntity(g.player)
+	if g.partner != nil {
+		g.addEntity(g.partner)
+	}
+
+	// Endless mode starts with an empty field and spawns from the edges
+	if g.mode == ModeEndless {
+		return
+	}
+	// Station mode sends its waves in from the edges too
+	if g.mode == ModeStation {
+		g.startStation()
+		return
+	}
+
+	g.startClassicWave()
+}
+
+// generateAsteroidShape creates an asteroid polygon with a random size and
+// outline, from one of the archetypes picked for the given wave
+func generateAsteroidShape(rng *rand.Rand, wave int) *geometry.PolygonObject {
+	// Random base radius between 20 and 50
+	baseRadius := 20.0 + rng.Float64()*30.0
+	archetype := pickArchetype(rng, wave)
+	return geometry.CreatePolygon(archetype.generate(rng, baseRadius))
+}
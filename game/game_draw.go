@@ -0,0 +1,128 @@
+//go:build !headless
+
+package game
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Draw draws the game screen.
+// Draw is called every frame (typically 1/60[s] for 60Hz display).
+func (g *Game) Draw(screen *ebiten.Image) {
+	if g.debugOverlay {
+		defer g.timeDraw(time.Now())
+	}
+	ctx := DrawContext{
+		LineWidthBoost: g.config.Accessibility.LineWidthBoost(),
+		Flashing:       g.config.Accessibility.Flashing(),
+		Rewind:         g.drawRewind(),
+	}
+
+	// The world goes through the camera and post-processing, and the HUD
+	// is drawn over it as it is
+	world := offscreen(&g.worldLayer, screen)
+	g.drawWorld(world, ctx)
+	screen.DrawImage(world, &ebiten.DrawImageOptions{GeoM: g.camera()})
+	g.drawHUDLayer(screen, ctx)
+}
+
+// drawPhosphorGhost draws the previous tick's world faintly over this
+// frame's, then keeps this one for the next tick's trail. The HUD is drawn
+// afterwards, so it leaves no trail. While the run is frozen no ticks pass,
+// so the trail holds still with it.
+func (g *Game) drawPhosphorGhost(world *ebiten.Image) {
+	if g.phosphorGhost != nil {
+		op := &ebiten.DrawImageOptions{}
+		op.ColorScale.ScaleAlpha(g.phosphorGhostAlpha)
+		world.DrawImage(g.phosphorGhost, op)
+		g.phosphorGhostAlpha = 1
+		if !g.phosphorDue {
+			return
+		}
+	}
+	// Capture the current world for next tick's trail
+	g.phosphorGhost = ebiten.NewImageFromImage(world)
+	g.phosphorDue = false
+}
+
+// drawScore draws the score in the top-right corner
+func (g *Game) drawScore(screen *ebiten.Image) {
+	scoreStr := formatScore(g.scoreCounter.value())
+	scoreWidth := g.vectorFont.GetWidth(scoreStr)
+	scoreX := float32(g.screenWidth) - scoreWidth - 20 // 20 pixels from right edge
+	scoreY := float32(20)                              // 20 pixels from top
+	g.vectorFont.SetColor(g.scoreCounter.color(g.palette.UI, g.palette.Score))
+	if g.scoreFlash/4%2 == 1 {
+		g.vectorFont.SetColor(g.palette.Pickup)
+	}
+	g.vectorFont.DrawString(screen, scoreStr, scoreX, scoreY)
+	g.vectorFont.SetColor(g.palette.UI)
+}
+
+// drawSurvivalTime draws the survival time in the top-left corner in
+// endless mode
+func (g *Game) drawSurvivalTime(screen *ebiten.Image) {
+	if g.mode == ModeEndless {
+		g.vectorFont.DrawString(screen, formatSurvivalTime(g.survivalTicks), 20, 20)
+	}
+}
+
+// drawGameOverScreen draws the game over screen with score and restart
+// instruction, once the run is over
+func (g *Game) drawGameOverScreen(screen *ebiten.Image) {
+	if g.state != GameStateGameOver {
+		return
+	}
+	centerX := float32(g.screenWidth / 2)
+	centerY := float32(g.screenHeight / 2)
+
+	// Draw game over reason (GAME OVER or YOU WIN!)
+	reasonWidth := g.vectorFont.GetWidth(g.gameOverReason)
+	reasonX := centerX - (reasonWidth / 2)
+	reasonY := centerY - 60
+	g.vectorFont.DrawString(screen, g.gameOverReason, reasonX, reasonY)
+
+	// Draw final score
+	scoreText := "SCORE: " + formatScore(g.score)
+	scoreWidth := g.vectorFont.GetWidth(scoreText)
+	scoreX := centerX - (scoreWidth / 2)
+	scoreY := centerY - 20
+	g.vectorFont.DrawString(screen, scoreText, scoreX, scoreY)
+
+	// The run's other results each get a row of their own under the score
+	rowY := centerY + 10
+	drawRow := func(text string) {
+		g.vectorFont.DrawString(screen, text, centerX-(g.vectorFont.GetWidth(text)/2), rowY)
+		rowY += 30
+	}
+
+	// Endless mode is played for survival time
+	if g.mode == ModeEndless {
+		drawRow("TIME: " + formatSurvivalTime(g.survivalTicks))
+	}
+
+	// The best single wave accuracy, once a wave has been cleared
+	if g.bestWaveAccuracy >= 0 {
+		drawRow(fmt.Sprintf("BEST WAVE ACCURACY: %d%%", g.bestWaveAccuracy))
+	}
+
+	// Draw restart instruction. A network game can't be restarted, so the
+	// points each ship earned are shown instead.
+	restartText := "PRESS ENTER TO RESTART"
+	if g.net != nil {
+		restartText = g.shipPointsLine()
+	}
+	restartWidth := g.vectorFont.GetWidth(restartText)
+	restartX := centerX - (restartWidth / 2)
+	restartY := max(rowY, centerY+40)
+	g.vectorFont.DrawString(screen, restartText, restartX, restartY)
+
+	titleText := "PRESS ESC FOR TITLE"
+	titleX := centerX - (g.vectorFont.GetWidth(titleText) / 2)
+	g.vectorFont.DrawString(screen, titleText, titleX, restartY+40)
+
+	g.drawHighScores(screen, centerY-250)
+}
@@ -0,0 +1,27 @@
+package game
+
+const (
+	// getReadyCount is the number the countdown starts from
+	getReadyCount = 3
+	// getReadyStepTicks is how long each number of the countdown is shown
+	getReadyStepTicks = ticksPerSecond * 7 / 10
+	// getReadyTicks is the length of the whole countdown
+	getReadyTicks = getReadyCount * getReadyStepTicks
+)
+
+// updateGetReady counts down to the start of play. The asteroids drift, but
+// the ship ignores input and can't be hit until the countdown is over.
+func (g *Game) updateGetReady() error {
+	g.updateBackground(g.advanceTime())
+	g.getReadyTicks--
+	if g.getReadyTicks <= 0 {
+		g.startPlaying()
+	}
+	return nil
+}
+
+// startPlaying ends the countdown and hands control to the player
+func (g *Game) startPlaying() {
+	g.getReadyTicks = 0
+	g.state = GameStatePlaying
+}
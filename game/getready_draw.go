@@ -0,0 +1,32 @@
+//go:build !headless
+
+package game
+
+import (
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// drawGetReady draws the current countdown number, which shrinks and fades
+// as its time runs out
+func (g *Game) drawGetReady(screen *ebiten.Image) {
+	elapsed := getReadyTicks - g.getReadyTicks
+	number := getReadyCount - elapsed/getReadyStepTicks
+	progress := float64(elapsed%getReadyStepTicks) / getReadyStepTicks
+
+	// Shrink from three times the title size to the title size, above the
+	// ship so it stays in view
+	scale := float32(3 - 2*progress)
+	width, height := 32*scale, 48*scale
+	g.titleFont.SetSize(width, height)
+	g.titleFont.SetColor(fadeColor(g.palette.UI, 1-progress))
+
+	text := fmt.Sprint(number)
+	x := float32(g.screenWidth)/2 - g.titleFont.GetWidth(text)/2
+	y := float32(g.screenHeight)/3 - height/2
+	g.titleFont.DrawString(screen, text, x, y)
+
+	g.titleFont.SetSize(32, 48)
+	g.titleFont.SetColor(g.palette.UI)
+}
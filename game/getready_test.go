@@ -0,0 +1,48 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestGetReadyCountdown(t *testing.T) {
+	g := New()
+	g.Restart(RestartOptions{})
+	g.entities = []Entity{g.player}
+	ship := g.player.polygon
+	asteroid := placeAsteroid(g, 30, ship.Position.X, ship.Position.Y)
+	asteroid.polygon.SetVelocity(1, 0)
+	start := asteroid.polygon.Position
+
+	if g.state != GameStateGetReady {
+		t.Fatalf("Expected a restart to count down first, got %v", g.state)
+	}
+
+	// The asteroid sits on the ship for the whole countdown, and the
+	// controls are held down, without anything happening to the ship
+	inputs := make([]InputState, getReadyTicks)
+	for i := range inputs {
+		inputs[i] = InputState{Left: true, Thrust: true, Fire: true}
+	}
+	if err := Simulate(g, inputs, getReadyTicks-1); err != nil {
+		t.Fatal(err)
+	}
+	if g.state != GameStateGetReady {
+		t.Fatalf("Expected to still be counting down, got %v", g.state)
+	}
+	if ship.Rotation != 0 || ship.Velocity.X != 0 || ship.Velocity.Y != 0 || g.countEntities(TagBullet) != 0 {
+		t.Errorf("Expected input to be ignored during the countdown")
+	}
+	if asteroid.polygon.Position == start {
+		t.Errorf("Expected the asteroids to drift during the countdown")
+	}
+	if g.survivalTicks != 0 {
+		t.Errorf("Expected the run's clock not to start, got %d ticks", g.survivalTicks)
+	}
+
+	if err := Simulate(g, nil, 1); err != nil {
+		t.Fatal(err)
+	}
+	if g.state != GameStatePlaying {
+		t.Errorf("Expected play to start after the countdown, got %v", g.state)
+	}
+}
@@ -0,0 +1,62 @@
+package game
+
+import (
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+const (
+	// ghostWarningTicks is how long before the ship turns solid again that
+	// it starts blinking
+	ghostWarningTicks = 3 * ticksPerSecond / 2
+	// ghostAlpha is how opaque the ship is drawn while intangible
+	ghostAlpha = 0.4
+	// ghostDash is the length of each dash, and of each gap, in the ship's
+	// outline while intangible
+	ghostDash = 4.0
+	// ghostPassesThrough is the layers an intangible ship passes through
+	ghostPassesThrough = LayerAsteroid | LayerEnemyBullet | LayerComet
+)
+
+// CollisionLayers leaves the hazards out of the ship's collisions while it
+// is intangible. It still collects pickups and bounces off solid objects.
+func (p *Player) CollisionLayers() (layer, collidesWith CollisionLayer) {
+	layer, collidesWith = p.entityBase.CollisionLayers()
+	if p.ghostTicks > 0 {
+		collidesWith &^= ghostPassesThrough
+	}
+	return layer, collidesWith
+}
+
+// holdGhost keeps the ship intangible while it is inside anything it would
+// collide with, so turning solid again can't destroy it
+func (g *Game) holdGhost() bool {
+	for _, p := range []*Player{g.player, g.partner} {
+		if p != nil && g.insideHazard(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// insideHazard reports whether the ship overlaps anything an intangible
+// ship passes through
+func (g *Game) insideHazard(p *Player) bool {
+	for _, e := range g.entities {
+		if layer, _ := e.CollisionLayers(); layer&ghostPassesThrough == 0 || e.Dead() {
+			continue
+		}
+		if geometry.PolygonsCollide(p.polygon, e.Collider()) {
+			return true
+		}
+	}
+	return false
+}
+
+// syncGhost tells the ships how long they have left as ghosts
+func (g *Game) syncGhost() {
+	for _, p := range []*Player{g.player, g.partner} {
+		if p != nil {
+			p.ghostTicks = g.powerUps[PickupGhost]
+		}
+	}
+}
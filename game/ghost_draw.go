@@ -0,0 +1,37 @@
+//go:build !headless
+
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// drawGhost draws the intangible ship as a faint dashed outline, blinking
+// as it is about to turn solid again
+func (p *Player) drawGhost(screen *ebiten.Image, ctx DrawContext) {
+	if ctx.Flashing && p.ghostTicks < ghostWarningTicks && int(p.ghostTicks)/4%2 == 1 {
+		return
+	}
+	c := fadeColor(p.shipColor, ghostAlpha)
+
+	// Drawn where the ship is this frame, along with the copies across
+	// any edge it is wrapping over
+	position, _ := p.polygon.InterpolatedPose(1 - ctx.Rewind)
+	shift := geometry.Vector2{X: position.X - p.polygon.Position.X, Y: position.Y - p.polygon.Position.Y}
+	vertices := p.polygon.TransformedVertices()
+	width, height := float64(screen.Bounds().Dx()), float64(screen.Bounds().Dy())
+	for _, dx := range []float64{-width, 0, width} {
+		for _, dy := range []float64{-height, 0, height} {
+			for i, from := range vertices {
+				to := vertices[(i+1)%len(vertices)]
+				x, y := shift.X+dx, shift.Y+dy
+				geometry.DrawDashedLine(screen,
+					geometry.Vector2{X: from.X + x, Y: from.Y + y},
+					geometry.Vector2{X: to.X + x, Y: to.Y + y},
+					ghostDash, 1+ctx.LineWidthBoost, c)
+			}
+		}
+	}
+}
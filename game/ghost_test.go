@@ -0,0 +1,96 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// newGhostGame returns a game in play with the ship intangible for the
+// given number of ticks
+func newGhostGame(ticks float64) *Game {
+	g := New()
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+	g.wind = geometry.Vector2{}
+	g.powerUps = map[PickupKind]float64{PickupGhost: ticks}
+	g.syncGhost()
+	return g
+}
+
+func TestGhostPassesThroughAsteroids(t *testing.T) {
+	g := newGhostGame(100)
+	ship := g.player.polygon.Position
+	placeAsteroid(g, 30, ship.X, ship.Y)
+	events := recordEvents(g)
+	g.input = InputState{Fire: true}
+	g.player.lastShotTick = -g.tuning.BulletCooldown
+	if err := g.updatePlaying(); err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range *events {
+		if _, ok := e.(PlayerHit); ok {
+			t.Fatalf("Expected the intangible ship to pass through the asteroid")
+		}
+	}
+	if g.player.lastShotTick != g.survivalTicks {
+		t.Errorf("Expected the ship to still be able to fire")
+	}
+}
+
+func TestGhostStillCollectsPickups(t *testing.T) {
+	g := newGhostGame(100)
+	ship := g.player.polygon.Position
+	g.addEntity(newPickup(PickupScore, ship))
+	g.checkCollisions()
+	g.dispatchEvents()
+	if g.score != pickupPoints {
+		t.Errorf("Expected the pickup to be collected, score %d", g.score)
+	}
+}
+
+func TestGhostHeldWhileInsideAsteroid(t *testing.T) {
+	g := newGhostGame(1)
+	ship := g.player.polygon.Position
+	a := placeAsteroid(g, 30, ship.X, ship.Y)
+
+	// Running out inside the asteroid would be fatal, so the ship stays
+	// intangible for as long as it is inside
+	for i := 0; i < 10; i++ {
+		if err := g.updatePlaying(); err != nil {
+			t.Fatal(err)
+		}
+		if !g.powerUpActive(PickupGhost) || g.player.ghostTicks <= 0 {
+			t.Fatalf("Expected the ship to stay intangible inside the asteroid on tick %d", i)
+		}
+	}
+	if g.state != GameStatePlaying {
+		t.Fatalf("Expected the ship to survive, got state %v", g.state)
+	}
+
+	// Once clear it turns solid again
+	a.polygon.SetPosition(ship.X+200, ship.Y)
+	if err := g.updatePlaying(); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.updatePlaying(); err != nil {
+		t.Fatal(err)
+	}
+	if g.powerUpActive(PickupGhost) || g.player.ghostTicks != 0 {
+		t.Errorf("Expected the ship to turn solid once clear")
+	}
+}
+
+func TestGhostEndsWhenClear(t *testing.T) {
+	g := newGhostGame(1)
+	if err := g.updatePlaying(); err != nil {
+		t.Fatal(err)
+	}
+	if g.powerUpActive(PickupGhost) {
+		t.Errorf("Expected ghost mode to run out")
+	}
+	if _, mask := g.player.CollisionLayers(); mask&LayerAsteroid == 0 {
+		t.Errorf("Expected the ship to collide with asteroids again")
+	}
+}
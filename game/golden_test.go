@@ -0,0 +1,143 @@
+package game
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// updateGolden rewrites the golden files from the current simulation, for
+// when a change to how the game plays is intended
+var updateGolden = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// goldenTicks is how many ticks each golden run is simulated for
+const goldenTicks = 600
+
+// goldenState is a digest of a game's state at the end of a golden run
+type goldenState struct {
+	State     GameState    `json:"state"`
+	Score     int          `json:"score"`
+	Wave      int          `json:"wave"`
+	Ticks     int          `json:"ticks"`
+	Entities  int          `json:"entities"`
+	Player    [2]float64   `json:"player"`
+	Asteroids [][2]float64 `json:"asteroids"`
+}
+
+// roundPosition rounds a position to a millionth of a pixel, well beyond
+// anything visible but clear of the last bits of floating point noise
+func roundPosition(x, y float64) [2]float64 {
+	return [2]float64{math.Round(x*1e6) / 1e6, math.Round(y*1e6) / 1e6}
+}
+
+// digest returns the golden state of g
+func digest(g *Game) goldenState {
+	ship := g.player.polygon.Position
+	s := goldenState{
+		State:    g.state,
+		Score:    g.score,
+		Wave:     g.wave,
+		Ticks:    g.survivalTicks,
+		Entities: len(g.entities),
+		Player:   roundPosition(ship.X, ship.Y),
+	}
+	for _, e := range g.entities {
+		if e.Tag() == TagAsteroid {
+			p := e.Collider().Position
+			s.Asteroids = append(s.Asteroids, roundPosition(p.X, p.Y))
+		}
+	}
+	return s
+}
+
+// diffGolden lists each field in which got differs from want
+func diffGolden(want, got goldenState) []string {
+	var diffs []string
+	field := func(name string, want, got any) {
+		if want != got {
+			diffs = append(diffs, fmt.Sprintf("%s: want %v, got %v", name, want, got))
+		}
+	}
+	field("state", want.State, got.State)
+	field("score", want.Score, got.Score)
+	field("wave", want.Wave, got.Wave)
+	field("ticks", want.Ticks, got.Ticks)
+	field("entities", want.Entities, got.Entities)
+	field("player", want.Player, got.Player)
+	field("asteroid count", len(want.Asteroids), len(got.Asteroids))
+	for i := range min(len(want.Asteroids), len(got.Asteroids)) {
+		field(fmt.Sprintf("asteroid %d", i), want.Asteroids[i], got.Asteroids[i])
+	}
+	return diffs
+}
+
+// goldenInput is the scripted input for a tick of a golden run: firing in
+// bursts while sweeping round to the right, with a burst of thrust now and
+// then and a dash
+func goldenInput(tick int) InputState {
+	return InputState{
+		Fire:   tick%20 < 3,
+		Right:  tick%90 < 30,
+		Thrust: tick%150 >= 120,
+		Beam:   tick%200 >= 180,
+		Dash:   tick == 400,
+	}
+}
+
+func TestGoldenRuns(t *testing.T) {
+	tests := []struct {
+		name string
+		mode GameMode
+		seed int64
+	}{
+		{"classic", ModeClassic, 1},
+		{"endless", ModeEndless, 2},
+		{"station", ModeStation, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := New(WithSeed(tt.seed))
+			g.mode = tt.mode
+			g.Restart(RestartOptions{})
+			inputs := make([]InputState, goldenTicks)
+			for i := range inputs {
+				inputs[i] = goldenInput(i)
+			}
+			if err := Simulate(g, inputs, goldenTicks); err != nil {
+				t.Fatal(err)
+			}
+			got := digest(g)
+
+			path := filepath.Join("testdata", "golden", tt.name+".json")
+			if *updateGolden {
+				data, err := json.MarshalIndent(got, "", "\t")
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("Reading the golden file, which -update writes: %v", err)
+			}
+			var want goldenState
+			if err := json.Unmarshal(data, &want); err != nil {
+				t.Fatal(err)
+			}
+			for _, diff := range diffGolden(want, got) {
+				t.Error(diff)
+			}
+		})
+	}
+}
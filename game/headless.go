@@ -0,0 +1,61 @@
+//go:build headless
+
+package game
+
+// A headless build has no window, keyboard or gamepads. Nothing is ever
+// pressed, so the game can only be driven through InputState, and there is
+// no window to update.
+
+// drawer is implemented by every entity, to render itself to the screen.
+// With no screen, there is nothing to implement.
+type drawer interface{}
+
+// layers are the images frames are drawn through, of which there are none
+type layers struct{}
+
+// dropPhosphorGhost forgets the last tick's world, so no trail is drawn
+// from it
+func (l *layers) dropPhosphorGhost() {}
+
+// ReadInput samples the keyboard for this tick
+func ReadInput() InputState {
+	return InputState{}
+}
+
+// keyPressed reports whether a key is held down
+func keyPressed(k key) bool {
+	return false
+}
+
+// keyJustPressed reports whether a key was pressed on this tick
+func keyJustPressed(k key) bool {
+	return false
+}
+
+// readMenuButtons reads the menu controls
+func readMenuButtons() menuButtons {
+	return menuButtons{}
+}
+
+// pausePressed reports whether pause was just pressed
+func pausePressed() bool {
+	return false
+}
+
+// CurrentWindowState reads the state of the game's window, which there
+// isn't one of
+func CurrentWindowState() WindowState {
+	return WindowState{}
+}
+
+// windowClosing reports whether the window is being closed
+func windowClosing() bool {
+	return false
+}
+
+// setWindowTitle changes the title of the game's window
+func setWindowTitle(title string) {}
+
+// applyTickRate updates the game at the configured rate. A headless game is
+// stepped by its caller instead.
+func (g *Game) applyTickRate() {}
@@ -0,0 +1,58 @@
+package game
+
+import (
+	"log"
+	"sort"
+)
+
+// maxHighScores is how many entries the high score table keeps
+const maxHighScores = 5
+
+// HighScore is an entry in the high score table
+type HighScore struct {
+	Score int    `json:"score"`
+	Mode  string `json:"mode"`
+	// Mutated is set if any mutators were active, as those scores aren't
+	// directly comparable with normal ones
+	Mutated bool `json:"mutated,omitempty"`
+	// Assisted is set if aim assist was on
+	Assisted bool `json:"assisted,omitempty"`
+}
+
+// addHighScore inserts a score into the table if it is good enough,
+// reporting whether it was added
+func (c *Config) addHighScore(entry HighScore) bool {
+	if entry.Score <= 0 {
+		return false
+	}
+	scores := append(c.HighScores, entry)
+	// Stable, so older entries stay ahead of new ones with the same score
+	sort.SliceStable(scores, func(i, j int) bool {
+		return scores[i].Score > scores[j].Score
+	})
+	if len(scores) > maxHighScores {
+		scores = scores[:maxHighScores]
+	}
+	c.HighScores = scores
+	for _, s := range scores {
+		if s == entry {
+			return true
+		}
+	}
+	return false
+}
+
+// handleHighScores records the final score when a run ends, unless it was
+// only practice
+func (g *Game) handleHighScores(e Event) {
+	if _, ok := e.(GameEnded); !ok || g.mode == ModePractice {
+		return
+	}
+	entry := HighScore{Score: g.score, Mode: g.mode.String(), Mutated: g.mutators.Any(), Assisted: g.aimAssist() > 0}
+	if !g.config.addHighScore(entry) {
+		return
+	}
+	if err := g.saveConfig(); err != nil {
+		log.Printf("Saving config: %v", err)
+	}
+}
@@ -0,0 +1,34 @@
+//go:build !headless
+
+package game
+
+import (
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// drawHighScores draws the high score table centered at y. Scores earned
+// with mutators are marked with an M, and with aim assist with an A.
+func (g *Game) drawHighScores(screen *ebiten.Image, y float32) {
+	if len(g.config.HighScores) == 0 {
+		return
+	}
+	centerX := float32(g.screenWidth / 2)
+
+	header := "HIGH SCORES"
+	g.vectorFont.DrawString(screen, header, centerX-g.vectorFont.GetWidth(header)/2, y)
+	for i, entry := range g.config.HighScores {
+		line := fmt.Sprintf("%d %6d %-8s", i+1, entry.Score, entry.Mode)
+		line += " " + marker(entry.Mutated, "M") + marker(entry.Assisted, "A")
+		g.vectorFont.DrawString(screen, line, centerX-g.vectorFont.GetWidth(line)/2, y+float32(i+1)*30)
+	}
+}
+
+// marker returns mark if set is true, or a space to keep the columns lined up
+func marker(set bool, mark string) string {
+	if set {
+		return mark
+	}
+	return " "
+}
@@ -0,0 +1,54 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestHighScoresSortedAndTrimmed(t *testing.T) {
+	var cfg Config
+	for _, score := range []int{10, 50, 30, 20, 40, 60, 5} {
+		cfg.addHighScore(HighScore{Score: score, Mode: "CLASSIC"})
+	}
+
+	if len(cfg.HighScores) != maxHighScores {
+		t.Fatalf("Expected %d high scores, got %d", maxHighScores, len(cfg.HighScores))
+	}
+	want := []int{60, 50, 40, 30, 20}
+	for i, entry := range cfg.HighScores {
+		if entry.Score != want[i] {
+			t.Errorf("Expected score %d at position %d, got %d", want[i], i, entry.Score)
+		}
+	}
+}
+
+func TestHighScoreFlaggedWithMutators(t *testing.T) {
+	g := New()
+	g.mutators = Mutators{Ricochet: true}
+	g.Restart(RestartOptions{})
+	g.score = 42
+
+	g.endRun("GAME OVER")
+	g.dispatchEvents()
+
+	if len(g.config.HighScores) != 1 {
+		t.Fatalf("Expected the score to be recorded, got %+v", g.config.HighScores)
+	}
+	if !g.config.HighScores[0].Mutated {
+		t.Errorf("Expected the score to be flagged as mutated")
+	}
+}
+
+func TestHighScoreFlaggedWithAimAssist(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.AimAssist = 6
+	g := New(WithConfig(cfg))
+	g.Restart(RestartOptions{})
+	g.score = 42
+
+	g.endRun("GAME OVER")
+	g.dispatchEvents()
+
+	if len(g.config.HighScores) != 1 || !g.config.HighScores[0].Assisted || g.config.HighScores[0].Mutated {
+		t.Errorf("Expected the score to be flagged as assisted, got %+v", g.config.HighScores)
+	}
+}
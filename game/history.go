@@ -0,0 +1,35 @@
+package game
+
+import (
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// historyLength is how many ticks of positions a positionHistory keeps
+const historyLength = 40
+
+// positionHistory is a ring buffer of where something has been over its
+// last historyLength ticks
+type positionHistory struct {
+	points [historyLength]geometry.Vector2
+	// next is where the next point goes, and count how many are held
+	next  int
+	count int
+}
+
+// record adds a position to the history, replacing the oldest once it is
+// full
+func (h *positionHistory) record(position geometry.Vector2) {
+	h.points[h.next] = position
+	h.next = (h.next + 1) % historyLength
+	h.count = min(h.count+1, historyLength)
+}
+
+// path returns the positions in the history, oldest first
+func (h *positionHistory) path() []geometry.Vector2 {
+	path := make([]geometry.Vector2, h.count)
+	start := h.next - h.count + historyLength
+	for i := range path {
+		path[i] = h.points[(start+i)%historyLength]
+	}
+	return path
+}
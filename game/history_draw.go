@@ -0,0 +1,30 @@
+//go:build !headless
+
+package game
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// draw draws the history as a line fading in from its oldest point to its
+// newest, at most alpha opaque. Steps where it wrapped around the screen
+// edges are left out rather than drawn across the screen.
+func (h *positionHistory) draw(screen *ebiten.Image, c color.RGBA, width float32, alpha float64) {
+	path := h.path()
+	bounds := screen.Bounds()
+	screenWidth, screenHeight := float64(bounds.Dx()), float64(bounds.Dy())
+	for i := 1; i < len(path); i++ {
+		from, to := path[i-1], path[i]
+		delta := geometry.Vector2{X: to.X - from.X, Y: to.Y - from.Y}
+		if geometry.WrapDelta(from, to, screenWidth, screenHeight) != delta {
+			continue
+		}
+		fade := fadeColor(c, alpha*float64(i)/float64(len(path)-1))
+		vector.StrokeLine(screen, float32(from.X), float32(from.Y), float32(to.X), float32(to.Y), width, fade, true)
+	}
+}
@@ -0,0 +1,37 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+func TestPositionHistoryKeepsNewest(t *testing.T) {
+	var h positionHistory
+	if len(h.path()) != 0 {
+		t.Errorf("Expected an empty history to have no path")
+	}
+	for i := range historyLength + 5 {
+		h.record(geometry.Vector2{X: float64(i)})
+	}
+
+	path := h.path()
+	if len(path) != historyLength {
+		t.Fatalf("Expected %d points, got %d", historyLength, len(path))
+	}
+	if path[0].X != 5 || path[len(path)-1].X != historyLength+4 {
+		t.Errorf("Expected the path from 5 to %d, got %v to %v", historyLength+4, path[0].X, path[len(path)-1].X)
+	}
+}
+
+func TestAsteroidRecordsHistory(t *testing.T) {
+	g := newWeaponsGame()
+	a := placeAsteroid(g, 10, 100, 100)
+	a.polygon.Velocity = geometry.Vector2{X: 2}
+
+	stepTicks(t, g, 3)
+	path := a.history.path()
+	if len(path) != 3 || path[2] != a.polygon.Position {
+		t.Errorf("Expected 3 points ending where the asteroid is, got %v", path)
+	}
+}
@@ -0,0 +1,123 @@
+package game
+
+import (
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+const (
+	// playerHull is how many hull points the ship starts with when the hull
+	// mutator is on
+	playerHull = 5
+	// hullImmunityTicks is how long the ship ignores further damage after
+	// being hit, so a single graze only costs one point
+	hullImmunityTicks = ticksPerSecond / 2
+	// hullRegenTicks is how long it takes to regenerate one hull point
+	hullRegenTicks = 8 * ticksPerSecond
+	// bounceRestitution is the fraction of the ship's speed into an asteroid
+	// that it keeps when bouncing off
+	bounceRestitution = 0.6
+	// minBounceSpeed is the least speed the ship leaves an asteroid with, so
+	// it always separates even from a glancing or rotating contact
+	minBounceSpeed = 1.0
+)
+
+// setHull gives the ship a full hull of the given size. A size of zero means
+// the ship has no hull and is destroyed by any collision.
+func (p *Player) setHull(size int) {
+	p.hull = size
+	p.maxHull = size
+	p.immuneTicks = 0
+	p.regenTicks = 0
+}
+
+// updateHull counts down the contact immunity and slowly repairs the hull
+func (p *Player) updateHull(timeScale float64) {
+	p.immuneTicks = max(p.immuneTicks-timeScale, 0)
+	if p.hull <= 0 || p.hull >= p.maxHull {
+		p.regenTicks = 0
+		return
+	}
+	p.regenTicks += timeScale
+	if p.regenTicks >= hullRegenTicks {
+		p.regenTicks -= hullRegenTicks
+		p.hull++
+	}
+}
+
+// bounceOff bounces the ship off an asteroid, and reports whether the hit
+// damaged the hull. Hits during contact immunity bounce without doing damage.
+func (p *Player) bounceOff(asteroid *geometry.PolygonObject) bool {
+	p.reflectOff(asteroid)
+	return p.takeHit()
+}
+
+// reflectOff reflects the ship's velocity relative to the obstacle about the
+// collision normal, losing some energy
+func (p *Player) reflectOff(obstacle *geometry.PolygonObject) {
+	ship := p.polygon
+	normal := geometry.CollisionNormal(ship, obstacle)
+
+	// Work in the obstacle's frame so a moving obstacle knocks the ship away
+	relX := ship.Velocity.X - obstacle.Velocity.X
+	relY := ship.Velocity.Y - obstacle.Velocity.Y
+	along := relX*normal.X + relY*normal.Y
+	if along < 0 {
+		relX -= (1 + bounceRestitution) * along * normal.X
+		relY -= (1 + bounceRestitution) * along * normal.Y
+		along = -bounceRestitution * along
+	}
+	if along < minBounceSpeed {
+		relX += (minBounceSpeed - along) * normal.X
+		relY += (minBounceSpeed - along) * normal.Y
+	}
+	ship.SetVelocity(obstacle.Velocity.X+relX, obstacle.Velocity.Y+relY)
+}
+
+// takeHit costs the ship a hull point, and reports whether it did. Hits
+// during contact immunity do no damage.
+func (p *Player) takeHit() bool {
+	if p.immuneTicks > 0 {
+		return false
+	}
+	p.hull--
+	p.immuneTicks = hullImmunityTicks
+	return true
+}
+
+// collideWithAsteroid handles a ship touching an asteroid. Without a hull
+// this ends the run; otherwise the ship bounces off and the run only ends
+// once the hull is gone.
+func (g *Game) collideWithAsteroid(ship, asteroid Entity) {
+	p := ship.(*Player)
+	if p.maxHull > 0 && !p.bounceOff(asteroid.Collider()) {
+		return
+	}
+	g.damagePlayer(p, "GAME OVER")
+}
+
+// collideWithBullet handles a ship being shot, which costs a hull point like
+// an asteroid collision, without the bounce. reason is shown on the game
+// over screen if it destroys the ship.
+func (g *Game) collideWithBullet(ship Entity, reason string) {
+	p := ship.(*Player)
+	if p.maxHull > 0 && !p.takeHit() {
+		return
+	}
+	g.damagePlayer(p, reason)
+}
+
+// damagePlayer reports a hit that has already been taken off a ship's hull,
+// or destroys the ship if it had no hull left, ending the run with reason
+func (g *Game) damagePlayer(p *Player, reason string) {
+	if g.mode == ModePractice {
+		g.practiceHit(p)
+		return
+	}
+	partner := p == g.partner
+	if p.maxHull > 0 && p.hull > 0 {
+		g.emit(PlayerDamaged{Hull: p.hull, Partner: partner})
+		return
+	}
+	g.emit(PlayerHit{Partner: partner})
+	g.endRun(reason)
+}
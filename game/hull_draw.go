@@ -0,0 +1,31 @@
+//go:build !headless
+
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// drawHullBar draws the ship's hull as a row of segments in the bottom-left
+// corner, with the segment being repaired partly filled
+func (g *Game) drawHullBar(screen *ebiten.Image) {
+	p := g.localShip()
+	if p.maxHull == 0 {
+		return
+	}
+	const width, height, gap = 20, 8, 4
+	x := float32(20)
+	y := float32(g.screenHeight) - 20 - height
+	for i := 0; i < p.maxHull; i++ {
+		vector.StrokeRect(screen, x, y, width, height, 1, g.palette.UI, false)
+		switch {
+		case i < p.hull:
+			vector.FillRect(screen, x, y, width, height, g.palette.UI, false)
+		case i == p.hull:
+			fraction := float32(p.regenTicks / hullRegenTicks)
+			vector.FillRect(screen, x, y, width*fraction, height, g.palette.UIDim, false)
+		}
+		x += width + gap
+	}
+}
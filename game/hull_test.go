@@ -0,0 +1,126 @@
+package game
+
+import (
+	"testing"
+)
+
+// newHullGame returns a game playing with the hull mutator, holding only the
+// player
+func newHullGame(t *testing.T) *Game {
+	t.Helper()
+	g := New()
+	g.mutators = Mutators{Hull: true}
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+	// Deliver the start of the run before any test subscribes
+	g.dispatchEvents()
+	return g
+}
+
+func TestHullBounceDamagesShip(t *testing.T) {
+	g := newHullGame(t)
+	events := recordEvents(g)
+
+	// Fly the ship right into an asteroid just to its right
+	ship := g.player.polygon
+	ship.SetVelocity(3, 0)
+	placeAsteroid(g, 30, ship.Position.X+25, ship.Position.Y)
+
+	if err := g.updatePlaying(); err != nil {
+		t.Fatal(err)
+	}
+
+	if g.state != GameStatePlaying {
+		t.Fatalf("Expected the ship to survive the collision")
+	}
+	if g.player.hull != playerHull-1 {
+		t.Errorf("Expected %d hull left, got %d", playerHull-1, g.player.hull)
+	}
+	if ship.Velocity.X >= 0 {
+		t.Errorf("Expected the ship to bounce back to the left, velocity %v", ship.Velocity)
+	}
+	if speed := -ship.Velocity.X; speed > 3 {
+		t.Errorf("Expected the bounce to lose energy, speed %v", speed)
+	}
+	if len(*events) != 2 {
+		t.Fatalf("Expected 2 events, got %d: %#v", len(*events), *events)
+	}
+	if damaged, ok := (*events)[1].(PlayerDamaged); !ok || damaged.Hull != playerHull-1 {
+		t.Errorf("Expected PlayerDamaged{Hull: %d}, got %#v", playerHull-1, (*events)[1])
+	}
+}
+
+func TestHullImmunityAfterHit(t *testing.T) {
+	g := newHullGame(t)
+	ship := g.player.polygon
+	asteroid := placeAsteroid(g, 30, ship.Position.X, ship.Position.Y)
+
+	if !g.player.bounceOff(asteroid.polygon) {
+		t.Fatalf("Expected the first contact to do damage")
+	}
+	if g.player.bounceOff(asteroid.polygon) {
+		t.Errorf("Expected no damage during contact immunity")
+	}
+
+	for i := 0; i < hullImmunityTicks; i++ {
+		g.player.updateHull(1)
+	}
+	if !g.player.bounceOff(asteroid.polygon) {
+		t.Errorf("Expected damage once the immunity wore off")
+	}
+	if g.player.hull != playerHull-2 {
+		t.Errorf("Expected %d hull left, got %d", playerHull-2, g.player.hull)
+	}
+}
+
+func TestHullGameOverAtZero(t *testing.T) {
+	g := newHullGame(t)
+	events := recordEvents(g)
+	g.player.hull = 1
+
+	ship := g.player.polygon
+	placeAsteroid(g, 30, ship.Position.X, ship.Position.Y)
+
+	if err := g.updatePlaying(); err != nil {
+		t.Fatal(err)
+	}
+
+	if g.state != GameStateGameOver {
+		t.Errorf("Expected game over once the hull is gone")
+	}
+	if len(*events) < 2 {
+		t.Fatalf("Expected events, got %#v", *events)
+	}
+	if _, ok := (*events)[1].(PlayerHit); !ok {
+		t.Errorf("Expected PlayerHit, got %#v", (*events)[1])
+	}
+}
+
+func TestHullRegenerates(t *testing.T) {
+	g := newHullGame(t)
+	g.player.hull = playerHull - 1
+
+	for i := 0; i < hullRegenTicks; i++ {
+		g.player.updateHull(1)
+	}
+	if g.player.hull != playerHull {
+		t.Errorf("Expected the hull to repair to %d, got %d", playerHull, g.player.hull)
+	}
+
+	// A full hull doesn't bank repairs for later
+	for i := 0; i < hullRegenTicks; i++ {
+		g.player.updateHull(1)
+	}
+	if g.player.hull != playerHull || g.player.regenTicks != 0 {
+		t.Errorf("Expected the hull to stay at %d, got %d with %v regen", playerHull, g.player.hull, g.player.regenTicks)
+	}
+}
+
+func TestNoHullWithoutMutator(t *testing.T) {
+	g := New()
+	g.Restart(RestartOptions{})
+	if g.player.maxHull != 0 {
+		t.Errorf("Expected no hull without the mutator, got %d", g.player.maxHull)
+	}
+}
@@ -0,0 +1,140 @@
+package game
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+const (
+	// impactHalfEnergy is the impact energy of a middling hit, halfway to
+	// the hardest. A bullet hitting a rock is well under it, and the ship
+	// ramming one at speed well over.
+	impactHalfEnergy = 500.0
+	// impactMinSparks and impactMaxSparks bound how many sparks fly from an
+	// impact, from the softest to the hardest
+	impactMinSparks = 3
+	impactMaxSparks = 12
+	// impactSparkTicks is how long the sparks fly for
+	impactSparkTicks = 15
+	// impactSparkSpeed is how fast the sparks of the hardest impact fly, in
+	// pixels per tick
+	impactSparkSpeed = 3.0
+	// impactSparkLength is how long each spark is, in pixels
+	impactSparkLength = 3.0
+	// impactMinBrightness is how bright the sparks of the softest impact
+	// start out, as a fraction of the hardest's
+	impactMinBrightness = 0.3
+	// impactShakeLevel is the impact level from which an impact shakes the
+	// screen, up to impactMaxShake pixels for the hardest
+	impactShakeLevel = 0.5
+	impactMaxShake   = 4.0
+)
+
+// polygonMass approximates a polygon's mass by its area, as if everything
+// were equally dense
+func polygonMass(p *geometry.PolygonObject) float64 {
+	return p.Area() * p.Scale * p.Scale
+}
+
+// impactEnergy returns the kinetic energy of the collision between a and b:
+// that of their relative velocity, carried by their reduced mass. It is
+// zero if they move together, or if neither has any area.
+func impactEnergy(a, b *geometry.PolygonObject) float64 {
+	massA, massB := polygonMass(a), polygonMass(b)
+	if massA+massB == 0 {
+		return 0
+	}
+	dx, dy := a.Velocity.X-b.Velocity.X, a.Velocity.Y-b.Velocity.Y
+	return 0.5 * massA * massB / (massA + massB) * (dx*dx + dy*dy)
+}
+
+// impactLevel maps an impact energy onto how hard the hit looks and
+// sounds, from 0 for a touch to nearly 1 for the hardest
+func impactLevel(energy float64) float64 {
+	return energy / (energy + impactHalfEnergy)
+}
+
+// lastImpact returns the energy of the last hit an asteroid took, or zero
+// for any other entity
+func lastImpact(e Entity) float64 {
+	if a, ok := e.(*Asteroid); ok {
+		return a.impact
+	}
+	return 0
+}
+
+// showImpact throws sparks from an impact of the given energy at position,
+// more and brighter the harder it was, and shakes the screen for the
+// hardest
+func (g *Game) showImpact(position geometry.Vector2, energy float64) {
+	level := impactLevel(energy)
+	g.addEntity(newImpactSparks(position, level))
+	if level > impactShakeLevel {
+		g.shake((level - impactShakeLevel) / (1 - impactShakeLevel) * impactMaxShake)
+	}
+}
+
+// ImpactSparks is a burst of short lines flying out from where two things
+// hit each other
+type ImpactSparks struct {
+	position geometry.Vector2
+	// level is how hard the impact was, from impactLevel
+	level float64
+	count int
+	// age counts the ticks since the impact
+	age   float64
+	color color.RGBA
+	dead  bool
+}
+
+// newImpactSparks creates the sparks of an impact at position, with the
+// given level from impactLevel
+func newImpactSparks(position geometry.Vector2, level float64) *ImpactSparks {
+	count := impactMinSparks + int(math.Round(level*(impactMaxSparks-impactMinSparks)))
+	return &ImpactSparks{position: position, level: level, count: count}
+}
+
+// Update ages the sparks, removing them once they have finished
+func (s *ImpactSparks) Update(ctx UpdateContext) {
+	s.age += ctx.TimeScale
+	if s.age >= impactSparkTicks {
+		s.dead = true
+	}
+}
+
+// Bounds returns the point the sparks fly from
+func (s *ImpactSparks) Bounds() geometry.BoundingBox {
+	return geometry.BoundingBox{MinX: s.position.X, MinY: s.position.Y, MaxX: s.position.X, MaxY: s.position.Y}
+}
+
+// Collider returns nil, as effects never collide
+func (s *ImpactSparks) Collider() *geometry.PolygonObject {
+	return nil
+}
+
+// Tag returns TagEffect
+func (s *ImpactSparks) Tag() EntityTag {
+	return TagEffect
+}
+
+// CollisionLayers puts the sparks on no layers
+func (s *ImpactSparks) CollisionLayers() (layer, collidesWith CollisionLayer) {
+	return 0, 0
+}
+
+// Kill removes the sparks early
+func (s *ImpactSparks) Kill() {
+	s.dead = true
+}
+
+// Dead reports whether the sparks have finished
+func (s *ImpactSparks) Dead() bool {
+	return s.dead
+}
+
+// ApplyPalette colors the sparks like the engine flame
+func (s *ImpactSparks) ApplyPalette(p *Palette) {
+	s.color = p.Flame
+}
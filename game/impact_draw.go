@@ -0,0 +1,27 @@
+//go:build !headless
+
+package game
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Draw renders the sparks spread evenly round the impact, flying out and
+// fading as they age. Harder impacts throw them faster and brighter.
+func (s *ImpactSparks) Draw(screen *ebiten.Image, ctx DrawContext) {
+	life := 1 - s.age/impactSparkTicks
+	brightness := impactMinBrightness + (1-impactMinBrightness)*s.level
+	c := fadeColor(s.color, brightness*life)
+	distance := s.age * impactSparkSpeed * (0.5 + 0.5*s.level)
+	for i := range s.count {
+		angle := 2 * math.Pi * float64(i) / float64(s.count)
+		dx, dy := math.Sin(angle), -math.Cos(angle)
+		vector.StrokeLine(screen,
+			float32(s.position.X+dx*distance), float32(s.position.Y+dy*distance),
+			float32(s.position.X+dx*(distance+impactSparkLength)), float32(s.position.Y+dy*(distance+impactSparkLength)),
+			1+ctx.LineWidthBoost, c, true)
+	}
+}
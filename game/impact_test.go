@@ -0,0 +1,88 @@
+package game
+
+import (
+	"math"
+	"testing"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// movingSquare returns a square of the given side, and so mass, moving with
+// velocity
+func movingSquare(side float64, velocity geometry.Vector2) *geometry.PolygonObject {
+	h := side / 2
+	p := geometry.CreatePolygon([]geometry.Vector2{{X: -h, Y: -h}, {X: h, Y: -h}, {X: h, Y: h}, {X: -h, Y: h}})
+	p.SetVelocity(velocity.X, velocity.Y)
+	return p
+}
+
+func TestImpactEnergy(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b *geometry.PolygonObject
+		want float64
+	}{
+		// Masses 4 and 4 reduce to 2, closing at 2
+		{"equal masses", movingSquare(2, geometry.Vector2{X: 1}), movingSquare(2, geometry.Vector2{X: -1}), 4},
+		// Masses 4 and 12 reduce to 3, closing at 5
+		{"unequal masses", movingSquare(2, geometry.Vector2{Y: 3}), movingSquare(math.Sqrt(12), geometry.Vector2{Y: -2}), 37.5},
+		{"moving together", movingSquare(2, geometry.Vector2{X: 3, Y: 1}), movingSquare(4, geometry.Vector2{X: 3, Y: 1}), 0},
+		// Masses 16 and 16 reduce to 8, closing at 1
+		{"scaled", movingSquare(4, geometry.Vector2{X: 1}), movingSquare(2, geometry.Vector2{}), 4},
+	}
+	tests[3].b.Scale = 2
+	for _, tt := range tests {
+		if got := impactEnergy(tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: expected an energy of %v, got %v", tt.name, tt.want, got)
+		}
+		if got := impactEnergy(tt.b, tt.a); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: expected the same energy either way round, got %v", tt.name, got)
+		}
+	}
+}
+
+func TestImpactLevel(t *testing.T) {
+	if impactLevel(0) != 0 || impactLevel(impactHalfEnergy) != 0.5 {
+		t.Errorf("Expected levels of 0 and 0.5, got %v and %v", impactLevel(0), impactLevel(impactHalfEnergy))
+	}
+	if level := impactLevel(1e9); level >= 1 || level < 0.99 {
+		t.Errorf("Expected the hardest impacts to approach 1, got %v", level)
+	}
+}
+
+func TestHarderImpactsThrowMoreSparks(t *testing.T) {
+	g := newWeaponsGame()
+	g.showImpact(geometry.Vector2{}, 10)
+	g.showImpact(geometry.Vector2{}, 100*impactHalfEnergy)
+	soft, hard := g.entities[1].(*ImpactSparks), g.entities[2].(*ImpactSparks)
+	if soft.count >= hard.count || hard.count > impactMaxSparks || soft.count < impactMinSparks {
+		t.Errorf("Expected more sparks from a harder impact, got %d and %d", soft.count, hard.count)
+	}
+	if g.shakeTicks == 0 {
+		t.Errorf("Expected the hardest impact to shake the screen")
+	}
+}
+
+func TestShotAsteroidCarriesImpact(t *testing.T) {
+	g := newWeaponsGame()
+	g.player.polygon.SetPosition(100, 100)
+	a := placeAsteroid(g, 30, 400, 300)
+	events := recordEvents(g)
+	bullet := newBullet(geometry.Vector2{X: 400, Y: 260}, geometry.Vector2{Y: 8})
+	want := impactEnergy(bullet.polygon, a.polygon)
+	g.addEntity(bullet)
+
+	stepTicks(t, g, 10)
+	if !a.Dead() {
+		t.Fatalf("Expected the bullet to split the asteroid")
+	}
+	for _, e := range *events {
+		if d, ok := e.(AsteroidDestroyed); ok {
+			if d.Energy != want || want == 0 {
+				t.Errorf("Expected an energy of %v, got %v", want, d.Energy)
+			}
+			return
+		}
+	}
+	t.Errorf("Expected the asteroid's destruction to be reported")
+}
@@ -0,0 +1,57 @@
+package game
+
+import (
+	"math"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+const (
+	// indicatorMargin is how far inside the screen edge indicators are drawn
+	indicatorMargin = 16.0
+	// indicatorRange is the distance beyond the edge at which an indicator
+	// is at its smallest and dimmest
+	indicatorRange = 300.0
+)
+
+// threat is implemented by entities that get an indicator at the edge of
+// the screen while they are out of sight
+type threat interface {
+	// offscreenThreat reports whether the entity should be indicated while
+	// it is off the screen. Asteroids that merely wrap around don't count.
+	offscreenThreat() bool
+}
+
+// offscreenThreat marks asteroids that are still coming in from outside
+// the screen
+func (a *Asteroid) offscreenThreat() bool {
+	return a.entering
+}
+
+// edgeIndicator returns where on the screen to point at target from, found
+// by following the line from the center of the screen towards target until
+// it reaches the edge, less margin. angle is the direction to point in, with
+// 0 being up the screen.
+func edgeIndicator(target geometry.Vector2, width, height, margin float64) (position geometry.Vector2, angle float64) {
+	center := geometry.Vector2{X: width / 2, Y: height / 2}
+	dx, dy := target.X-center.X, target.Y-center.Y
+	angle = geometry.NormalizeAngle(math.Atan2(dx, -dy))
+	if dx == 0 && dy == 0 {
+		return center, angle
+	}
+
+	// Scale the direction until it hits whichever edge comes first
+	t := math.Inf(1)
+	if dx != 0 {
+		t = (width/2 - margin) / math.Abs(dx)
+	}
+	if dy != 0 {
+		t = math.Min(t, (height/2-margin)/math.Abs(dy))
+	}
+	return geometry.Vector2{X: center.X + dx*t, Y: center.Y + dy*t}, angle
+}
+
+// onScreen reports whether any part of the box can be seen
+func onScreen(b geometry.BoundingBox, width, height float64) bool {
+	return b.MaxX >= 0 && b.MinX <= width && b.MaxY >= 0 && b.MinY <= height
+}
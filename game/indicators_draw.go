@@ -0,0 +1,39 @@
+//go:build !headless
+
+package game
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// drawThreatIndicators draws a triangle at the screen edge pointing at each
+// threat that can't be seen yet. Closer threats get bigger, brighter
+// triangles.
+func (g *Game) drawThreatIndicators(screen *ebiten.Image, ctx DrawContext) {
+	if g.indicator == nil {
+		g.indicator = geometry.CreatePolygon([]geometry.Vector2{{X: 0, Y: -8}, {X: 6, Y: 6}, {X: -6, Y: 6}})
+	}
+	opts := ctx.polygonOptions()
+	opts.NoWrap = true
+
+	for _, e := range g.entities {
+		t, ok := e.(threat)
+		if !ok || e.Dead() || !t.offscreenThreat() || onScreen(e.Bounds(), g.screenWidth, g.screenHeight) {
+			continue
+		}
+		target := e.Collider().Position
+		position, angle := edgeIndicator(target, g.screenWidth, g.screenHeight, indicatorMargin)
+		distance := math.Hypot(target.X-position.X, target.Y-position.Y)
+		proximity := 1 - math.Min(distance/indicatorRange, 1)
+
+		g.indicator.SetPosition(position.X, position.Y)
+		g.indicator.SetRotation(angle)
+		g.indicator.SetScale(0.6 + 0.8*proximity)
+		g.indicator.SetColor(fadeColor(g.palette.Hit, 0.4+0.6*proximity))
+		g.indicator.DrawWithOptions(screen, opts)
+	}
+}
@@ -0,0 +1,60 @@
+package game
+
+import (
+	"math"
+	"testing"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+func TestEdgeIndicator(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   geometry.Vector2
+		position geometry.Vector2
+		angle    float64
+	}{
+		{"Above", geometry.Vector2{X: 400, Y: -100}, geometry.Vector2{X: 400, Y: 10}, 0},
+		{"Right", geometry.Vector2{X: 1000, Y: 300}, geometry.Vector2{X: 790, Y: 300}, math.Pi / 2},
+		{"Below", geometry.Vector2{X: 400, Y: 900}, geometry.Vector2{X: 400, Y: 590}, math.Pi},
+		{"Left", geometry.Vector2{X: -50, Y: 300}, geometry.Vector2{X: 10, Y: 300}, 3 * math.Pi / 2},
+		// Along the diagonal, the top edge is reached first
+		{"Up and right", geometry.Vector2{X: 1000, Y: -300}, geometry.Vector2{X: 690, Y: 10}, math.Pi / 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			position, angle := edgeIndicator(tt.target, 800, 600, 10)
+			if math.Abs(position.X-tt.position.X) > 1e-9 || math.Abs(position.Y-tt.position.Y) > 1e-9 {
+				t.Errorf("Expected position %v, got %v", tt.position, position)
+			}
+			if math.Abs(angle-tt.angle) > 1e-9 {
+				t.Errorf("Expected angle %v, got %v", tt.angle, angle)
+			}
+		})
+	}
+}
+
+func TestThreatsOnlyIncomingAsteroids(t *testing.T) {
+	g := New()
+	g.mode = ModeEndless
+	g.Restart(RestartOptions{})
+
+	incoming := g.spawnAsteroidAtEdge()
+	if !incoming.offscreenThreat() || onScreen(incoming.Bounds(), g.screenWidth, g.screenHeight) {
+		t.Fatalf("Expected a new endless asteroid to be an off-screen threat")
+	}
+
+	// Once it has come into view it is an ordinary asteroid
+	for i := 0; i < 1000 && incoming.entering; i++ {
+		incoming.Update(UpdateContext{ScreenWidth: g.screenWidth, ScreenHeight: g.screenHeight, TimeScale: 1})
+	}
+	if incoming.offscreenThreat() {
+		t.Errorf("Expected the asteroid to stop being a threat once on the screen")
+	}
+
+	// Classic asteroids wrapping around the edge never count
+	wrapping := placeAsteroid(g, 20, -100, 300)
+	if wrapping.offscreenThreat() {
+		t.Errorf("Expected wrapping asteroids not to be indicated")
+	}
+}
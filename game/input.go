@@ -0,0 +1,130 @@
+package game
+
+// InputState is the player's controls for a single tick. Gameplay reads
+// input only through it, so the game can be driven without a keyboard.
+type InputState struct {
+	Left   bool
+	Right  bool
+	Thrust bool
+	Fire   bool
+	// Beam holds the repulsor beam on
+	Beam bool
+	// Dash jumps the ship forward. Unlike the other controls it is only set
+	// on the tick the dash is asked for, not while a key is held.
+	Dash bool
+	// Select holds the radial menu of secondary weapons open, and Secondary
+	// fires the one chosen
+	Select    bool
+	Secondary bool
+}
+
+// JustPressed returns the controls that are down in in but were up the tick
+// before, in prev
+func (in InputState) JustPressed(prev InputState) InputState {
+	return InputState{
+		Left:      in.Left && !prev.Left,
+		Right:     in.Right && !prev.Right,
+		Thrust:    in.Thrust && !prev.Thrust,
+		Fire:      in.Fire && !prev.Fire,
+		Beam:      in.Beam && !prev.Beam,
+		Dash:      in.Dash && !prev.Dash,
+		Select:    in.Select && !prev.Select,
+		Secondary: in.Secondary && !prev.Secondary,
+	}
+}
+
+// Or returns the controls held in either in or other
+func (in InputState) Or(other InputState) InputState {
+	return InputState{
+		Left:      in.Left || other.Left,
+		Right:     in.Right || other.Right,
+		Thrust:    in.Thrust || other.Thrust,
+		Fire:      in.Fire || other.Fire,
+		Beam:      in.Beam || other.Beam,
+		Dash:      in.Dash || other.Dash,
+		Select:    in.Select || other.Select,
+		Secondary: in.Secondary || other.Secondary,
+	}
+}
+
+// FireMode is how holding the fire button behaves
+type FireMode string
+
+const (
+	// FireAuto keeps firing for as long as fire is held
+	FireAuto FireMode = "auto"
+	// FireSemi fires once each time fire is pressed
+	FireSemi FireMode = "semi"
+)
+
+// fireMode returns how the fire button behaves: as set in the config, or
+// semi-automatic in classic mode and automatic otherwise. Network games
+// always use the mode's default, so both players' games agree.
+func (g *Game) fireMode() FireMode {
+	if g.net == nil && g.config.FireMode != "" {
+		return g.config.FireMode
+	}
+	if g.mode == ModeClassic {
+		return FireSemi
+	}
+	return FireAuto
+}
+
+// key is a keyboard key that is read directly, for the few controls that
+// aren't part of InputState or MenuActions
+type key int
+
+const (
+	keyEnter key = iota
+	keyEscape
+	keySpace
+	keyArrowUp
+	keyP
+	keyY
+	keyN
+	keyF3
+	keyDelete
+	keyBackspace
+)
+
+// doubleTapTicks is the most time between two presses of a key for them to
+// count as a double tap
+const doubleTapTicks = 15
+
+// doubleTap spots a key being pressed twice in quick succession
+type doubleTap struct {
+	// window counts down the ticks left for a second press to complete a
+	// double tap
+	window int
+}
+
+// update is called every tick with whether the key was just pressed, and
+// reports whether that press completed a double tap
+func (d *doubleTap) update(justPressed bool) bool {
+	if !justPressed {
+		if d.window > 0 {
+			d.window--
+		}
+		return false
+	}
+	if d.window > 0 {
+		// Don't let the second press start another double tap
+		d.window = 0
+		return true
+	}
+	d.window = doubleTapTicks
+	return false
+}
+
+// readInput takes the input latched for the next step, adding a dash when
+// thrust is double tapped. The controls held stay latched for any further
+// steps on the same tick, but a dash is only taken once.
+func (g *Game) readInput() InputState {
+	in := g.latched
+	if g.thrustTap.update(g.thrustPressed) {
+		in.Dash = true
+	}
+	g.latched.Dash = false
+	g.thrustPressed = false
+	return in
+}
@@ -0,0 +1,93 @@
+//go:build !headless
+
+package game
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// ReadInput samples the keyboard for this tick
+func ReadInput() InputState {
+	return InputState{
+		Left:      ebiten.IsKeyPressed(ebiten.KeyArrowLeft),
+		Right:     ebiten.IsKeyPressed(ebiten.KeyArrowRight),
+		Thrust:    ebiten.IsKeyPressed(ebiten.KeyArrowUp),
+		Fire:      ebiten.IsKeyPressed(ebiten.KeySpace),
+		Beam:      ebiten.IsKeyPressed(ebiten.KeyArrowDown),
+		Dash:      inpututil.IsKeyJustPressed(ebiten.KeyShiftLeft) || inpututil.IsKeyJustPressed(ebiten.KeyShiftRight),
+		Select:    ebiten.IsKeyPressed(ebiten.KeyTab),
+		Secondary: ebiten.IsKeyPressed(ebiten.KeyX),
+	}
+}
+
+// ebitenKeys are the ebiten keys for each key read directly
+var ebitenKeys = [...]ebiten.Key{
+	keyEnter:     ebiten.KeyEnter,
+	keyEscape:    ebiten.KeyEscape,
+	keySpace:     ebiten.KeySpace,
+	keyArrowUp:   ebiten.KeyArrowUp,
+	keyP:         ebiten.KeyP,
+	keyY:         ebiten.KeyY,
+	keyN:         ebiten.KeyN,
+	keyF3:        ebiten.KeyF3,
+	keyDelete:    ebiten.KeyDelete,
+	keyBackspace: ebiten.KeyBackspace,
+}
+
+// keyPressed reports whether a key is held down
+func keyPressed(k key) bool {
+	return ebiten.IsKeyPressed(ebitenKeys[k])
+}
+
+// keyJustPressed reports whether a key was pressed on this tick
+func keyJustPressed(k key) bool {
+	return inpututil.IsKeyJustPressed(ebitenKeys[k])
+}
+
+// readMenuButtons reads the menu controls from the keyboard and any
+// gamepads with a standard layout. A confirms and B goes back, as do Enter
+// and Escape.
+func readMenuButtons() menuButtons {
+	b := menuButtons{
+		Up:      ebiten.IsKeyPressed(ebiten.KeyArrowUp),
+		Down:    ebiten.IsKeyPressed(ebiten.KeyArrowDown),
+		Confirm: inpututil.IsKeyJustPressed(ebiten.KeyEnter),
+		Back:    inpututil.IsKeyJustPressed(ebiten.KeyEscape),
+	}
+	for _, id := range ebiten.AppendGamepadIDs(nil) {
+		if !ebiten.IsStandardGamepadLayoutAvailable(id) {
+			continue
+		}
+		b.Up = b.Up || ebiten.IsStandardGamepadButtonPressed(id, ebiten.StandardGamepadButtonLeftTop)
+		b.Down = b.Down || ebiten.IsStandardGamepadButtonPressed(id, ebiten.StandardGamepadButtonLeftBottom)
+		if y := ebiten.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisLeftStickVertical); math.Abs(y) > math.Abs(b.StickY) {
+			b.StickY = y
+		}
+		b.Confirm = b.Confirm ||
+			inpututil.IsStandardGamepadButtonJustPressed(id, ebiten.StandardGamepadButtonRightBottom) ||
+			inpututil.IsStandardGamepadButtonJustPressed(id, ebiten.StandardGamepadButtonCenterRight)
+		b.Back = b.Back || inpututil.IsStandardGamepadButtonJustPressed(id, ebiten.StandardGamepadButtonRightRight)
+	}
+	return b
+}
+
+// pausePressed reports whether Escape, P or a gamepad's start button was
+// just pressed, to pause a run
+func pausePressed() bool {
+	return keyJustPressed(keyEscape) || keyJustPressed(keyP) ||
+		gamepadJustPressed(ebiten.StandardGamepadButtonCenterRight)
+}
+
+// gamepadJustPressed reports whether a button was just pressed on any
+// gamepad with a standard layout
+func gamepadJustPressed(button ebiten.StandardGamepadButton) bool {
+	for _, id := range ebiten.AppendGamepadIDs(nil) {
+		if ebiten.IsStandardGamepadLayoutAvailable(id) && inpututil.IsStandardGamepadButtonJustPressed(id, button) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+package game
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+const (
+	// interceptPoints is the score for shooting down an enemy bullet
+	interceptPoints = 5
+	// bulletHitDistance is how close the centers of two bullets have to come
+	// for them to hit each other
+	bulletHitDistance = 2.0
+	// sparkCount is how many sparks fly out when bullets collide
+	sparkCount = 6
+	// sparkTicks is how long the sparks take to fade away
+	sparkTicks = 12
+	// sparkSpeed is how fast the sparks fly out, in pixels per tick
+	sparkSpeed = 1.5
+)
+
+// bulletsCross reports whether two bullets came within hitting distance of
+// each other at any point during the last tick. Bullets are too small and
+// fast to reliably overlap on a tick, so their paths are tested instead.
+func (g *Game) bulletsCross(a, b Entity) bool {
+	p, q := a.(*Bullet), b.(*Bullet)
+	// Unwrap the paths, so a bullet that has just wrapped isn't treated as
+	// having crossed the whole screen
+	p1, q1 := p.polygon.Position, q.polygon.Position
+	pMoved := geometry.WrapDelta(p.previous, p1, g.screenWidth, g.screenHeight)
+	qMoved := geometry.WrapDelta(q.previous, q1, g.screenWidth, g.screenHeight)
+	apart := geometry.WrapDelta(p1, q1, g.screenWidth, g.screenHeight)
+	q1 = geometry.Vector2{X: p1.X + apart.X, Y: p1.Y + apart.Y}
+	p0 := geometry.Vector2{X: p1.X - pMoved.X, Y: p1.Y - pMoved.Y}
+	q0 := geometry.Vector2{X: q1.X - qMoved.X, Y: q1.Y - qMoved.Y}
+	return geometry.ClosestApproach(p0, p1, q0, q1) <= bulletHitDistance
+}
+
+// bulletHitsEnemyBullet destroys both bullets in a burst of sparks
+func (g *Game) bulletHitsEnemyBullet(bullet, enemy Entity) bool {
+	bullet.Kill()
+	g.bulletLanded(bullet.(*Bullet))
+	enemy.Kill()
+	position := enemy.Collider().Position
+	g.addEntity(g.newSparks(position))
+	g.emit(BulletIntercepted{Position: position, Points: interceptPoints, Owner: bullet.(*Bullet).owner})
+	return false
+}
+
+// Sparks is a small burst of short lines flying out from a point, left
+// where two bullets collide
+type Sparks struct {
+	position geometry.Vector2
+	// angles are the directions the sparks fly in
+	angles []float64
+	// age counts the ticks since the sparks appeared
+	age   float64
+	color color.RGBA
+	dead  bool
+}
+
+// newSparks creates a burst of sparks at position, flying out in random
+// directions
+func (g *Game) newSparks(position geometry.Vector2) *Sparks {
+	s := &Sparks{position: position}
+	for i := 0; i < sparkCount; i++ {
+		s.angles = append(s.angles, g.rng.Float64()*2*math.Pi)
+	}
+	return s
+}
+
+// Update ages the sparks, removing them once they have faded
+func (s *Sparks) Update(ctx UpdateContext) {
+	s.age += ctx.TimeScale
+	if s.age >= sparkTicks {
+		s.dead = true
+	}
+}
+
+// Bounds returns the area the sparks can reach
+func (s *Sparks) Bounds() geometry.BoundingBox {
+	reach := sparkTicks*sparkSpeed + 3
+	return geometry.BoundingBox{
+		MinX: s.position.X - reach, MinY: s.position.Y - reach,
+		MaxX: s.position.X + reach, MaxY: s.position.Y + reach,
+	}
+}
+
+// Collider returns nil, as effects never collide
+func (s *Sparks) Collider() *geometry.PolygonObject {
+	return nil
+}
+
+// Tag returns TagEffect
+func (s *Sparks) Tag() EntityTag {
+	return TagEffect
+}
+
+// CollisionLayers puts the sparks on no layers
+func (s *Sparks) CollisionLayers() (layer, collidesWith CollisionLayer) {
+	return 0, 0
+}
+
+// Kill removes the sparks early
+func (s *Sparks) Kill() {
+	s.dead = true
+}
+
+// Dead reports whether the sparks have faded away
+func (s *Sparks) Dead() bool {
+	return s.dead
+}
+
+// ApplyPalette colors the sparks like the engine flame
+func (s *Sparks) ApplyPalette(p *Palette) {
+	s.color = p.Flame
+}
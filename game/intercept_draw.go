@@ -0,0 +1,24 @@
+//go:build !headless
+
+package game
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Draw renders each spark as a short streak, further out and fainter as
+// the sparks age
+func (s *Sparks) Draw(screen *ebiten.Image, ctx DrawContext) {
+	c := fadeColor(s.color, 1-s.age/sparkTicks)
+	inner, outer := s.age*sparkSpeed, s.age*sparkSpeed+3
+	for _, angle := range s.angles {
+		dx, dy := math.Sin(angle), -math.Cos(angle)
+		vector.StrokeLine(screen,
+			float32(s.position.X+dx*inner), float32(s.position.Y+dy*inner),
+			float32(s.position.X+dx*outer), float32(s.position.Y+dy*outer),
+			1+ctx.LineWidthBoost, c, true)
+	}
+}
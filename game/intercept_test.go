@@ -0,0 +1,74 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// newInterceptGame returns a game in play with a player bullet and an enemy
+// bullet heading towards each other along y, offset sideways by gap
+func newInterceptGame(t *testing.T, gap float64) (g *Game, bullet, enemy *Bullet) {
+	t.Helper()
+	g = New()
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+	placeAsteroid(g, 10, 50, 50)
+	g.dispatchEvents()
+
+	// Far enough apart that they jump past each other between ticks,
+	// without ever overlapping on one
+	bullet = newBullet(geometry.Vector2{X: 100, Y: 300}, geometry.Vector2{X: 8})
+	enemy = newEnemyBullet(geometry.Vector2{X: 122, Y: 300 + gap}, geometry.Vector2{X: -8}, OwnerTurret)
+	g.addEntity(bullet)
+	g.addEntity(enemy)
+	return g, bullet, enemy
+}
+
+func TestBulletsAnnihilateHeadOn(t *testing.T) {
+	g, bullet, enemy := newInterceptGame(t, 0)
+	events := recordEvents(g)
+
+	for i := 0; i < 3 && !bullet.Dead(); i++ {
+		if err := g.updatePlaying(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !bullet.Dead() || !enemy.Dead() {
+		t.Fatalf("Expected the bullets to destroy each other")
+	}
+	if g.score != interceptPoints {
+		t.Errorf("Expected %d points for the interception, got %d", interceptPoints, g.score)
+	}
+	found := false
+	for _, e := range *events {
+		if _, ok := e.(BulletIntercepted); ok {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected a BulletIntercepted event, got %#v", *events)
+	}
+	sparks := false
+	for _, e := range g.entities {
+		if _, ok := e.(*Sparks); ok {
+			sparks = true
+		}
+	}
+	if !sparks {
+		t.Errorf("Expected sparks where the bullets met")
+	}
+}
+
+func TestBulletsPassingApart(t *testing.T) {
+	g, bullet, enemy := newInterceptGame(t, 5)
+	for i := 0; i < 3; i++ {
+		if err := g.updatePlaying(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if bullet.Dead() || enemy.Dead() {
+		t.Errorf("Expected bullets passing 5 pixels apart to miss")
+	}
+}
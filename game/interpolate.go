@@ -0,0 +1,49 @@
+package game
+
+import (
+	"time"
+)
+
+// tickDuration is how long a tick lasts in real time
+const tickDuration = time.Second / ticksPerSecond
+
+// WithInterpolation turns drawing between ticks on or off. When it is on,
+// which is the default, frames drawn between ticks show objects part way
+// between their last two positions, so motion is smooth on displays that
+// refresh faster than the game ticks. Collisions always use the positions
+// at the ticks.
+func WithInterpolation(on bool) Option {
+	return func(g *Game) {
+		g.interpolate = on
+	}
+}
+
+// savePoses records where every entity is at the start of a tick, for
+// drawing to interpolate from
+func (g *Game) savePoses() {
+	g.lastTick = time.Now()
+	g.poseSteps = g.steps
+	for _, e := range g.entities {
+		if p := e.Collider(); p != nil {
+			p.SavePose()
+		}
+	}
+}
+
+// drawRewind returns how far back towards the previous tick to draw this
+// frame. A frame drawn straight after a tick shows the previous tick's
+// positions, and one drawn just before the next tick shows the latest ones,
+// so drawing runs a tick behind but never jumps.
+func (g *Game) drawRewind() float64 {
+	if !g.interpolate || g.lastTick.IsZero() {
+		return 0
+	}
+	// A tick of several steps is drawn moving through all of them
+	return rewindAt(time.Since(g.lastTick) / time.Duration(max(g.poseSteps, 1)))
+}
+
+// rewindAt returns how far back to draw a frame drawn elapsed after a tick
+func rewindAt(elapsed time.Duration) float64 {
+	fraction := float64(elapsed) / float64(tickDuration)
+	return 1 - min(max(fraction, 0), 1)
+}
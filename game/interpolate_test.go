@@ -0,0 +1,44 @@
+package game
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestRewindAt(t *testing.T) {
+	tests := []struct {
+		elapsed float64 // in ticks
+		want    float64
+	}{
+		{0, 1},
+		{0.25, 0.75},
+		{0.5, 0.5},
+		{1, 0},
+		{3, 0},
+		{-1, 1},
+	}
+	for _, test := range tests {
+		got := rewindAt(time.Duration(test.elapsed * float64(tickDuration)))
+		if math.Abs(got-test.want) > 1e-6 {
+			t.Errorf("%v ticks after a tick: expected rewind %v, got %v", test.elapsed, test.want, got)
+		}
+	}
+}
+
+func TestInterpolationOption(t *testing.T) {
+	g := New(WithInterpolation(false))
+	g.Update()
+	if got := g.drawRewind(); got != 0 {
+		t.Errorf("Expected no rewind with interpolation off, got %v", got)
+	}
+
+	g = New()
+	if got := g.drawRewind(); got != 0 {
+		t.Errorf("Expected no rewind before the first tick, got %v", got)
+	}
+	g.Update()
+	if g.lastTick.IsZero() {
+		t.Errorf("Expected the tick to be timed for drawing to interpolate from")
+	}
+}
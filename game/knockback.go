@@ -0,0 +1,88 @@
+package game
+
+import (
+	"math"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+const (
+	// knockbackRange is how close to the ship an asteroid must be destroyed
+	// to push it away
+	knockbackRange = 50.0
+	// knockbackStrength scales the push, which grows with the asteroid's
+	// size and shrinks with its distance
+	knockbackStrength = 0.5
+	// knockbackMinDistance stops an explosion right on top of the ship
+	// giving an enormous push
+	knockbackMinDistance = 10.0
+	// shakeTicks is how long the screen shakes for
+	shakeTicks = 12
+	// shakePerKnockback is how far the screen shakes, in pixels, for each
+	// unit of knockback
+	shakePerKnockback = 3.0
+)
+
+// knockbackEnabled reports whether explosions push the ship this run. Both
+// players of a network game have to agree, so there it always happens.
+func (g *Game) knockbackEnabled() bool {
+	return g.net != nil || g.config.Accessibility.Knockback()
+}
+
+// knockback returns the push, in pixels per frame, from an asteroid of the
+// given size destroyed at from onto a ship at to. It is zero out of range.
+func knockback(from, to geometry.Vector2, size, screenWidth, screenHeight float64) geometry.Vector2 {
+	delta := geometry.WrapDelta(from, to, screenWidth, screenHeight)
+	distance := math.Hypot(delta.X, delta.Y)
+	if distance >= knockbackRange {
+		return geometry.Vector2{}
+	}
+	strength := knockbackStrength * size / math.Max(distance, knockbackMinDistance)
+	if distance == 0 {
+		// Straight up, for want of any better direction
+		return geometry.Vector2{Y: -strength}
+	}
+	return geometry.Vector2{X: delta.X / distance * strength, Y: delta.Y / distance * strength}
+}
+
+// handleKnockback pushes ships away from asteroids destroyed at point-blank
+// range, shaking the screen. The ship's top speed still applies.
+func (g *Game) handleKnockback(e Event) {
+	d, ok := e.(AsteroidDestroyed)
+	if !ok || !g.knockbackEnabled() {
+		return
+	}
+	for _, p := range []*Player{g.player, g.partner} {
+		if p == nil || p.Dead() {
+			continue
+		}
+		push := knockback(d.Position, p.polygon.Position, d.Size, g.screenWidth, g.screenHeight)
+		if push == (geometry.Vector2{}) {
+			continue
+		}
+		p.polygon.ApplyForce(push.X, push.Y)
+		g.shake(math.Hypot(push.X, push.Y) * shakePerKnockback)
+	}
+}
+
+// shake starts the screen shaking, unless it is already shaking harder
+func (g *Game) shake(magnitude float64) {
+	if !g.config.Accessibility.ScreenShake() {
+		return
+	}
+	if g.shakeTicks == 0 || magnitude > g.shakeMagnitude {
+		g.shakeMagnitude = magnitude
+	}
+	g.shakeTicks = shakeTicks
+}
+
+// shakeOffset returns how far the world is drawn out of place by the screen
+// shake, which dies away as it finishes. It wobbles with the tick rather than
+// random numbers, so it is the same every time.
+func (g *Game) shakeOffset() geometry.Vector2 {
+	if g.shakeTicks == 0 {
+		return geometry.Vector2{}
+	}
+	amount := g.shakeMagnitude * float64(g.shakeTicks) / shakeTicks
+	return geometry.Vector2{X: math.Cos(float64(g.shakeTicks)*2.3) * amount, Y: math.Sin(float64(g.shakeTicks)*3.7) * amount}
+}
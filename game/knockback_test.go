@@ -0,0 +1,86 @@
+package game
+
+import (
+	"math"
+	"testing"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// explodeNear destroys an asteroid of the given size at an offset from the
+// ship, returning the push the ship is given
+func explodeNear(g *Game, size, dx, dy float64) geometry.Vector2 {
+	p := g.player.polygon
+	p.Acceleration = geometry.Vector2{}
+	g.emit(AsteroidDestroyed{Size: size, Position: geometry.Vector2{X: p.Position.X + dx, Y: p.Position.Y + dy}})
+	g.dispatchEvents()
+	return p.Acceleration
+}
+
+func TestKnockbackDirection(t *testing.T) {
+	g := New()
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+
+	// An explosion to the left pushes the ship right
+	push := explodeNear(g, 30, -20, 0)
+	if push.X <= 0 || push.Y != 0 {
+		t.Errorf("Expected a push to the right, got %v", push)
+	}
+	// A bigger, closer explosion pushes harder
+	if harder := explodeNear(g, 40, -15, 0); harder.X <= push.X {
+		t.Errorf("Expected a harder push than %v, got %v", push, harder)
+	}
+	// One below pushes it up
+	if push := explodeNear(g, 30, 0, 20); push.Y >= 0 || push.X != 0 {
+		t.Errorf("Expected a push upwards, got %v", push)
+	}
+	if g.shakeTicks == 0 {
+		t.Errorf("Expected the screen to shake")
+	}
+}
+
+func TestKnockbackOutOfRange(t *testing.T) {
+	g := New()
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	if push := explodeNear(g, 50, knockbackRange+1, 0); push != (geometry.Vector2{}) {
+		t.Errorf("Expected a far explosion to leave the ship alone, got %v", push)
+	}
+	if g.shakeTicks != 0 {
+		t.Errorf("Expected the screen to stay still")
+	}
+}
+
+func TestKnockbackReducedMotion(t *testing.T) {
+	g := New()
+	g.config.Accessibility.ReducedMotion = true
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	if push := explodeNear(g, 30, -20, 0); push != (geometry.Vector2{}) {
+		t.Errorf("Expected no knockback with reduced motion, got %v", push)
+	}
+}
+
+func TestKnockbackRespectsMaxSpeed(t *testing.T) {
+	g := New()
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+	p := g.player.polygon
+	g.wind = geometry.Vector2{}
+	explodeNear(g, 1000, 0, knockbackMinDistance)
+	// Play on past the hit-stop of such a big explosion
+	for i := 0; i < 30 && p.Acceleration != (geometry.Vector2{}); i++ {
+		if err := g.updatePlaying(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	speed := math.Hypot(p.Velocity.X, p.Velocity.Y)
+	if speed == 0 {
+		t.Fatalf("Expected the ship to be pushed")
+	}
+	if speed > p.MaxSpeed+1e-9 {
+		t.Errorf("Expected the ship's speed to be capped at %v, got %v", p.MaxSpeed, speed)
+	}
+}
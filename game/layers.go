@@ -0,0 +1,174 @@
+//go:build !headless
+
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// layers are the offscreen images a frame is drawn through
+type layers struct {
+	// worldLayer is the image the world is drawn into, to go on the screen
+	// through the camera, and hudLayer the one the HUD elements placed in
+	// the world are drawn into while the camera is moving
+	worldLayer *ebiten.Image
+	hudLayer   *ebiten.Image
+	// We keep the last tick's world for phosphor ghosting effect
+	phosphorGhost *ebiten.Image
+}
+
+// dropPhosphorGhost forgets the last tick's world, so no trail is drawn
+// from it
+func (l *layers) dropPhosphorGhost() {
+	l.phosphorGhost = nil
+}
+
+// hudElement is one part of the HUD
+type hudElement struct {
+	draw func(g *Game, screen *ebiten.Image)
+	// world elements are placed at positions in the world, so they move
+	// with the camera, but are still drawn crisply over the post-processing
+	world bool
+}
+
+// hudElements are the parts of the HUD, in the order they are drawn
+var hudElements = []hudElement{
+	{draw: (*Game).drawScore},
+	{draw: (*Game).drawPowerUpBars},
+	{draw: (*Game).drawHullBar},
+	{draw: (*Game).drawBeamBar},
+	{draw: (*Game).drawDashPip},
+	{draw: (*Game).drawChargeIndicator},
+	{draw: (*Game).drawStationBar},
+	{draw: (*Game).drawWeaponSlot},
+	{draw: (*Game).drawRadialMenu, world: true},
+	{draw: (*Game).drawPopups, world: true},
+	{draw: (*Game).drawFeed},
+	{draw: (*Game).drawDeathReview, world: true},
+	{draw: (*Game).drawBanner},
+	{draw: (*Game).drawWaveSummary},
+	{draw: (*Game).drawTally},
+	{draw: (*Game).drawSurvivalTime},
+	{draw: (*Game).drawGameOverScreen},
+}
+
+// camera returns the transform from the world onto the screen, which moves
+// the world about while the screen shakes
+func (g *Game) camera() ebiten.GeoM {
+	var camera ebiten.GeoM
+	offset := g.shakeOffset()
+	camera.Translate(offset.X, offset.Y)
+	return camera
+}
+
+// offscreen returns *layer cleared, first replacing it with a new image if
+// it isn't the size of the screen, which is the size Layout gives
+func offscreen(layer **ebiten.Image, screen *ebiten.Image) *ebiten.Image {
+	bounds := screen.Bounds()
+	if *layer == nil || (*layer).Bounds() != bounds {
+		*layer = ebiten.NewImage(bounds.Dx(), bounds.Dy())
+	}
+	(*layer).Clear()
+	return *layer
+}
+
+// drawThroughCamera draws onto the screen through draw, moved by the
+// camera. While the camera isn't moving it is drawn straight onto the
+// screen.
+func (g *Game) drawThroughCamera(screen *ebiten.Image, layer **ebiten.Image, draw func(*ebiten.Image)) {
+	camera := g.camera()
+	// The zero GeoM is the identity
+	if camera == (ebiten.GeoM{}) {
+		draw(screen)
+		return
+	}
+	image := offscreen(layer, screen)
+	draw(image)
+	screen.DrawImage(image, &ebiten.DrawImageOptions{GeoM: camera})
+}
+
+// drawWorld draws the world pass: everything in the game world, with the
+// post-processing effects applied to it. It is drawn into its own image, to
+// be put on the screen through the camera.
+func (g *Game) drawWorld(world *ebiten.Image, ctx DrawContext) {
+	g.drawDust(world)
+
+	// Draw all entities. The title screen only shows the drifting
+	// asteroids.
+	for _, e := range g.entities {
+		if g.inMenu() && e.Tag() == TagPlayer {
+			continue
+		}
+		// Effects are drawn after the trail snapshot
+		if e.Tag() == TagEffect {
+			continue
+		}
+		e.Draw(world, ctx)
+	}
+
+	if g.config.Accessibility.Trails() {
+		g.drawPhosphorGhost(world)
+	}
+	// Aids drawn from here on leave no trail
+
+	if !g.inMenu() {
+		g.drawMomentum(world)
+		g.drawMagnetLines(world)
+		g.drawPractice(world, ctx)
+	}
+	g.drawOverlays(world, ctx)
+	g.drawDebugOverlay(world)
+}
+
+// drawHUDLayer draws the HUD pass straight onto the screen, after the world:
+// the menus and the HUD, which stay still and crisp whatever the world is
+// doing
+func (g *Game) drawHUDLayer(screen *ebiten.Image, ctx DrawContext) {
+	switch g.state {
+	case GameStateTitle:
+		g.drawTitleScreen(screen)
+	case GameStateSettings:
+		if g.settingsReturn == GameStatePaused {
+			g.drawHUD(screen)
+			g.drawDim(screen)
+		}
+		g.drawSettingsScreen(screen)
+	case GameStateMutators:
+		g.drawMutatorsScreen(screen)
+	case GameStateGetReady:
+		g.drawThreatIndicators(screen, ctx)
+		g.drawHUD(screen)
+		g.drawGetReady(screen)
+	case GameStateNetError:
+		g.drawNetErrorScreen(screen)
+	case GameStateRecords:
+		g.drawRecordsScreen(screen)
+	case GameStateSlots:
+		g.drawSlotsScreen(screen)
+	case GameStatePaused:
+		g.drawHUD(screen)
+		g.drawDim(screen)
+		g.drawPauseScreen(screen)
+	default:
+		g.drawThreatIndicators(screen, ctx)
+		g.drawHUD(screen)
+	}
+
+	g.drawDebugStats(screen)
+	g.drawQuitPrompt(screen)
+	g.drawToast(screen)
+}
+
+// drawHUD draws each of the HUD's elements, those placed in the world
+// through the camera
+func (g *Game) drawHUD(screen *ebiten.Image) {
+	for _, e := range hudElements {
+		if !e.world {
+			e.draw(g, screen)
+			continue
+		}
+		g.drawThroughCamera(screen, &g.hudLayer, func(image *ebiten.Image) {
+			e.draw(g, image)
+		})
+	}
+}
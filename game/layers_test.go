@@ -0,0 +1,61 @@
+//go:build !headless
+
+package game
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestCameraFollowsShake(t *testing.T) {
+	g := New()
+	if camera := g.camera(); camera != (ebiten.GeoM{}) {
+		t.Errorf("Expected the camera to stay still without shaking, got %v", camera.String())
+	}
+
+	g.shakeTicks = shakeTicks / 2
+	g.shakeMagnitude = 8
+	offset := g.shakeOffset()
+	camera := g.camera()
+	if x, y := camera.Apply(100, 100); x != 100+offset.X || y != 100+offset.Y {
+		t.Errorf("Expected the camera to move the world by %v, got (%v, %v)", offset, x-100, y-100)
+	}
+}
+
+func TestOffscreenFollowsLayout(t *testing.T) {
+	var layer *ebiten.Image
+	small := ebiten.NewImage(80, 60)
+	first := offscreen(&layer, small)
+	if first.Bounds() != small.Bounds() {
+		t.Fatalf("Expected a layer the size of the screen, got %v", first.Bounds())
+	}
+	if again := offscreen(&layer, small); again != first {
+		t.Errorf("Expected the layer to be reused while the screen stays the same size")
+	}
+
+	big := ebiten.NewImage(160, 120)
+	if resized := offscreen(&layer, big); resized.Bounds() != big.Bounds() {
+		t.Errorf("Expected the layer to be resized with the screen, got %v", resized.Bounds())
+	}
+}
+
+func TestHUDWorldElementsThroughCamera(t *testing.T) {
+	g := New()
+	g.Restart(RestartOptions{SkipGetReady: true})
+	screen := ebiten.NewImage(int(g.screenWidth), int(g.screenHeight))
+
+	// With the camera still, everything goes straight onto the screen
+	g.drawHUD(screen)
+	if g.hudLayer != nil {
+		t.Errorf("Expected no HUD layer while the camera is still")
+	}
+
+	// While it shakes, the elements placed in the world are moved with it
+	g.shakeTicks = shakeTicks
+	g.shakeMagnitude = 8
+	g.drawHUD(screen)
+	if g.hudLayer == nil || g.hudLayer.Bounds() != screen.Bounds() {
+		t.Errorf("Expected the world elements to be drawn through a layer the size of the screen")
+	}
+}
@@ -0,0 +1,48 @@
+package game
+
+import (
+	"math"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+const (
+	// magnetRange is how close a pickup must be for the magnet to pull it
+	magnetRange = 250.0
+	// magnetPull is the pull on a pickup right next to the ship, in pixels
+	// per frame squared. It fades to nothing at the edge of the range.
+	magnetPull = 0.3
+	// magnetDash is the length of each dash, and of each gap, in the line
+	// drawn to a pickup being pulled in
+	magnetDash = 6.0
+)
+
+// magnetTargets calls f for each pickup in range of the magnet, with the
+// shortest way from the ship to it and its distance, while the magnet is
+// running
+func (g *Game) magnetTargets(f func(p *Pickup, toPickup geometry.Vector2, distance float64)) {
+	if !g.powerUpActive(PickupMagnet) || g.player.Dead() {
+		return
+	}
+	ship := g.player.polygon.Position
+	for _, e := range g.entities {
+		p, ok := e.(*Pickup)
+		if !ok || p.Dead() {
+			continue
+		}
+		toPickup := geometry.WrapDelta(ship, p.polygon.Position, g.screenWidth, g.screenHeight)
+		distance := math.Hypot(toPickup.X, toPickup.Y)
+		if distance < magnetRange && distance > 0 {
+			f(p, toPickup, distance)
+		}
+	}
+}
+
+// applyMagnet pulls the pickups in range towards the ship, harder the
+// closer they are. Their top speed stops them overshooting and circling.
+func (g *Game) applyMagnet() {
+	g.magnetTargets(func(p *Pickup, toPickup geometry.Vector2, distance float64) {
+		pull := magnetPull * (1 - distance/magnetRange)
+		p.polygon.ApplyForce(-toPickup.X/distance*pull, -toPickup.Y/distance*pull)
+	})
+}
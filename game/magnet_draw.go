@@ -0,0 +1,20 @@
+//go:build !headless
+
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// drawMagnetLines draws a faint dashed line from the ship to each pickup
+// being pulled in, the short way across any edge
+func (g *Game) drawMagnetLines(screen *ebiten.Image) {
+	c := fadeColor(PickupMagnet.color(&g.palette), 0.4)
+	ship := g.player.polygon.Position
+	g.magnetTargets(func(_ *Pickup, toPickup geometry.Vector2, _ float64) {
+		to := geometry.Vector2{X: ship.X + toPickup.X, Y: ship.Y + toPickup.Y}
+		geometry.DrawDashedLine(screen, ship, to, magnetDash, 1, c)
+	})
+}
@@ -0,0 +1,78 @@
+package game
+
+import (
+	"math"
+	"testing"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// newMagnetGame returns a game in play with the magnet running and the ship
+// at x, y
+func newMagnetGame(x, y float64) *Game {
+	g := New()
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+	g.wind = geometry.Vector2{}
+	g.player.polygon.SetPosition(x, y)
+	g.powerUps = map[PickupKind]float64{PickupMagnet: 100}
+	return g
+}
+
+// placePickup adds a score pickup at x, y
+func placePickup(g *Game, x, y float64) *Pickup {
+	p := newPickup(PickupScore, geometry.Vector2{X: x, Y: y})
+	g.addEntity(p)
+	return p
+}
+
+func TestMagnetPullsPickups(t *testing.T) {
+	g := newMagnetGame(400, 300)
+	near := placePickup(g, 500, 300)
+	nearer := placePickup(g, 400, 250)
+	far := placePickup(g, 400, 300-magnetRange-10)
+	g.applyMagnet()
+
+	if a := near.polygon.Acceleration; a.X >= 0 || a.Y != 0 {
+		t.Errorf("Expected the pickup to the right to be pulled left, got %v", a)
+	}
+	if a := nearer.polygon.Acceleration; a.Y <= 0 || a.Y <= -near.polygon.Acceleration.X {
+		t.Errorf("Expected the closer pickup to be pulled down harder, got %v", a)
+	}
+	if a := far.polygon.Acceleration; a != (geometry.Vector2{}) {
+		t.Errorf("Expected the far pickup to be left alone, got %v", a)
+	}
+
+	// Nothing is pulled once the magnet runs out
+	delete(g.powerUps, PickupMagnet)
+	near.polygon.Acceleration = geometry.Vector2{}
+	g.applyMagnet()
+	if a := near.polygon.Acceleration; a != (geometry.Vector2{}) {
+		t.Errorf("Expected no pull without the magnet, got %v", a)
+	}
+}
+
+func TestMagnetPullsAcrossEdge(t *testing.T) {
+	g := newMagnetGame(10, 300)
+	p := placePickup(g, g.screenWidth-40, 300)
+	g.applyMagnet()
+	if a := p.polygon.Acceleration; a.X <= 0 {
+		t.Errorf("Expected the pickup to be pulled right across the edge, got %v", a)
+	}
+}
+
+func TestMagnetRespectsPickupMaxSpeed(t *testing.T) {
+	g := newMagnetGame(400, 300)
+	// Keep the ship from collecting it
+	g.player.collidesWith = 0
+	p := placePickup(g, 400, 300-magnetRange+10)
+	for i := 0; i < 300; i++ {
+		if err := g.updatePlaying(); err != nil {
+			t.Fatal(err)
+		}
+		if speed := math.Hypot(p.polygon.Velocity.X, p.polygon.Velocity.Y); speed > pickupMaxSpeed+1e-9 {
+			t.Fatalf("Expected the pickup to stay under %v, got %v", pickupMaxSpeed, speed)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package game
+
+const (
+	// menuRepeatDelay is how long up or down has to be held before the
+	// selection starts moving on its own, in ticks
+	menuRepeatDelay = 24
+	// menuRepeatInterval is how often the selection moves after that
+	menuRepeatInterval = 6
+	// stickPress is how far the stick has to be pushed to count as
+	// pressing up or down, and stickRelease how far it has to come back
+	// before it counts as let go. The gap between them stops a stick
+	// resting near the threshold from flickering between the two.
+	stickPress   = 0.5
+	stickRelease = 0.3
+)
+
+// MenuActions is what the player asked a menu to do on one update
+type MenuActions struct {
+	Up      bool
+	Down    bool
+	Confirm bool
+	Back    bool
+}
+
+// menuButtons is the state of the menu controls on one update, from the
+// keyboard and every gamepad together
+type menuButtons struct {
+	// Up and Down are held, from the arrow keys or a d-pad
+	Up   bool
+	Down bool
+	// StickY is the furthest any left stick is pushed up (negative) or down
+	StickY float64
+	// Confirm and Back are only set on the update they are pressed
+	Confirm bool
+	Back    bool
+}
+
+// MenuInput turns the keyboard and gamepads into menu navigation, shared by
+// every menu so they all handle key repeat and analog sticks the same way.
+// Up and down move the selection once when pressed, then repeat if held.
+// Holding is timed in steps of the world rather than updates, so it repeats
+// at the same speed whatever the tick rate.
+type MenuInput struct {
+	// held is the direction being held, -1 for up and 1 for down, and
+	// heldTicks how many steps it has been held for
+	held      int
+	heldTicks int
+	// stick is the direction the stick is pushed, once past stickPress
+	stick int
+}
+
+// Read samples the keyboard and gamepads for this update, which covers the
+// given number of steps
+func (m *MenuInput) Read(steps int) MenuActions {
+	return m.update(readMenuButtons(), steps)
+}
+
+// update works out this update's menu actions from the state of the
+// controls, holding them for the given number of steps
+func (m *MenuInput) update(b menuButtons, steps int) MenuActions {
+	switch {
+	case b.StickY <= -stickPress:
+		m.stick = -1
+	case b.StickY >= stickPress:
+		m.stick = 1
+	case b.StickY > -stickRelease && b.StickY < stickRelease:
+		m.stick = 0
+	}
+
+	held := m.stick
+	if b.Up != b.Down {
+		held = 1
+		if b.Up {
+			held = -1
+		}
+	}
+	// A fresh press moves straight away, and a held one each time a repeat
+	// falls due in the steps this update covers
+	move := false
+	if held != m.held {
+		m.held, m.heldTicks = held, 0
+		move = held != 0
+	} else if held != 0 {
+		for range steps {
+			m.heldTicks++
+			move = move || m.heldTicks >= menuRepeatDelay && (m.heldTicks-menuRepeatDelay)%menuRepeatInterval == 0
+		}
+	}
+	return MenuActions{
+		Up:      move && held < 0,
+		Down:    move && held > 0,
+		Confirm: b.Confirm,
+		Back:    b.Back,
+	}
+}
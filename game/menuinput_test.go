@@ -0,0 +1,56 @@
+package game
+
+import "testing"
+
+// holdMenu feeds the same controls to a MenuInput for a number of ticks
+// and counts how often the selection moves down
+func holdMenu(m *MenuInput, b menuButtons, ticks int) (downs int) {
+	for i := 0; i < ticks; i++ {
+		if m.update(b, 1).Down {
+			downs++
+		}
+	}
+	return downs
+}
+
+func TestMenuInputRepeat(t *testing.T) {
+	var m MenuInput
+	if downs := holdMenu(&m, menuButtons{Down: true}, menuRepeatDelay); downs != 1 {
+		t.Errorf("Expected one move before the repeat delay, got %d", downs)
+	}
+	if downs := holdMenu(&m, menuButtons{Down: true}, 3*menuRepeatInterval); downs != 3 {
+		t.Errorf("Expected a move every %d ticks once repeating, got %d", menuRepeatInterval, downs)
+	}
+
+	// Letting go and pressing again moves straight away
+	holdMenu(&m, menuButtons{}, 1)
+	if !m.update(menuButtons{Down: true}, 1).Down {
+		t.Errorf("Expected a fresh press to move")
+	}
+	if a := m.update(menuButtons{Up: true}, 1); !a.Up || a.Down {
+		t.Errorf("Expected changing direction to move the other way, got %+v", a)
+	}
+}
+
+func TestMenuInputStickHysteresis(t *testing.T) {
+	var m MenuInput
+	if downs := holdMenu(&m, menuButtons{StickY: 0.4}, 10); downs != 0 {
+		t.Errorf("Expected a light push not to move, got %d", downs)
+	}
+	if !m.update(menuButtons{StickY: 0.6}, 1).Down {
+		t.Errorf("Expected pushing the stick down to move")
+	}
+	// Wobbling around the threshold counts as still holding it
+	for _, y := range []float64{0.45, 0.55, 0.4, 0.6, 0.35} {
+		if m.update(menuButtons{StickY: y}, 1).Down {
+			t.Errorf("Expected a stick held at %v not to move again", y)
+		}
+	}
+	holdMenu(&m, menuButtons{StickY: 0.1}, 1)
+	if !m.update(menuButtons{StickY: 0.6}, 1).Down {
+		t.Errorf("Expected pushing again after letting go to move")
+	}
+	if !m.update(menuButtons{StickY: -0.7}, 1).Up {
+		t.Errorf("Expected pushing the stick up to move up")
+	}
+}
@@ -0,0 +1,90 @@
+package game
+
+import (
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// mergeFlashDuration is how long a merged asteroid flashes white
+const mergeFlashDuration = 300 * time.Millisecond
+
+// mergeable reports whether an asteroid is one of the smallest fragments,
+// which can merge with another when the field is crowded
+func mergeable(a *Asteroid) bool {
+	return !a.Dead() && !a.entering && a.spawning == 0 && a.orbit == nil && a.turret == nil && approximateRadius(a.polygon) < minAsteroidSize
+}
+
+// mergeFragments keeps the field from filling up with tiny fragments. While
+// there are more asteroids than the tuning's cap, pairs of the smallest
+// fragments that touch merge into one, instead of passing through each
+// other. Checking is skipped entirely while the field is under the cap.
+func (g *Game) mergeFragments() {
+	limit := g.tuning.AsteroidCap
+	if limit <= 0 {
+		return
+	}
+	count := g.countEntities(TagAsteroid)
+	if count <= limit {
+		return
+	}
+
+	var fragments []*Asteroid
+	for _, e := range g.entities {
+		if a, ok := e.(*Asteroid); ok && mergeable(a) {
+			fragments = append(fragments, a)
+		}
+	}
+	for i, a := range fragments {
+		for _, b := range fragments[i+1:] {
+			if count <= limit {
+				return
+			}
+			if a.Dead() || b.Dead() || !geometry.PolygonsCollide(a.polygon, b.polygon) {
+				continue
+			}
+			g.mergeAsteroids(a, b)
+			count--
+		}
+	}
+}
+
+// mergeAsteroids replaces two asteroids with a single new one holding their
+// combined area, at their center of mass and carrying their momentum
+func (g *Game) mergeAsteroids(a, b *Asteroid) {
+	p, q := a.polygon, b.polygon
+	massA, massB := polygonMass(p), polygonMass(q)
+	total := massA + massB
+	if total == 0 {
+		massA, massB, total = 1, 1, 2
+	}
+	// Average through the wrapped screen, so fragments touching across an
+	// edge merge where they meet
+	delta := geometry.WrapDelta(p.Position, q.Position, g.screenWidth, g.screenHeight)
+	position := geometry.WrapPosition(geometry.Vector2{
+		X: p.Position.X + delta.X*massB/total,
+		Y: p.Position.Y + delta.Y*massB/total,
+	}, g.screenWidth, g.screenHeight)
+
+	// Keep the combined area, treating each outline as roughly a circle
+	radius := math.Hypot(approximateRadius(p), approximateRadius(q))
+	polygon := geometry.CreateAsteroid(radius, radius*0.3, 6+g.rng.Intn(5))
+	polygon.SetPosition(position.X, position.Y)
+	polygon.SetVelocity(
+		(p.Velocity.X*massA+q.Velocity.X*massB)/total,
+		(p.Velocity.Y*massA+q.Velocity.Y*massB)/total,
+	)
+	polygon.SetRotationSpeed((g.rng.Float64() - 0.5) * 0.15)
+
+	g.showImpact(position, impactEnergy(p, q))
+	a.Kill()
+	b.Kill()
+	merged := newAsteroid(polygon)
+	g.spawnAsteroid(merged)
+	if g.config.Accessibility.Flashing() {
+		polygon.Flash(color.White, mergeFlashDuration)
+	}
+	g.emit(AsteroidsMerged{Size: approximateRadius(polygon), Position: position})
+}
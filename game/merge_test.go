@@ -0,0 +1,111 @@
+package game
+
+import (
+	"math"
+	"testing"
+)
+
+// newCrowdedGame returns a game in play holding just the player, with the
+// asteroid cap set to limit
+func newCrowdedGame(t *testing.T, limit int) *Game {
+	t.Helper()
+	g := New(WithAsteroidCap(limit))
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+	g.dispatchEvents()
+	return g
+}
+
+func TestMergeConservesMomentum(t *testing.T) {
+	g := newCrowdedGame(t, 1)
+	events := recordEvents(g)
+	a := placeAsteroid(g, 8, 100, 100)
+	a.polygon.SetVelocity(1, 0.5)
+	b := placeAsteroid(g, 8, 104, 100)
+	b.polygon.SetVelocity(-1, 0.5)
+	// Too far away to merge with anything
+	placeAsteroid(g, 8, 600, 500)
+
+	g.mergeFragments()
+	if !a.Dead() || !b.Dead() {
+		t.Fatalf("Expected the touching fragments to merge")
+	}
+	merged := g.entities[len(g.entities)-1].(*Asteroid)
+	if v := merged.polygon.Velocity; math.Abs(v.X) > 1e-9 || math.Abs(v.Y-0.5) > 1e-9 {
+		t.Errorf("Expected the fragments' momentum to be kept, got %v", v)
+	}
+	if p := merged.polygon.Position; math.Abs(p.X-102) > 1e-9 || math.Abs(p.Y-100) > 1e-9 {
+		t.Errorf("Expected the merge between the fragments, got %v", p)
+	}
+	if size := approximateRadius(merged.polygon); size <= 8 {
+		t.Errorf("Expected a bigger asteroid, got size %v", size)
+	}
+
+	g.dispatchEvents()
+	found := false
+	for _, e := range *events {
+		if _, ok := e.(AsteroidsMerged); ok {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected an AsteroidsMerged event, got %#v", *events)
+	}
+	if g.score != 0 {
+		t.Errorf("Expected merging to score nothing, got %d", g.score)
+	}
+}
+
+func TestMergeOnlyOverCap(t *testing.T) {
+	g := newCrowdedGame(t, 2)
+	a := placeAsteroid(g, 8, 100, 100)
+	b := placeAsteroid(g, 8, 104, 100)
+
+	g.mergeFragments()
+	if a.Dead() || b.Dead() {
+		t.Errorf("Expected no merging while under the cap")
+	}
+
+	// Bigger asteroids never merge
+	g = newCrowdedGame(t, 1)
+	a = placeAsteroid(g, 30, 100, 100)
+	b = placeAsteroid(g, 8, 104, 100)
+	g.mergeFragments()
+	if a.Dead() || b.Dead() {
+		t.Errorf("Expected only the smallest fragments to merge")
+	}
+}
+
+func TestCrowdedFieldShrinksUnderCap(t *testing.T) {
+	const limit = 20
+	g := newCrowdedGame(t, limit)
+	events := recordEvents(g)
+
+	// Pairs of fragments closing on each other, well away from the ship
+	for i := 0; i < 15; i++ {
+		x := 60 + float64(i%5)*60
+		y := 60 + float64(i/5)*60
+		placeAsteroid(g, 8, x, y).polygon.SetVelocity(0.5, 0)
+		placeAsteroid(g, 8, x+25, y).polygon.SetVelocity(-0.5, 0)
+	}
+
+	const maxTicks = 60
+	for i := 0; i < maxTicks && g.countEntities(TagAsteroid) > limit; i++ {
+		if err := g.updatePlaying(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := g.countEntities(TagAsteroid); got > limit {
+		t.Fatalf("Expected the field to shrink to %d asteroids within %d ticks, still has %d", limit, maxTicks, got)
+	}
+	merges := 0
+	for _, e := range *events {
+		if _, ok := e.(AsteroidsMerged); ok {
+			merges++
+		}
+	}
+	if merges != 30-limit {
+		t.Errorf("Expected just enough merges to get under the cap, got %d", merges)
+	}
+}
@@ -0,0 +1,38 @@
+package game
+
+import (
+	"math"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+const (
+	// momentumScale is how long the momentum indicator is for each pixel per
+	// tick of speed, and momentumMaxLength the longest it gets
+	momentumScale     = 8.0
+	momentumMaxLength = 40.0
+	// momentumMinSpeed is the slowest drift the indicator is shown for
+	momentumMinSpeed = 0.1
+	// momentumHeadLength is the length of the sides of the arrowhead
+	momentumHeadLength = 5.0
+)
+
+// momentumLine returns the ends of the momentum indicator for a ship, from
+// its centroid the way it is drifting, and how close it is to its top speed
+// from 0 to 1. It returns false if the ship is barely moving.
+func momentumLine(ship *geometry.PolygonObject) (from, to geometry.Vector2, fraction float64, ok bool) {
+	speed := math.Hypot(ship.Velocity.X, ship.Velocity.Y)
+	if speed < momentumMinSpeed {
+		return from, to, 0, false
+	}
+	length := math.Min(speed*momentumScale, momentumMaxLength)
+	from = centerOf(ship)
+	to = geometry.Vector2{
+		X: from.X + ship.Velocity.X/speed*length,
+		Y: from.Y + ship.Velocity.Y/speed*length,
+	}
+	if ship.MaxSpeed > 0 {
+		fraction = math.Min(1, speed/ship.MaxSpeed)
+	}
+	return from, to, fraction, true
+}
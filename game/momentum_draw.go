@@ -0,0 +1,43 @@
+//go:build !headless
+
+package game
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// drawMomentum draws the local ship's momentum indicator, if it is turned
+// on: an arrow the way the ship is drifting, longer the faster it goes, in
+// a dim gray that brightens towards the ship's top speed. It is hidden
+// while the ship is blinking or a ghost, which is busy enough already.
+func (g *Game) drawMomentum(world *ebiten.Image) {
+	p := g.localShip()
+	if !g.config.MomentumIndicator || p.immuneTicks > 0 || p.ghostTicks > 0 {
+		return
+	}
+	from, to, fraction, ok := momentumLine(p.polygon)
+	if !ok {
+		return
+	}
+	c := geometry.InterpolateColor(g.palette.UIDim, g.palette.UI, fraction)
+	strokeArrow(world, from, to, c)
+}
+
+// strokeArrow draws a line from one point to another with an arrowhead at
+// the far end
+func strokeArrow(screen *ebiten.Image, from, to geometry.Vector2, c color.Color) {
+	vector.StrokeLine(screen, float32(from.X), float32(from.Y), float32(to.X), float32(to.Y), 1, c, true)
+	angle := math.Atan2(to.Y-from.Y, to.X-from.X)
+	for _, side := range []float64{-1, 1} {
+		barb := angle + math.Pi - side*math.Pi/6
+		vector.StrokeLine(screen, float32(to.X), float32(to.Y),
+			float32(to.X+math.Cos(barb)*momentumHeadLength), float32(to.Y+math.Sin(barb)*momentumHeadLength),
+			1, c, true)
+	}
+}
@@ -0,0 +1,39 @@
+package game
+
+import (
+	"math"
+	"testing"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+func TestMomentumLine(t *testing.T) {
+	ship := geometry.CreatePlayer(20)
+	ship.SetPosition(400, 300)
+	ship.MaxSpeed = 5
+
+	if _, _, _, ok := momentumLine(ship); ok {
+		t.Errorf("Expected no indicator for a ship at rest")
+	}
+
+	ship.SetVelocity(0, 2)
+	from, to, fraction, ok := momentumLine(ship)
+	if !ok || from != centerOf(ship) {
+		t.Fatalf("Expected an indicator from the ship's centroid")
+	}
+	if to.X != from.X || to.Y-from.Y != 2*momentumScale {
+		t.Errorf("Expected the line to point down, %v long, got %v", 2*momentumScale, to.Y-from.Y)
+	}
+	if fraction != 0.4 {
+		t.Errorf("Expected to be at 0.4 of top speed, got %v", fraction)
+	}
+
+	ship.SetVelocity(-30, 40)
+	from, to, fraction, _ = momentumLine(ship)
+	if length := math.Hypot(to.X-from.X, to.Y-from.Y); math.Abs(length-momentumMaxLength) > 1e-9 {
+		t.Errorf("Expected the length to be capped at %v, got %v", momentumMaxLength, length)
+	}
+	if fraction != 1 {
+		t.Errorf("Expected to be at top speed, got %v", fraction)
+	}
+}
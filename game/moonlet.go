@@ -0,0 +1,122 @@
+package game
+
+import (
+	"math"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+const (
+	// moonletChance is the chance of a large asteroid having moonlets
+	moonletChance = 0.3
+	// moonletRadius is the size of a moonlet
+	moonletRadius = 7.0
+	// moonletPoints is the score for shooting down a moonlet
+	moonletPoints = 25
+	// moonletOrbitGap is how far outside its parent's outline a moonlet
+	// orbits
+	moonletOrbitGap = 15.0
+	// moonletOrbitSpeed is the slowest a moonlet orbits, in radians per frame
+	moonletOrbitSpeed = 0.03
+)
+
+// orbit is the path of a moonlet around its parent asteroid. The moonlet's
+// position is worked out from its parent's each tick, rather than by
+// physics.
+type orbit struct {
+	parent *Asteroid
+	radius float64
+	// angle is where around the parent the moonlet is, and speed how fast
+	// that changes, in radians per frame
+	angle float64
+	speed float64
+}
+
+// addMoonlets gives some large asteroids one or two moonlets, on opposite
+// sides of the same orbit
+func (g *Game) addMoonlets(parent *Asteroid) {
+	if approximateRadius(parent.polygon) < largeAsteroidSize || g.rng.Float64() >= moonletChance {
+		return
+	}
+	count := 1 + g.rng.Intn(2)
+	radius := outlineRadius(parent.polygon) + moonletOrbitGap
+	speed := moonletOrbitSpeed * (1 + g.rng.Float64()/2)
+	if g.rng.Intn(2) == 0 {
+		speed = -speed
+	}
+	angle := g.rng.Float64() * 2 * math.Pi
+	for i := 0; i < count; i++ {
+		m := newAsteroid(geometry.CreateAsteroid(moonletRadius, moonletRadius*0.3, 6))
+		m.orbit = &orbit{parent: parent, radius: radius, angle: angle + float64(i)*math.Pi, speed: speed}
+		m.followOrbit(g.screenWidth, g.screenHeight)
+		g.spawnAsteroid(m)
+		if parent.spawning > 0 {
+			m.materialize()
+		}
+	}
+}
+
+// outlineRadius returns how far a polygon's outline reaches from its origin
+func outlineRadius(p *geometry.PolygonObject) float64 {
+	radius := 0.0
+	for _, v := range p.Vertices {
+		radius = math.Max(radius, math.Hypot(v.X, v.Y)*p.Scale)
+	}
+	return radius
+}
+
+// followOrbit places a moonlet on its orbit around its parent, with the
+// velocity it has there, so it flies off on the right course if released.
+// It wraps with its parent, unless the parent is still entering the screen.
+func (a *Asteroid) followOrbit(screenWidth, screenHeight float64) {
+	o := a.orbit
+	parent := o.parent.polygon
+	sin, cos := math.Sincos(o.angle)
+	position := geometry.Vector2{X: parent.Position.X + cos*o.radius, Y: parent.Position.Y + sin*o.radius}
+	a.entering = o.parent.entering
+	a.polygon.Margin = parent.Margin + o.radius
+	if !a.entering {
+		position = geometry.WrapPosition(position, screenWidth, screenHeight)
+	}
+	a.polygon.SetPosition(position.X, position.Y)
+	a.polygon.SetVelocity(parent.Velocity.X-sin*o.radius*o.speed, parent.Velocity.Y+cos*o.radius*o.speed)
+}
+
+// moonlets returns the live moonlets orbiting an asteroid
+func (g *Game) moonlets(parent *Asteroid) []*Asteroid {
+	var found []*Asteroid
+	for _, e := range g.entities {
+		if m, ok := e.(*Asteroid); ok && !m.Dead() && m.orbit != nil && m.orbit.parent == parent {
+			found = append(found, m)
+		}
+	}
+	return found
+}
+
+// destroyMoonlets breaks up the moonlets of an asteroid being split. They
+// aren't worth anything, as the player didn't shoot them.
+func (g *Game) destroyMoonlets(parent *Asteroid) {
+	for _, m := range g.moonlets(parent) {
+		m.Kill()
+		g.emit(AsteroidDestroyed{Size: approximateRadius(m.polygon), Position: m.polygon.Position})
+	}
+}
+
+// releaseMoonlets sets free the moonlets of any asteroid that has died
+// without splitting, such as by a charged shot or hitting the station. They
+// fly off as ordinary small asteroids, with the velocity they had on their
+// orbit.
+func (g *Game) releaseMoonlets() {
+	for _, e := range g.entities {
+		if m, ok := e.(*Asteroid); ok && !m.Dead() && m.orbit != nil && m.orbit.parent.Dead() {
+			m.orbit = nil
+		}
+	}
+}
+
+// shootMoonlet destroys a moonlet hit by a bullet fired by by
+func (g *Game) shootMoonlet(m *Asteroid, by BulletOwner) {
+	m.Kill()
+	g.waveStats.destroyed++
+	g.emit(MoonletDestroyed{Position: m.polygon.Position, Points: moonletPoints, Owner: by})
+}
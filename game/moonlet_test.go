@@ -0,0 +1,96 @@
+package game
+
+import (
+	"math"
+	"testing"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// placeMoonlet puts a moonlet in orbit around parent at the given angle
+func placeMoonlet(g *Game, parent *Asteroid, angle float64) *Asteroid {
+	m := newAsteroid(geometry.CreateAsteroid(moonletRadius, 0, 6))
+	m.orbit = &orbit{parent: parent, radius: 60, angle: angle, speed: moonletOrbitSpeed}
+	m.followOrbit(g.screenWidth, g.screenHeight)
+	g.addEntity(m)
+	return m
+}
+
+// newMoonletGame returns a game in play with a large asteroid drifting
+// right at 100, 100, and no wind to disturb it
+func newMoonletGame(t *testing.T) (*Game, *Asteroid) {
+	t.Helper()
+	g := New()
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+	g.wind = geometry.Vector2{}
+	parent := placeAsteroid(g, 50, 100, 100)
+	parent.polygon.SetVelocity(1, 0)
+	return g, parent
+}
+
+func TestMoonletOrbitsParent(t *testing.T) {
+	g, parent := newMoonletGame(t)
+	m := placeMoonlet(g, parent, 0)
+	for i := 0; i < 10; i++ {
+		if err := g.updatePlaying(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	offset := geometry.Vector2{X: m.polygon.Position.X - parent.polygon.Position.X, Y: m.polygon.Position.Y - parent.polygon.Position.Y}
+	if distance := math.Hypot(offset.X, offset.Y); math.Abs(distance-60) > 1e-9 {
+		t.Errorf("Expected the moonlet to stay 60 from its parent, got %v", distance)
+	}
+	if offset.Y <= 0 {
+		t.Errorf("Expected the moonlet to have moved round its orbit, got offset %v", offset)
+	}
+}
+
+func TestMoonletWrapsWithParent(t *testing.T) {
+	g, parent := newMoonletGame(t)
+	// Out past the right edge of the screen
+	m := placeMoonlet(g, parent, math.Pi)
+	parent.polygon.SetPosition(g.screenWidth-20, 100)
+	m.followOrbit(g.screenWidth, g.screenHeight)
+	if x := m.polygon.Position.X; x < 0 || x > g.screenWidth {
+		t.Errorf("Expected the moonlet to wrap onto the screen, got x %v", x)
+	}
+}
+
+func TestShootMoonlet(t *testing.T) {
+	g, parent := newMoonletGame(t)
+	m := placeMoonlet(g, parent, 0)
+	g.addEntity(newBullet(m.polygon.Position, geometry.Vector2{}))
+	g.checkCollisions()
+	g.dispatchEvents()
+	if !m.Dead() || parent.Dead() {
+		t.Fatalf("Expected just the moonlet to be shot")
+	}
+	if g.score != moonletPoints {
+		t.Errorf("Expected %d points, got %d", moonletPoints, g.score)
+	}
+}
+
+func TestSplittingParentDestroysMoonlets(t *testing.T) {
+	g, parent := newMoonletGame(t)
+	m := placeMoonlet(g, parent, 0)
+	g.splitAsteroid(parent, OwnerPlayer)
+	if !m.Dead() {
+		t.Errorf("Expected the moonlet to be destroyed with its parent")
+	}
+}
+
+func TestKillingParentReleasesMoonlets(t *testing.T) {
+	g, parent := newMoonletGame(t)
+	m := placeMoonlet(g, parent, 0)
+	orbitalVelocity := m.polygon.Velocity
+	g.destroyAsteroid(parent, OwnerPlayer)
+	g.releaseMoonlets()
+	if m.Dead() || m.orbit != nil {
+		t.Fatalf("Expected the moonlet to fly free")
+	}
+	if m.polygon.Velocity != orbitalVelocity || orbitalVelocity.Y == 0 {
+		t.Errorf("Expected the moonlet to keep its orbital velocity %v, got %v", orbitalVelocity, m.polygon.Velocity)
+	}
+}
@@ -0,0 +1,190 @@
+package game
+
+import (
+	"log"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// Mutators are optional rule changes that make the game play differently.
+// Each one is described by an entry in mutatorList.
+type Mutators struct {
+	Ricochet      bool `json:"ricochet"`
+	NoFriction    bool `json:"no_friction"`
+	BulletGravity bool `json:"bullet_gravity"`
+	DoubleSpeed   bool `json:"double_speed"`
+	Hull          bool `json:"hull"`
+	SelfHazard    bool `json:"self_hazard"`
+}
+
+// Any reports whether any mutator is enabled
+func (m Mutators) Any() bool {
+	for _, def := range mutatorList {
+		if *def.enabled(&m) {
+			return true
+		}
+	}
+	return false
+}
+
+// rules are the tunable parts of the game that mutators may change at the
+// start of a run
+type rules struct {
+	// shipDrag is the fraction of the ship's velocity lost each tick
+	shipDrag float64
+	// asteroidSpeed multiplies the speed of newly created asteroids
+	asteroidSpeed float64
+	// hull is how many asteroid collisions the ship survives, bouncing off
+	// each one. Zero means the first collision is fatal.
+	hull int
+	// selfHazard lets a ship's own bullets hit it, once they have flown for
+	// selfHazardGraceTicks
+	selfHazard bool
+}
+
+// mutator describes a mutator and the hooks it uses to change the game.
+// Unused hooks are left nil.
+type mutator struct {
+	name        string
+	description string
+	enabled     func(m *Mutators) *bool
+	// start adjusts the rules when a run begins
+	start func(r *rules)
+	// tick runs once per world update
+	tick func(g *Game, timeScale float64)
+	// bulletSpawned adjusts every bullet as it is fired
+	bulletSpawned func(b *Bullet)
+}
+
+// bulletGravity is how fast bullets accelerate downwards with the bullet
+// gravity mutator, in pixels per tick squared
+const bulletGravity = 0.05
+
+// pullDown accelerates a bullet downwards for timeScale ticks of bullet
+// gravity
+func pullDown(p *geometry.PolygonObject, timeScale float64) {
+	p.Velocity.Y += bulletGravity * timeScale
+}
+
+// mutatorList is every available mutator, in the order they are shown
+var mutatorList = []mutator{
+	{
+		name:          "RICOCHET",
+		description:   "BULLETS BOUNCE OFF THE EDGES",
+		enabled:       func(m *Mutators) *bool { return &m.Ricochet },
+		bulletSpawned: (*Bullet).makeRicochet,
+	},
+	{
+		name:        "NO FRICTION",
+		description: "YOUR SHIP NEVER SLOWS DOWN",
+		enabled:     func(m *Mutators) *bool { return &m.NoFriction },
+		start:       func(r *rules) { r.shipDrag = 0 },
+	},
+	{
+		name:        "BULLET GRAVITY",
+		description: "BULLETS ARC DOWNWARDS",
+		enabled:     func(m *Mutators) *bool { return &m.BulletGravity },
+		tick: func(g *Game, timeScale float64) {
+			for _, e := range g.entities {
+				if e.Tag() == TagBullet {
+					pullDown(e.Collider(), timeScale)
+				}
+			}
+		},
+	},
+	{
+		name:        "DOUBLE SPEED",
+		description: "ASTEROIDS MOVE TWICE AS FAST",
+		enabled:     func(m *Mutators) *bool { return &m.DoubleSpeed },
+		start:       func(r *rules) { r.asteroidSpeed *= 2 },
+	},
+	{
+		name:        "HULL",
+		description: "BOUNCE OFF ASTEROIDS UNTIL YOUR HULL BREAKS",
+		enabled:     func(m *Mutators) *bool { return &m.Hull },
+		start:       func(r *rules) { r.hull = playerHull },
+	},
+	{
+		name:        "SELF HAZARD",
+		description: "YOUR OWN BULLETS CAN HIT YOU",
+		enabled:     func(m *Mutators) *bool { return &m.SelfHazard },
+		start:       func(r *rules) { r.selfHazard = true },
+	},
+}
+
+// activeMutators returns the mutators enabled for the current run
+func (g *Game) activeMutators() []mutator {
+	var active []mutator
+	for _, def := range mutatorList {
+		if *def.enabled(&g.mutators) {
+			active = append(active, def)
+		}
+	}
+	return active
+}
+
+// applyMutatorRules resets the rules for the tuning and difficulty and lets
+// the active mutators adjust them
+func (g *Game) applyMutatorRules() {
+	g.rules = g.tuning.rules()
+	g.rules.asteroidSpeed *= g.config.Difficulty.asteroidSpeed()
+	for _, def := range g.activeMutators() {
+		if def.start != nil {
+			def.start(&g.rules)
+		}
+	}
+}
+
+// runMutatorTicks runs the per-tick hooks of the active mutators
+func (g *Game) runMutatorTicks(timeScale float64) {
+	for _, def := range g.activeMutators() {
+		if def.tick != nil {
+			def.tick(g, timeScale)
+		}
+	}
+}
+
+// runMutatorBulletHooks lets the active mutators adjust a new bullet
+func (g *Game) runMutatorBulletHooks(b *Bullet) {
+	for _, def := range g.activeMutators() {
+		if def.bulletSpawned != nil {
+			def.bulletSpawned(b)
+		}
+	}
+}
+
+// updateMutators handles toggling mutators before a run starts
+func (g *Game) updateMutators() error {
+	g.updateBackground(float64(g.steps))
+
+	items := len(mutatorList) + 2
+	in := g.readMenu()
+	if in.Up {
+		g.mutatorSelection = (g.mutatorSelection + items - 1) % items
+	}
+	if in.Down {
+		g.mutatorSelection = (g.mutatorSelection + 1) % items
+	}
+	if in.Back {
+		g.state = GameStateTitle
+		return nil
+	}
+	if !in.Confirm {
+		return nil
+	}
+
+	switch g.mutatorSelection {
+	case len(mutatorList): // START
+		g.Restart(RestartOptions{ResetMutators: true})
+	case len(mutatorList) + 1: // BACK
+		g.state = GameStateTitle
+	default:
+		enabled := mutatorList[g.mutatorSelection].enabled(&g.config.Mutators)
+		*enabled = !*enabled
+		// Remember the choice for next time
+		if err := g.saveConfig(); err != nil {
+			log.Printf("Saving config: %v", err)
+		}
+	}
+	return nil
+}
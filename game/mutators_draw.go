@@ -0,0 +1,40 @@
+//go:build !headless
+
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// mutatorItems returns the mutator screen menu text, ending with START and BACK
+func (g *Game) mutatorItems() []string {
+	items := make([]string, 0, len(mutatorList)+2)
+	for _, def := range mutatorList {
+		state := "OFF"
+		if *def.enabled(&g.config.Mutators) {
+			state = "ON"
+		}
+		items = append(items, def.name+": "+state)
+	}
+	return append(items, "START", "BACK")
+}
+
+// drawMutatorsScreen draws the mutator list, with a description of the
+// highlighted one
+func (g *Game) drawMutatorsScreen(screen *ebiten.Image) {
+	centerX := float32(g.screenWidth / 2)
+	centerY := float32(g.screenHeight / 2)
+
+	title := "MUTATORS"
+	titleX := centerX - g.titleFont.GetWidth(title)/2
+	g.titleFont.DrawString(screen, title, titleX, centerY-200)
+
+	items := g.mutatorItems()
+	g.drawMenu(screen, items, g.mutatorSelection, centerY-120)
+
+	if g.mutatorSelection < len(mutatorList) {
+		description := mutatorList[g.mutatorSelection].description
+		descriptionX := centerX - g.vectorFont.GetWidth(description)/2
+		g.vectorFont.DrawString(screen, description, descriptionX, centerY-120+float32(len(items))*40+20)
+	}
+}
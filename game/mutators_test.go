@@ -0,0 +1,132 @@
+package game
+
+import (
+	"math"
+	"testing"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+func TestMutatorRicochetBounces(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Mutators.Ricochet = true
+	g := New(WithConfig(cfg))
+	g.state = GameStatePlaying
+	g.entities = []Entity{g.player}
+
+	bullet := g.spawnBullet(g.player, geometry.Vector2{X: 4, Y: 300}, -math.Pi/2)
+	ctx := UpdateContext{ScreenWidth: g.screenWidth, ScreenHeight: g.screenHeight, TimeScale: 1}
+	bullet.Update(ctx)
+
+	if bullet.polygon.Velocity.X <= 0 {
+		t.Errorf("Expected the bullet to bounce off the left edge, velocity %v", bullet.polygon.Velocity)
+	}
+	if bullet.polygon.Position.X < 0 {
+		t.Errorf("Expected the bullet to be back on screen, got %v", bullet.polygon.Position)
+	}
+	if bullet.bouncesLeft != ricochetBounces-1 {
+		t.Errorf("Expected one bounce to be used, %d left", bullet.bouncesLeft)
+	}
+}
+
+func TestMutatorRicochetExpiresAfterBounces(t *testing.T) {
+	g := New()
+	bullet := newBullet(geometry.Vector2{X: 4, Y: 300}, geometry.Vector2{X: -8})
+	bullet.makeRicochet()
+	g.addEntity(bullet)
+
+	ctx := UpdateContext{ScreenWidth: g.screenWidth, ScreenHeight: g.screenHeight, TimeScale: 1}
+	for i := 0; i < 1000 && !bullet.Dead(); i++ {
+		g.updateEntity(bullet, ctx)
+	}
+
+	if !bullet.Dead() {
+		t.Errorf("Expected the bullet to fizzle out after its bounces")
+	}
+	if bullet.bounces != ricochetBounces {
+		t.Errorf("Expected %d bounces, got %d", ricochetBounces, bullet.bounces)
+	}
+}
+
+func TestMutatorNormalBulletsDespawn(t *testing.T) {
+	g := New()
+	bullet := g.spawnBullet(g.player, geometry.Vector2{X: 4, Y: 300}, -math.Pi/2)
+	ctx := UpdateContext{ScreenWidth: g.screenWidth, ScreenHeight: g.screenHeight, TimeScale: 1}
+	bullet.Update(ctx)
+
+	if bullet.polygon.Velocity.X >= 0 {
+		t.Errorf("Expected a normal bullet to keep going, velocity %v", bullet.polygon.Velocity)
+	}
+}
+
+func TestMutatorStartHooksAdjustRules(t *testing.T) {
+	g := New()
+	g.mutators = Mutators{NoFriction: true, DoubleSpeed: true}
+	g.Restart(RestartOptions{})
+
+	if g.rules.shipDrag != 0 {
+		t.Errorf("Expected no friction, got %v drag", g.rules.shipDrag)
+	}
+	if g.rules.asteroidSpeed != 2 {
+		t.Errorf("Expected double speed asteroids, got %v", g.rules.asteroidSpeed)
+	}
+
+	g.mutators = Mutators{}
+	g.Restart(RestartOptions{})
+	if g.rules != g.tuning.rules() {
+		t.Errorf("Expected default rules without mutators, got %+v", g.rules)
+	}
+}
+
+func TestMutatorBulletGravityTickHook(t *testing.T) {
+	g := New()
+	g.mutators = Mutators{BulletGravity: true}
+	g.Restart(RestartOptions{})
+	g.entities = []Entity{g.player}
+
+	bullet := g.spawnBullet(g.player, geometry.Vector2{X: 400, Y: 100}, math.Pi/2)
+	g.updatePlaying()
+
+	if bullet.polygon.Velocity.Y <= 0 {
+		t.Errorf("Expected gravity to pull the bullet down, velocity %v", bullet.polygon.Velocity)
+	}
+}
+
+func TestMutatorsAny(t *testing.T) {
+	if (Mutators{}).Any() {
+		t.Errorf("Expected no mutators to be enabled by default")
+	}
+	if !(Mutators{DoubleSpeed: true}).Any() {
+		t.Errorf("Expected Any to report an enabled mutator")
+	}
+}
+
+func TestMutatorSelfHazard(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		g := New()
+		g.mutators = Mutators{SelfHazard: enabled}
+		g.Restart(RestartOptions{})
+		g.startPlaying()
+		g.entities = []Entity{g.player}
+		g.wind = geometry.Vector2{}
+		// Keep the wave from being cleared
+		placeAsteroid(g, 10, 20, 20)
+
+		// A bullet of the ship's own, sitting where the ship is
+		bullet := g.newShipBullet(g.player, g.player.polygon.Position, 0)
+		bullet.polygon.Velocity = geometry.Vector2{}
+		g.addEntity(bullet)
+		if g.shipHitByBullet(g.player, bullet) {
+			t.Errorf("Enabled %v: expected a bullet fresh out of the gun to miss", enabled)
+		}
+
+		bullet.age = selfHazardGraceTicks
+		stepTicks(t, g, 1)
+		if over := g.state == GameStateGameOver; over != enabled {
+			t.Errorf("Enabled %v: expected the run being over to be %v", enabled, enabled)
+		}
+		if enabled && g.gameOverReason != "SHOT YOURSELF" {
+			t.Errorf("Expected the shame of shooting yourself, got %q", g.gameOverReason)
+		}
+	}
+}
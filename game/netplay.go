@@ -0,0 +1,519 @@
+package game
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+)
+
+// A network game is two instances of the game running the same simulation in
+// lockstep. Only the players' inputs cross the connection: each instance
+// simulates both ships itself, and steps only once it has both inputs for a
+// tick. The protocol is deliberately simple. Every value is big-endian.
+//
+// The handshake, once the joining instance has connected:
+//
+//	client: "SDNP" version:uint8
+//	host:   "SDNP" version:uint8 seed:int64 mode:uint8 difficulty:uint8
+//	        width:uint16 height:uint16 flags:uint8
+//
+// The client takes the run's seed, mode, difficulty, playfield size and
+// flags, such as friendly fire, from the host, so both simulations start out
+// identical. After that each side
+// sends a stream of messages, each starting with a one byte kind:
+//
+//	'I' tick:uint32 buttons:uint8  the sender's input for a tick
+//	'H' tick:uint32 hash:uint64    the sender's StateHash after a tick
+//
+// Inputs are sent netInputDelay ticks ahead of when they are used, which
+// gives them that long to arrive before the simulation has to wait for them.
+// The first netInputDelay ticks of a run have no input. A message for a tick
+// the other side couldn't have reached yet, or one already simulated, ends
+// the game as a protocol error.
+
+const (
+	// netMagic starts both halves of the handshake
+	netMagic = "SDNP"
+	// netVersion is bumped whenever the protocol or the simulation changes
+	// in a way that would stop two builds staying in step
+	netVersion = 3
+	// netInputDelay is how many ticks ahead inputs are sent
+	netInputDelay = 3
+	// netHashInterval is how often, in ticks, the state hashes are compared
+	netHashInterval = ticksPerSecond
+	// netRemoteAhead is how far past this side's next tick the other side's
+	// inputs can be. It can get netInputDelay+1 ticks ahead on the inputs
+	// this side has sent, then sends its own netInputDelay ticks further on.
+	netRemoteAhead = 2*netInputDelay + 1
+)
+
+// errNetProtocol is returned for a message the other side should never
+// have sent, such as an input for a tick that has already been simulated
+var errNetProtocol = errors.New("network protocol error")
+
+// Message kinds
+const (
+	netInputMessage = 'I'
+	netHashMessage  = 'H'
+)
+
+// Bits of the flags byte in the host's handshake
+const (
+	netFlagFriendlyFire = 1 << iota
+)
+
+// Bits of the buttons byte in an input message
+const (
+	netButtonLeft = 1 << iota
+	netButtonRight
+	netButtonThrust
+	netButtonFire
+	netButtonBeam
+	netButtonDash
+	netButtonSelect
+	netButtonSecondary
+)
+
+// encodeButtons packs an input into the buttons byte of an input message
+func encodeButtons(in InputState) uint8 {
+	var b uint8
+	set := func(bit uint8, pressed bool) {
+		if pressed {
+			b |= bit
+		}
+	}
+	set(netButtonLeft, in.Left)
+	set(netButtonRight, in.Right)
+	set(netButtonThrust, in.Thrust)
+	set(netButtonFire, in.Fire)
+	set(netButtonBeam, in.Beam)
+	set(netButtonDash, in.Dash)
+	set(netButtonSelect, in.Select)
+	set(netButtonSecondary, in.Secondary)
+	return b
+}
+
+// decodeButtons unpacks the buttons byte of an input message
+func decodeButtons(b uint8) InputState {
+	return InputState{
+		Left:      b&netButtonLeft != 0,
+		Right:     b&netButtonRight != 0,
+		Thrust:    b&netButtonThrust != 0,
+		Fire:      b&netButtonFire != 0,
+		Beam:      b&netButtonBeam != 0,
+		Dash:      b&netButtonDash != 0,
+		Select:    b&netButtonSelect != 0,
+		Secondary: b&netButtonSecondary != 0,
+	}
+}
+
+// netMessage is a message received from the other player
+type netMessage struct {
+	kind  byte
+	tick  uint32
+	input InputState
+	hash  uint64
+}
+
+// NetSession is the connection to the other player of a network game, made
+// with Host or Join and passed to the game with WithNetSession. The hosting
+// player flies the first ship and the joining player the second.
+type NetSession struct {
+	conn net.Conn
+	host bool
+
+	// The settings the run is played with, chosen by the host
+	seed          int64
+	mode          GameMode
+	difficulty    Difficulty
+	width, height int
+	friendlyFire  bool
+
+	// incoming receives the messages read from the connection, until
+	// reading fails and the error is sent on failed
+	incoming chan netMessage
+	failed   chan error
+
+	// tick is the next tick to be simulated, and nextSend the next tick to
+	// send this player's input for
+	tick     uint32
+	nextSend uint32
+	// local and remote hold each player's inputs for the ticks that haven't
+	// been simulated yet
+	local, remote map[uint32]InputState
+	// localHashes and remoteHashes hold the state hashes that haven't been
+	// compared yet, and remoteHashTick is the tick of the last hash received
+	localHashes, remoteHashes map[uint32]uint64
+	remoteHashTick            uint32
+}
+
+// Host waits for another player to join on addr, such as ":7777", and
+// agrees the settings of the run with them. It blocks until they join.
+func Host(addr string, seed int64, mode GameMode, difficulty Difficulty, width, height int, friendlyFire bool) (*NetSession, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	defer listener.Close()
+	conn, err := listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	s, err := hostSession(conn, seed, mode, difficulty, width, height, friendlyFire)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Join connects to a player hosting on addr, such as "example.com:7777",
+// and learns the settings of the run from them
+func Join(addr string) (*NetSession, error) {
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	s, err := joinSession(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// hostSession runs the host's half of the handshake over conn
+func hostSession(conn net.Conn, seed int64, mode GameMode, difficulty Difficulty, width, height int, friendlyFire bool) (*NetSession, error) {
+	if err := readHello(conn); err != nil {
+		return nil, err
+	}
+	difficultyIndex := 0
+	for i, d := range difficulties {
+		if d == difficulty {
+			difficultyIndex = i + 1
+		}
+	}
+	reply := append([]byte(netMagic), netVersion)
+	reply = binary.BigEndian.AppendUint64(reply, uint64(seed))
+	reply = append(reply, byte(mode), byte(difficultyIndex))
+	reply = binary.BigEndian.AppendUint16(reply, uint16(width))
+	reply = binary.BigEndian.AppendUint16(reply, uint16(height))
+	var flags byte
+	if friendlyFire {
+		flags |= netFlagFriendlyFire
+	}
+	reply = append(reply, flags)
+	if _, err := conn.Write(reply); err != nil {
+		return nil, err
+	}
+	s := newNetSession(conn, true, seed, mode, difficulty, width, height)
+	s.friendlyFire = friendlyFire
+	return s, nil
+}
+
+// joinSession runs the joining player's half of the handshake over conn
+func joinSession(conn net.Conn) (*NetSession, error) {
+	if _, err := conn.Write(append([]byte(netMagic), netVersion)); err != nil {
+		return nil, err
+	}
+	if err := readHello(conn); err != nil {
+		return nil, err
+	}
+	var settings [15]byte
+	if _, err := io.ReadFull(conn, settings[:]); err != nil {
+		return nil, err
+	}
+	seed := int64(binary.BigEndian.Uint64(settings[0:8]))
+	mode := GameMode(settings[8])
+	var difficulty Difficulty
+	if i := int(settings[9]); i > 0 && i <= len(difficulties) {
+		difficulty = difficulties[i-1]
+	}
+	width := int(binary.BigEndian.Uint16(settings[10:12]))
+	height := int(binary.BigEndian.Uint16(settings[12:14]))
+	s := newNetSession(conn, false, seed, mode, difficulty, width, height)
+	s.friendlyFire = settings[14]&netFlagFriendlyFire != 0
+	return s, nil
+}
+
+// readHello reads the magic and version that start each half of the
+// handshake, checking the other side speaks the same protocol
+func readHello(r io.Reader) error {
+	var hello [len(netMagic) + 1]byte
+	if _, err := io.ReadFull(r, hello[:]); err != nil {
+		return err
+	}
+	if string(hello[:len(netMagic)]) != netMagic {
+		return errors.New("the other side isn't a SpaceDebris game")
+	}
+	if v := hello[len(netMagic)]; v != netVersion {
+		return fmt.Errorf("the other side uses network version %d, this game uses %d", v, netVersion)
+	}
+	return nil
+}
+
+// newNetSession starts a session over a connection that has completed the
+// handshake, with the first ticks' inputs already filled in as empty
+func newNetSession(conn net.Conn, host bool, seed int64, mode GameMode, difficulty Difficulty, width, height int) *NetSession {
+	s := &NetSession{
+		conn:         conn,
+		host:         host,
+		seed:         seed,
+		mode:         mode,
+		difficulty:   difficulty,
+		width:        width,
+		height:       height,
+		incoming:     make(chan netMessage, 64),
+		failed:       make(chan error, 1),
+		nextSend:     netInputDelay,
+		local:        make(map[uint32]InputState),
+		remote:       make(map[uint32]InputState),
+		localHashes:  make(map[uint32]uint64),
+		remoteHashes: make(map[uint32]uint64),
+	}
+	for tick := uint32(0); tick < netInputDelay; tick++ {
+		s.local[tick] = InputState{}
+		s.remote[tick] = InputState{}
+	}
+	go s.read()
+	return s
+}
+
+// read reads messages from the connection until it fails
+func (s *NetSession) read() {
+	r := bufio.NewReader(s.conn)
+	for {
+		msg, err := readNetMessage(r)
+		if err != nil {
+			s.failed <- err
+			close(s.incoming)
+			return
+		}
+		s.incoming <- msg
+	}
+}
+
+// readNetMessage reads a single message
+func readNetMessage(r *bufio.Reader) (netMessage, error) {
+	var msg netMessage
+	kind, err := r.ReadByte()
+	if err != nil {
+		return msg, err
+	}
+	msg.kind = kind
+	var buf [12]byte
+	switch kind {
+	case netInputMessage:
+		if _, err := io.ReadFull(r, buf[:5]); err != nil {
+			return msg, err
+		}
+		msg.input = decodeButtons(buf[4])
+	case netHashMessage:
+		if _, err := io.ReadFull(r, buf[:12]); err != nil {
+			return msg, err
+		}
+		msg.hash = binary.BigEndian.Uint64(buf[4:12])
+	default:
+		return msg, fmt.Errorf("unknown network message %q", kind)
+	}
+	msg.tick = binary.BigEndian.Uint32(buf[0:4])
+	return msg, nil
+}
+
+// sendInput sends this player's input for tick
+func (s *NetSession) sendInput(tick uint32, in InputState) error {
+	msg := binary.BigEndian.AppendUint32([]byte{netInputMessage}, tick)
+	_, err := s.conn.Write(append(msg, encodeButtons(in)))
+	return err
+}
+
+// sendHash sends this simulation's state hash after tick
+func (s *NetSession) sendHash(tick uint32, hash uint64) error {
+	msg := binary.BigEndian.AppendUint32([]byte{netHashMessage}, tick)
+	_, err := s.conn.Write(binary.BigEndian.AppendUint64(msg, hash))
+	return err
+}
+
+// receive takes in every message that has arrived, without waiting for more.
+// It returns an error once the connection has failed, or wrapping
+// errNetProtocol for a message whose tick is out of step with this side.
+func (s *NetSession) receive() error {
+	for {
+		select {
+		case msg, ok := <-s.incoming:
+			if !ok {
+				return <-s.failed
+			}
+			switch msg.kind {
+			case netInputMessage:
+				if _, seen := s.remote[msg.tick]; seen || msg.tick < s.tick || msg.tick > s.tick+netRemoteAhead {
+					return fmt.Errorf("%w: input for tick %d at tick %d", errNetProtocol, msg.tick, s.tick)
+				}
+				s.remote[msg.tick] = msg.input
+			case netHashMessage:
+				// Hashes come in order, for ticks the other side has
+				// simulated, which can't be past the inputs sent to it
+				if msg.tick%netHashInterval != 0 || msg.tick <= s.remoteHashTick || msg.tick > s.tick+netInputDelay+1 {
+					return fmt.Errorf("%w: hash for tick %d at tick %d", errNetProtocol, msg.tick, s.tick)
+				}
+				s.remoteHashTick = msg.tick
+				s.remoteHashes[msg.tick] = msg.hash
+			}
+		default:
+			return nil
+		}
+	}
+}
+
+// desync compares the state hashes both sides have reported, and returns
+// the first tick they differ on, if any. Several ticks can be compared at
+// once when the remote hashes arrive late, so every pair is checked to find
+// the earliest.
+func (s *NetSession) desync() (uint32, bool) {
+	first, found := uint32(0), false
+	for tick, local := range s.localHashes {
+		remote, ok := s.remoteHashes[tick]
+		if !ok {
+			continue
+		}
+		delete(s.localHashes, tick)
+		delete(s.remoteHashes, tick)
+		if local != remote && (!found || tick < first) {
+			first, found = tick, true
+		}
+	}
+	return first, found
+}
+
+// Size returns the playfield size agreed for the run
+func (s *NetSession) Size() (width, height int) {
+	return s.width, s.height
+}
+
+// Close closes the connection, which the other player sees as it dropping
+func (s *NetSession) Close() error {
+	return s.conn.Close()
+}
+
+// WithNetSession plays a network game over s, with the settings agreed in
+// its handshake. The run starts straight away, without mutators, which the
+// other player might not have.
+func WithNetSession(s *NetSession) Option {
+	return func(g *Game) {
+		g.net = s
+		g.seed = s.seed
+		g.mode = s.mode
+		g.config.Difficulty = s.difficulty
+		g.config.FriendlyFire = s.friendlyFire
+		g.screenWidth = float64(s.width)
+		g.screenHeight = float64(s.height)
+	}
+}
+
+// partnerOffset is how far to the right of the player's ship the partner's
+// ship starts
+const partnerOffset = 60.0
+
+// ship returns the partner's ship if partner is set, or the player's
+func (g *Game) ship(partner bool) *Player {
+	if partner && g.partner != nil {
+		return g.partner
+	}
+	return g.player
+}
+
+// localShip returns the ship flown from this keyboard, which in a network
+// game is the partner's ship for the joining player
+func (g *Game) localShip() *Player {
+	if g.net != nil && !g.net.host {
+		return g.partner
+	}
+	return g.player
+}
+
+// updateNet runs a tick of a network game with this player's input. It
+// waits, without stepping, until the other player's input for the tick has
+// arrived.
+func (g *Game) updateNet(local InputState) error {
+	s := g.net
+	if err := s.receive(); errors.Is(err, errNetProtocol) {
+		g.failNetGame("NETWORK PROTOCOL ERROR")
+		return nil
+	} else if err != nil {
+		g.leaveNetGame()
+		g.showToast("CONNECTION LOST")
+		return nil
+	}
+	if tick, ok := s.desync(); ok {
+		g.failNetGame(fmt.Sprintf("OUT OF SYNC AT TICK %d", tick))
+		return nil
+	}
+
+	// Send this tick's input for use netInputDelay ticks from now. While
+	// waiting on the other player, the input has nowhere to go, so it is
+	// dropped.
+	if s.nextSend <= s.tick+netInputDelay {
+		s.local[s.nextSend] = local
+		if err := s.sendInput(s.nextSend, local); err != nil {
+			g.leaveNetGame()
+			g.showToast("CONNECTION LOST")
+			return nil
+		}
+		s.nextSend++
+	}
+
+	remote, ok := s.remote[s.tick]
+	if !ok {
+		return nil
+	}
+	hostInput, joinInput := s.local[s.tick], remote
+	if !s.host {
+		hostInput, joinInput = joinInput, hostInput
+	}
+	delete(s.local, s.tick)
+	delete(s.remote, s.tick)
+	s.tick++
+
+	g.partnerInput = joinInput
+	if err := g.Step(hostInput); err != nil {
+		return err
+	}
+
+	if s.tick%netHashInterval == 0 {
+		hash := g.StateHash()
+		s.localHashes[s.tick] = hash
+		if err := s.sendHash(s.tick, hash); err != nil {
+			g.leaveNetGame()
+			g.showToast("CONNECTION LOST")
+		}
+	}
+	return nil
+}
+
+// failNetGame abandons a network game, showing why on the error screen
+func (g *Game) failNetGame(reason string) {
+	g.net.Close()
+	g.netError = reason
+	g.state = GameStateNetError
+}
+
+// leaveNetGame closes the connection and goes back to the title screen
+func (g *Game) leaveNetGame() {
+	g.net.Close()
+	g.net = nil
+	g.partner = nil
+	g.state = GameStateTitle
+}
+
+// updateNetError waits on the error screen for the player to go back to the
+// title
+func (g *Game) updateNetError() error {
+	g.updateBackground(float64(g.steps))
+	if g.readMenu().Back {
+		g.leaveNetGame()
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+//go:build !headless
+
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// drawNetErrorScreen explains why a network game was abandoned
+func (g *Game) drawNetErrorScreen(screen *ebiten.Image) {
+	centerX := float32(g.screenWidth / 2)
+	centerY := float32(g.screenHeight / 2)
+	for i, line := range []string{"NETWORK ERROR", g.netError, "PRESS ESC FOR TITLE"} {
+		x := centerX - g.vectorFont.GetWidth(line)/2
+		g.vectorFont.DrawString(screen, line, x, centerY-40+float32(i)*40)
+	}
+}
@@ -0,0 +1,281 @@
+package game
+
+import (
+	"errors"
+	"net"
+	"runtime"
+	"testing"
+)
+
+// newNetPair connects a hosting and a joining session over an in-memory
+// connection
+func newNetPair(t *testing.T, seed int64, mode GameMode) (host, join *NetSession) {
+	t.Helper()
+	a, b := net.Pipe()
+	type result struct {
+		s   *NetSession
+		err error
+	}
+	hosted := make(chan result)
+	go func() {
+		s, err := hostSession(a, seed, mode, DifficultyHard, 640, 480, false)
+		hosted <- result{s, err}
+	}()
+	join, err := joinSession(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := <-hosted
+	if r.err != nil {
+		t.Fatal(r.err)
+	}
+	t.Cleanup(func() {
+		r.s.Close()
+		join.Close()
+	})
+	return r.s, join
+}
+
+// newNetGames creates a hosting and a joining game playing each other
+func newNetGames(t *testing.T) (host, join *Game) {
+	t.Helper()
+	hs, js := newNetPair(t, 42, ModeClassic)
+	return New(WithNetSession(hs)), New(WithNetSession(js))
+}
+
+// runNet updates both games with fixed inputs until both have simulated
+// ticks ticks, or until stop returns true
+func runNet(t *testing.T, host, join *Game, ticks uint32, stop func() bool) {
+	t.Helper()
+	hostInput := InputState{Left: true, Fire: true}
+	joinInput := InputState{Thrust: true, Right: true}
+	for i := 0; i < 1000000; i++ {
+		if stop != nil && stop() {
+			return
+		}
+		if host.net == nil || join.net == nil {
+			t.Fatalf("Expected both games to stay connected")
+		}
+		if host.net.tick >= ticks && join.net.tick >= ticks {
+			return
+		}
+		for _, step := range []struct {
+			g  *Game
+			in InputState
+		}{{host, hostInput}, {join, joinInput}} {
+			if step.g.state == GameStateNetError {
+				continue
+			}
+			if err := step.g.updateNet(step.in); err != nil {
+				t.Fatal(err)
+			}
+		}
+		// Give the readers a chance to catch up
+		runtime.Gosched()
+	}
+	t.Fatalf("Timed out at ticks %d and %d", host.net.tick, join.net.tick)
+}
+
+func TestNetHandshake(t *testing.T) {
+	host, join := newNetPair(t, 42, ModeStation)
+	if !host.host || join.host {
+		t.Errorf("Expected only the host session to host")
+	}
+	if join.seed != 42 || join.mode != ModeStation || join.difficulty != DifficultyHard {
+		t.Errorf("Expected the host's settings, got seed %d mode %v difficulty %q", join.seed, join.mode, join.difficulty)
+	}
+	if w, h := join.Size(); w != 640 || h != 480 {
+		t.Errorf("Expected a 640x480 playfield, got %dx%d", w, h)
+	}
+}
+
+func TestNetHandshakeRejectsStranger(t *testing.T) {
+	a, b := net.Pipe()
+	defer a.Close()
+	defer b.Close()
+	go b.Write([]byte("HTTP/1.1"))
+	if _, err := hostSession(a, 1, ModeClassic, "", 800, 600, false); err == nil {
+		t.Errorf("Expected a handshake from something else to be rejected")
+	}
+}
+
+func TestNetButtons(t *testing.T) {
+	for _, in := range []InputState{
+		{},
+		{Left: true, Fire: true},
+		{Right: true, Thrust: true, Beam: true, Dash: true},
+		{Select: true, Secondary: true},
+	} {
+		if got := decodeButtons(encodeButtons(in)); got != in {
+			t.Errorf("Expected %+v to survive encoding, got %+v", in, got)
+		}
+	}
+}
+
+func TestNetGameStart(t *testing.T) {
+	host, join := newNetGames(t)
+	for _, g := range []*Game{host, join} {
+		if g.state != GameStateGetReady {
+			t.Errorf("Expected the run to start straight away, got state %v", g.state)
+		}
+		if g.partner == nil || !g.partner.partner || g.countEntities(TagPlayer) != 2 {
+			t.Fatalf("Expected two ships")
+		}
+		if g.screenWidth != 640 || g.config.Difficulty != DifficultyHard {
+			t.Errorf("Expected the host's settings")
+		}
+	}
+	if host.localShip() != host.player || join.localShip() != join.partner {
+		t.Errorf("Expected each player to fly their own ship")
+	}
+}
+
+func TestNetGamesStayInSync(t *testing.T) {
+	host, join := newNetGames(t)
+	runNet(t, host, join, 5*netHashInterval, nil)
+
+	if host.StateHash() != join.StateHash() {
+		t.Fatalf("Expected the games to stay in sync")
+	}
+	if host.player.polygon.Rotation == join.partner.polygon.Rotation {
+		t.Errorf("Expected each ship to follow its own player's input")
+	}
+	if host.player.polygon.Rotation != join.player.polygon.Rotation {
+		t.Errorf("Expected the host's ship to match in both games")
+	}
+}
+
+func TestNetDesync(t *testing.T) {
+	host, join := newNetGames(t)
+	runNet(t, host, join, netHashInterval/2, nil)
+
+	// Knock one game out of step with the other
+	join.player.polygon.Position.X += 10
+	runNet(t, host, join, 4*netHashInterval, func() bool {
+		return host.state == GameStateNetError || join.state == GameStateNetError
+	})
+
+	for _, g := range []*Game{host, join} {
+		if g.state == GameStateNetError {
+			if g.netError != "OUT OF SYNC AT TICK 60" {
+				t.Errorf("Expected the desync to be explained, got %q", g.netError)
+			}
+			return
+		}
+	}
+	t.Fatalf("Expected the desync to be spotted")
+}
+
+func TestNetDesyncReportsFirstTick(t *testing.T) {
+	s := &NetSession{
+		localHashes:  map[uint32]uint64{60: 1, 120: 2, 180: 3, 240: 4},
+		remoteHashes: map[uint32]uint64{60: 1, 120: 9, 180: 9},
+	}
+	if tick, ok := s.desync(); !ok || tick != 120 {
+		t.Errorf("Expected the desync at tick 120, got %d %v", tick, ok)
+	}
+	if len(s.localHashes) != 1 || len(s.remoteHashes) != 0 {
+		t.Errorf("Expected only the unmatched hash to be kept, got %v and %v", s.localHashes, s.remoteHashes)
+	}
+}
+
+func TestNetConnectionLost(t *testing.T) {
+	host, join := newNetGames(t)
+	runNet(t, host, join, 10, nil)
+
+	join.net.Close()
+	for i := 0; i < 1000000 && host.net != nil; i++ {
+		if err := host.updateNet(InputState{}); err != nil {
+			t.Fatal(err)
+		}
+		runtime.Gosched()
+	}
+	if host.state != GameStateTitle || host.partner != nil {
+		t.Errorf("Expected to go back to the title, got state %v", host.state)
+	}
+	if host.toast != "CONNECTION LOST" {
+		t.Errorf("Expected the dropped connection to be explained, got %q", host.toast)
+	}
+}
+
+func TestNetPartnerHit(t *testing.T) {
+	host, _ := newNetGames(t)
+	host.startPlaying()
+	host.entities = []Entity{host.player, host.partner}
+	events := recordEvents(host)
+	p := host.partner.polygon.Position
+	placeAsteroid(host, 30, p.X, p.Y)
+
+	if err := host.updatePlaying(); err != nil {
+		t.Fatal(err)
+	}
+	if host.state != GameStateGameOver {
+		t.Errorf("Expected losing the partner's ship to end the run")
+	}
+	found := false
+	for _, e := range *events {
+		if hit, ok := e.(PlayerHit); ok && hit.Partner {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected the partner to be hit, got %#v", *events)
+	}
+}
+
+func TestNetRejectsOutOfStepTicks(t *testing.T) {
+	// Just short of a hash, with the other side's input for the tick after
+	// already in
+	const tick = 2*netHashInterval - 2
+	tests := []struct {
+		name string
+		msg  netMessage
+		ok   bool
+	}{
+		{"next input", netMessage{kind: netInputMessage, tick: tick}, true},
+		{"furthest input", netMessage{kind: netInputMessage, tick: tick + netRemoteAhead}, true},
+		{"simulated input", netMessage{kind: netInputMessage, tick: tick - 1}, false},
+		{"repeated input", netMessage{kind: netInputMessage, tick: tick + 1}, false},
+		{"input too far ahead", netMessage{kind: netInputMessage, tick: tick + 1 + netRemoteAhead}, false},
+		{"next hash", netMessage{kind: netHashMessage, tick: 2 * netHashInterval}, true},
+		{"old hash", netMessage{kind: netHashMessage, tick: netHashInterval}, false},
+		{"hash between intervals", netMessage{kind: netHashMessage, tick: 2*netHashInterval + 1}, false},
+		{"hash too far ahead", netMessage{kind: netHashMessage, tick: 3 * netHashInterval}, false},
+	}
+	for _, tt := range tests {
+		s := &NetSession{
+			incoming:       make(chan netMessage, 1),
+			tick:           tick,
+			remote:         map[uint32]InputState{tick + 1: {}},
+			remoteHashes:   make(map[uint32]uint64),
+			remoteHashTick: netHashInterval,
+		}
+		s.incoming <- tt.msg
+		err := s.receive()
+		if tt.ok && err != nil {
+			t.Errorf("%s: expected it to be taken, got %v", tt.name, err)
+		}
+		if !tt.ok && !errors.Is(err, errNetProtocol) {
+			t.Errorf("%s: expected a protocol error, got %v", tt.name, err)
+		}
+	}
+}
+
+func TestNetStaleInputEndsGame(t *testing.T) {
+	host, join := newNetGames(t)
+	runNet(t, host, join, 10, nil)
+
+	// Send an input for a tick both sides have already simulated
+	if err := join.net.sendInput(0, InputState{}); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 1000000 && host.state != GameStateNetError; i++ {
+		if err := host.updateNet(InputState{}); err != nil {
+			t.Fatal(err)
+		}
+		runtime.Gosched()
+	}
+	if host.netError != "NETWORK PROTOCOL ERROR" {
+		t.Errorf("Expected the bad message to be explained, got %q", host.netError)
+	}
+}
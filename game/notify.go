@@ -0,0 +1,86 @@
+package game
+
+import (
+	"time"
+)
+
+// notificationBuffer is how many notifications wait for a slow consumer
+// before any more are dropped
+const notificationBuffer = 64
+
+// GameEventKind is what a GameEvent reports
+type GameEventKind string
+
+const (
+	// EventScore is sent whenever the score changes, including back to 0
+	// when a run starts
+	EventScore GameEventKind = "score"
+	// EventWave is sent when a wave starts
+	EventWave GameEventKind = "wave"
+	// EventDeath is sent when a ship is destroyed
+	EventDeath GameEventKind = "death"
+	// EventGameOver is sent when a run is over, whether won or lost
+	EventGameOver GameEventKind = "game_over"
+)
+
+// GameEvent is a notification of something that happened in the game, for
+// tools outside it such as a stream overlay
+type GameEvent struct {
+	Kind GameEventKind
+	// Time is when the game reported it, and Tick how far into the run it
+	// was, in ticks
+	Time time.Time
+	Tick int
+	// Score and Wave are as they were after it happened
+	Score int
+	Wave  int
+	// Partner is set for the death of the second ship of a network game
+	Partner bool
+	// Reason is what the game over screen shows, for EventGameOver
+	Reason string
+}
+
+// Events returns a channel of notifications of score changes, waves
+// starting, deaths and runs ending, in the order they happen. It is opt in:
+// nothing is sent until it is first called, which should be before the game
+// starts running. The game never waits for the channel: if the consumer
+// falls behind and the buffer fills, notifications are dropped. The
+// channel is never closed.
+func (g *Game) Events() <-chan GameEvent {
+	if g.notifications == nil {
+		g.notifications = make(chan GameEvent, notificationBuffer)
+		g.Subscribe(g.handleNotifications)
+	}
+	return g.notifications
+}
+
+// handleNotifications turns the game's events into notifications. It is
+// subscribed after everything else, so the score has already been updated
+// for each event it sees.
+func (g *Game) handleNotifications(e Event) {
+	switch e := e.(type) {
+	case WaveStarted:
+		g.notify(GameEvent{Kind: EventWave})
+	case PlayerHit:
+		g.notify(GameEvent{Kind: EventDeath, Partner: e.Partner})
+	case GameEnded:
+		g.notify(GameEvent{Kind: EventGameOver, Reason: e.Reason})
+	}
+	if _, ok := e.(GameStarted); ok || g.score != g.notifiedScore {
+		g.notifiedScore = g.score
+		g.notify(GameEvent{Kind: EventScore})
+	}
+}
+
+// notify sends a notification, filling in when it happened and the state
+// of the run, or drops it if the channel is full
+func (g *Game) notify(n GameEvent) {
+	n.Time = time.Now()
+	n.Tick = g.survivalTicks
+	n.Score = g.score
+	n.Wave = g.wave
+	select {
+	case g.notifications <- n:
+	default:
+	}
+}
@@ -0,0 +1,25 @@
+package game
+
+import "fmt"
+
+// A tool such as a stream overlay asks for the events before the game runs,
+// then reads them as they come. This one reads what is waiting after each
+// step rather than blocking, as the game never closes the channel.
+func ExampleGame_Events() {
+	g := New(WithSeed(1))
+	events := g.Events()
+	g.Restart(RestartOptions{SkipGetReady: true})
+	for range 3 {
+		if err := g.Step(InputState{}); err != nil {
+			fmt.Println(err)
+			return
+		}
+		for len(events) > 0 {
+			e := <-events
+			fmt.Printf("%s: tick %d, score %d, wave %d\n", e.Kind, e.Tick, e.Score, e.Wave)
+		}
+	}
+	// Output:
+	// score: tick 1, score 0, wave 1
+	// wave: tick 1, score 0, wave 1
+}
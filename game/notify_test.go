@@ -0,0 +1,92 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEventsInOrder(t *testing.T) {
+	g := New()
+	events := g.Events()
+	g.Restart(RestartOptions{SkipGetReady: true})
+	g.emit(AsteroidDestroyed{Size: 30, ByBullet: true})
+	g.emit(PlayerHit{})
+	g.endRun("GAME OVER")
+	g.dispatchEvents()
+
+	want := []GameEventKind{EventScore, EventWave, EventScore, EventDeath, EventGameOver}
+	var last time.Time
+	for i, kind := range want {
+		select {
+		case e := <-events:
+			if e.Kind != kind {
+				t.Errorf("Notification %d: expected %s, got %s", i, kind, e.Kind)
+			}
+			if e.Time.Before(last) {
+				t.Errorf("Notification %d: time went backwards", i)
+			}
+			last = e.Time
+			if i >= 2 && e.Score != g.score {
+				t.Errorf("Notification %d: expected the score %d, got %d", i, g.score, e.Score)
+			}
+		default:
+			t.Fatalf("Expected %d notifications, got %d", len(want), i)
+		}
+	}
+	if len(events) != 0 {
+		t.Errorf("Expected no more notifications, got %+v", <-events)
+	}
+}
+
+func TestEventsFullChannelDoesNotStall(t *testing.T) {
+	g := New()
+	events := g.Events()
+	g.Restart(RestartOptions{SkipGetReady: true})
+
+	// Nobody reads the channel while far more notifications are sent than
+	// it can hold
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range 4 * notificationBuffer {
+			g.emit(AsteroidDestroyed{Size: 30, ByBullet: true})
+			g.dispatchEvents()
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("The game stalled on a full notification channel")
+	}
+
+	if len(events) != notificationBuffer {
+		t.Fatalf("Expected a full channel, got %d notifications", len(events))
+	}
+	// The notifications that fit are the earliest, still in order
+	previous := -1
+	for len(events) > 0 {
+		e := <-events
+		if e.Score <= previous && e.Kind == EventScore {
+			t.Fatalf("Expected the score to climb, got %d after %d", e.Score, previous)
+		}
+		if e.Kind == EventScore {
+			previous = e.Score
+		}
+	}
+	if previous >= g.score {
+		t.Errorf("Expected the later notifications to be dropped, last kept score %d of %d", previous, g.score)
+	}
+}
+
+func TestEventsOptIn(t *testing.T) {
+	g := New()
+	handlers := len(g.events.handlers)
+	g.Restart(RestartOptions{})
+	g.dispatchEvents()
+	if g.notifications != nil || len(g.events.handlers) != handlers {
+		t.Errorf("Expected nothing to be sent until the channel is asked for")
+	}
+	if g.Events() != g.Events() {
+		t.Errorf("Expected the same channel each time")
+	}
+}
@@ -0,0 +1,80 @@
+package game
+
+import (
+	"math"
+	"time"
+)
+
+// WithScreenSize sets the size of the playfield, in pixels. The default is
+// 800x600.
+func WithScreenSize(width, height int) Option {
+	return func(g *Game) {
+		g.screenWidth = float64(width)
+		g.screenHeight = float64(height)
+	}
+}
+
+// WithSeed seeds the random number generator, so the same seed lays out the
+// same asteroids. By default it is seeded from the current time.
+func WithSeed(seed int64) Option {
+	return func(g *Game) {
+		g.seed = seed
+	}
+}
+
+// WithAsteroidCount sets how many asteroids each classic mode run starts
+// with. The default is 3.
+func WithAsteroidCount(n int) Option {
+	return func(g *Game) {
+		g.tuning.AsteroidCount = n
+	}
+}
+
+// WithAsteroidCap sets how many asteroids the field holds before the
+// smallest fragments merge when they touch. Zero turns merging off. The
+// default is 40.
+func WithAsteroidCap(n int) Option {
+	return func(g *Game) {
+		g.tuning.AsteroidCap = n
+	}
+}
+
+// WithBulletCooldown sets the shortest time between shots, rounded to the
+// nearest tick. The default is 100ms.
+func WithBulletCooldown(d time.Duration) Option {
+	return func(g *Game) {
+		g.tuning.BulletCooldown = int(math.Round(d.Seconds() * ticksPerSecond))
+	}
+}
+
+// Score returns the score of the current run
+func (g *Game) Score() int {
+	return g.score
+}
+
+// State returns which screen the game is on
+func (g *Game) State() GameState {
+	return g.state
+}
+
+// InRun reports whether a run is under way, either being played or counting
+// down to the start
+func (g *Game) InRun() bool {
+	return g.state == GameStatePlaying || g.state == GameStateGetReady
+}
+
+// Wave returns the wave the current run is on
+func (g *Game) Wave() int {
+	return g.wave
+}
+
+// SetPaused freezes or unfreezes the game. While paused nothing updates, but
+// the game still draws its last state.
+func (g *Game) SetPaused(paused bool) {
+	g.paused = paused
+}
+
+// Paused reports whether the game has been paused with SetPaused
+func (g *Game) Paused() bool {
+	return g.paused
+}
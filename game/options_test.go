@@ -0,0 +1,120 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+// asteroidPositions returns where each asteroid in the game is
+func asteroidPositions(g *Game) []float64 {
+	var positions []float64
+	for _, e := range g.entities {
+		if e.Tag() == TagAsteroid {
+			positions = append(positions, e.Collider().Position.X, e.Collider().Position.Y)
+		}
+	}
+	return positions
+}
+
+func TestOptionDefaults(t *testing.T) {
+	g := New()
+	if w, h := g.Layout(0, 0); w != 800 || h != 600 {
+		t.Errorf("Expected an 800x600 screen, got %dx%d", w, h)
+	}
+	if got := g.countEntities(TagAsteroid); got != 3 {
+		t.Errorf("Expected 3 asteroids, got %d", got)
+	}
+	if g.tuning.BulletCooldown != 6 {
+		t.Errorf("Expected a 6 tick cooldown, got %d", g.tuning.BulletCooldown)
+	}
+}
+
+func TestWithScreenSize(t *testing.T) {
+	g := New(WithScreenSize(320, 240))
+	if w, h := g.Layout(1920, 1080); w != 320 || h != 240 {
+		t.Errorf("Expected a 320x240 screen, got %dx%d", w, h)
+	}
+	if pos := g.player.polygon.Position; pos.X != 160 || pos.Y != 120 {
+		t.Errorf("Expected the ship in the middle of the screen, got %v", pos)
+	}
+	for _, e := range g.entities {
+		if pos := e.Collider().Position; pos.X < 0 || pos.X > 320 || pos.Y < 0 || pos.Y > 240 {
+			t.Errorf("Expected everything on screen, got %v", pos)
+		}
+	}
+}
+
+func TestWithSeed(t *testing.T) {
+	first := asteroidPositions(New(WithSeed(42)))
+	second := asteroidPositions(New(WithSeed(42)))
+	other := asteroidPositions(New(WithSeed(43)))
+
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("Expected the same seed to give the same field, got %v and %v", first, second)
+		}
+	}
+	if first[0] == other[0] && first[1] == other[1] {
+		t.Errorf("Expected a different seed to give a different field, got %v", other)
+	}
+}
+
+func TestWithAsteroidCount(t *testing.T) {
+	g := New(WithAsteroidCount(7))
+	if got := g.countEntities(TagAsteroid); got != 7 {
+		t.Errorf("Expected 7 asteroids, got %d", got)
+	}
+	g.Restart(RestartOptions{})
+	if got := g.countEntities(TagAsteroid); got != 7 {
+		t.Errorf("Expected 7 asteroids after a restart, got %d", got)
+	}
+}
+
+func TestWithBulletCooldown(t *testing.T) {
+	g := New(WithBulletCooldown(250 * time.Millisecond))
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+	// Keep an asteroid out of the line of fire so the wave isn't cleared
+	placeAsteroid(g, 10, 50, 50)
+	if g.tuning.BulletCooldown != 15 {
+		t.Fatalf("Expected a 15 tick cooldown, got %d", g.tuning.BulletCooldown)
+	}
+
+	// Hold fire down, which only keeps firing in automatic mode
+	g.config.FireMode = FireAuto
+	inputs := make([]InputState, 31)
+	for i := range inputs {
+		inputs[i] = InputState{Fire: true}
+	}
+	if err := Simulate(g, inputs, len(inputs)); err != nil {
+		t.Fatal(err)
+	}
+	if got := g.countEntities(TagBullet); got != 2 {
+		t.Errorf("Expected 2 shots in 31 ticks, got %d", got)
+	}
+}
+
+func TestAccessorsAndPause(t *testing.T) {
+	g := New()
+	if g.State() != GameStateTitle || g.Score() != 0 || g.Wave() != 1 {
+		t.Errorf("Unexpected initial state %v, score %d, wave %d", g.State(), g.Score(), g.Wave())
+	}
+
+	g.Restart(RestartOptions{})
+	g.SetPaused(true)
+	if !g.Paused() {
+		t.Fatalf("Expected the game to be paused")
+	}
+	for i := 0; i < 10; i++ {
+		if err := g.Update(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if g.survivalTicks != 0 {
+		t.Errorf("Expected nothing to advance while paused, got %d ticks", g.survivalTicks)
+	}
+	if g.State() != GameStateGetReady || g.getReadyTicks != getReadyTicks {
+		t.Errorf("Expected the countdown to be frozen, got %v", g.State())
+	}
+}
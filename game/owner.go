@@ -0,0 +1,89 @@
+package game
+
+// BulletOwner is who fired a bullet, or who a ship belongs to, which decides
+// who its bullets can hurt and who is credited with what they hit
+type BulletOwner int
+
+const (
+	// OwnerNone is for anything nobody fired, such as a rock the ship flew
+	// into
+	OwnerNone BulletOwner = iota
+	// OwnerPlayer is the first ship, flown by the hosting player in a
+	// network game
+	OwnerPlayer
+	// OwnerPartner is the second ship of a network game
+	OwnerPartner
+	// OwnerTurret is a turret mounted on an asteroid
+	OwnerTurret
+)
+
+// enemy reports whether the owner is on the side against the players
+func (o BulletOwner) enemy() bool {
+	return o == OwnerTurret
+}
+
+// FirePolicy decides whose bullets can hurt whom
+type FirePolicy int
+
+const (
+	// PolicyCoop keeps the players' bullets from hurting each other
+	PolicyCoop FirePolicy = iota
+	// PolicyFriendlyFire is co-op where the players' bullets hurt each other
+	PolicyFriendlyFire
+	// PolicyVersus has everyone hurting everyone, for playing against each
+	// other
+	PolicyVersus
+)
+
+// shouldCollide reports whether a bullet fired by a can hurt b under the
+// policy. Bullets never hurt whoever fired them, and anything fired by
+// nobody hurts everyone. Enemies never hurt each other outside of versus,
+// and the players only hurt each other with friendly fire or in versus.
+func shouldCollide(a, b BulletOwner, policy FirePolicy) bool {
+	switch {
+	case a == OwnerNone || b == OwnerNone:
+		return true
+	case a == b:
+		return false
+	case policy == PolicyVersus:
+		return true
+	case a.enemy() != b.enemy():
+		return true
+	case a.enemy():
+		return false
+	}
+	return policy == PolicyFriendlyFire
+}
+
+// firePolicy returns the policy the run is played with
+func (g *Game) firePolicy() FirePolicy {
+	if g.config.FriendlyFire {
+		return PolicyFriendlyFire
+	}
+	return PolicyCoop
+}
+
+// owner returns the owner of the ship's bullets
+func (p *Player) owner() BulletOwner {
+	if p.partner {
+		return OwnerPartner
+	}
+	return OwnerPlayer
+}
+
+// credit adds points and kills to the tally of the ship that owns them, if
+// it was a ship
+func (g *Game) credit(owner BulletOwner, points, destroyed int) {
+	var ship *Player
+	switch owner {
+	case OwnerPlayer:
+		ship = g.player
+	case OwnerPartner:
+		ship = g.partner
+	}
+	if ship == nil {
+		return
+	}
+	ship.points += points
+	ship.destroyed += destroyed
+}
@@ -0,0 +1,16 @@
+//go:build !headless
+
+package game
+
+import "fmt"
+
+// shipPointsLine returns the points and kills credited to each ship, for
+// the end of a network game
+func (g *Game) shipPointsLine() string {
+	if g.partner == nil {
+		return ""
+	}
+	return fmt.Sprintf("P1: %s (%d)  P2: %s (%d)",
+		formatScore(g.player.points), g.player.destroyed,
+		formatScore(g.partner.points), g.partner.destroyed)
+}
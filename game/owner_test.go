@@ -0,0 +1,122 @@
+package game
+
+import (
+	"net"
+	"testing"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+func TestShouldCollide(t *testing.T) {
+	owners := []BulletOwner{OwnerNone, OwnerPlayer, OwnerPartner, OwnerTurret}
+	// want[policy][a][b] is whether a bullet fired by owners[a] hurts
+	// owners[b]
+	want := map[FirePolicy][4][4]bool{
+		PolicyCoop: {
+			{true, true, true, true},
+			{true, false, false, true},
+			{true, false, false, true},
+			{true, true, true, false},
+		},
+		PolicyFriendlyFire: {
+			{true, true, true, true},
+			{true, false, true, true},
+			{true, true, false, true},
+			{true, true, true, false},
+		},
+		PolicyVersus: {
+			{true, true, true, true},
+			{true, false, true, true},
+			{true, true, false, true},
+			{true, true, true, false},
+		},
+	}
+	for policy, table := range want {
+		for i, a := range owners {
+			for j, b := range owners {
+				if got := shouldCollide(a, b, policy); got != table[i][j] {
+					t.Errorf("Policy %d: expected shouldCollide(%d, %d) to be %v", policy, a, b, table[i][j])
+				}
+			}
+		}
+	}
+}
+
+// newTwoShipGame creates a game in play with a partner ship alongside the
+// player's, as in a network game, and nothing else
+func newTwoShipGame(friendlyFire bool) *Game {
+	g := New()
+	g.config.FriendlyFire = friendlyFire
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.partner = g.newShip(g.screenWidth/2+partnerOffset, g.screenHeight/2)
+	g.partner.partner = true
+	g.entities = []Entity{g.player, g.partner}
+	placeAsteroid(g, 10, 50, 50)
+	g.wind = geometry.Vector2{}
+	return g
+}
+
+func TestFriendlyFire(t *testing.T) {
+	for _, friendlyFire := range []bool{false, true} {
+		g := newTwoShipGame(friendlyFire)
+		events := recordEvents(g)
+		// The player's bullet sits on the partner's ship, and the partner's
+		// own bullet on top of it too
+		g.addEntity(newBullet(g.partner.polygon.Position, geometry.Vector2{}))
+		own := newBullet(g.partner.polygon.Position, geometry.Vector2{})
+		own.owner = OwnerPartner
+		g.addEntity(own)
+		stepTicks(t, g, 1)
+
+		hit := false
+		for _, e := range *events {
+			if e, ok := e.(PlayerHit); ok {
+				hit = true
+				if !e.Partner {
+					t.Errorf("Expected only the partner to be hit")
+				}
+			}
+		}
+		if hit != friendlyFire {
+			t.Errorf("Friendly fire %v: expected the partner being hit to be %v", friendlyFire, friendlyFire)
+		}
+		if own.Dead() {
+			t.Errorf("Expected the partner's own bullet not to hit it")
+		}
+	}
+}
+
+func TestPointsCreditedToOwner(t *testing.T) {
+	g := newTwoShipGame(false)
+	g.splitAsteroid(placeAsteroid(g, 40, 100, 100), OwnerPartner)
+	g.destroyAsteroid(placeAsteroid(g, 10, 300, 100), OwnerPlayer)
+	g.destroyAsteroid(placeAsteroid(g, 10, 500, 100), OwnerNone)
+	g.emit(CometDestroyed{Points: cometPoints, Owner: OwnerPartner})
+	g.emit(BulletIntercepted{Points: interceptPoints, Owner: OwnerPlayer})
+	g.dispatchEvents()
+
+	if g.player.points != 1+interceptPoints || g.player.destroyed != 1 {
+		t.Errorf("Expected the player to be credited with %d points and 1 kill, got %d and %d", 1+interceptPoints, g.player.points, g.player.destroyed)
+	}
+	if g.partner.points != 1+cometPoints || g.partner.destroyed != 2 {
+		t.Errorf("Expected the partner to be credited with %d points and 2 kills, got %d and %d", 1+cometPoints, g.partner.points, g.partner.destroyed)
+	}
+	if g.score != g.player.points+g.partner.points {
+		t.Errorf("Expected the shared score to be the sum of the ships', got %d", g.score)
+	}
+}
+
+func TestNetHandshakeFriendlyFire(t *testing.T) {
+	a, b := net.Pipe()
+	defer a.Close()
+	defer b.Close()
+	go hostSession(a, 1, ModeClassic, "", 640, 480, true)
+	s, err := joinSession(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g := New(WithNetSession(s)); !g.config.FriendlyFire {
+		t.Errorf("Expected the host's friendly fire to be used")
+	}
+}
@@ -0,0 +1,125 @@
+package game
+
+import (
+	"image/color"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// Palette maps each role color plays in the game to an actual color. Game
+// code should always color objects by role, so that the whole game can be
+// switched to a palette the player can tell apart.
+type Palette struct {
+	// Name is shown on the settings screen and stored in the config
+	Name string `json:"name"`
+
+	Player        color.RGBA `json:"player"`
+	Flame         color.RGBA `json:"flame"`
+	Bullet        color.RGBA `json:"bullet"`
+	Tracer        color.RGBA `json:"tracer"` // the streak behind moving bullets
+	Asteroid      color.RGBA `json:"asteroid"`
+	FreshFragment color.RGBA `json:"fresh_fragment"` // newly split, dangerous fragments
+	Armored       color.RGBA `json:"armored"`
+	Pickup        color.RGBA `json:"pickup"`
+	Dilated       color.RGBA `json:"dilated"`  // the tint on objects slowed by time dilation
+	PowerUp       color.RGBA `json:"power_up"` // weapon power-up pickups
+	Hit           color.RGBA `json:"hit"`      // the flash when the player is hit
+	Score         color.RGBA `json:"score"`    // the pulse when the score goes up
+	Comet         color.RGBA `json:"comet"`
+	UI            color.RGBA `json:"ui"`
+	UIDim         color.RGBA `json:"ui_dim"` // unselected menu items
+}
+
+// palettes lists every selectable palette. The first is the default.
+var palettes = []Palette{
+	{
+		Name:          "DEFAULT",
+		Player:        color.RGBA{0, 0, 255, 255},
+		Flame:         color.RGBA{255, 69, 0, 255},
+		Bullet:        color.RGBA{255, 255, 255, 255},
+		Tracer:        color.RGBA{140, 140, 140, 255},
+		Asteroid:      color.RGBA{255, 255, 255, 255},
+		FreshFragment: color.RGBA{255, 100, 100, 255},
+		Armored:       color.RGBA{255, 165, 0, 255},
+		Pickup:        color.RGBA{0, 255, 0, 255},
+		Dilated:       color.RGBA{150, 180, 255, 255},
+		PowerUp:       color.RGBA{255, 0, 255, 255},
+		Hit:           color.RGBA{255, 50, 50, 255},
+		Score:         color.RGBA{255, 220, 0, 255},
+		Comet:         color.RGBA{180, 255, 255, 255},
+		UI:            color.RGBA{255, 255, 255, 255},
+		UIDim:         color.RGBA{100, 100, 100, 255},
+	},
+	{
+		// Based on the Okabe-Ito palette, which stays distinguishable with
+		// the common forms of color blindness
+		Name:          "COLORBLIND",
+		Player:        color.RGBA{86, 180, 233, 255},
+		Flame:         color.RGBA{240, 228, 66, 255},
+		Bullet:        color.RGBA{255, 255, 255, 255},
+		Tracer:        color.RGBA{140, 140, 140, 255},
+		Asteroid:      color.RGBA{255, 255, 255, 255},
+		FreshFragment: color.RGBA{230, 159, 0, 255},
+		Armored:       color.RGBA{204, 121, 167, 255},
+		Pickup:        color.RGBA{0, 158, 115, 255},
+		Dilated:       color.RGBA{0, 114, 178, 255},
+		PowerUp:       color.RGBA{240, 228, 66, 255},
+		Hit:           color.RGBA{213, 94, 0, 255},
+		Score:         color.RGBA{240, 228, 66, 255},
+		Comet:         color.RGBA{255, 255, 255, 255},
+		UI:            color.RGBA{255, 255, 255, 255},
+		UIDim:         color.RGBA{110, 110, 110, 255},
+	},
+}
+
+// paletteByName returns the palette with the given name, or the default
+// palette if there is no such palette
+func paletteByName(name string) Palette {
+	for _, p := range palettes {
+		if p.Name == name {
+			return p
+		}
+	}
+	return palettes[0]
+}
+
+// nextPaletteName returns the name of the palette after the named one,
+// wrapping back to the first
+func nextPaletteName(name string) string {
+	for i, p := range palettes {
+		if p.Name == name {
+			return palettes[(i+1)%len(palettes)].Name
+		}
+	}
+	return palettes[0].Name
+}
+
+// recolor sets a polygon to the given color. If the polygon is part way
+// through a fade, the fade is retargeted to the new colors instead, so
+// effects in progress carry on in the new palette.
+func recolor(polygon *geometry.PolygonObject, fadeFrom, target color.Color) {
+	if polygon.IsFading {
+		polygon.FadeStartColor = fadeFrom
+		polygon.FadeEndColor = target
+		return
+	}
+	polygon.SetColor(target)
+}
+
+// applyPalette resolves the configured palette, in the current wave's theme,
+// and recolors the fonts and every existing entity to match it straight
+// away, cutting short any change of theme in progress
+func (g *Game) applyPalette() {
+	g.themeTicks = 0
+	g.setPalette(g.targetPalette())
+}
+
+// setPalette recolors the fonts and every existing entity with p
+func (g *Game) setPalette(p Palette) {
+	g.palette = p
+	g.vectorFont.SetColor(g.palette.UI)
+	g.titleFont.SetColor(g.palette.UI)
+	for _, e := range g.entities {
+		e.ApplyPalette(&g.palette)
+	}
+}
@@ -0,0 +1,61 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestPaletteByNameFallsBackToDefault(t *testing.T) {
+	if p := paletteByName("NO SUCH PALETTE"); p.Name != palettes[0].Name {
+		t.Errorf("Expected unknown palette to fall back to %s, got %s", palettes[0].Name, p.Name)
+	}
+	if p := paletteByName("COLORBLIND"); p.Name != "COLORBLIND" {
+		t.Errorf("Expected COLORBLIND palette, got %s", p.Name)
+	}
+}
+
+func TestPaletteCyclesThroughAll(t *testing.T) {
+	name := palettes[0].Name
+	for range palettes {
+		name = nextPaletteName(name)
+	}
+	if name != palettes[0].Name {
+		t.Errorf("Expected cycling through every palette to return to %s, got %s", palettes[0].Name, name)
+	}
+}
+
+func TestPaletteFromConfig(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Palette = "COLORBLIND"
+	g := New(WithConfig(cfg))
+
+	if g.player.polygon.Color != g.palette.Player {
+		t.Errorf("Expected player to use the colorblind player color, got %v", g.player.polygon.Color)
+	}
+}
+
+func TestPaletteChangeRecolorsEntities(t *testing.T) {
+	g := New()
+	g.entities = []Entity{g.player}
+	still := placeAsteroid(g, 40, 100, 100)
+	g.splitAsteroid(placeAsteroid(g, 40, 400, 300), OwnerPlayer)
+
+	g.config.Palette = "COLORBLIND"
+	g.applyPalette()
+
+	if g.player.polygon.Color != g.palette.Player {
+		t.Errorf("Expected player to be recolored, got %v", g.player.polygon.Color)
+	}
+	if still.polygon.Color != g.palette.Asteroid {
+		t.Errorf("Expected asteroid to be recolored, got %v", still.polygon.Color)
+	}
+	for _, e := range g.entities {
+		fragment, ok := e.(*Asteroid)
+		if !ok || fragment.Dead() || fragment == still {
+			continue
+		}
+		if fragment.polygon.FadeStartColor != g.palette.FreshFragment ||
+			fragment.polygon.FadeEndColor != g.palette.Asteroid {
+			t.Errorf("Expected fragment fade to be retargeted to the new palette")
+		}
+	}
+}
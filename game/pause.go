@@ -0,0 +1,99 @@
+package game
+
+import (
+	"errors"
+)
+
+// ErrQuit is returned from Update when the player quits, from the pause
+// menu, the title screen or by closing the window. It is a normal exit
+// rather than a failure.
+var ErrQuit = errors.New("quit")
+
+// Entries on the pause menu, in display order
+const (
+	pauseResume = iota
+	pauseRestart
+	pauseSettings
+	pauseQuit
+)
+
+// pauseItems is the pause menu
+var pauseItems = []string{"RESUME", "RESTART", "SETTINGS", "QUIT"}
+
+// confirmItems is the menu asking whether to throw away the run in
+// progress. No comes first, so it is hard to restart by accident.
+var confirmItems = []string{"NO", "YES"}
+
+// pause freezes the run and opens the pause menu. Network games can't be
+// paused, as the other player's game would carry on.
+func (g *Game) pause() {
+	if g.net != nil {
+		return
+	}
+	g.state = GameStatePaused
+	g.pauseSelection = pauseResume
+	g.confirmingRestart = false
+}
+
+// updatePause handles the pause menu. Nothing in the world moves while it
+// is open.
+func (g *Game) updatePause() error {
+	g.menuTicks += g.steps
+
+	items := len(pauseItems)
+	if g.confirmingRestart {
+		items = len(confirmItems)
+	}
+	in := g.readMenu()
+	if in.Up {
+		g.pauseSelection = (g.pauseSelection + items - 1) % items
+	}
+	if in.Down {
+		g.pauseSelection = (g.pauseSelection + 1) % items
+	}
+	if in.Back {
+		if g.confirmingRestart {
+			g.confirmingRestart = false
+			g.pauseSelection = pauseRestart
+		} else {
+			g.state = GameStatePlaying
+		}
+		return nil
+	}
+	if in.Confirm {
+		return g.choosePauseItem(g.pauseSelection)
+	}
+	return nil
+}
+
+// choosePauseItem acts on the selected pause menu entry, or on the answer
+// to the restart confirmation while it is showing
+func (g *Game) choosePauseItem(item int) error {
+	if g.confirmingRestart {
+		g.confirmingRestart = false
+		if confirmItems[item] == "YES" {
+			g.Restart(RestartOptions{})
+		} else {
+			g.pauseSelection = pauseRestart
+		}
+		return nil
+	}
+
+	switch item {
+	case pauseResume:
+		g.state = GameStatePlaying
+	case pauseRestart:
+		// Only ask when there is something to lose
+		if g.score > 0 {
+			g.confirmingRestart = true
+			g.pauseSelection = 0
+			return nil
+		}
+		g.Restart(RestartOptions{})
+	case pauseSettings:
+		g.openSettings(GameStatePaused)
+	case pauseQuit:
+		return g.quit()
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+//go:build !headless
+
+package game
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// drawDim darkens everything drawn so far, so a menu stands out over it
+func (g *Game) drawDim(screen *ebiten.Image) {
+	vector.FillRect(screen, 0, 0, float32(g.screenWidth), float32(g.screenHeight), color.RGBA{A: 180}, false)
+}
+
+// drawPauseScreen draws the pause menu, or the restart confirmation, over
+// the frozen game
+func (g *Game) drawPauseScreen(screen *ebiten.Image) {
+	centerX := float32(g.screenWidth / 2)
+	centerY := float32(g.screenHeight / 2)
+
+	title, items := "PAUSED", pauseItems
+	if g.confirmingRestart {
+		title, items = "RESTART?", confirmItems
+	}
+	titleX := centerX - g.titleFont.GetWidth(title)/2
+	g.titleFont.DrawString(screen, title, titleX, centerY-140)
+
+	y := centerY - 40
+	g.drawMenu(screen, items, g.pauseSelection, y)
+	g.drawMenuBrackets(screen, items[g.pauseSelection], y+float32(g.pauseSelection)*40)
+}
+
+// drawMenuBrackets draws a pair of brackets either side of the selected
+// menu item at y, pulsing in and out
+func (g *Game) drawMenuBrackets(screen *ebiten.Image, item string, y float32) {
+	const gap = 16
+	pulse := float32(3 * (1 + math.Sin(float64(g.menuTicks)*0.15)))
+	halfWidth := g.vectorFont.GetWidth(item) / 2
+	bracketWidth := g.vectorFont.GetWidth("[")
+	centerX := float32(g.screenWidth / 2)
+	g.vectorFont.DrawString(screen, "[", centerX-halfWidth-gap-pulse-bracketWidth, y)
+	g.vectorFont.DrawString(screen, "]", centerX+halfWidth+gap+pulse, y)
+}
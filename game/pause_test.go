@@ -0,0 +1,158 @@
+package game
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// newPausedGame returns a game paused in the middle of a run
+func newPausedGame(t *testing.T) *Game {
+	t.Helper()
+	g := New()
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.pause()
+	if g.state != GameStatePaused {
+		t.Fatalf("Expected the game to pause, got state %v", g.state)
+	}
+	return g
+}
+
+func TestPauseResume(t *testing.T) {
+	g := newPausedGame(t)
+	if err := g.choosePauseItem(pauseResume); err != nil {
+		t.Fatal(err)
+	}
+	if g.state != GameStatePlaying {
+		t.Errorf("Expected play to resume, got state %v", g.state)
+	}
+}
+
+func TestPauseFreezesWorld(t *testing.T) {
+	g := newPausedGame(t)
+	g.player.polygon.SetVelocity(2, 0)
+	before := g.StateHash()
+	for i := 0; i < 10; i++ {
+		if err := g.Update(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if g.StateHash() != before {
+		t.Errorf("Expected nothing to move while paused")
+	}
+}
+
+func TestPauseChangesNothingAfter(t *testing.T) {
+	// Thrust and fire throughout, so a shot's cooldown and the dash's are
+	// part way through when the game is paused
+	inputs := script(120, map[int]InputState{})
+	for i := range inputs {
+		inputs[i] = InputState{Thrust: true, Fire: true, Right: i%20 < 5}
+	}
+	newRun := func() *Game {
+		g := New(WithSeed(1))
+		g.Restart(RestartOptions{})
+		g.startPlaying()
+		if err := Simulate(g, inputs[:60], 60); err != nil {
+			t.Fatal(err)
+		}
+		g.player.dashCooldown = dashCooldownTicks / 2
+		g.shake(3)
+		g.showPopup("CLOSE!", geometry.Vector2{X: 100, Y: 100})
+		return g
+	}
+	paused, unpaused := newRun(), newRun()
+
+	paused.pause()
+	paused.phosphorDue = false
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if err := paused.Update(); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(tickDuration)
+	}
+	paused.state = GameStatePlaying
+
+	if paused.shakeTicks != unpaused.shakeTicks || len(paused.popups) != len(unpaused.popups) ||
+		paused.popups[0].ticks != unpaused.popups[0].ticks {
+		t.Errorf("Expected the shake and popups to hold still while paused")
+	}
+	if paused.phosphorDue {
+		t.Errorf("Expected the phosphor trail to hold still while paused")
+	}
+	for _, g := range []*Game{paused, unpaused} {
+		if err := Simulate(g, inputs[60:], 60); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if paused.StateHash() != unpaused.StateHash() {
+		t.Errorf("Expected pausing to make no difference to the run")
+	}
+	if paused.player.dashCooldown != unpaused.player.dashCooldown || paused.player.lastShotTick != unpaused.player.lastShotTick {
+		t.Errorf("Expected the cooldowns to hold still while paused")
+	}
+}
+
+func TestPauseRestart(t *testing.T) {
+	// With nothing scored there is nothing to lose, so it restarts straight away
+	g := newPausedGame(t)
+	if err := g.choosePauseItem(pauseRestart); err != nil {
+		t.Fatal(err)
+	}
+	if g.state != GameStateGetReady {
+		t.Errorf("Expected an immediate restart, got state %v", g.state)
+	}
+
+	// Otherwise it asks first, and saying no goes back to the menu
+	g = newPausedGame(t)
+	g.score = 10
+	g.choosePauseItem(pauseRestart)
+	if !g.confirmingRestart || g.state != GameStatePaused {
+		t.Fatalf("Expected to be asked to confirm the restart")
+	}
+	g.choosePauseItem(0)
+	if g.confirmingRestart || g.score != 10 || g.pauseSelection != pauseRestart {
+		t.Errorf("Expected no to keep the run, with RESTART selected")
+	}
+
+	g.choosePauseItem(pauseRestart)
+	g.choosePauseItem(1)
+	if g.state != GameStateGetReady || g.score != 0 {
+		t.Errorf("Expected yes to restart, got state %v with score %d", g.state, g.score)
+	}
+}
+
+func TestPauseSettings(t *testing.T) {
+	g := newPausedGame(t)
+	g.choosePauseItem(pauseSettings)
+	if g.state != GameStateSettings || g.settingsReturn != GameStatePaused {
+		t.Fatalf("Expected the settings screen, got state %v", g.state)
+	}
+	if g.inMenu() {
+		t.Errorf("Expected the paused run to stay visible behind the settings")
+	}
+	g.closeSettings()
+	if g.state != GameStatePaused {
+		t.Errorf("Expected to go back to the pause menu, got state %v", g.state)
+	}
+}
+
+func TestPauseQuit(t *testing.T) {
+	g := newPausedGame(t)
+	if err := g.choosePauseItem(pauseQuit); !errors.Is(err, ErrQuit) {
+		t.Errorf("Expected ErrQuit, got %v", err)
+	}
+}
+
+func TestNetGamesCantPause(t *testing.T) {
+	host, _ := newNetGames(t)
+	host.startPlaying()
+	host.pause()
+	if host.state != GameStatePlaying {
+		t.Errorf("Expected a network game to ignore pausing, got state %v", host.state)
+	}
+}
@@ -0,0 +1,90 @@
+package game
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+)
+
+const (
+	// perfSmoothing is how much weight each new timing gets in the rolling
+	// averages of the performance readout
+	perfSmoothing = 0.05
+	// memStatsTicks is how often allocations are sampled for the readout.
+	// Reading them briefly stops the world, so it is only done once a second.
+	memStatsTicks = ticksPerSecond
+	// perfLineHeight is the spacing of the lines of the readout
+	perfLineHeight = 16
+)
+
+// perfStats are the measurements shown in the performance readout. They are
+// only taken while the debug overlay is on.
+type perfStats struct {
+	// update, draw and collisions are rolling averages of how long each
+	// tick, frame and collision pass takes
+	update     time.Duration
+	draw       time.Duration
+	collisions time.Duration
+	// allocsPerFrame is how many heap allocations each frame made, averaged
+	// over the last sample
+	allocsPerFrame float64
+	// sampleTicks counts down to the next allocation sample, frames counts
+	// the frames drawn since the last one, and mallocs is the running total
+	// of allocations it saw
+	sampleTicks int
+	frames      int
+	mallocs     uint64
+}
+
+// rollingAverage folds sample into the rolling average avg, starting it
+// from the first sample
+func rollingAverage(avg *time.Duration, sample time.Duration) {
+	if *avg == 0 {
+		*avg = sample
+		return
+	}
+	*avg += time.Duration(float64(sample-*avg) * perfSmoothing)
+}
+
+// timeUpdate records a tick that started at start, sampling allocations if
+// it is time to. It is deferred from the start of Update.
+func (g *Game) timeUpdate(start time.Time) {
+	rollingAverage(&g.perf.update, time.Since(start))
+	g.perf.sampleTicks--
+	if g.perf.sampleTicks > 0 {
+		return
+	}
+	g.perf.sampleTicks = memStatsTicks
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	if g.perf.mallocs != 0 && g.perf.frames > 0 {
+		g.perf.allocsPerFrame = float64(m.Mallocs-g.perf.mallocs) / float64(g.perf.frames)
+	}
+	g.perf.mallocs = m.Mallocs
+	g.perf.frames = 0
+}
+
+// timeDraw records a frame that started drawing at start. It is deferred
+// from the start of Draw.
+func (g *Game) timeDraw(start time.Time) {
+	rollingAverage(&g.perf.draw, time.Since(start))
+	g.perf.frames++
+}
+
+// millis returns d in milliseconds, for the readout
+func millis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// perfReadout returns the lines of the performance readout
+func (g *Game) perfReadout() []string {
+	return []string{
+		fmt.Sprintf("UPDATE %.2f MS", millis(g.perf.update)),
+		fmt.Sprintf("DRAW %.2f MS", millis(g.perf.draw)),
+		fmt.Sprintf("COLLISIONS %.2f MS", millis(g.perf.collisions)),
+		fmt.Sprintf("ENTITIES %d", len(g.entities)),
+		fmt.Sprintf("ASTEROIDS %d BULLETS %d", g.countEntities(TagAsteroid), g.countEntities(TagBullet)),
+		fmt.Sprintf("EFFECTS %d", g.countEntities(TagEffect)),
+		fmt.Sprintf("ALLOCS %.0f PER FRAME", g.perf.allocsPerFrame),
+	}
+}
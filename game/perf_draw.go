@@ -0,0 +1,21 @@
+//go:build !headless
+
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// drawDebugStats draws the performance readout in a column in the
+// bottom-left corner, while the debug overlay is on
+func (g *Game) drawDebugStats(screen *ebiten.Image) {
+	if !g.debugOverlay {
+		return
+	}
+	lines := g.perfReadout()
+	y := float32(g.screenHeight) - 50 - float32(len(lines)-1)*perfLineHeight
+	for _, line := range lines {
+		g.debugFont.DrawString(screen, line, 20, y)
+		y += perfLineHeight
+	}
+}
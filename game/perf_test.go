@@ -0,0 +1,62 @@
+package game
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestRollingAverage(t *testing.T) {
+	var avg time.Duration
+	rollingAverage(&avg, 10*time.Millisecond)
+	if avg != 10*time.Millisecond {
+		t.Errorf("Expected the first sample to start the average, got %v", avg)
+	}
+	rollingAverage(&avg, 30*time.Millisecond)
+	if avg <= 10*time.Millisecond || avg >= 20*time.Millisecond {
+		t.Errorf("Expected the average to move a little towards a new sample, got %v", avg)
+	}
+}
+
+// allocSink keeps the allocations in TestAllocationsSampledOncePerSecond
+// from being optimized away
+var allocSink []*int
+
+func TestAllocationsSampledOncePerSecond(t *testing.T) {
+	g := New()
+	// The first tick takes the starting sample
+	g.timeUpdate(time.Now())
+	if g.perf.mallocs == 0 || g.perf.sampleTicks != memStatsTicks {
+		t.Fatalf("Expected a first sample, got %+v", g.perf)
+	}
+
+	g.timeDraw(time.Now())
+	g.timeDraw(time.Now())
+	for range 100 {
+		allocSink = append(allocSink, new(int))
+	}
+	for range memStatsTicks - 1 {
+		g.timeUpdate(time.Now())
+	}
+	if g.perf.allocsPerFrame != 0 {
+		t.Errorf("Expected no sample before a second had passed, got %v", g.perf.allocsPerFrame)
+	}
+	g.timeUpdate(time.Now())
+	if g.perf.allocsPerFrame < 50 || g.perf.frames != 0 {
+		t.Errorf("Expected at least 50 allocations for each of the 2 frames, got %v", g.perf.allocsPerFrame)
+	}
+}
+
+func TestPerfReadoutCountsEntities(t *testing.T) {
+	g := newWeaponsGame()
+	placeAsteroid(g, 10, 100, 100)
+	placeAsteroid(g, 10, 200, 100)
+	g.spawnBullet(g.player, g.player.nose(), 0)
+
+	lines := g.perfReadout()
+	for _, want := range []string{"ENTITIES 4", "ASTEROIDS 2 BULLETS 1"} {
+		if !slices.Contains(lines, want) {
+			t.Errorf("Expected %q in the readout, got %q", want, lines)
+		}
+	}
+}
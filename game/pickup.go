@@ -0,0 +1,187 @@
+package game
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+const (
+	// pickupChance is the chance of a pickup dropping when a small asteroid
+	// is removed
+	pickupChance = 0.15
+	// pickupPoints is the score for collecting a pickup
+	pickupPoints = 500
+	// pickupLifetime is how many ticks a pickup lasts before expiring
+	pickupLifetime = 10 * ticksPerSecond
+	// pickupWarningTicks is how long before expiring a pickup starts blinking
+	pickupWarningTicks = 2 * ticksPerSecond
+	// pickupMaxSpeed is the fastest a pickup drifts, however hard the
+	// magnet pulls it
+	pickupMaxSpeed = 3.0
+	// scoreFlashTicks is how long the score flashes after collecting a pickup
+	scoreFlashTicks = ticksPerSecond / 2
+)
+
+// PickupKind is what a pickup gives the player when collected
+type PickupKind int
+
+const (
+	// PickupScore awards pickupPoints
+	PickupScore PickupKind = iota
+	// PickupTimeDilation slows asteroids down for a while
+	PickupTimeDilation
+	// PickupRearGun fires a second bullet backwards for a while
+	PickupRearGun
+	// PickupPiercing makes bullets pass through asteroids for a while
+	PickupPiercing
+	// PickupMagnet pulls nearby pickups towards the ship for a while
+	PickupMagnet
+	// PickupGhost makes the ship intangible for a while
+	PickupGhost
+	// PickupRepair restores the ship's hull. It only drops while a hull is
+	// damaged, in place of a score pickup.
+	PickupRepair
+)
+
+// pickupKinds lists the kinds of pickup that can drop, weighted by how often
+// each appears
+var pickupKinds = []PickupKind{
+	PickupScore, PickupScore, PickupScore, PickupScore,
+	PickupTimeDilation, PickupTimeDilation,
+	PickupRearGun, PickupRearGun,
+	PickupPiercing, PickupPiercing,
+	PickupMagnet, PickupMagnet,
+	PickupGhost,
+}
+
+// String returns the name of the pickup kind
+func (k PickupKind) String() string {
+	switch k {
+	case PickupScore:
+		return "SCORE"
+	case PickupTimeDilation:
+		return "TIME DILATION"
+	case PickupRearGun:
+		return "REAR GUN"
+	case PickupPiercing:
+		return "PIERCING"
+	case PickupMagnet:
+		return "MAGNET"
+	case PickupGhost:
+		return "GHOST"
+	case PickupRepair:
+		return "REPAIR"
+	}
+	return "UNKNOWN"
+}
+
+// starPoints returns how many points the pickup's star has, so each kind
+// can be told apart by shape as well as color
+func (k PickupKind) starPoints() int {
+	switch k {
+	case PickupTimeDilation:
+		return 4
+	case PickupRearGun:
+		return 3
+	case PickupPiercing:
+		return 6
+	case PickupMagnet:
+		return 7
+	case PickupGhost:
+		return 8
+	case PickupRepair:
+		return 9
+	}
+	return 5
+}
+
+// color returns the palette color for the pickup kind
+func (k PickupKind) color(p *Palette) color.RGBA {
+	switch k {
+	case PickupTimeDilation:
+		return p.Dilated
+	case PickupRearGun, PickupPiercing, PickupMagnet, PickupGhost:
+		return p.PowerUp
+	}
+	return p.Pickup
+}
+
+// Pickup is a slowly drifting star that gives the player points or a
+// power-up when the ship touches it
+type Pickup struct {
+	entityBase
+	kind         PickupKind
+	color        color.Color
+	warningColor color.Color
+}
+
+// newPickup creates a stationary star-shaped pickup of the given kind at
+// position
+func newPickup(kind PickupKind, position geometry.Vector2) *Pickup {
+	points := kind.starPoints()
+	vertices := make([]geometry.Vector2, points*2)
+	for i := range vertices {
+		radius := 8.0
+		if i%2 == 1 {
+			radius = 3.5
+		}
+		angle := float64(i) * math.Pi / float64(points)
+		vertices[i] = geometry.Vector2{X: math.Sin(angle) * radius, Y: -math.Cos(angle) * radius}
+	}
+	star := geometry.CreatePolygon(vertices)
+	star.SetPosition(position.X, position.Y)
+	star.SetRotationSpeed(0.03)
+	star.MaxSpeed = pickupMaxSpeed
+
+	return &Pickup{
+		entityBase: entityBase{polygon: star, tag: TagPickup, layer: LayerPickup, lifetime: newLifetime(pickupLifetime)},
+		kind:       kind,
+	}
+}
+
+// ApplyPalette colors the pickup
+func (p *Pickup) ApplyPalette(palette *Palette) {
+	p.color = p.kind.color(palette)
+	p.warningColor = palette.UIDim
+	p.polygon.SetColor(p.color)
+}
+
+// Update drifts the pickup, wrapping around the screen. It expires once its
+// lifetime runs out.
+func (p *Pickup) Update(ctx UpdateContext) {
+	p.polygon.Step(ctx.TimeScale, ctx.ScreenWidth, ctx.ScreenHeight)
+}
+
+// maybeDropPickup occasionally drops a random pickup at position, slowly
+// drifting in a random direction
+func (g *Game) maybeDropPickup(position geometry.Vector2) {
+	if g.rng.Float64() >= pickupChance {
+		return
+	}
+	kind := pickupKinds[g.rng.Intn(len(pickupKinds))]
+	if kind == PickupScore && g.hullDamaged() {
+		kind = PickupRepair
+	}
+	pickup := newPickup(kind, position)
+	heading := g.rng.Float64() * 2 * math.Pi
+	speed := 0.3 + g.rng.Float64()*0.3
+	pickup.polygon.SetVelocity(math.Cos(heading)*speed, math.Sin(heading)*speed)
+	g.addEntity(pickup)
+}
+
+// points returns the score for collecting the pickup
+func (p *Pickup) points() int {
+	if p.kind == PickupScore {
+		return pickupPoints
+	}
+	return 0
+}
+
+// handleScoreFlash flashes the score when a pickup is collected
+func (g *Game) handleScoreFlash(e Event) {
+	if e, ok := e.(PickupCollected); ok && e.Points > 0 && g.config.Accessibility.Flashing() {
+		g.scoreFlash = scoreFlashTicks
+	}
+}
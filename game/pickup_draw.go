@@ -0,0 +1,21 @@
+//go:build !headless
+
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Draw renders the pickup, blinking as a warning when it is about to expire.
+// If flashing is disabled it is dimmed instead.
+func (p *Pickup) Draw(screen *ebiten.Image, ctx DrawContext) {
+	remaining := p.lifetime.Remaining
+	if remaining < pickupWarningTicks {
+		if !ctx.Flashing {
+			p.polygon.SetColor(p.warningColor)
+		} else if int(remaining)/8%2 == 0 {
+			return
+		}
+	}
+	p.polygon.DrawWithOptions(screen, ctx.polygonOptions())
+}
@@ -0,0 +1,80 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+func TestPickupCollectedByPlayer(t *testing.T) {
+	g := New()
+	g.state = GameStatePlaying
+	g.entities = []Entity{g.player}
+	events := recordEvents(g)
+
+	pickup := newPickup(PickupScore, g.player.polygon.Position)
+	g.addEntity(pickup)
+	g.checkCollisions()
+	g.dispatchEvents()
+
+	if !pickup.Dead() {
+		t.Errorf("Expected the pickup to be collected")
+	}
+	if g.score != pickupPoints {
+		t.Errorf("Expected score %d, got %d", pickupPoints, g.score)
+	}
+	if len(*events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(*events))
+	}
+	if c, ok := (*events)[0].(Collision); !ok || c.A != TagPlayer || c.B != TagPickup {
+		t.Errorf("Expected a player and pickup Collision, got %#v", (*events)[0])
+	}
+	if _, ok := (*events)[1].(PickupCollected); !ok {
+		t.Errorf("Expected PickupCollected, got %T", (*events)[1])
+	}
+}
+
+func TestPickupBulletsPassThrough(t *testing.T) {
+	g := New()
+	g.state = GameStatePlaying
+	g.entities = []Entity{g.player}
+
+	pickup := newPickup(PickupScore, geometry.Vector2{X: 100, Y: 100})
+	g.addEntity(pickup)
+	bullet := newBullet(geometry.Vector2{X: 100, Y: 100}, geometry.Vector2{})
+	g.addEntity(bullet)
+	g.checkCollisions()
+
+	if pickup.Dead() || bullet.Dead() {
+		t.Errorf("Expected bullets and pickups not to interact")
+	}
+}
+
+func TestPickupExpires(t *testing.T) {
+	g := New()
+	g.state = GameStatePlaying
+	g.entities = []Entity{g.player}
+	g.wave = classicWaves // Clearing the field ends the run rather than spawning asteroids
+
+	pickup := newPickup(PickupScore, geometry.Vector2{X: 100, Y: 100})
+	pickup.polygon.SetVelocity(0, 0) // Keep it away from the player
+	g.addEntity(pickup)
+	for i := 0; i < pickupLifetime; i++ {
+		g.updatePlaying()
+	}
+
+	if g.countEntities(TagPickup) != 0 {
+		t.Errorf("Expected the pickup to expire after %d ticks", pickupLifetime)
+	}
+}
+
+func TestPickupClearedByRestart(t *testing.T) {
+	g := New()
+	g.addEntity(newPickup(PickupScore, geometry.Vector2{X: 100, Y: 100}))
+
+	g.Restart(RestartOptions{})
+
+	if g.countEntities(TagPickup) != 0 {
+		t.Errorf("Expected Restart to clear pickups")
+	}
+}
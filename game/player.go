@@ -0,0 +1,110 @@
+package game
+
+import (
+	"image/color"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// Player is the ship controlled by the user
+type Player struct {
+	entityBase
+	flame        *geometry.PolygonObject
+	accelerating bool
+
+	// hull and maxHull are the ship's hull points, when the hull mutator lets
+	// it survive collisions. maxHull is zero otherwise.
+	hull    int
+	maxHull int
+	// immuneTicks counts down the contact immunity after taking damage
+	immuneTicks float64
+	// regenTicks is the progress towards repairing the next hull point
+	regenTicks float64
+	// thrust is the fraction of its full thrust the engine gives this tick,
+	// which is less once the hull is badly damaged. dropoutTicks counts
+	// down while a sputtering engine has cut out, and wingOut is set on the
+	// ticks a damaged ship's wing flickers out.
+	thrust       float64
+	dropoutTicks int
+	wingOut      bool
+
+	// beamEnergy is how many more ticks the repulsor beam can be held for,
+	// and beaming is set while it is on
+	beamEnergy float64
+	beaming    bool
+	beamColor  color.RGBA
+
+	// dashCooldown counts down the ticks until the ship can dash again
+	dashCooldown float64
+	// lastShotTick is the survival tick the ship last fired on
+	lastShotTick int
+	// fireBufferedUntil is the last survival tick on which a fire press made
+	// while the gun wasn't ready will still fire it. Play starts on tick 1,
+	// so zero holds no press.
+	fireBufferedUntil int
+	// lastInput is the controls from the tick before, to tell when one has
+	// just been pressed
+	lastInput InputState
+	// charge is how long fire has been held towards a charged shot, and
+	// chargeColor the color of the glow on the nose while charging
+	charge      float64
+	chargeColor color.RGBA
+	// ghostTicks is how long the ship has left intangible, and shipColor
+	// the color its faint outline is drawn in meanwhile
+	ghostTicks float64
+	shipColor  color.RGBA
+	// partner marks the second ship of a network game, which is colored
+	// differently to tell the two apart
+	partner bool
+	// points and destroyed are the score and kills credited to the ship's
+	// own bullets this run
+	points    int
+	destroyed int
+	// weapons is the ship's secondary weapons and their ammo
+	weapons weaponInventory
+}
+
+// newPlayer creates the player entity from the ship outline, with an engine
+// flame, at the given position
+func newPlayer(ship *geometry.PolygonObject, x, y float64) *Player {
+	ship.SetPosition(x, y)
+
+	flame := geometry.CreatePlayerFlame(25)
+	flame.SetPosition(ship.Position.X, ship.Position.Y)
+	flame.SetRotation(ship.Rotation)
+
+	return &Player{
+		entityBase: entityBase{
+			polygon:      ship,
+			tag:          TagPlayer,
+			layer:        LayerPlayer,
+			collidesWith: LayerAsteroid | LayerPickup | LayerEnemyBullet | LayerBullet | LayerComet | LayerDebris | LayerStation,
+		},
+		flame:      flame,
+		thrust:     1,
+		beamEnergy: beamMaxEnergy,
+		weapons:    newWeaponInventory(),
+	}
+}
+
+// ApplyPalette colors the ship and its flame
+func (p *Player) ApplyPalette(palette *Palette) {
+	shipColor := palette.Player
+	if p.partner {
+		shipColor = palette.Pickup
+	}
+	recolor(p.polygon, palette.Hit, shipColor)
+	p.shipColor = shipColor
+	p.flame.SetColor(palette.Flame)
+	p.beamColor = palette.Pickup
+	p.chargeColor = palette.PowerUp
+}
+
+// Update moves the ship with wrapping and keeps the flame attached to it
+func (p *Player) Update(ctx UpdateContext) {
+	p.polygon.Step(ctx.TimeScale, ctx.ScreenWidth, ctx.ScreenHeight)
+	p.updateHull(ctx.TimeScale)
+
+	// Update player flame position and rotation to match player
+	p.flame.MatchPose(p.polygon)
+}
@@ -0,0 +1,30 @@
+//go:build !headless
+
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Draw renders the ship, plus the flame if the engine is firing and the glow
+// of a charging shot. The ship
+// blinks while it is immune to damage, if flashing is allowed, and is only
+// outlined while intangible.
+func (p *Player) Draw(screen *ebiten.Image, ctx DrawContext) {
+	if ctx.Flashing && int(p.immuneTicks)/4%2 == 1 {
+		return
+	}
+	if p.ghostTicks > 0 {
+		p.drawGhost(screen, ctx)
+	} else {
+		opts := ctx.polygonOptions()
+		if p.wingOut {
+			opts.HiddenEdges = 1 << wingEdge(p.polygon.Vertices)
+		}
+		p.polygon.DrawWithOptions(screen, opts)
+	}
+	if p.accelerating {
+		p.flame.DrawWithOptions(screen, ctx.polygonOptions())
+	}
+	p.drawChargeGlow(screen, ctx)
+}
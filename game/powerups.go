@@ -0,0 +1,77 @@
+package game
+
+import (
+	"math"
+)
+
+// powerUpTiming describes how long a timed power-up lasts
+type powerUpTiming struct {
+	kind PickupKind
+	// duration is how many ticks each pickup adds
+	duration int
+	// maxDuration caps how long stacked pickups can extend the power-up to
+	maxDuration int
+}
+
+// timedPowerUps lists every timed power-up, in the order their countdown
+// bars are shown
+var timedPowerUps = []powerUpTiming{
+	{kind: PickupTimeDilation, duration: 8 * ticksPerSecond, maxDuration: 20 * ticksPerSecond},
+	{kind: PickupRearGun, duration: 10 * ticksPerSecond, maxDuration: 20 * ticksPerSecond},
+	{kind: PickupPiercing, duration: 8 * ticksPerSecond, maxDuration: 16 * ticksPerSecond},
+	{kind: PickupMagnet, duration: 12 * ticksPerSecond, maxDuration: 24 * ticksPerSecond},
+	{kind: PickupGhost, duration: 5 * ticksPerSecond, maxDuration: 10 * ticksPerSecond},
+}
+
+// powerUpTimingFor returns the timing of a timed power-up
+func powerUpTimingFor(kind PickupKind) (powerUpTiming, bool) {
+	for _, timing := range timedPowerUps {
+		if timing.kind == kind {
+			return timing, true
+		}
+	}
+	return powerUpTiming{}, false
+}
+
+// timeDilationFactor is how fast asteroids move while time dilation is active
+const timeDilationFactor = 0.5
+
+// powerUpActive reports whether a timed power-up is currently running
+func (g *Game) powerUpActive(kind PickupKind) bool {
+	return g.powerUps[kind] > 0
+}
+
+// handlePowerUps starts or extends a timed power-up when its pickup is collected
+func (g *Game) handlePowerUps(e Event) {
+	collected, ok := e.(PickupCollected)
+	if !ok {
+		return
+	}
+	timing, ok := powerUpTimingFor(collected.Kind)
+	if !ok {
+		return
+	}
+	if g.powerUps == nil {
+		g.powerUps = make(map[PickupKind]float64)
+	}
+	remaining := g.powerUps[collected.Kind] + float64(timing.duration)
+	g.powerUps[collected.Kind] = min(remaining, float64(timing.maxDuration))
+}
+
+// updatePowerUps counts down the active power-ups in world time. Ghost
+// mode doesn't run out while a ship is inside something it would hit, and
+// is held for another tick until it isn't.
+func (g *Game) updatePowerUps(timeScale float64) {
+	for kind, remaining := range g.powerUps {
+		remaining -= timeScale
+		if remaining <= 0 && kind == PickupGhost && g.holdGhost() {
+			remaining = math.SmallestNonzeroFloat64
+		}
+		if remaining <= 0 {
+			delete(g.powerUps, kind)
+			continue
+		}
+		g.powerUps[kind] = remaining
+	}
+	g.syncGhost()
+}
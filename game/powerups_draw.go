@@ -0,0 +1,27 @@
+//go:build !headless
+
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// drawPowerUpBars draws a countdown bar for each active timed power-up,
+// centered at the top of the screen
+func (g *Game) drawPowerUpBars(screen *ebiten.Image) {
+	const width, height = 160, 6
+	x := float32(g.screenWidth/2) - width/2
+	y := float32(24)
+	for _, timing := range timedPowerUps {
+		remaining, ok := g.powerUps[timing.kind]
+		if !ok {
+			continue
+		}
+		c := timing.kind.color(&g.palette)
+		fraction := float32(remaining / float64(timing.maxDuration))
+		vector.StrokeRect(screen, x, y, width, height, 1, c, false)
+		vector.FillRect(screen, x, y, width*fraction, height, c, false)
+		y += height + 6
+	}
+}
@@ -0,0 +1,143 @@
+package game
+
+import (
+	"math"
+	"testing"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+func TestPowerUpTimeDilationSlowsAsteroids(t *testing.T) {
+	g := New()
+	g.state = GameStatePlaying
+	g.entities = []Entity{g.player}
+	// Still air, so the asteroid's speed only depends on the dilation
+	g.wind = geometry.Vector2{}
+	asteroid := placeAsteroid(g, 20, 100, 100)
+	asteroid.polygon.SetVelocity(2, 0)
+
+	g.emit(PickupCollected{Kind: PickupTimeDilation})
+	g.dispatchEvents()
+	if !g.powerUpActive(PickupTimeDilation) {
+		t.Fatalf("Expected time dilation to be active")
+	}
+
+	g.updatePlaying()
+	if math.Abs(asteroid.polygon.Position.X-(100+2*timeDilationFactor)) > 1e-9 {
+		t.Errorf("Expected the asteroid to move at reduced speed, got x=%v", asteroid.polygon.Position.X)
+	}
+	if asteroid.polygon.Velocity.X != 2 {
+		t.Errorf("Expected the asteroid's velocity to be unchanged, got %v", asteroid.polygon.Velocity.X)
+	}
+	if !asteroid.polygon.IsFading || asteroid.polygon.FadeEndColor != g.palette.Dilated {
+		t.Errorf("Expected the asteroid to fade to the dilation tint")
+	}
+
+	// Once the power-up expires the asteroid returns to full speed
+	g.powerUps[PickupTimeDilation] = 1
+	g.updatePlaying()
+	x := asteroid.polygon.Position.X
+	g.updatePlaying()
+	if math.Abs(asteroid.polygon.Position.X-(x+2)) > 1e-9 {
+		t.Errorf("Expected the asteroid to move at full speed after expiry, moved %v", asteroid.polygon.Position.X-x)
+	}
+}
+
+func TestPowerUpStackingIsCapped(t *testing.T) {
+	g := New()
+	timing, _ := powerUpTimingFor(PickupTimeDilation)
+
+	for i := 0; i < 10; i++ {
+		g.emit(PickupCollected{Kind: PickupTimeDilation})
+	}
+	g.dispatchEvents()
+
+	if g.powerUps[PickupTimeDilation] != float64(timing.maxDuration) {
+		t.Errorf("Expected stacked duration to be capped at %d, got %v", timing.maxDuration, g.powerUps[PickupTimeDilation])
+	}
+}
+
+func TestPowerUpRearGunFiresBackwards(t *testing.T) {
+	g := New()
+	g.state = GameStatePlaying
+	g.entities = []Entity{g.player}
+
+	g.emit(PickupCollected{Kind: PickupRearGun})
+	g.dispatchEvents()
+	g.createBullet(g.player)
+
+	var bullets []*Bullet
+	for _, e := range g.entities {
+		if b, ok := e.(*Bullet); ok {
+			bullets = append(bullets, b)
+		}
+	}
+	if len(bullets) != 2 {
+		t.Fatalf("Expected a front and a rear bullet, got %d", len(bullets))
+	}
+	// The ship faces up, so the front bullet goes up and the rear one down
+	if bullets[0].polygon.Velocity.Y >= 0 || bullets[1].polygon.Velocity.Y <= 0 {
+		t.Errorf("Expected bullets to fire in opposite directions, got %v and %v",
+			bullets[0].polygon.Velocity, bullets[1].polygon.Velocity)
+	}
+}
+
+func TestPowerUpRearGunRespectsBulletCap(t *testing.T) {
+	g := New()
+	g.state = GameStatePlaying
+	g.entities = []Entity{g.player}
+
+	g.emit(PickupCollected{Kind: PickupRearGun})
+	g.dispatchEvents()
+	for i := 0; i < maxActiveBullets; i++ {
+		g.createBullet(g.player)
+	}
+
+	if g.countEntities(TagBullet) != maxActiveBullets {
+		t.Errorf("Expected bullets to be capped at %d, got %d", maxActiveBullets, g.countEntities(TagBullet))
+	}
+}
+
+func TestPowerUpPiercingBullet(t *testing.T) {
+	g := New()
+	g.state = GameStatePlaying
+	g.entities = []Entity{g.player}
+
+	g.emit(PickupCollected{Kind: PickupPiercing})
+	g.dispatchEvents()
+
+	bullet := g.spawnBullet(g.player, geometry.Vector2{X: 100, Y: 100}, 0)
+	if bullet.hitsLeft != piercingHits {
+		t.Fatalf("Expected a piercing bullet with %d hits, got %d", piercingHits, bullet.hitsLeft)
+	}
+
+	for i := 0; i < piercingHits; i++ {
+		placeAsteroid(g, 20, 100, 100)
+		g.checkCollisions()
+		g.removeDeadEntities()
+		// Move on a tick so the new fragments can be hit
+		g.survivalTicks++
+	}
+
+	if !bullet.Dead() {
+		t.Errorf("Expected the bullet to be used up after %d hits", piercingHits)
+	}
+}
+
+func TestPowerUpPiercingSkipsNewFragments(t *testing.T) {
+	g := New()
+	g.state = GameStatePlaying
+	g.entities = []Entity{g.player}
+
+	bullet := g.spawnBullet(g.player, geometry.Vector2{X: 100, Y: 100}, 0)
+	bullet.makePiercing()
+	placeAsteroid(g, 40, 100, 100)
+
+	// The fragments overlap the bullet, but were created this tick
+	g.checkCollisions()
+	g.checkCollisions()
+
+	if bullet.hitsLeft != piercingHits-1 {
+		t.Errorf("Expected exactly one hit in the first tick, got %d", piercingHits-bullet.hitsLeft)
+	}
+}
@@ -0,0 +1,82 @@
+package game
+
+import (
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+const (
+	// practiceGhostTicks is how long the ship is intangible after being hit
+	// in practice mode, to get clear of whatever hit it
+	practiceGhostTicks = 2 * ticksPerSecond
+	// practiceSpawnInterval is the ticks between asteroids drifting in to
+	// top the field back up in practice mode
+	practiceSpawnInterval = endlessStartInterval
+	// predictionTicks is how far ahead the asteroids' ghosts are shown
+	predictionTicks = ticksPerSecond
+	// predictionDash is the length of each dash, and of each gap, in the
+	// predicted path of a shot
+	predictionDash = 3.0
+)
+
+// updatePractice keeps the practice field topped up, sending a new asteroid
+// in from the edge every so often while there are fewer than a classic
+// wave starts with
+func (g *Game) updatePractice() {
+	if g.countEntities(TagAsteroid) >= g.tuning.AsteroidCount {
+		return
+	}
+	g.spawnTimer--
+	if g.spawnTimer > 0 {
+		return
+	}
+	g.spawnTimer = practiceSpawnInterval
+	g.spawnAsteroidAtEdge()
+}
+
+// practiceHit makes a ship that has been hit in practice mode briefly
+// intangible, rather than ending the run
+func (g *Game) practiceHit(p *Player) {
+	if g.powerUps == nil {
+		g.powerUps = make(map[PickupKind]float64)
+	}
+	g.powerUps[PickupGhost] = max(g.powerUps[PickupGhost], practiceGhostTicks)
+	g.syncGhost()
+	g.showPopup("HIT", p.polygon.Position)
+}
+
+// predictShot returns where a shot fired from the ship's nose right now
+// would be on each tick of its flight, at normal speed, starting from where
+// it is fired. It is stepped the same way as a real bullet, so it carries
+// the ship's momentum, drifts in the wind and leaves or bounces off the
+// edges, but it doesn't stop at anything it would hit.
+func (g *Game) predictShot(p *Player) []geometry.Vector2 {
+	tip := p.nose()
+	b := g.newShipBullet(p, tip, g.assistAim(tip, p.polygon.Rotation))
+	ctx := UpdateContext{ScreenWidth: g.screenWidth, ScreenHeight: g.screenHeight, TimeScale: 1}
+	path := []geometry.Vector2{b.polygon.Position}
+	for tick := 0; ; tick++ {
+		// A real bullet is fired after the mutators have ticked, so gravity
+		// only starts pulling it down on the tick after
+		if tick > 0 && g.mutators.BulletGravity {
+			pullDown(b.polygon, ctx.TimeScale)
+		}
+		g.blow(b.polygon)
+		b.Update(ctx)
+		path = append(path, b.polygon.Position)
+		if b.Dead() || b.lifetime.tick(ctx.TimeScale) {
+			return path
+		}
+	}
+}
+
+// predictAsteroid returns the outline of an asteroid where it will be after
+// the given number of ticks at normal speed, if nothing hits it. It steps a
+// copy of the asteroid's polygon the same way the asteroid moves itself.
+func (g *Game) predictAsteroid(a *Asteroid, ticks int) geometry.DrawablePolygon {
+	future := *a.polygon
+	for range ticks {
+		g.blow(&future)
+		future.Step(1, g.screenWidth, g.screenHeight)
+	}
+	return future.TransformedVertices()
+}
@@ -0,0 +1,27 @@
+//go:build !headless
+
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// drawPractice draws the practice mode aids: the path a shot fired now
+// would take, and faint outlines of where each asteroid will be a second
+// from now
+func (g *Game) drawPractice(screen *ebiten.Image, ctx DrawContext) {
+	if g.mode != ModePractice || g.state != GameStatePlaying {
+		return
+	}
+	width := 1 + ctx.LineWidthBoost
+	ghost := fadeColor(g.palette.Asteroid, 0.25)
+	for _, e := range g.entities {
+		if a, ok := e.(*Asteroid); ok && !a.Dead() {
+			g.predictAsteroid(a, predictionTicks).Draw(screen, width, ghost)
+		}
+	}
+	p := g.localShip()
+	geometry.DrawDashedPath(screen, g.predictShot(p), predictionDash, width, fadeColor(g.palette.Bullet, 0.5))
+}
@@ -0,0 +1,115 @@
+package game
+
+import (
+	"math"
+	"testing"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// newPracticeGame starts a practice run with just the ship and one asteroid
+// out of the way in the corner, and nothing more drifting in for a while
+func newPracticeGame() *Game {
+	g := New()
+	g.mode = ModePractice
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+	g.wind = geometry.Vector2{}
+	g.spawnTimer = 10 * practiceSpawnInterval
+	placeAsteroid(g, 10, 60, 60)
+	return g
+}
+
+func TestPredictShotMatchesBullet(t *testing.T) {
+	g := newPracticeGame()
+	g.wind = geometry.Vector2{X: 0.02, Y: -0.01}
+	g.player.polygon.SetVelocity(1.5, 0.5)
+	g.player.polygon.SetRotation(0.3)
+
+	path := g.predictShot(g.player)
+	if len(path) < 2 || len(path) > bulletLifetime+1 {
+		t.Fatalf("Expected a point for each tick of the shot's flight, got %d", len(path))
+	}
+
+	tip := g.player.nose()
+	b := g.spawnBullet(g.player, tip, g.assistAim(tip, g.player.polygon.Rotation))
+	for i, want := range path {
+		if i > 0 {
+			stepTicks(t, g, 1)
+		}
+		got := b.polygon.Position
+		if math.Abs(got.X-want.X) > 1e-9 || math.Abs(got.Y-want.Y) > 1e-9 {
+			t.Fatalf("Tick %d: predicted %v, bullet at %v", i, want, got)
+		}
+		// It is gone on the last tick of the prediction
+		if last := i == len(path)-1; b.Dead() != last {
+			t.Fatalf("Tick %d: expected the bullet dead to be %v", i, last)
+		}
+	}
+}
+
+func TestPredictShotLeavesScreen(t *testing.T) {
+	g := newPracticeGame()
+	// Fired straight up from the middle, a shot crosses the top edge well
+	// within its lifetime, and the path ends once it is past the margin
+	path := g.predictShot(g.player)
+	if len(path) > bulletLifetime {
+		t.Fatalf("Expected the path to end before the shot's lifetime, got %d points", len(path))
+	}
+	for i := 1; i < len(path); i++ {
+		if path[i].Y > path[i-1].Y {
+			t.Fatalf("Expected the path to head straight up without wrapping, got %v after %v", path[i], path[i-1])
+		}
+	}
+	if last := path[len(path)-1]; last.Y > -despawnMargin {
+		t.Errorf("Expected the path to end past the top edge, got %v", last)
+	}
+}
+
+func TestPredictAsteroidLeavesAsteroidAlone(t *testing.T) {
+	g := newPracticeGame()
+	a := placeAsteroid(g, 20, 300, 300)
+	a.polygon.SetVelocity(2, -1)
+	before := a.polygon.Position
+
+	future := g.predictAsteroid(a, predictionTicks)
+	if a.polygon.Position != before {
+		t.Fatalf("Predicting moved the asteroid from %v to %v", before, a.polygon.Position)
+	}
+
+	stepTicks(t, g, predictionTicks)
+	now := a.polygon.TransformedVertices()
+	for i := range now {
+		if math.Abs(now[i].X-future[i].X) > 1e-9 || math.Abs(now[i].Y-future[i].Y) > 1e-9 {
+			t.Fatalf("Vertex %d: predicted %v, asteroid at %v", i, future[i], now[i])
+		}
+	}
+}
+
+func TestPracticeHitDoesNotEndRun(t *testing.T) {
+	g := newPracticeGame()
+
+	g.damagePlayer(g.player, "GAME OVER")
+	if g.state != GameStatePlaying {
+		t.Fatalf("Expected practice to carry on after a hit, got state %v", g.state)
+	}
+	if g.player.ghostTicks <= 0 {
+		t.Errorf("Expected the ship to be intangible after a hit")
+	}
+}
+
+func TestPracticeNoHighScore(t *testing.T) {
+	g := newPracticeGame()
+	g.score = 1000
+
+	g.endRun("GAME OVER")
+	g.dispatchEvents()
+
+	if len(g.config.HighScores) != 0 {
+		t.Errorf("Expected practice not to add a high score, got %+v", g.config.HighScores)
+	}
+	if g.records.GamesPlayed != 0 {
+		t.Errorf("Expected practice not to count towards the records")
+	}
+}
@@ -0,0 +1,73 @@
+package game
+
+import (
+	"log"
+)
+
+// midRun reports whether a run is under way, including while it is paused
+// behind a menu
+func (g *Game) midRun() bool {
+	switch g.state {
+	case GameStatePlaying, GameStateGetReady, GameStatePaused:
+		return true
+	case GameStateSettings:
+		return g.settingsReturn == GameStatePaused
+	}
+	return false
+}
+
+// requestQuit is called when the player asks to close the game. Outside a
+// run it quits straight away, but during one it asks first, unless it is
+// already asking.
+func (g *Game) requestQuit() error {
+	if !g.midRun() || g.confirmingQuit {
+		return g.quit()
+	}
+	g.confirmingQuit = true
+	return nil
+}
+
+// updateQuitPrompt waits for an answer to whether to quit mid-run. The run
+// stays frozen until the question is answered. On a gamepad, confirming
+// answers yes and going back answers no.
+func (g *Game) updateQuitPrompt() error {
+	in := g.readMenu()
+	switch {
+	case keyJustPressed(keyY), in.Confirm:
+		return g.answerQuit(true)
+	case keyJustPressed(keyN), in.Back:
+		return g.answerQuit(false)
+	}
+	return nil
+}
+
+// answerQuit either quits or goes back to the run
+func (g *Game) answerQuit(yes bool) error {
+	g.confirmingQuit = false
+	if yes {
+		return g.quit()
+	}
+	return nil
+}
+
+// quit saves everything worth keeping, including the window's state if it
+// is being remembered, and returns ErrQuit. A run in progress is saved into
+// a slot to continue later, or if it can't be, ended first, so its score
+// can still make the high scores.
+func (g *Game) quit() error {
+	if g.midRun() && !g.saveRun() {
+		g.endRun("QUIT")
+		g.dispatchEvents()
+	}
+	if g.persistWindow {
+		window := CurrentWindowState()
+		g.config.Window = &window
+	}
+	if err := g.saveConfig(); err != nil {
+		log.Printf("Saving config: %v", err)
+	}
+	if g.net != nil {
+		g.net.Close()
+	}
+	return ErrQuit
+}
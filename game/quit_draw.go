@@ -0,0 +1,18 @@
+//go:build !headless
+
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// drawQuitPrompt asks whether to quit, over the dimmed game
+func (g *Game) drawQuitPrompt(screen *ebiten.Image) {
+	if !g.confirmingQuit {
+		return
+	}
+	g.drawDim(screen)
+	const prompt = "QUIT? Y/N"
+	x := float32(g.screenWidth)/2 - g.titleFont.GetWidth(prompt)/2
+	g.titleFont.DrawString(screen, prompt, x, float32(g.screenHeight)/2-24)
+}
@@ -0,0 +1,55 @@
+package game
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestQuitOutsideRun(t *testing.T) {
+	g := New()
+	if err := g.requestQuit(); !errors.Is(err, ErrQuit) {
+		t.Errorf("Expected to quit straight from the title, got %v", err)
+	}
+}
+
+func TestQuitMidRunAsks(t *testing.T) {
+	g := New()
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	if err := g.requestQuit(); err != nil {
+		t.Fatal(err)
+	}
+	if !g.confirmingQuit {
+		t.Fatalf("Expected to be asked before quitting mid-run")
+	}
+	if err := g.answerQuit(false); err != nil || g.confirmingQuit || g.state != GameStatePlaying {
+		t.Errorf("Expected no to carry on playing, got %v in state %v", err, g.state)
+	}
+
+	// Asking to close again while the question is up quits
+	g.requestQuit()
+	if err := g.requestQuit(); !errors.Is(err, ErrQuit) {
+		t.Errorf("Expected a second close to quit, got %v", err)
+	}
+}
+
+func TestQuitSavesHighScore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	g := New(WithConfigPath(path))
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.score = 42
+	g.requestQuit()
+	if err := g.answerQuit(true); !errors.Is(err, ErrQuit) {
+		t.Fatalf("Expected ErrQuit, got %v", err)
+	}
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.HighScores) != 1 || cfg.HighScores[0].Score != 42 {
+		t.Errorf("Expected the run's score to be saved, got %+v", cfg.HighScores)
+	}
+}
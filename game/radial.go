@@ -0,0 +1,88 @@
+package game
+
+import (
+	"math"
+)
+
+const (
+	// radialTimeScale is how slow the world runs while the radial menu is
+	// open, and radialRampTicks how long time takes to slow down for it and
+	// speed back up
+	radialTimeScale = 0.3
+	radialRampTicks = 8
+	// radialRadius is how far from the ship the menu's icons sit, and
+	// radialIconSize how wide they are
+	radialRadius   = 70.0
+	radialIconSize = 20.0
+)
+
+// updateWeaponSelect runs the radial menu and secondary fire for a ship,
+// returning the controls left for flying it this tick. While select is held
+// the menu is open and the arrow keys pick out a weapon instead of steering,
+// and letting go of select chooses it.
+func (g *Game) updateWeaponSelect(p *Player, in InputState) InputState {
+	w := &p.weapons
+	pressed := in.JustPressed(p.lastInput)
+	switch {
+	case pressed.Select:
+		w.highlighted = w.selected
+		g.slowForMenu(true)
+	case !in.Select && p.lastInput.Select:
+		w.selected = w.highlighted
+		g.slowForMenu(false)
+	}
+
+	if in.Select {
+		if slot, ok := radialSlot(in); ok {
+			w.highlighted = slot
+		}
+		in.Left, in.Right, in.Thrust, in.Beam, in.Dash = false, false, false, false, false
+	}
+
+	if pressed.Secondary {
+		g.fireSecondary(p)
+	}
+	return in
+}
+
+// slowForMenu slows the world down while the radial menu is open, and
+// speeds it back up once it closes, unless something else has changed the
+// speed meanwhile. Both players of a network game have to agree on the
+// speed, so it isn't changed there.
+func (g *Game) slowForMenu(open bool) {
+	if g.net != nil {
+		return
+	}
+	if open {
+		g.SetTimeScale(radialTimeScale, radialRampTicks)
+		return
+	}
+	if g.time.target == radialTimeScale {
+		g.SetTimeScale(1, radialRampTicks)
+	}
+}
+
+// radialSlot returns the weapon whose slot on the radial menu is closest to
+// the direction the arrow keys in in point, or false if they don't point
+// anywhere
+func radialSlot(in InputState) (SecondaryWeapon, bool) {
+	var dx, dy float64
+	if in.Left {
+		dx--
+	}
+	if in.Right {
+		dx++
+	}
+	if in.Thrust {
+		dy--
+	}
+	if in.Beam {
+		dy++
+	}
+	if dx == 0 && dy == 0 {
+		return 0, false
+	}
+	step := 2 * math.Pi / float64(secondaryWeapons)
+	slot := int(math.Round(math.Atan2(dx, -dy) / step))
+	return SecondaryWeapon((slot%int(secondaryWeapons) + int(secondaryWeapons)) % int(secondaryWeapons)), true
+}
@@ -0,0 +1,59 @@
+//go:build !headless
+
+package game
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// radialSlotAngle returns the direction of a weapon's slot on the radial
+// menu, clockwise from straight up the screen
+func radialSlotAngle(w SecondaryWeapon) float64 {
+	return 2 * math.Pi * float64(w) / float64(secondaryWeapons)
+}
+
+// drawRadialMenu draws a ring of the secondary weapons around each ship
+// with its menu open, showing the ammo left for each and naming the one
+// picked out
+func (g *Game) drawRadialMenu(screen *ebiten.Image) {
+	if g.state != GameStatePlaying && g.state != GameStatePaused {
+		return
+	}
+	for _, p := range []*Player{g.player, g.partner} {
+		if p == nil || !p.lastInput.Select {
+			continue
+		}
+		center := p.polygon.Position
+		cx, cy := float32(center.X), float32(center.Y)
+		vector.StrokeCircle(screen, cx, cy, radialRadius, 1, g.palette.UIDim, true)
+
+		w := p.weapons
+		for weapon, def := range weaponDefs {
+			angle := radialSlotAngle(SecondaryWeapon(weapon))
+			x := cx + float32(math.Sin(angle)*radialRadius)
+			y := cy - float32(math.Cos(angle)*radialRadius)
+
+			c := g.palette.UI
+			if w.ammo[weapon] == 0 {
+				c = g.palette.UIDim
+			}
+			if SecondaryWeapon(weapon) == w.highlighted {
+				vector.StrokeCircle(screen, x, y, radialIconSize*0.8, 2, g.palette.PowerUp, true)
+			}
+			drawGlyph(screen, def.glyph, x, y, radialIconSize, c)
+
+			count := strconv.Itoa(w.ammo[weapon])
+			g.vectorFont.SetColor(c)
+			g.vectorFont.DrawString(screen, count, x+radialIconSize*0.8+4, y-12)
+		}
+
+		name := weaponDefs[w.highlighted].name
+		g.vectorFont.SetColor(g.palette.UI)
+		g.vectorFont.DrawString(screen, name, cx-g.vectorFont.GetWidth(name)/2, cy+radialRadius+10)
+	}
+	g.vectorFont.SetColor(g.palette.UI)
+}
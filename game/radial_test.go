@@ -0,0 +1,82 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// newWeaponsGame returns a game in play with only the ship in it, on the
+// last wave so the empty field doesn't bring on another wave and its wind
+func newWeaponsGame() *Game {
+	g := New()
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+	g.wind = geometry.Vector2{}
+	g.wave = classicWaves
+	return g
+}
+
+func TestRadialSlot(t *testing.T) {
+	tests := []struct {
+		in   InputState
+		want SecondaryWeapon
+		ok   bool
+	}{
+		{InputState{}, 0, false},
+		{InputState{Left: true, Right: true}, 0, false},
+		{InputState{Thrust: true}, WeaponMissile, true},
+		{InputState{Right: true, Beam: true}, WeaponMine, true},
+		{InputState{Left: true, Beam: true}, WeaponBomb, true},
+		{InputState{Left: true}, WeaponBomb, true},
+	}
+	for _, tt := range tests {
+		got, ok := radialSlot(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("%+v: expected %v %v, got %v %v", tt.in, tt.want, tt.ok, got, ok)
+		}
+	}
+}
+
+func TestRadialMenuSelectsOnRelease(t *testing.T) {
+	g := newWeaponsGame()
+	rotation := g.player.polygon.Rotation
+
+	g.input = InputState{Select: true}
+	stepTicks(t, g, 1)
+	if g.time.target != radialTimeScale {
+		t.Errorf("Expected time to slow while the menu is open, got a target of %v", g.time.target)
+	}
+
+	g.input = InputState{Select: true, Left: true, Beam: true}
+	stepTicks(t, g, 5)
+	if g.player.polygon.Rotation != rotation {
+		t.Errorf("Expected the arrows not to turn the ship while the menu is open")
+	}
+	if g.player.weapons.selected != WeaponMissile || g.player.weapons.highlighted != WeaponBomb {
+		t.Errorf("Expected the bomb picked out but not chosen yet, got %+v", g.player.weapons)
+	}
+
+	g.input = InputState{}
+	stepTicks(t, g, 1)
+	if g.player.weapons.selected != WeaponBomb {
+		t.Errorf("Expected letting go to choose the bomb, got %v", g.player.weapons.selected)
+	}
+	if g.time.target != 1 {
+		t.Errorf("Expected time to speed back up, got a target of %v", g.time.target)
+	}
+}
+
+func TestRadialMenuLeavesDeathSlowdown(t *testing.T) {
+	g := newWeaponsGame()
+	g.input = InputState{Select: true}
+	stepTicks(t, g, 1)
+	g.SetTimeScale(0.5, 0)
+
+	g.input = InputState{}
+	stepTicks(t, g, 1)
+	if g.time.target != 0.5 {
+		t.Errorf("Expected closing the menu to leave the speed alone, got a target of %v", g.time.target)
+	}
+}
@@ -0,0 +1,116 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+// recordingsKept is how many of the most recent runs are kept on disk
+const recordingsKept = 5
+
+// RecordingsDir returns the directory recorded runs are kept in, beside the
+// config file
+func RecordingsDir(configPath string) string {
+	return filepath.Join(filepath.Dir(configPath), "runs")
+}
+
+// recordingPath returns where the n'th most recent recording is kept,
+// counting from 1
+func recordingPath(dir string, n int) string {
+	return filepath.Join(dir, fmt.Sprintf("run-%d.json", n))
+}
+
+// WithRunRecording records every run's seed and inputs into dir, keeping
+// the last few so one that went wrong can be attached to a bug report and
+// replayed exactly
+func WithRunRecording(dir string) Option {
+	return func(g *Game) {
+		g.recordingDir = dir
+	}
+}
+
+// recordingRuns reports whether runs are being recorded, to keep on disk or
+// to save into a slot if the game is quit part way through. Network games
+// never are.
+func (g *Game) recordingRuns() bool {
+	return (g.recordingDir != "" || g.savesDir != "") && g.net == nil
+}
+
+// startRecording begins recording a new run, saving any run that was still
+// being recorded. The run must have been freshly seeded, so a replay can
+// start from its seed. skipGetReady says whether it goes straight into play.
+func (g *Game) startRecording(skipGetReady bool) {
+	if !g.recordingRuns() {
+		return
+	}
+	g.saveRecording()
+	g.recording = &RunLog{
+		Version:       RunLogVersion,
+		Seed:          g.seed,
+		Mode:          g.mode.String(),
+		Difficulty:    g.config.Difficulty,
+		FireMode:      g.config.FireMode,
+		Mutators:      g.mutators,
+		ReducedMotion: g.config.Accessibility.ReducedMotion,
+		AimAssist:     g.config.AimAssist,
+		Width:         int(g.screenWidth),
+		Height:        int(g.screenHeight),
+		SkipGetReady:  skipGetReady,
+	}
+}
+
+// handleRecording saves the run being recorded when it ends
+func (g *Game) handleRecording(e Event) {
+	if _, ok := e.(GameEnded); ok {
+		g.saveRecording()
+	}
+}
+
+// saveRecording writes out the run being recorded as the most recent, moving
+// the older ones down and dropping the oldest, if runs are being kept on
+// disk. Runs left before a single tick was played aren't worth keeping.
+func (g *Game) saveRecording() {
+	l := g.recording
+	g.recording = nil
+	if l == nil || len(l.Inputs) == 0 || g.recordingDir == "" {
+		return
+	}
+	l.Score = g.score
+	if err := saveRecording(g.recordingDir, *l); err != nil {
+		log.Printf("Saving run recording: %v", err)
+	}
+}
+
+// saveRecording rotates the recordings in dir and writes l as the newest
+func saveRecording(dir string, l RunLog) error {
+	data, err := json.Marshal(l)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	for n := recordingsKept - 1; n >= 1; n-- {
+		err := os.Rename(recordingPath(dir, n), recordingPath(dir, n+1))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return os.WriteFile(recordingPath(dir, 1), data, 0o644)
+}
+
+// LoadRunLog reads a recorded run from path
+func LoadRunLog(path string) (RunLog, error) {
+	var l RunLog
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return l, err
+	}
+	if err := json.Unmarshal(data, &l); err != nil {
+		return l, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return l, nil
+}
@@ -0,0 +1,121 @@
+package game
+
+import (
+	"os"
+	"testing"
+)
+
+// playRecordedRun plays a run with the demo AI for up to ticks ticks, ending
+// it as if quit if it is still going. It reports whether it had to quit,
+// and the state of the game after each tick.
+func playRecordedRun(t *testing.T, g *Game, ticks int) (bool, []uint64) {
+	t.Helper()
+	g.Restart(RestartOptions{})
+	var hashes []uint64
+	for i := 0; i < ticks && g.InRun(); i++ {
+		if err := g.Step(g.DemoInput()); err != nil {
+			t.Fatal(err)
+		}
+		hashes = append(hashes, g.StateHash())
+	}
+	if !g.InRun() {
+		return false, hashes
+	}
+	g.endRun("QUIT")
+	g.dispatchEvents()
+	return true, hashes
+}
+
+func TestRecordingReplays(t *testing.T) {
+	dir := t.TempDir()
+	g := New(WithSeed(4), WithRunRecording(dir))
+	g.mode = ModeClassic
+	g.mutators.DoubleSpeed = true
+	// The second run starts with the random numbers moved on by the first
+	playRecordedRun(t, g, 300)
+	quit, hashes := playRecordedRun(t, g, 2000)
+
+	l, err := LoadRunLog(recordingPath(dir, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !l.Mutators.DoubleSpeed || l.Version != RunLogVersion {
+		t.Errorf("Expected the run's settings to be recorded, got %+v", l)
+	}
+	result, err := ReplayRun(l)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A run that ended by itself has to end the same way when replayed
+	if result.Score != g.Score() || result.Score == 0 || result.Wave != g.Wave() || result.Ticks != len(l.Inputs) || result.Finished == quit {
+		t.Errorf("Expected the replay to score %d on wave %d, got %+v", g.Score(), g.Wave(), result)
+	}
+
+	// Everything has to be where it was on every tick, not just at the end
+	mode, width, height, err := checkRunLog(l)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replay := New(WithSeed(l.Seed), WithScreenSize(width, height))
+	replay.startReplay(l, mode)
+	if len(l.Inputs) != len(hashes) {
+		t.Fatalf("Expected %d ticks recorded, got %d", len(hashes), len(l.Inputs))
+	}
+	for tick, buttons := range l.Inputs {
+		if err := replay.Step(decodeButtons(buttons)); err != nil {
+			t.Fatal(err)
+		}
+		if replay.StateHash() != hashes[tick] {
+			t.Fatalf("Expected the replay to match the recording, it went its own way on tick %d", tick)
+		}
+	}
+}
+
+func TestRecordingRotation(t *testing.T) {
+	dir := t.TempDir()
+	g := New(WithSeed(1), WithRunRecording(dir))
+	for i := 0; i < recordingsKept+1; i++ {
+		playRecordedRun(t, g, 10+i)
+	}
+	for n := 1; n <= recordingsKept; n++ {
+		l, err := LoadRunLog(recordingPath(dir, n))
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The newest run is the longest
+		if want := 10 + recordingsKept + 1 - n; len(l.Inputs) != want {
+			t.Errorf("Expected run %d to be %d ticks, got %d", n, want, len(l.Inputs))
+		}
+	}
+	if _, err := os.Stat(recordingPath(dir, recordingsKept+1)); !os.IsNotExist(err) {
+		t.Errorf("Expected only %d runs to be kept, got %v", recordingsKept, err)
+	}
+}
+
+func TestRecordingReplaysInstantRetry(t *testing.T) {
+	dir := t.TempDir()
+	g := New(WithSeed(4), WithRunRecording(dir))
+	g.mode = ModeClassic
+	g.Restart(instantRetry)
+	for range 300 {
+		if err := g.Step(g.DemoInput()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	score := g.Score()
+	g.endRun("QUIT")
+	g.dispatchEvents()
+
+	l, err := LoadRunLog(recordingPath(dir, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := ReplayRun(l)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Without the countdown, the inputs would be played from the wrong tick
+	if !l.SkipGetReady || result.Score != score {
+		t.Errorf("Expected the replay without a countdown to score %d, got %+v", score, result)
+	}
+}
@@ -0,0 +1,149 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+// recordsVersion is the version of the records file format. New fields can
+// be added without changing it, as older files simply leave them at zero.
+const recordsVersion = 1
+
+// Records holds the lifetime totals, kept across every run in a file beside
+// the config
+type Records struct {
+	Version            int `json:"version"`
+	GamesPlayed        int `json:"games_played"`
+	AsteroidsDestroyed int `json:"asteroids_destroyed"`
+	ShotsFired         int `json:"shots_fired"`
+	// PlayTicks is the total time spent in runs, in ticks
+	PlayTicks int `json:"play_ticks"`
+	BestWave  int `json:"best_wave"`
+	// CloseCalls counts the asteroids shot from up close
+	CloseCalls int `json:"close_calls"`
+	// BestScores holds the best score for each difficulty played
+	BestScores map[Difficulty]int `json:"best_scores,omitempty"`
+}
+
+// runTotals counts what the player did over the whole run, to be added to
+// the records when it ends
+type runTotals struct {
+	destroyed  int
+	shots      int
+	closeCalls int
+}
+
+// RecordsPath returns where the lifetime records are kept, beside the config
+// file
+func RecordsPath(configPath string) string {
+	return filepath.Join(filepath.Dir(configPath), "records.json")
+}
+
+// LoadRecords reads the records file at path. A missing file is not an
+// error, and results in empty records. A corrupt file is moved aside to
+// path.corrupt, so it can be looked at but doesn't stop new records being
+// kept, and empty records are returned along with the error.
+func LoadRecords(path string) (Records, error) {
+	r := Records{Version: recordsVersion}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return r, nil
+	}
+	if err != nil {
+		return r, err
+	}
+	var loaded Records
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		if renameErr := os.Rename(path, path+".corrupt"); renameErr != nil {
+			return r, fmt.Errorf("parsing %s: %w, and moving it aside: %v", path, err, renameErr)
+		}
+		return r, fmt.Errorf("parsing %s, moved it to %s.corrupt: %w", path, path, err)
+	}
+	loaded.Version = recordsVersion
+	return loaded, nil
+}
+
+// Save writes the records to path, creating its directory if needed
+func (r Records) Save(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// add counts a finished run towards the records
+func (r *Records) add(difficulty Difficulty, score, wave, ticks int, totals runTotals) {
+	if difficulty == "" {
+		difficulty = DifficultyNormal
+	}
+	r.GamesPlayed++
+	r.AsteroidsDestroyed += totals.destroyed
+	r.ShotsFired += totals.shots
+	r.CloseCalls += totals.closeCalls
+	r.PlayTicks += ticks
+	r.BestWave = max(r.BestWave, wave)
+	if r.BestScores == nil {
+		r.BestScores = map[Difficulty]int{}
+	}
+	r.BestScores[difficulty] = max(r.BestScores[difficulty], score)
+}
+
+// WithRecordsPath keeps the lifetime records in the file at path, loading
+// them now and saving them at the end of every run
+func WithRecordsPath(path string) Option {
+	return func(g *Game) {
+		g.recordsPath = path
+		records, err := LoadRecords(path)
+		if err != nil {
+			log.Printf("Starting new records: %v", err)
+		}
+		g.records = records
+	}
+}
+
+// handleRecords counts the asteroids the player destroys, and adds the run
+// to the records when it ends, unless it was only practice
+func (g *Game) handleRecords(e Event) {
+	switch e := e.(type) {
+	case AsteroidDestroyed:
+		if e.ByBullet {
+			g.runTotals.destroyed++
+		}
+	case GameEnded:
+		// Practice runs don't count
+		if g.mode == ModePractice {
+			return
+		}
+		g.records.add(g.config.Difficulty, g.score, g.wave, g.survivalTicks, g.runTotals)
+		if g.recordsPath == "" {
+			return
+		}
+		if err := g.records.Save(g.recordsPath); err != nil {
+			log.Printf("Saving records: %v", err)
+		}
+	}
+}
+
+// updateRecords waits for the player to leave the records page
+func (g *Game) updateRecords() error {
+	g.updateBackground(float64(g.steps))
+	if in := g.readMenu(); in.Back || in.Confirm {
+		g.state = GameStateTitle
+	}
+	return nil
+}
+
+// formatPlayTime formats a tick count as hours, minutes and seconds
+func formatPlayTime(ticks int) string {
+	seconds := ticks / ticksPerSecond
+	return fmt.Sprintf("%d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+}
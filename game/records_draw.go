@@ -0,0 +1,42 @@
+//go:build !headless
+
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// recordsLines returns the lines shown on the records page
+func (g *Game) recordsLines() []string {
+	r := g.records
+	lines := []string{
+		fmt.Sprintf("GAMES PLAYED: %d", r.GamesPlayed),
+		fmt.Sprintf("ASTEROIDS DESTROYED: %d", r.AsteroidsDestroyed),
+		fmt.Sprintf("SHOTS FIRED: %d", r.ShotsFired),
+		"PLAY TIME: " + formatPlayTime(r.PlayTicks),
+		fmt.Sprintf("BEST WAVE: %d", r.BestWave),
+		fmt.Sprintf("CLOSE CALLS: %d", r.CloseCalls),
+	}
+	for _, d := range difficulties {
+		if score, ok := r.BestScores[d]; ok {
+			lines = append(lines, fmt.Sprintf("BEST %s: %s", strings.ToUpper(string(d)), formatScore(score)))
+		}
+	}
+	return lines
+}
+
+// drawRecordsScreen draws the lifetime records
+func (g *Game) drawRecordsScreen(screen *ebiten.Image) {
+	centerX := float32(g.screenWidth / 2)
+	centerY := float32(g.screenHeight / 2)
+
+	title := "RECORDS"
+	g.titleFont.DrawString(screen, title, centerX-g.titleFont.GetWidth(title)/2, centerY-200)
+
+	for i, line := range append(g.recordsLines(), "", "PRESS ESC TO GO BACK") {
+		g.vectorFont.DrawString(screen, line, centerX-g.vectorFont.GetWidth(line)/2, centerY-120+float32(i)*32)
+	}
+}
@@ -0,0 +1,122 @@
+package game
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestRecordsRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "records.json")
+
+	r := Records{Version: recordsVersion}
+	r.add(DifficultyHard, 120, 3, 600, runTotals{destroyed: 7, shots: 20})
+	if err := r.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadRecords(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded, r) {
+		t.Errorf("Expected %+v, got %+v", r, loaded)
+	}
+}
+
+func TestLoadRecordsMissingFile(t *testing.T) {
+	r, err := LoadRecords(filepath.Join(t.TempDir(), "records.json"))
+	if err != nil {
+		t.Fatalf("Expected no error for a missing file, got %v", err)
+	}
+	if r.GamesPlayed != 0 || r.Version != recordsVersion {
+		t.Errorf("Expected empty records, got %+v", r)
+	}
+}
+
+func TestLoadRecordsOlderFile(t *testing.T) {
+	// Written before some fields existed, and with one this build doesn't
+	// know about
+	path := filepath.Join(t.TempDir(), "records.json")
+	data := `{"version": 1, "games_played": 4, "best_wave": 2, "from_the_future": true}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	r, err := LoadRecords(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.GamesPlayed != 4 || r.BestWave != 2 || r.ShotsFired != 0 {
+		t.Errorf("Expected the known fields to load, got %+v", r)
+	}
+}
+
+func TestLoadRecordsCorruptIsQuarantined(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "records.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	g := New(WithRecordsPath(path))
+	if g.records.GamesPlayed != 0 {
+		t.Errorf("Expected fresh records, got %+v", g.records)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Expected the corrupt file to be moved away, got %v", err)
+	}
+	if data, err := os.ReadFile(path + ".corrupt"); err != nil || string(data) != "{not json" {
+		t.Errorf("Expected the corrupt file to be kept aside, got %q (%v)", data, err)
+	}
+
+	// The next run writes a good file in its place
+	g.Restart(RestartOptions{})
+	g.endRun("GAME OVER")
+	g.dispatchEvents()
+	if r, err := LoadRecords(path); err != nil || r.GamesPlayed != 1 {
+		t.Errorf("Expected the records to be recreated, got %+v (%v)", r, err)
+	}
+}
+
+func TestRecordsUpdatedAtGameOver(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "records.json")
+	g := New(WithRecordsPath(path))
+	for run, score := range []int{50, 30} {
+		g.Restart(RestartOptions{})
+		g.startPlaying()
+		g.entities = []Entity{g.player}
+		g.spawnBullet(g.player, g.player.polygon.Position, 0)
+		g.spawnBullet(g.player, g.player.polygon.Position, 0)
+		g.splitAsteroid(placeAsteroid(g, 40, 100, 100), OwnerPlayer)
+		// Ramming an asteroid doesn't count as destroying it
+		g.splitAsteroid(placeAsteroid(g, 40, 300, 100), OwnerNone)
+		g.dispatchEvents()
+		g.score = score
+		g.wave = 2 + run
+		g.survivalTicks = 60
+		g.endRun("GAME OVER")
+		g.dispatchEvents()
+	}
+
+	r, err := LoadRecords(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Records{
+		Version:            recordsVersion,
+		GamesPlayed:        2,
+		AsteroidsDestroyed: 2,
+		ShotsFired:         4,
+		PlayTicks:          120,
+		BestWave:           3,
+		BestScores:         map[Difficulty]int{DifficultyNormal: 50},
+	}
+	if !reflect.DeepEqual(r, want) {
+		t.Errorf("Expected %+v, got %+v", want, r)
+	}
+}
+
+func TestFormatPlayTime(t *testing.T) {
+	if got := formatPlayTime((3600 + 2*60 + 5) * 60); got != "1:02:05" {
+		t.Errorf("Expected 1:02:05, got %q", got)
+	}
+}
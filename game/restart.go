@@ -0,0 +1,53 @@
+package game
+
+import (
+	"math/rand"
+)
+
+// RestartSeed is what a restart does with the random numbers
+type RestartSeed int
+
+const (
+	// SeedContinue carries on from wherever the last run left the random
+	// numbers, so each run is different
+	SeedContinue RestartSeed = iota
+	// SeedKeep starts the random numbers again from the current seed, so
+	// the run lays out the same as the last one started from it
+	SeedKeep
+	// SeedFresh picks a new seed and starts the random numbers from it
+	SeedFresh
+)
+
+// RestartOptions say what a restart keeps from the game before it. The zero
+// value carries on the random numbers, keeps the mutators of the last run
+// and counts down to the start of play. The difficulty is a setting, so it
+// is always kept.
+type RestartOptions struct {
+	// Seed is what to do with the random numbers
+	Seed RestartSeed
+	// ResetMutators plays the run with the mutators chosen in the config,
+	// rather than those of the last run
+	ResetMutators bool
+	// SkipGetReady starts play straight away, without the countdown
+	SkipGetReady bool
+}
+
+// instantRetry is how a run is restarted from the game over screen: straight
+// into play with a new layout, but otherwise the same as the last run
+var instantRetry = RestartOptions{Seed: SeedFresh, SkipGetReady: true}
+
+// reseed does what a restart's options say with the random numbers. A run
+// being recorded always gets a seed of its own, since a replay starts from
+// a freshly seeded game.
+func (g *Game) reseed(seed RestartSeed) {
+	if seed == SeedContinue && g.recordingRuns() {
+		seed = SeedFresh
+	}
+	switch seed {
+	case SeedKeep:
+		g.rng = rand.New(rand.NewSource(g.seed))
+	case SeedFresh:
+		g.seed = g.rng.Int63()
+		g.rng = rand.New(rand.NewSource(g.seed))
+	}
+}
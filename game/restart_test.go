@@ -0,0 +1,119 @@
+package game
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// asteroidLayout returns where each asteroid starts
+func asteroidLayout(g *Game) []geometry.Vector2 {
+	var layout []geometry.Vector2
+	for _, e := range g.entities {
+		if e.Tag() == TagAsteroid {
+			layout = append(layout, e.Collider().Position)
+		}
+	}
+	return layout
+}
+
+func TestRestartSeed(t *testing.T) {
+	tests := []struct {
+		name        string
+		seed        RestartSeed
+		sameLayout  bool
+		seedChanges bool
+	}{
+		{"continue", SeedContinue, false, false},
+		{"keep", SeedKeep, true, false},
+		{"fresh", SeedFresh, false, true},
+	}
+	for _, tt := range tests {
+		g := New(WithSeed(7))
+		g.Restart(RestartOptions{Seed: SeedKeep})
+		first, seed := asteroidLayout(g), g.seed
+
+		g.Restart(RestartOptions{Seed: tt.seed})
+		if got := slices.Equal(first, asteroidLayout(g)); got != tt.sameLayout {
+			t.Errorf("%s: expected the same layout to be %v, got %v", tt.name, tt.sameLayout, got)
+		}
+		if got := g.seed != seed; got != tt.seedChanges {
+			t.Errorf("%s: expected the seed changing to be %v, got %v", tt.name, tt.seedChanges, got)
+		}
+	}
+}
+
+func TestRestartFreshSeedCanBeReplayed(t *testing.T) {
+	g := New(WithSeed(7))
+	g.Restart(RestartOptions{Seed: SeedFresh})
+	fresh := asteroidLayout(g)
+
+	g.Restart(RestartOptions{Seed: SeedKeep})
+	if !slices.Equal(fresh, asteroidLayout(g)) {
+		t.Errorf("Expected keeping a fresh seed to lay the run out again")
+	}
+}
+
+func TestRestartMutators(t *testing.T) {
+	for _, reset := range []bool{false, true} {
+		g := New()
+		g.config.Mutators = Mutators{Ricochet: true}
+		g.mutators = Mutators{DoubleSpeed: true}
+		g.config.Difficulty = DifficultyHard
+
+		g.Restart(RestartOptions{ResetMutators: reset})
+		want := Mutators{DoubleSpeed: true}
+		if reset {
+			want = g.config.Mutators
+		}
+		if g.mutators != want {
+			t.Errorf("Reset %v: expected mutators %+v, got %+v", reset, want, g.mutators)
+		}
+		if g.config.Difficulty != DifficultyHard {
+			t.Errorf("Reset %v: expected the difficulty to be kept, got %v", reset, g.config.Difficulty)
+		}
+	}
+}
+
+func TestRestartSkipGetReady(t *testing.T) {
+	g := New()
+	g.Restart(RestartOptions{})
+	if g.state != GameStateGetReady || g.getReadyTicks != getReadyTicks {
+		t.Errorf("Expected the countdown, got state %v with %d ticks", g.state, g.getReadyTicks)
+	}
+
+	g.Restart(RestartOptions{SkipGetReady: true})
+	if g.state != GameStatePlaying || g.getReadyTicks != 0 {
+		t.Errorf("Expected play to start straight away, got state %v with %d ticks", g.state, g.getReadyTicks)
+	}
+}
+
+func TestInstantRetry(t *testing.T) {
+	g := New(WithSeed(7))
+	g.mutators = Mutators{Ricochet: true}
+	g.config.Difficulty = DifficultyHard
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	layout, seed := asteroidLayout(g), g.seed
+	g.endRun("GAME OVER")
+
+	g.Restart(instantRetry)
+	if g.state != GameStatePlaying {
+		t.Errorf("Expected a retry to go straight into play, got state %v", g.state)
+	}
+	if g.seed == seed || slices.Equal(layout, asteroidLayout(g)) {
+		t.Errorf("Expected a retry to lay out a new field")
+	}
+	if g.mutators != (Mutators{Ricochet: true}) || g.config.Difficulty != DifficultyHard {
+		t.Errorf("Expected a retry to keep the rules, got %+v on %v", g.mutators, g.config.Difficulty)
+	}
+}
+
+func TestRestartRecordsOwnSeed(t *testing.T) {
+	g := New(WithSeed(7), WithRunRecording(t.TempDir()))
+	g.Restart(RestartOptions{})
+	if g.recording == nil || g.recording.Seed != g.seed || g.seed == 7 {
+		t.Fatalf("Expected a recorded run to get a fresh seed of its own")
+	}
+}
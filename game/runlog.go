@@ -0,0 +1,139 @@
+package game
+
+import (
+	"fmt"
+)
+
+// RunLogVersion is the version of the simulation that run logs are recorded
+// with. It must go up whenever a change alters how a run plays out, so old
+// logs are turned away rather than replaying differently.
+const RunLogVersion = 19
+
+// maxRunLogTicks is the longest run log that will be replayed, an hour of
+// play, to bound the work a submitted log can cause
+const maxRunLogTicks = 60 * 60 * ticksPerSecond
+
+// RunLog records a run: the settings it was played with and the player's
+// input on every tick. Since the simulation is deterministic, that is
+// enough to play the run again and check the score it claims.
+type RunLog struct {
+	// Version is the RunLogVersion the run was played with
+	Version    int        `json:"version"`
+	Seed       int64      `json:"seed"`
+	Mode       string     `json:"mode"`
+	Difficulty Difficulty `json:"difficulty,omitempty"`
+	// FireMode is the fire mode the run was played with, if it was set
+	FireMode FireMode `json:"fire_mode,omitempty"`
+	// Mutators are the mutators the run was played with
+	Mutators Mutators `json:"mutators"`
+	// ReducedMotion is set if the run was played without knockback
+	ReducedMotion bool `json:"reduced_motion,omitempty"`
+	// AimAssist is the aim assist strength the run was played with
+	AimAssist int `json:"aim_assist,omitempty"`
+	// Width and Height are the size of the playfield, 800x600 if unset
+	Width  int `json:"width,omitempty"`
+	Height int `json:"height,omitempty"`
+	// SkipGetReady is set if the run went straight into play, without
+	// counting down first
+	SkipGetReady bool `json:"skip_get_ready,omitempty"`
+	// Inputs holds the controls for each tick from the start of the run,
+	// packed one byte per tick as in the network protocol
+	Inputs []byte `json:"inputs"`
+	// Score is the score the run finished with, as claimed by whoever
+	// played it
+	Score int `json:"score"`
+}
+
+// Record appends the controls for the next tick to the log
+func (l *RunLog) Record(in InputState) {
+	l.Inputs = append(l.Inputs, encodeButtons(in))
+}
+
+// RunResult is how a run turned out when its log was played again
+type RunResult struct {
+	Score int `json:"score"`
+	Wave  int `json:"wave"`
+	// Ticks is how many ticks of the log were played before the run ended
+	Ticks int `json:"ticks"`
+	// Finished reports whether the run ended within the log
+	Finished bool `json:"finished"`
+}
+
+// checkRunLog returns the mode and playfield size of the run recorded in a
+// log, or why it can't be replayed
+func checkRunLog(l RunLog) (mode GameMode, width, height int, err error) {
+	if l.Version != RunLogVersion {
+		return 0, 0, 0, fmt.Errorf("run log was recorded with version %d of the game, this is version %d", l.Version, RunLogVersion)
+	}
+	mode, err = ParseGameMode(l.Mode)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	if len(l.Inputs) > maxRunLogTicks {
+		return 0, 0, 0, fmt.Errorf("run log is %d ticks long, the most that can be replayed is %d", len(l.Inputs), maxRunLogTicks)
+	}
+	width, height = l.Width, l.Height
+	if width == 0 && height == 0 {
+		width, height = 800, 600
+	}
+	if width <= 0 || height <= 0 {
+		return 0, 0, 0, fmt.Errorf("invalid playfield size %dx%d", width, height)
+	}
+	return mode, width, height, nil
+}
+
+// startReplay restarts the game as the run recorded in a log started, with
+// its settings
+func (g *Game) startReplay(l RunLog, mode GameMode) {
+	g.config.Difficulty = l.Difficulty
+	g.config.FireMode = l.FireMode
+	g.mutators = l.Mutators
+	g.config.Accessibility.ReducedMotion = l.ReducedMotion
+	g.config.AimAssist = l.AimAssist
+	g.mode = mode
+	// Recorded runs start from their seed, rather than from wherever the
+	// game left the random numbers
+	g.seed = l.Seed
+	g.Restart(RestartOptions{Seed: SeedKeep, SkipGetReady: l.SkipGetReady})
+}
+
+// replayRunLog restarts the game as the run recorded in a log started and
+// plays its inputs until they run out or the run ends, without a sound. It
+// returns how many ticks were played.
+func (g *Game) replayRunLog(l RunLog, mode GameMode) (int, error) {
+	g.replaying = true
+	defer func() { g.replaying = false }()
+	g.startReplay(l, mode)
+	ticks := 0
+	for _, buttons := range l.Inputs {
+		if !g.InRun() {
+			break
+		}
+		if err := g.Step(decodeButtons(buttons)); err != nil {
+			return ticks, err
+		}
+		ticks++
+	}
+	return ticks, nil
+}
+
+// ReplayRun plays the run recorded in a log again from the start, without
+// drawing anything, and returns how it turned out. The claimed score is
+// left for the caller to compare.
+func ReplayRun(l RunLog) (RunResult, error) {
+	mode, width, height, err := checkRunLog(l)
+	if err != nil {
+		return RunResult{}, err
+	}
+
+	g := New(WithSeed(l.Seed), WithScreenSize(width, height))
+	var result RunResult
+	result.Ticks, err = g.replayRunLog(l, mode)
+	if err != nil {
+		return result, err
+	}
+	result.Score = g.Score()
+	result.Wave = g.Wave()
+	result.Finished = !g.InRun()
+	return result, nil
+}
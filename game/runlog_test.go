@@ -0,0 +1,60 @@
+package game
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// recordDemoRun plays a run with the demo AI for up to ticks ticks,
+// recording it
+func recordDemoRun(t *testing.T, seed int64, ticks int) RunLog {
+	t.Helper()
+	g := New(WithSeed(seed))
+	g.mode = ModeClassic
+	g.rng = rand.New(rand.NewSource(seed))
+	g.Restart(RestartOptions{})
+	l := RunLog{Version: RunLogVersion, Seed: seed, Mode: "classic"}
+	for i := 0; i < ticks && g.InRun(); i++ {
+		in := g.DemoInput()
+		l.Record(in)
+		if err := g.Step(in); err != nil {
+			t.Fatal(err)
+		}
+	}
+	l.Score = g.Score()
+	return l
+}
+
+func TestReplayRun(t *testing.T) {
+	l := recordDemoRun(t, 1, 2000)
+	result, err := ReplayRun(l)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Score != l.Score || result.Score == 0 {
+		t.Errorf("Expected the replay to score %d, got %d", l.Score, result.Score)
+	}
+	if result.Ticks != len(l.Inputs) {
+		t.Errorf("Expected all %d ticks to be played, got %d", len(l.Inputs), result.Ticks)
+	}
+
+	// The same inputs from a different seed play out differently
+	l.Seed = 2
+	if other, err := ReplayRun(l); err != nil || other == result {
+		t.Errorf("Expected a different seed to change the run, got %+v (%v)", other, err)
+	}
+}
+
+func TestReplayRunErrors(t *testing.T) {
+	for _, l := range []RunLog{
+		{Version: RunLogVersion, Mode: "arcade"},
+		{Version: RunLogVersion, Mode: "classic", Width: -1, Height: 600},
+		{Version: RunLogVersion, Mode: "classic", Inputs: make([]byte, maxRunLogTicks+1)},
+		// Recorded by an older build, whose runs may play out differently
+		{Version: RunLogVersion - 1, Mode: "classic"},
+	} {
+		if _, err := ReplayRun(l); err == nil {
+			t.Errorf("Expected an error replaying %+v", l)
+		}
+	}
+}
@@ -0,0 +1,306 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// saveSlots is how many runs in progress can be saved at once
+	saveSlots = 3
+	// saveVersion is the version of the save file format. Saves of any
+	// other version, or whose runs were recorded with another
+	// RunLogVersion, can't be continued.
+	saveVersion = 1
+	// deleteHoldTicks is how long the delete key has to be held on a slot
+	// before deleting it is offered
+	deleteHoldTicks = ticksPerSecond
+	// slotSpacing is the distance between the slots on the continue screen
+	slotSpacing = 80
+)
+
+// SavedRun is a run saved part way through, to be continued from the title
+// screen. The run is kept as its log, which is replayed to pick it up where
+// it left off.
+type SavedRun struct {
+	// Version is the saveVersion the run was saved with
+	Version int       `json:"version"`
+	SavedAt time.Time `json:"saved_at"`
+	// Wave and Score are how far the run had got, to show before it is
+	// continued
+	Wave  int    `json:"wave"`
+	Score int    `json:"score"`
+	Run   RunLog `json:"run"`
+}
+
+// SavesDir returns the directory saved runs are kept in, beside the config
+// file
+func SavesDir(configPath string) string {
+	return filepath.Join(filepath.Dir(configPath), "saves")
+}
+
+// WithSaveSlots saves the run in progress into a slot in dir when the game
+// is quit, to be continued from the title screen
+func WithSaveSlots(dir string) Option {
+	return func(g *Game) {
+		g.savesDir = dir
+	}
+}
+
+// slotPath returns where a slot, counting from 0, is kept
+func slotPath(dir string, slot int) string {
+	return filepath.Join(dir, fmt.Sprintf("slot-%d.json", slot+1))
+}
+
+// slotStatus is what a save slot holds
+type slotStatus int
+
+const (
+	slotEmpty slotStatus = iota
+	// slotSaved holds a run that can be continued
+	slotSaved
+	// slotIncompatible holds a save that can't be read, or was made by a
+	// version of the game that played differently
+	slotIncompatible
+)
+
+// saveSlot is what a slot held when it was last read
+type saveSlot struct {
+	status slotStatus
+	run    SavedRun
+}
+
+// readSlot reads a slot from dir. A save that can't be continued on a
+// playfield of the given size is incompatible rather than an error, so the
+// slot can still be shown and deleted.
+func readSlot(dir string, slot, width, height int) saveSlot {
+	data, err := os.ReadFile(slotPath(dir, slot))
+	if os.IsNotExist(err) {
+		return saveSlot{}
+	}
+	var run SavedRun
+	if err == nil {
+		err = json.Unmarshal(data, &run)
+	}
+	if err != nil {
+		log.Printf("Reading save slot %d: %v", slot+1, err)
+		return saveSlot{status: slotIncompatible}
+	}
+	if run.Version != saveVersion {
+		return saveSlot{status: slotIncompatible, run: run}
+	}
+	if _, w, h, err := checkRunLog(run.Run); err != nil || w != width || h != height {
+		return saveSlot{status: slotIncompatible, run: run}
+	}
+	return saveSlot{status: slotSaved, run: run}
+}
+
+// refreshSlots reads every slot again, after one has changed
+func (g *Game) refreshSlots() {
+	if g.savesDir == "" {
+		return
+	}
+	for i := range g.slots {
+		g.slots[i] = readSlot(g.savesDir, i, int(g.screenWidth), int(g.screenHeight))
+	}
+}
+
+// latestSave returns the slot holding the most recently saved run that can
+// be continued, or -1 if there isn't one
+func (g *Game) latestSave() int {
+	latest := -1
+	for i, s := range g.slots {
+		if s.status == slotSaved && (latest < 0 || s.run.SavedAt.After(g.slots[latest].run.SavedAt)) {
+			latest = i
+		}
+	}
+	return latest
+}
+
+// chooseSlot returns the slot to save the run in progress into: the one it
+// was continued from, or else the first empty slot, or else the one saved
+// longest ago
+func (g *Game) chooseSlot() int {
+	if g.runSlot >= 0 {
+		return g.runSlot
+	}
+	oldest := 0
+	for i, s := range g.slots {
+		if s.status == slotEmpty {
+			return i
+		}
+		if s.run.SavedAt.Before(g.slots[oldest].run.SavedAt) {
+			oldest = i
+		}
+	}
+	return oldest
+}
+
+// saveRun saves the run in progress into a slot, and reports whether it
+// did. Only recorded runs can be saved, since a save is the run's log.
+func (g *Game) saveRun() bool {
+	if g.savesDir == "" || g.recording == nil || len(g.recording.Inputs) == 0 {
+		return false
+	}
+	slot := g.chooseSlot()
+	run := SavedRun{
+		Version: saveVersion,
+		SavedAt: time.Now(),
+		Wave:    g.wave,
+		Score:   g.score,
+		Run:     *g.recording,
+	}
+	run.Run.Score = g.score
+	data, err := json.Marshal(run)
+	if err == nil {
+		err = os.MkdirAll(g.savesDir, 0o755)
+	}
+	if err == nil {
+		err = os.WriteFile(slotPath(g.savesDir, slot), data, 0o644)
+	}
+	if err != nil {
+		log.Printf("Saving the run: %v", err)
+		return false
+	}
+	g.runSlot = slot
+	g.slots[slot] = saveSlot{status: slotSaved, run: run}
+	// Keep the recording of the run so far too, if runs are being kept
+	g.saveRecording()
+	return true
+}
+
+// deleteSlot throws away the run saved in a slot
+func (g *Game) deleteSlot(slot int) {
+	if err := os.Remove(slotPath(g.savesDir, slot)); err != nil && !os.IsNotExist(err) {
+		log.Printf("Deleting save slot %d: %v", slot+1, err)
+	}
+	g.slots[slot] = saveSlot{}
+}
+
+// handleSaves deletes the save of a continued run once it ends, as there is
+// nothing left to continue
+func (g *Game) handleSaves(e Event) {
+	if _, ok := e.(GameEnded); ok && g.runSlot >= 0 {
+		g.deleteSlot(g.runSlot)
+		g.runSlot = -1
+	}
+}
+
+// continueSlot picks up the run saved in a slot where it left off, by
+// replaying it silently from the start, and pauses it so the player can get
+// their bearings. The run carries on with the settings it was started with.
+func (g *Game) continueSlot(slot int) error {
+	s := g.slots[slot]
+	if s.status != slotSaved {
+		return nil
+	}
+	mode, _, _, err := checkRunLog(s.run.Run)
+	if err != nil {
+		return err
+	}
+	if _, err := g.replayRunLog(s.run.Run, mode); err != nil {
+		return err
+	}
+	if !g.InRun() {
+		// The run played out differently to when it was saved
+		g.slots[slot].status = slotIncompatible
+		g.state = GameStateSlots
+		return nil
+	}
+	g.runSlot = slot
+	g.pause()
+	return nil
+}
+
+// openSlots shows the continue screen, with the slots freshly read
+func (g *Game) openSlots() {
+	g.refreshSlots()
+	g.state = GameStateSlots
+	g.slotSelection = max(0, g.latestSave())
+	g.deleteHeld = 0
+	g.confirmingDelete = false
+}
+
+// deleteKeyHeld reports whether either of the keys that delete a slot is
+// held down
+func deleteKeyHeld() bool {
+	return keyPressed(keyDelete) || keyPressed(keyBackspace)
+}
+
+// updateSlots handles the continue screen: picking a slot to continue, or
+// holding delete on one and confirming to throw it away
+func (g *Game) updateSlots() error {
+	g.updateBackground(float64(g.steps))
+	g.menuTicks += g.steps
+	in := g.readMenu()
+	if g.confirmingDelete {
+		g.updateDeleteConfirm(in)
+		return nil
+	}
+
+	if in.Up {
+		g.slotSelection = (g.slotSelection + saveSlots - 1) % saveSlots
+		g.deleteHeld = 0
+	}
+	if in.Down {
+		g.slotSelection = (g.slotSelection + 1) % saveSlots
+		g.deleteHeld = 0
+	}
+	if in.Back {
+		g.state = GameStateTitle
+		return nil
+	}
+	if in.Confirm {
+		return g.continueSlot(g.slotSelection)
+	}
+	g.holdDelete(deleteKeyHeld())
+	return nil
+}
+
+// holdDelete counts how long delete has been held on a slot with something
+// in it, asking whether to delete it once it has been held long enough
+func (g *Game) holdDelete(held bool) {
+	if !held || g.slots[g.slotSelection].status == slotEmpty {
+		g.deleteHeld = 0
+		return
+	}
+	g.deleteHeld++
+	if g.deleteHeld >= deleteHoldTicks {
+		g.deleteHeld = 0
+		g.confirmingDelete = true
+		g.deleteSelection = 0
+	}
+}
+
+// updateDeleteConfirm waits for an answer to whether to delete the selected
+// slot
+func (g *Game) updateDeleteConfirm(in MenuActions) {
+	if in.Up || in.Down {
+		g.deleteSelection = 1 - g.deleteSelection
+	}
+	switch {
+	case in.Back:
+		g.confirmingDelete = false
+	case in.Confirm:
+		g.confirmingDelete = false
+		if confirmItems[g.deleteSelection] == "YES" {
+			g.deleteSlot(g.slotSelection)
+		}
+	}
+}
+
+// label returns the two lines describing a slot on the continue screen:
+// how far its run had got, and when it was saved
+func (s saveSlot) label() (progress, saved string) {
+	switch s.status {
+	case slotEmpty:
+		return "EMPTY", ""
+	case slotIncompatible:
+		return "INCOMPATIBLE", ""
+	}
+	return fmt.Sprintf("WAVE %d  %s", s.run.Wave, formatScore(s.run.Score)), s.run.SavedAt.Local().Format("2006/01/02 15:04")
+}
@@ -0,0 +1,58 @@
+//go:build !headless
+
+package game
+
+import (
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/AndreRenaud/SpaceDebris/vectorfont"
+)
+
+// drawSlotsScreen draws the continue screen: each slot's progress and when
+// it was saved, with the selected slot highlighted, or the question of
+// whether to delete it
+func (g *Game) drawSlotsScreen(screen *ebiten.Image) {
+	centerX := float32(g.screenWidth / 2)
+	centerY := float32(g.screenHeight / 2)
+	font := g.vectorFont
+
+	if g.confirmingDelete {
+		title := fmt.Sprintf("DELETE SLOT %d?", g.slotSelection+1)
+		g.titleFont.DrawStringAligned(screen, title, centerX, centerY-140, vectorfont.AlignCenter)
+		g.drawMenu(screen, confirmItems, g.deleteSelection, centerY-40)
+		g.drawMenuBrackets(screen, confirmItems[g.deleteSelection], centerY-40+float32(g.deleteSelection)*40)
+		return
+	}
+
+	g.titleFont.DrawStringAligned(screen, "CONTINUE", centerX, centerY-200, vectorfont.AlignCenter)
+	left, right := centerX-280, centerX+280
+	for i, s := range g.slots {
+		y := centerY - 110 + float32(i)*slotSpacing
+		if i == g.slotSelection {
+			font.SetColor(g.palette.UI)
+		} else {
+			font.SetColor(g.palette.UIDim)
+		}
+		progress, saved := s.label()
+		font.DrawStringAligned(screen, fmt.Sprintf("SLOT %d", i+1), left, y, vectorfont.AlignLeft)
+		font.DrawStringAligned(screen, progress, right, y, vectorfont.AlignRight)
+		if saved != "" {
+			font.SetColor(g.palette.UIDim)
+			font.DrawStringAligned(screen, saved, right, y+32, vectorfont.AlignRight)
+		}
+	}
+	font.SetColor(g.palette.UI)
+
+	// A bar under the selected slot fills while delete is held on it
+	if g.deleteHeld > 0 {
+		y := centerY - 110 + float32(g.slotSelection)*slotSpacing + 64
+		width := (right - left) * float32(g.deleteHeld) / deleteHoldTicks
+		vector.StrokeLine(screen, left, y, left+width, y, 2, g.palette.UI, false)
+	}
+
+	font.DrawStringAligned(screen, "ENTER TO CONTINUE", centerX, float32(g.screenHeight)-90, vectorfont.AlignCenter)
+	font.DrawStringAligned(screen, "HOLD DEL TO DELETE", centerX, float32(g.screenHeight)-50, vectorfont.AlignCenter)
+}
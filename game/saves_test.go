@@ -0,0 +1,171 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+// playSavedRun plays the first ticks of a classic run with the demo AI, in
+// a game saving into dir, and quits it
+func playSavedRun(t *testing.T, dir string, ticks int) *Game {
+	t.Helper()
+	g := New(WithSeed(7), WithSaveSlots(dir))
+	g.mode = ModeClassic
+	g.Restart(RestartOptions{})
+	for range ticks {
+		if err := g.Step(g.DemoInput()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !g.InRun() {
+		t.Fatalf("Expected the run to still be going after %d ticks", ticks)
+	}
+	return g
+}
+
+func TestSaveOnQuitAndContinue(t *testing.T) {
+	dir := t.TempDir()
+	g := playSavedRun(t, dir, 400)
+	events := recordEvents(g)
+	if err := g.quit(); !errors.Is(err, ErrQuit) {
+		t.Fatalf("Expected to quit, got %v", err)
+	}
+	for _, e := range *events {
+		if _, ok := e.(GameEnded); ok {
+			t.Errorf("Expected a saved run not to be ended")
+		}
+	}
+
+	speaker := &countingSpeaker{}
+	resumed := New(WithSaveSlots(dir), WithSpeaker(speaker))
+	slot := resumed.slots[0]
+	if slot.status != slotSaved || slot.run.Wave != g.Wave() || slot.run.Score != g.Score() {
+		t.Fatalf("Expected the run saved on wave %d with %d points, got %+v", g.Wave(), g.Score(), slot)
+	}
+	if err := resumed.continueSlot(0); err != nil {
+		t.Fatal(err)
+	}
+	if resumed.state != GameStatePaused || resumed.runSlot != 0 {
+		t.Fatalf("Expected the run to carry on paused, got state %d", resumed.state)
+	}
+	if len(speaker.played) != 0 {
+		t.Errorf("Expected catching up with the run to be silent, played %d sounds", len(speaker.played))
+	}
+	resumed.state = GameStatePlaying
+	if resumed.StateHash() != g.StateHash() {
+		t.Errorf("Expected the run to pick up exactly where it was saved")
+	}
+}
+
+func TestSaveDeletedWhenRunEnds(t *testing.T) {
+	dir := t.TempDir()
+	if err := playSavedRun(t, dir, 100).quit(); !errors.Is(err, ErrQuit) {
+		t.Fatal(err)
+	}
+	g := New(WithSaveSlots(dir))
+	if err := g.continueSlot(0); err != nil {
+		t.Fatal(err)
+	}
+	g.endRun("GAME OVER")
+	g.dispatchEvents()
+	if _, err := os.Stat(slotPath(dir, 0)); !os.IsNotExist(err) {
+		t.Errorf("Expected the save to be deleted once its run ended, got %v", err)
+	}
+	if g.slots[0].status != slotEmpty {
+		t.Errorf("Expected the slot to show as empty")
+	}
+}
+
+func TestIncompatibleSaves(t *testing.T) {
+	dir := t.TempDir()
+	write := func(slot int, data []byte) {
+		if err := os.WriteFile(slotPath(dir, slot), data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	old, err := json.Marshal(SavedRun{Version: saveVersion, Run: RunLog{Version: RunLogVersion - 1, Mode: "classic", Inputs: []byte{0}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	write(0, old)
+	write(1, []byte("{not json"))
+
+	g := New(WithSaveSlots(dir))
+	for slot, want := range []slotStatus{slotIncompatible, slotIncompatible, slotEmpty} {
+		if g.slots[slot].status != want {
+			t.Errorf("Slot %d: expected status %d, got %d", slot+1, want, g.slots[slot].status)
+		}
+	}
+	if progress, _ := g.slots[0].label(); progress != "INCOMPATIBLE" {
+		t.Errorf("Expected an incompatible save to say so, got %q", progress)
+	}
+	// Continuing one does nothing
+	if err := g.continueSlot(0); err != nil || g.state != GameStateTitle {
+		t.Errorf("Expected an incompatible save not to be continued, got state %d and %v", g.state, err)
+	}
+}
+
+func TestChooseSlot(t *testing.T) {
+	g := New()
+	now := time.Now()
+	g.slots[0] = saveSlot{status: slotSaved, run: SavedRun{SavedAt: now}}
+	if slot := g.chooseSlot(); slot != 1 {
+		t.Errorf("Expected the first empty slot, got %d", slot)
+	}
+	g.slots[1] = saveSlot{status: slotSaved, run: SavedRun{SavedAt: now.Add(-time.Hour)}}
+	g.slots[2] = saveSlot{status: slotIncompatible}
+	if slot := g.chooseSlot(); slot != 2 {
+		t.Errorf("Expected the incompatible slot, as the oldest, got %d", slot)
+	}
+	g.runSlot = 0
+	if slot := g.chooseSlot(); slot != 0 {
+		t.Errorf("Expected a continued run to go back in its own slot, got %d", slot)
+	}
+	if latest := g.latestSave(); latest != 0 {
+		t.Errorf("Expected slot 0 to be the latest save, got %d", latest)
+	}
+}
+
+func TestHoldDeleteAsksFirst(t *testing.T) {
+	dir := t.TempDir()
+	if err := playSavedRun(t, dir, 10).quit(); !errors.Is(err, ErrQuit) {
+		t.Fatal(err)
+	}
+	g := New(WithSaveSlots(dir))
+	g.openSlots()
+
+	// Letting go early starts again
+	for range deleteHoldTicks - 1 {
+		g.holdDelete(true)
+	}
+	g.holdDelete(false)
+	if g.deleteHeld != 0 || g.confirmingDelete {
+		t.Fatalf("Expected letting go of delete to start the hold again")
+	}
+	for range deleteHoldTicks {
+		g.holdDelete(true)
+	}
+	if !g.confirmingDelete {
+		t.Fatalf("Expected holding delete to ask before deleting")
+	}
+
+	// No is picked first
+	g.updateDeleteConfirm(MenuActions{Confirm: true})
+	if g.slots[0].status != slotSaved {
+		t.Fatalf("Expected answering no to keep the save")
+	}
+	for range deleteHoldTicks {
+		g.holdDelete(true)
+	}
+	g.updateDeleteConfirm(MenuActions{Down: true})
+	g.updateDeleteConfirm(MenuActions{Confirm: true})
+	if g.slots[0].status != slotEmpty {
+		t.Errorf("Expected answering yes to delete the save")
+	}
+	if _, err := os.Stat(slotPath(dir, 0)); !os.IsNotExist(err) {
+		t.Errorf("Expected the save file to be removed, got %v", err)
+	}
+}
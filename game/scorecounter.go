@@ -0,0 +1,86 @@
+package game
+
+import (
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+const (
+	// scoreCountTicks is roughly how long the displayed score takes to count
+	// up to a new value
+	scoreCountTicks = ticksPerSecond * 3 / 10
+	// scorePulseTicks is how long the score pulses for when it goes up
+	scorePulseTicks = ticksPerSecond * 3 / 10
+)
+
+// scoreCountRate is the fraction of the remaining distance the displayed
+// score covers each tick, so it is within 5% after scoreCountTicks
+var scoreCountRate = 1 - math.Pow(0.05, 1.0/scoreCountTicks)
+
+// formatScore writes a score with commas between each group of thousands,
+// so big scores can be read at a glance
+func formatScore(score int) string {
+	digits := strconv.Itoa(score)
+	sign := ""
+	if score < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(d)
+	}
+	return sign + b.String()
+}
+
+// scoreCounter is the score as drawn on the HUD, which counts up to the real
+// score rather than jumping to it
+type scoreCounter struct {
+	displayed float64
+	// target is the real score as of the last update
+	target int
+	// pulse counts down the ticks left of the color pulse
+	pulse int
+}
+
+// update moves the displayed score towards score, starting a pulse if the
+// score has gone up. A score that goes down, as on a restart, is shown
+// straight away.
+func (s *scoreCounter) update(score int, pulse bool) {
+	if score > s.target && pulse {
+		s.pulse = scorePulseTicks
+	} else if s.pulse > 0 {
+		s.pulse--
+	}
+	s.target = score
+
+	if float64(score) < s.displayed {
+		s.displayed = float64(score)
+		return
+	}
+	s.displayed += (float64(score) - s.displayed) * scoreCountRate
+	if float64(score)-s.displayed < 0.5 {
+		s.displayed = float64(score)
+	}
+}
+
+// value returns the score to draw
+func (s *scoreCounter) value() int {
+	return int(math.Round(s.displayed))
+}
+
+// color returns the score's color, fading from base to highlight and back
+// over the pulse
+func (s *scoreCounter) color(base, highlight color.Color) color.Color {
+	if s.pulse <= 0 {
+		return base
+	}
+	progress := float64(s.pulse) / scorePulseTicks
+	return geometry.InterpolateColor(base, highlight, 1-math.Abs(2*progress-1))
+}
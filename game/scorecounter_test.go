@@ -0,0 +1,73 @@
+package game
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestScoreCounterCountsUp(t *testing.T) {
+	var s scoreCounter
+	s.update(100, true)
+	if got := s.value(); got <= 0 || got >= 100 {
+		t.Errorf("Expected the score to start counting up, shown %d", got)
+	}
+
+	ticks := 1
+	for ; s.value() != 100 && ticks < 100; ticks++ {
+		s.update(100, true)
+	}
+	if ticks < scoreCountTicks/2 || ticks > scoreCountTicks*2 {
+		t.Errorf("Expected the count to take about %d ticks, took %d", scoreCountTicks, ticks)
+	}
+
+	// A restart shows the lower score straight away
+	s.update(0, true)
+	if s.value() != 0 {
+		t.Errorf("Expected the reset score to show immediately, shown %d", s.value())
+	}
+}
+
+func TestScoreCounterPulse(t *testing.T) {
+	white := color.RGBA{255, 255, 255, 255}
+	yellow := color.RGBA{255, 255, 0, 255}
+
+	var s scoreCounter
+	s.update(1, true)
+	for i := 0; i < scorePulseTicks/2; i++ {
+		s.update(1, true)
+	}
+	if c := s.color(white, yellow).(color.RGBA); c.B > 50 {
+		t.Errorf("Expected the score to be yellow mid-pulse, got %v", c)
+	}
+	for i := 0; i < scorePulseTicks; i++ {
+		s.update(1, true)
+	}
+	if c := s.color(white, yellow); c != color.Color(white) {
+		t.Errorf("Expected the score to be white after the pulse, got %v", c)
+	}
+
+	// No pulse when flashing is turned off
+	s.update(2, false)
+	if s.pulse != 0 {
+		t.Errorf("Expected no pulse without flashing")
+	}
+}
+
+func TestFormatScore(t *testing.T) {
+	for _, test := range []struct {
+		score int
+		want  string
+	}{
+		{0, "0"},
+		{999, "999"},
+		{1000, "1,000"},
+		{1240, "1,240"},
+		{123456, "123,456"},
+		{1234567, "1,234,567"},
+		{-1500, "-1,500"},
+	} {
+		if got := formatScore(test.score); got != test.want {
+			t.Errorf("formatScore(%d) = %q, expected %q", test.score, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package game
+
+// sequenceStep is one timed step of a scripted sequence
+type sequenceStep struct {
+	// ticks is how long the step lasts
+	ticks int
+	// update is called on each tick of the step, if set, with how many of
+	// its ticks have passed including this one
+	update func(tick int)
+	// skip finishes the step straight away, if set, when the sequence is
+	// skipped. It is called for steps that haven't started as well.
+	skip func()
+}
+
+// sequence plays a queue of timed steps one after another, for scripted
+// moments such as the end of wave tally. It can be skipped to the end.
+type sequence struct {
+	steps []sequenceStep
+	// tick is how many ticks of the first step have passed
+	tick int
+}
+
+// add queues a step at the end of the sequence
+func (s *sequence) add(step sequenceStep) {
+	s.steps = append(s.steps, step)
+}
+
+// then queues a call at the end of the sequence, made on a tick of its own
+// or when the sequence is skipped
+func (s *sequence) then(f func()) {
+	s.add(sequenceStep{ticks: 1, update: func(int) { f() }, skip: f})
+}
+
+// running reports whether the sequence has steps left to play
+func (s *sequence) running() bool {
+	return len(s.steps) > 0
+}
+
+// update plays the next tick of the sequence
+func (s *sequence) update() {
+	if !s.running() {
+		return
+	}
+	s.tick++
+	step := s.steps[0]
+	if step.update != nil {
+		step.update(s.tick)
+	}
+	if s.tick >= step.ticks {
+		s.steps = s.steps[1:]
+		s.tick = 0
+	}
+}
+
+// skip finishes every step left straight away, in order
+func (s *sequence) skip() {
+	steps := s.steps
+	s.clear()
+	for _, step := range steps {
+		if step.skip != nil {
+			step.skip()
+		}
+	}
+}
+
+// clear drops the sequence without finishing it
+func (s *sequence) clear() {
+	s.steps = nil
+	s.tick = 0
+}
@@ -0,0 +1,48 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSequencePlaysStepsInOrder(t *testing.T) {
+	var s sequence
+	var log []int
+	s.add(sequenceStep{ticks: 2, update: func(tick int) { log = append(log, tick) }})
+	s.add(sequenceStep{ticks: 1})
+	s.add(sequenceStep{ticks: 3, update: func(tick int) { log = append(log, 10+tick) }})
+	done := false
+	s.then(func() { done = true })
+
+	for i := 0; i < 6; i++ {
+		s.update()
+	}
+	if want := []int{1, 2, 11, 12, 13}; !reflect.DeepEqual(log, want) {
+		t.Errorf("Expected the steps to tick %v, got %v", want, log)
+	}
+	if done || !s.running() {
+		t.Fatalf("Expected the final call to wait for its own tick")
+	}
+	s.update()
+	if !done || s.running() {
+		t.Errorf("Expected the sequence to finish with its call")
+	}
+}
+
+func TestSequenceSkip(t *testing.T) {
+	var s sequence
+	var skipped []string
+	s.add(sequenceStep{ticks: 5, skip: func() { skipped = append(skipped, "first") }})
+	s.add(sequenceStep{ticks: 5})
+	s.add(sequenceStep{ticks: 5, skip: func() { skipped = append(skipped, "second") }})
+	s.then(func() { skipped = append(skipped, "then") })
+
+	s.update()
+	s.skip()
+	if want := []string{"first", "second", "then"}; !reflect.DeepEqual(skipped, want) {
+		t.Errorf("Expected %v to be finished, got %v", want, skipped)
+	}
+	if s.running() {
+		t.Errorf("Expected nothing left after skipping")
+	}
+}
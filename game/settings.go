@@ -0,0 +1,170 @@
+package game
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// setting is a single option shown on the settings screen
+type setting struct {
+	name string
+	// value returns the option's current value as displayed
+	value func(c *Config) string
+	// change moves the option on to its next value
+	change func(c *Config)
+}
+
+// boolSetting creates an on/off setting for the given config field
+func boolSetting(name string, field func(c *Config) *bool) setting {
+	return setting{
+		name: name,
+		value: func(c *Config) string {
+			if *field(c) {
+				return "ON"
+			}
+			return "OFF"
+		},
+		change: func(c *Config) {
+			*field(c) = !*field(c)
+		},
+	}
+}
+
+// settingsVisible is how many rows of the settings screen are shown at
+// once. The list scrolls to keep the selection among them.
+const settingsVisible = 7
+
+// settingsList is every option on the settings screen, in display order
+var settingsList = []setting{
+	boolSetting("REDUCED MOTION", func(c *Config) *bool { return &c.Accessibility.ReducedMotion }),
+	boolSetting("HIGH CONTRAST", func(c *Config) *bool { return &c.Accessibility.HighContrast }),
+	{
+		name:   "PALETTE",
+		value:  func(c *Config) string { return paletteByName(c.Palette).Name },
+		change: func(c *Config) { c.Palette = nextPaletteName(paletteByName(c.Palette).Name) },
+	},
+	{
+		name: "WAVE THEMES",
+		value: func(c *Config) string {
+			if c.FixedPalette {
+				return "OFF"
+			}
+			return "ON"
+		},
+		change: func(c *Config) { c.FixedPalette = !c.FixedPalette },
+	},
+	{
+		name: "FIRE MODE",
+		value: func(c *Config) string {
+			if c.FireMode == "" {
+				return "BY MODE"
+			}
+			return strings.ToUpper(string(c.FireMode))
+		},
+		change: func(c *Config) {
+			switch c.FireMode {
+			case "":
+				c.FireMode = FireAuto
+			case FireAuto:
+				c.FireMode = FireSemi
+			default:
+				c.FireMode = ""
+			}
+		},
+	},
+	{
+		name: "AIM ASSIST",
+		value: func(c *Config) string {
+			if c.AimAssist == 0 {
+				return "OFF"
+			}
+			return fmt.Sprintf("%d DEG", c.AimAssist)
+		},
+		change: func(c *Config) { c.AimAssist = nextAimAssist(c.AimAssist) },
+	},
+	boolSetting("FRIENDLY FIRE", func(c *Config) *bool { return &c.FriendlyFire }),
+	boolSetting("MOMENTUM", func(c *Config) *bool { return &c.MomentumIndicator }),
+	volumeSetting("MASTER VOLUME", func(v *Volumes) *int { return &v.Master }),
+	volumeSetting("SFX VOLUME", func(v *Volumes) *int { return v.channel(ChannelSFX) }),
+	volumeSetting("UI VOLUME", func(v *Volumes) *int { return v.channel(ChannelUI) }),
+	volumeSetting("MUSIC VOLUME", func(v *Volumes) *int { return v.channel(ChannelMusic) }),
+	{
+		name:   "TICK RATE",
+		value:  func(c *Config) string { return tickRateLabel(c.tickRate()) },
+		change: func(c *Config) { c.TickRate = nextTickRate(c.tickRate()) },
+	},
+	{
+		name: "WINDOW TITLE",
+		value: func(c *Config) string {
+			if c.PlainWindowTitle {
+				return "PLAIN"
+			}
+			return "LIVE"
+		},
+		change: func(c *Config) { c.PlainWindowTitle = !c.PlainWindowTitle },
+	},
+}
+
+// updateSettings handles navigating and toggling the settings
+func (g *Game) updateSettings() error {
+	// A paused run stays frozen behind the settings
+	if g.settingsReturn == GameStateTitle {
+		g.updateBackground(float64(g.steps))
+	}
+	g.menuTicks += g.steps
+
+	items := len(settingsList) + 1
+	in := g.readMenu()
+	if in.Up {
+		g.settingsSelection = (g.settingsSelection + items - 1) % items
+	}
+	if in.Down {
+		g.settingsSelection = (g.settingsSelection + 1) % items
+	}
+	if in.Back {
+		g.closeSettings()
+		return nil
+	}
+	if !in.Confirm {
+		return nil
+	}
+
+	if g.settingsSelection == len(settingsList) {
+		g.closeSettings()
+		return nil
+	}
+	g.toggleSetting(g.settingsSelection)
+	return nil
+}
+
+// openSettings shows the settings screen, which goes back to the given
+// screen when it is left
+func (g *Game) openSettings(from GameState) {
+	g.settingsSelection = 0
+	g.settingsReturn = from
+	g.state = GameStateSettings
+}
+
+// closeSettings goes back to the screen the settings were opened from
+func (g *Game) closeSettings() {
+	g.state = g.settingsReturn
+}
+
+// toggleSetting changes an option, applies it and saves the config
+func (g *Game) toggleSetting(index int) {
+	settingsList[index].change(&g.config)
+	g.applyAccessibility()
+	g.applyPalette()
+	g.applyTickRate()
+	if err := g.saveConfig(); err != nil {
+		log.Printf("Saving config: %v", err)
+	}
+}
+
+// settingsScroll returns the first row of the settings screen to show, so
+// the selected one is shown, as near the middle as the ends of the list
+// allow
+func settingsScroll(selected, rows int) int {
+	return max(0, min(selected-settingsVisible/2, rows-settingsVisible))
+}
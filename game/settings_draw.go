@@ -0,0 +1,44 @@
+//go:build !headless
+
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/AndreRenaud/SpaceDebris/vectorfont"
+)
+
+// settingsItems returns the settings screen menu text, ending with BACK
+func (g *Game) settingsItems() []string {
+	items := make([]string, 0, len(settingsList)+1)
+	for _, s := range settingsList {
+		items = append(items, s.name+": "+s.value(&g.config))
+	}
+	return append(items, "BACK")
+}
+
+// drawSettingsScreen draws the list of settings
+func (g *Game) drawSettingsScreen(screen *ebiten.Image) {
+	centerX := float32(g.screenWidth / 2)
+	centerY := float32(g.screenHeight / 2)
+
+	title := "SETTINGS"
+	titleX := centerX - g.titleFont.GetWidth(title)/2
+	g.titleFont.DrawString(screen, title, titleX, centerY-140)
+
+	items := g.settingsItems()
+	first := settingsScroll(g.settingsSelection, len(items))
+	last := min(first+settingsVisible, len(items))
+	top := centerY - 40
+	g.drawMenu(screen, items[first:last], g.settingsSelection-first, top)
+
+	// Show there is more of the list above or below
+	g.vectorFont.SetColor(g.palette.UIDim)
+	if first > 0 {
+		g.vectorFont.DrawStringAligned(screen, "...", centerX, top-40, vectorfont.AlignCenter)
+	}
+	if last < len(items) {
+		g.vectorFont.DrawStringAligned(screen, "...", centerX, top+settingsVisible*40, vectorfont.AlignCenter)
+	}
+	g.vectorFont.SetColor(g.palette.UI)
+}
@@ -0,0 +1,171 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// Shape roles decide what a custom shape is used for
+const (
+	// ShapeRolePlayer replaces the built-in player ship. The ship points
+	// towards -Y, with its nose at the first vertex.
+	ShapeRolePlayer = "player"
+	// ShapeRoleAsteroid adds a template to the asteroids that start a run
+	ShapeRoleAsteroid = "asteroid"
+)
+
+// Shape is a polygon defined in a shapes file
+type Shape struct {
+	Name     string       `json:"name"`
+	Role     string       `json:"role"`
+	Vertices [][2]float64 `json:"vertices"`
+	// Scale and LineWidth default to 1 when left out
+	Scale     float64 `json:"scale,omitempty"`
+	LineWidth float32 `json:"line_width,omitempty"`
+}
+
+// Shapes is the set of custom shapes loaded from a shapes file
+type Shapes struct {
+	Shapes []Shape `json:"shapes"`
+}
+
+// ShapesPath returns where the shapes file lives, next to the config file
+func ShapesPath(configPath string) string {
+	return filepath.Join(filepath.Dir(configPath), "shapes.json")
+}
+
+// LoadShapes reads and validates the shapes file at path. A missing file is
+// not an error, and results in no custom shapes.
+func LoadShapes(path string) (Shapes, error) {
+	var shapes Shapes
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return shapes, nil
+	}
+	if err != nil {
+		return shapes, err
+	}
+	if err := json.Unmarshal(data, &shapes); err != nil {
+		return Shapes{}, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if err := shapes.Validate(); err != nil {
+		return Shapes{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return shapes, nil
+}
+
+// Save writes the shapes to path
+func (s Shapes) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// Validate checks every shape, returning an error naming each bad one
+func (s Shapes) Validate() error {
+	var errs []error
+	players := 0
+	for i, shape := range s.Shapes {
+		name := shape.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", i+1)
+			errs = append(errs, fmt.Errorf("shape %s has no name", name))
+		}
+		switch shape.Role {
+		case ShapeRolePlayer:
+			players++
+		case ShapeRoleAsteroid:
+		default:
+			errs = append(errs, fmt.Errorf("shape %q has unknown role %q", name, shape.Role))
+		}
+		if len(shape.Vertices) < 3 {
+			errs = append(errs, fmt.Errorf("shape %q has %d vertices, at least 3 are needed", name, len(shape.Vertices)))
+		} else if !geometry.IsSimplePolygon(shape.vertices()) {
+			errs = append(errs, fmt.Errorf("shape %q has edges that cross each other", name))
+		}
+		if shape.Scale < 0 || shape.LineWidth < 0 {
+			errs = append(errs, fmt.Errorf("shape %q has a negative scale or line width", name))
+		}
+	}
+	if players > 1 {
+		errs = append(errs, fmt.Errorf("%d shapes have the %q role, only one is allowed", players, ShapeRolePlayer))
+	}
+	return errors.Join(errs...)
+}
+
+// vertices converts the shape's outline to geometry vectors
+func (s Shape) vertices() []geometry.Vector2 {
+	vertices := make([]geometry.Vector2, len(s.Vertices))
+	for i, v := range s.Vertices {
+		vertices[i] = geometry.Vector2{X: v[0], Y: v[1]}
+	}
+	return vertices
+}
+
+// Polygon creates a new polygon object with the shape's outline and defaults
+func (s Shape) Polygon() *geometry.PolygonObject {
+	polygon := geometry.CreatePolygon(s.vertices())
+	if s.Scale > 0 {
+		polygon.SetScale(s.Scale)
+	}
+	if s.LineWidth > 0 {
+		polygon.LineWidth = s.LineWidth
+	}
+	return polygon
+}
+
+// player returns the custom player shape, if there is one
+func (s Shapes) player() (Shape, bool) {
+	for _, shape := range s.Shapes {
+		if shape.Role == ShapeRolePlayer {
+			return shape, true
+		}
+	}
+	return Shape{}, false
+}
+
+// asteroids returns the custom asteroid templates
+func (s Shapes) asteroids() []Shape {
+	var templates []Shape
+	for _, shape := range s.Shapes {
+		if shape.Role == ShapeRoleAsteroid {
+			templates = append(templates, shape)
+		}
+	}
+	return templates
+}
+
+// WithShapes starts the game with custom shapes, which should already have
+// been validated (eg: by LoadShapes)
+func WithShapes(shapes Shapes) Option {
+	return func(g *Game) {
+		g.shapes = shapes
+	}
+}
+
+// newShipPolygon creates the player's ship, using the custom design if
+// there is one
+func (g *Game) newShipPolygon() *geometry.PolygonObject {
+	if shape, ok := g.shapes.player(); ok {
+		return shape.Polygon()
+	}
+	return geometry.CreatePlayer(20)
+}
+
+// randomAsteroidShape creates a new asteroid outline, either one of the
+// custom templates or a randomly generated one, with equal chance
+func (g *Game) randomAsteroidShape() *geometry.PolygonObject {
+	templates := g.shapes.asteroids()
+	if i := g.rng.Intn(len(templates) + 1); i < len(templates) {
+		return templates[i].Polygon()
+	}
+	return generateAsteroidShape(g.rng, g.wave)
+}
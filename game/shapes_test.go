@@ -0,0 +1,81 @@
+package game
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestShapesRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "shapes.json")
+
+	shapes := Shapes{Shapes: []Shape{
+		{Name: "dart", Role: ShapeRolePlayer, Vertices: [][2]float64{{0, -20}, {-10, 10}, {10, 10}}, LineWidth: 2},
+		{Name: "cube", Role: ShapeRoleAsteroid, Vertices: [][2]float64{{-20, -20}, {20, -20}, {20, 20}, {-20, 20}}, Scale: 1.5},
+	}}
+	if err := shapes.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadShapes(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded, shapes) {
+		t.Errorf("Expected %+v, got %+v", shapes, loaded)
+	}
+}
+
+func TestLoadShapesMissingFile(t *testing.T) {
+	shapes, err := LoadShapes(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Fatalf("Expected no error for a missing file, got %v", err)
+	}
+	if len(shapes.Shapes) != 0 {
+		t.Errorf("Expected no shapes, got %+v", shapes)
+	}
+}
+
+func TestLoadShapesExample(t *testing.T) {
+	shapes, err := LoadShapes(filepath.Join("..", "shapes.example.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := shapes.player(); !ok {
+		t.Errorf("Expected the example to define a player shape")
+	}
+	if len(shapes.asteroids()) == 0 {
+		t.Errorf("Expected the example to define asteroid templates")
+	}
+}
+
+func TestShapesValidateRejectsBadShapes(t *testing.T) {
+	shapes := Shapes{Shapes: []Shape{
+		{Name: "line", Role: ShapeRoleAsteroid, Vertices: [][2]float64{{0, 0}, {10, 0}}},
+		{Name: "bowtie", Role: ShapeRoleAsteroid, Vertices: [][2]float64{{0, 0}, {10, 10}, {10, 0}, {0, 10}}},
+		{Name: "fine", Role: ShapeRoleAsteroid, Vertices: [][2]float64{{0, 0}, {10, 0}, {0, 10}}},
+	}}
+
+	err := shapes.Validate()
+	if err == nil {
+		t.Fatal("Expected invalid shapes to be rejected")
+	}
+	for _, name := range []string{`"line"`, `"bowtie"`} {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("Expected error to name shape %s, got %v", name, err)
+		}
+	}
+	if strings.Contains(err.Error(), `"fine"`) {
+		t.Errorf("Expected valid shape not to be mentioned, got %v", err)
+	}
+}
+
+func TestShapesOverridePlayer(t *testing.T) {
+	dart := Shape{Name: "dart", Role: ShapeRolePlayer, Vertices: [][2]float64{{0, -20}, {-10, 10}, {10, 10}}}
+	g := New(WithShapes(Shapes{Shapes: []Shape{dart}}))
+
+	if len(g.player.polygon.Vertices) != len(dart.Vertices) {
+		t.Errorf("Expected the custom player ship, got %d vertices", len(g.player.polygon.Vertices))
+	}
+}
@@ -0,0 +1,80 @@
+package game
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+)
+
+// Step advances a run by one tick with the given input, without drawing
+// anything or reading the keyboard. Ticks spent counting down to the start
+// of play ignore their input, and once the run is over it does nothing.
+//
+// Everything the simulation does flows from the game's seed and the inputs
+// it is stepped with, so two games created with the same seed and stepped
+// with the same inputs stay identical, as reported by StateHash.
+func (g *Game) Step(input InputState) error {
+	if g.recording != nil {
+		g.recording.Record(input)
+	}
+	switch g.state {
+	case GameStateGetReady:
+		return g.updateGetReady()
+	case GameStatePlaying:
+		g.input = input
+		return g.updatePlaying()
+	}
+	return nil
+}
+
+// Simulate advances a game in play by up to ticks updates without drawing
+// anything or reading the keyboard. inputs[i] is the player's input on the
+// i'th tick, and once the inputs run out the controls are left untouched.
+// Ticks spent counting down to the start of play ignore their input. It
+// stops early if the run ends.
+func Simulate(g *Game, inputs []InputState, ticks int) error {
+	for i := 0; i < ticks && g.InRun(); i++ {
+		var input InputState
+		if i < len(inputs) {
+			input = inputs[i]
+		}
+		if err := g.Step(input); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StateHash returns a hash of the simulation's state: the position, velocity
+// and rotation of everything in the world, along with the score and the
+// progress of the run. Games that have stayed in step have the same hash.
+func (g *Game) StateHash() uint64 {
+	h := fnv.New64a()
+	var buf [8]byte
+	writeInt := func(v int) {
+		binary.LittleEndian.PutUint64(buf[:], uint64(v))
+		h.Write(buf[:])
+	}
+	writeFloat := func(v float64) {
+		binary.LittleEndian.PutUint64(buf[:], math.Float64bits(v))
+		h.Write(buf[:])
+	}
+
+	writeInt(int(g.state))
+	writeInt(g.score)
+	writeInt(g.wave)
+	writeInt(g.survivalTicks)
+	for _, e := range g.entities {
+		writeInt(int(e.Tag()))
+		p := e.Collider()
+		if p == nil {
+			continue
+		}
+		writeFloat(p.Position.X)
+		writeFloat(p.Position.Y)
+		writeFloat(p.Velocity.X)
+		writeFloat(p.Velocity.Y)
+		writeFloat(p.Rotation)
+	}
+	return h.Sum64()
+}
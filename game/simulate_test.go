@@ -0,0 +1,164 @@
+package game
+
+import (
+	"math/rand"
+	"runtime"
+	"testing"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// script builds an input sequence of the given length, with each step
+// applied from its starting tick until the next step
+func script(ticks int, steps map[int]InputState) []InputState {
+	inputs := make([]InputState, ticks)
+	for i := range inputs {
+		inputs[i] = steps[i]
+	}
+	return inputs
+}
+
+func TestSimulateScriptedGame(t *testing.T) {
+	g := New()
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+	// The bullets have to fly straight to hit
+	g.wind = geometry.Vector2{}
+	// On the last wave, so clearing it wins the run
+	g.wave = classicWaves
+
+	// Three stationary asteroids above, right of and below the ship, each
+	// small enough to be destroyed by a single shot
+	ship := g.player.polygon.Position
+	for _, offset := range []geometry.Vector2{{X: 0, Y: -150}, {X: 150, Y: 0}, {X: 0, Y: 150}} {
+		placeAsteroid(g, 12, ship.X+offset.X, ship.Y+offset.Y)
+	}
+
+	// Shoot, turn a quarter circle to the right, and repeat
+	steps := map[int]InputState{10: {Fire: true}, 30: {Fire: true}, 50: {Fire: true}}
+	for i := 11; i < 27; i++ {
+		steps[i] = InputState{Right: true}
+		steps[i+20] = InputState{Right: true}
+	}
+	if err := Simulate(g, script(60, steps), 600); err != nil {
+		t.Fatal(err)
+	}
+
+	if g.state != GameStateGameOver || g.gameOverReason != "YOU WIN!" {
+		t.Fatalf("Expected the wave to be cleared, state %v reason %q", g.state, g.gameOverReason)
+	}
+	// A point for each asteroid, and a bonus for not missing
+	if want := 3 + accuracyBonus(100); g.score != want {
+		t.Errorf("Expected a final score of %d, got %d", want, g.score)
+	}
+}
+
+func TestSimulateStopsWhenRunEnds(t *testing.T) {
+	g := New()
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+	ship := g.player.polygon.Position
+	placeAsteroid(g, 30, ship.X, ship.Y)
+
+	if err := Simulate(g, nil, 100); err != nil {
+		t.Fatal(err)
+	}
+	if g.survivalTicks != 1 {
+		t.Errorf("Expected the simulation to stop after the fatal tick, ran %d", g.survivalTicks)
+	}
+}
+
+func TestStepDeterministic(t *testing.T) {
+	const steps = 10000
+	a, b := New(WithSeed(42)), New(WithSeed(42))
+	a.Restart(RestartOptions{})
+	b.Restart(RestartOptions{})
+
+	// Random but repeatable inputs, held for a few ticks at a time
+	inputs := rand.New(rand.NewSource(7))
+	var input InputState
+	for i := 0; i < steps; i++ {
+		if i%10 == 0 {
+			input = InputState{
+				Left:   inputs.Intn(3) == 0,
+				Right:  inputs.Intn(3) == 0,
+				Thrust: inputs.Intn(2) == 0,
+				Fire:   inputs.Intn(2) == 0,
+				Beam:   inputs.Intn(8) == 0,
+				Dash:   inputs.Intn(20) == 0,
+			}
+		}
+		// Keep playing new runs, as the random pilot doesn't last long
+		if !a.InRun() {
+			a.Restart(RestartOptions{})
+			b.Restart(RestartOptions{})
+		}
+		if err := a.Step(input); err != nil {
+			t.Fatal(err)
+		}
+		if err := b.Step(input); err != nil {
+			t.Fatal(err)
+		}
+		if a.StateHash() != b.StateHash() {
+			t.Fatalf("Games diverged at step %d", i)
+		}
+	}
+}
+
+func TestStateHashChanges(t *testing.T) {
+	g := New(WithSeed(1))
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	before := g.StateHash()
+	if g.StateHash() != before {
+		t.Fatalf("Expected the hash to be stable")
+	}
+	if err := g.Step(InputState{Thrust: true}); err != nil {
+		t.Fatal(err)
+	}
+	if g.StateHash() == before {
+		t.Errorf("Expected the hash to change as the game moves")
+	}
+}
+
+// BenchmarkSimulateEndless simulates 10,000 ticks of endless mode with a
+// crowded field, the ship spinning and firing constantly
+func BenchmarkSimulateEndless(b *testing.B) {
+	const ticks = 10000
+	inputs := make([]InputState, ticks)
+	for i := range inputs {
+		inputs[i] = InputState{Right: i%120 < 60, Left: i%120 >= 60, Fire: true}
+	}
+
+	var mallocs uint64
+	var stats runtime.MemStats
+	for n := 0; n < b.N; n++ {
+		b.StopTimer()
+		g := New()
+		g.mode = ModeEndless
+		g.Restart(RestartOptions{})
+		g.startPlaying()
+		for i := 0; i < 300; i++ {
+			g.spawnAsteroidAtEdge()
+		}
+		// Let the ship survive the whole run
+		g.player.collidesWith = 0
+		runtime.ReadMemStats(&stats)
+		before := stats.Mallocs
+		b.StartTimer()
+
+		if err := Simulate(g, inputs, ticks); err != nil {
+			b.Fatal(err)
+		}
+
+		b.StopTimer()
+		runtime.ReadMemStats(&stats)
+		mallocs += stats.Mallocs - before
+		b.StartTimer()
+	}
+
+	b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*ticks), "ns/tick")
+	b.ReportMetric(float64(mallocs)/float64(b.N*ticks), "allocs/tick")
+}
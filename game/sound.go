@@ -0,0 +1,147 @@
+package game
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Speaker plays sounds made by the game
+type Speaker interface {
+	// Play starts playing pcm, 16 bit little-endian stereo at
+	// soundSampleRate, mixed over anything already playing. Its samples
+	// are scaled by volume, from 0 for silent to 1 for as they are.
+	Play(pcm []byte, volume float64)
+}
+
+// WithSpeaker plays the game's sounds through s, at the volumes in the
+// config, unless it mutes them
+func WithSpeaker(s Speaker) Option {
+	return func(g *Game) {
+		g.speaker = s
+	}
+}
+
+const (
+	// explosionBucketSize is the range of asteroid radii, in pixels, that
+	// share an explosion sound
+	explosionBucketSize = 10
+	// explosionBuckets is how many sizes of explosion there are. Anything
+	// bigger than the last uses its sound.
+	explosionBuckets = 8
+	// explosionBaseDuration and explosionDurationPerPixel set how long an
+	// explosion lasts, in seconds, growing with the asteroid's radius
+	explosionBaseDuration     = 0.15
+	explosionDurationPerPixel = 0.012
+	// explosionMaxCutoff is the low-pass cutoff, in hertz, for the
+	// smallest explosions. It halves by the time the radius reaches
+	// explosionCutoffRadius, so bigger rocks sound lower.
+	explosionMaxCutoff    = 8000.0
+	explosionCutoffRadius = 8.0
+	// maxExplosionVoices is the most explosions that play at once. Any more
+	// are dropped until one finishes.
+	maxExplosionVoices = 4
+	// explosionPeak is the loudest sample of an explosion, as a fraction of
+	// full scale, low enough that every voice at its peak together can't
+	// clip
+	explosionPeak = 1.0 / maxExplosionVoices
+	// impactSoundSteps is how many degrees of impact energy each size of
+	// explosion is synthesized at
+	impactSoundSteps = 4
+	// impactMinVolume and impactMinPitch are the volume and pitch of an
+	// explosion from the softest impact, relative to the hardest's. Harder
+	// hits are louder and higher.
+	impactMinVolume = 0.4
+	impactMinPitch  = 0.8
+)
+
+// explosionBucket returns which explosion sound an asteroid of the given
+// radius makes
+func explosionBucket(radius float64) int {
+	return max(0, min(explosionBuckets-1, int(radius/explosionBucketSize)))
+}
+
+// explosionDuration returns how long, in seconds, the explosion of an
+// asteroid of the given radius lasts
+func explosionDuration(radius float64) float64 {
+	return explosionBaseDuration + radius*explosionDurationPerPixel
+}
+
+// synthesizeExplosion makes the sound of an asteroid of the given radius
+// exploding: noise that dies away and is filtered, bigger rocks booming
+// lower and for longer than small ones crack. It is the same every time for
+// a given radius.
+func synthesizeExplosion(radius float64) []float64 {
+	duration := explosionDuration(radius)
+	rng := rand.New(rand.NewSource(int64(radius)))
+	samples := whiteNoise(rng, int(duration*soundSampleRate))
+	// Down to well under 1% by the end
+	decayEnvelope(samples, duration/5)
+	lowPass(samples, explosionMaxCutoff*explosionCutoffRadius/(explosionCutoffRadius+radius))
+	normalize(samples, explosionPeak)
+	return samples
+}
+
+// impactSoundStep returns which degree of impact an explosion from the
+// given impact energy sounds like. Asteroids destroyed without being hit,
+// such as by a bomb, sound like the hardest.
+func impactSoundStep(energy float64) int {
+	if energy == 0 {
+		return impactSoundSteps - 1
+	}
+	return min(impactSoundSteps-1, int(impactLevel(energy)*impactSoundSteps))
+}
+
+// synthesizeImpactExplosion makes the sound of an asteroid of the given
+// radius exploding from an impact of the given step, from impactSoundStep:
+// the explosion played back quieter and lower for softer impacts
+func synthesizeImpactExplosion(radius float64, step int) []float64 {
+	hardness := float64(step) / (impactSoundSteps - 1)
+	samples := resample(synthesizeExplosion(radius), impactMinPitch+(1-impactMinPitch)*hardness)
+	normalize(samples, explosionPeak*(impactMinVolume+(1-impactMinVolume)*hardness))
+	return samples
+}
+
+// explosionSound returns the sound of an asteroid of the given radius
+// exploding from an impact of the given energy, synthesizing it the first
+// time its size and step of impact are heard
+func (g *Game) explosionSound(radius, energy float64) []byte {
+	key := explosionBucket(radius)*impactSoundSteps + impactSoundStep(energy)
+	if pcm, ok := g.explosionSounds[key]; ok {
+		return pcm
+	}
+	if g.explosionSounds == nil {
+		g.explosionSounds = map[int][]byte{}
+	}
+	// Each bucket sounds like an asteroid in the middle of it
+	radius = (float64(explosionBucket(radius)) + 0.5) * explosionBucketSize
+	pcm := toPCM(synthesizeImpactExplosion(radius, impactSoundStep(energy)))
+	g.explosionSounds[key] = pcm
+	return pcm
+}
+
+// handleExplosionSounds plays an explosion whenever an asteroid is
+// destroyed, louder and higher the harder it was hit, while there is a
+// voice free for it
+func (g *Game) handleExplosionSounds(e Event) {
+	destroyed, ok := e.(AsteroidDestroyed)
+	if !ok || !g.audible(ChannelSFX) || len(g.explosionVoices) >= maxExplosionVoices {
+		return
+	}
+	pcm := g.explosionSound(destroyed.Size, destroyed.Energy)
+	// Each sample is 4 bytes, 2 for each channel
+	seconds := float64(len(pcm)/4) / soundSampleRate
+	g.explosionVoices = append(g.explosionVoices, int(math.Ceil(seconds*ticksPerSecond)))
+	g.play(ChannelSFX, pcm)
+}
+
+// updateSounds counts down the explosions playing, freeing the voices of
+// those that have finished
+func (g *Game) updateSounds() {
+	playing := g.explosionVoices[:0]
+	for _, ticks := range g.explosionVoices {
+		if ticks > 1 {
+			playing = append(playing, ticks-1)
+		}
+	}
+	g.explosionVoices = playing
+}
@@ -0,0 +1,110 @@
+package game
+
+import (
+	"math"
+	"testing"
+)
+
+// countingSpeaker counts the sounds it is asked to play, and the volume
+// each is played at
+type countingSpeaker struct {
+	played  [][]byte
+	volumes []float64
+}
+
+func (s *countingSpeaker) Play(pcm []byte, volume float64) {
+	s.played = append(s.played, pcm)
+	s.volumes = append(s.volumes, volume)
+}
+
+func TestExplosionScalesWithSize(t *testing.T) {
+	small := synthesizeExplosion(10)
+	big := synthesizeExplosion(60)
+	for _, test := range []struct {
+		radius  float64
+		samples []float64
+	}{{10, small}, {60, big}} {
+		if want := int(explosionDuration(test.radius) * soundSampleRate); len(test.samples) != want {
+			t.Errorf("Radius %v: expected %d samples, got %d", test.radius, want, len(test.samples))
+		}
+		if peak := peakLevel(test.samples); math.Abs(peak-explosionPeak) > 1e-9 {
+			t.Errorf("Radius %v: expected a peak of %v, got %v", test.radius, explosionPeak, peak)
+		}
+	}
+	if len(big) <= len(small) {
+		t.Errorf("Expected a big rock to boom for longer than a small one cracks")
+	}
+}
+
+func TestExplosionSoundCached(t *testing.T) {
+	g := New()
+	a := g.explosionSound(21, 0)
+	b := g.explosionSound(28, 0)
+	if &a[0] != &b[0] {
+		t.Errorf("Expected asteroids in the same size bucket to share a sound")
+	}
+	if c := g.explosionSound(45, 0); &c[0] == &a[0] {
+		t.Errorf("Expected a bigger asteroid to have its own sound")
+	}
+	if len(g.explosionSounds) != 2 {
+		t.Errorf("Expected 2 sounds synthesized, got %d", len(g.explosionSounds))
+	}
+	// Huge rocks all use the biggest sound
+	if explosionBucket(1000) != explosionBuckets-1 {
+		t.Errorf("Expected huge rocks to use the last bucket, got %d", explosionBucket(1000))
+	}
+}
+
+func TestExplosionScalesWithImpact(t *testing.T) {
+	soft := synthesizeImpactExplosion(25, impactSoundStep(10))
+	hard := synthesizeImpactExplosion(25, impactSoundStep(10*impactHalfEnergy))
+	if peakLevel(soft) >= peakLevel(hard) {
+		t.Errorf("Expected a soft impact to be quieter, got peaks %v and %v", peakLevel(soft), peakLevel(hard))
+	}
+	// Played back slower, so lower and longer
+	if len(soft) <= len(hard) {
+		t.Errorf("Expected a soft impact to be lower, got %d and %d samples", len(soft), len(hard))
+	}
+	if impactSoundStep(0) != impactSoundSteps-1 {
+		t.Errorf("Expected an explosion without an impact to sound like the hardest")
+	}
+
+	g := New()
+	if a, b := g.explosionSound(25, 10), g.explosionSound(25, 0); &a[0] == &b[0] {
+		t.Errorf("Expected each step of impact to have its own sound")
+	}
+}
+
+func TestExplosionPolyphonyCap(t *testing.T) {
+	speaker := &countingSpeaker{}
+	g := New(WithSpeaker(speaker))
+	for range 20 {
+		g.emit(AsteroidDestroyed{Size: 10})
+	}
+	g.dispatchEvents()
+	if len(speaker.played) != maxExplosionVoices {
+		t.Fatalf("Expected only %d explosions to play at once, got %d", maxExplosionVoices, len(speaker.played))
+	}
+
+	// Once they have finished, there is room for more
+	ticks := int(math.Ceil(explosionDuration((0.5+float64(explosionBucket(10)))*explosionBucketSize) * ticksPerSecond))
+	for range ticks {
+		g.updateSounds()
+	}
+	g.emit(AsteroidDestroyed{Size: 10})
+	g.dispatchEvents()
+	if len(speaker.played) != maxExplosionVoices+1 {
+		t.Errorf("Expected a free voice once the explosions finished, got %d played", len(speaker.played))
+	}
+}
+
+func TestExplosionMuted(t *testing.T) {
+	speaker := &countingSpeaker{}
+	g := New(WithSpeaker(speaker))
+	g.config.Muted = true
+	g.emit(AsteroidDestroyed{Size: 10})
+	g.dispatchEvents()
+	if len(speaker.played) != 0 {
+		t.Errorf("Expected no sound while muted")
+	}
+}
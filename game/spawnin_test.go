@@ -0,0 +1,80 @@
+package game
+
+import "testing"
+
+// spawningAsteroid places an asteroid at x, y that has just started to
+// materialize
+func spawningAsteroid(g *Game, x, y float64) *Asteroid {
+	a := placeAsteroid(g, 30, x, y)
+	a.materialize()
+	return a
+}
+
+func TestSpawningAsteroidCantHitPlayer(t *testing.T) {
+	g := New()
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+	p := g.player.polygon.Position
+	a := spawningAsteroid(g, p.X, p.Y)
+
+	if err := g.updatePlaying(); err != nil {
+		t.Fatal(err)
+	}
+	if g.state != GameStatePlaying {
+		t.Fatalf("Expected a materializing asteroid not to hit the ship")
+	}
+	if a.polygon.Scale >= 1 || a.polygon.Scale < 0.1 {
+		t.Errorf("Expected the asteroid to be growing, got scale %v", a.polygon.Scale)
+	}
+
+	// Once it has materialized it is as dangerous as any other
+	for i := 0; i < asteroidSpawnTicks && g.state == GameStatePlaying; i++ {
+		a.polygon.SetPosition(g.player.polygon.Position.X, g.player.polygon.Position.Y)
+		if err := g.updatePlaying(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if g.state != GameStateGameOver {
+		t.Errorf("Expected the asteroid to hit the ship once materialized")
+	}
+	if a.polygon.Scale != 1 {
+		t.Errorf("Expected the asteroid to reach full size, got %v", a.polygon.Scale)
+	}
+}
+
+func TestSpawningAsteroidCantBeShot(t *testing.T) {
+	g := New()
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+	a := spawningAsteroid(g, 100, 100)
+	bullet := newBullet(a.polygon.Position, a.polygon.Velocity)
+	g.addEntity(bullet)
+
+	g.checkCollisions()
+	if a.Dead() || bullet.Dead() {
+		t.Errorf("Expected the bullet to pass through a materializing asteroid")
+	}
+}
+
+func TestFragmentsDontMaterialize(t *testing.T) {
+	g := New()
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+	g.splitAsteroid(placeAsteroid(g, 40, 100, 100), OwnerPlayer)
+	for _, e := range g.entities {
+		if a, ok := e.(*Asteroid); ok && !a.Dead() && a.spawning > 0 {
+			t.Errorf("Expected fragments to be dangerous straight away")
+		}
+	}
+
+	// Whereas a new wave's asteroids do
+	g.Restart(RestartOptions{})
+	for _, e := range g.entities {
+		if a, ok := e.(*Asteroid); ok && a.spawning == 0 {
+			t.Errorf("Expected the wave's asteroids to materialize")
+		}
+	}
+}
@@ -0,0 +1,30 @@
+//go:build !headless
+
+package game
+
+import "github.com/hajimehoshi/ebiten/v2/audio"
+
+// audioSpeaker plays sounds through ebiten's audio, each on a player of its
+// own so they mix
+type audioSpeaker struct {
+	// context is made when the first sound plays, so a muted game never
+	// opens the sound device
+	context *audio.Context
+}
+
+// NewAudioSpeaker returns a Speaker that plays through the computer's sound
+// device. Only one can be used, as ebiten allows a single audio context.
+func NewAudioSpeaker() Speaker {
+	return &audioSpeaker{}
+}
+
+// Play starts pcm playing at volume. The player keeps itself alive until
+// it has finished.
+func (s *audioSpeaker) Play(pcm []byte, volume float64) {
+	if s.context == nil {
+		s.context = audio.NewContext(soundSampleRate)
+	}
+	p := s.context.NewPlayerFromBytes(pcm)
+	p.SetVolume(volume)
+	p.Play()
+}
@@ -0,0 +1,108 @@
+package game
+
+import (
+	"math"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+const (
+	// cutFragmentScale shrinks each half of a cut asteroid about its
+	// centroid, so the two keep the same area between them as generated
+	// fragments would
+	cutFragmentScale = 0.85
+	// cutMinFraction is the smallest share of the asteroid's area either
+	// half of a cut may have. Thinner slivers than that are generated
+	// fragments instead.
+	cutMinFraction = 0.1
+	// cutSeparationSpeed is how fast the halves of a cut asteroid move
+	// apart, in pixels per tick, on top of the asteroid's own velocity
+	cutSeparationSpeed = 0.5
+	// separationSteps bounds how many times the fragments of a split are
+	// pushed apart, so a crowded spot can't hold up the tick
+	separationSteps = 60
+	// separationPush is how far, in pixels, an overlapping fragment is moved
+	// each step
+	separationPush = 2.0
+)
+
+// separateFragments pushes the fragments of a split asteroid apart along the
+// line between their centroids until they no longer overlap, and nudges any
+// fragment that lands on a ship's hull away from it, so a split never starts
+// out as one blob or an unavoidable hit
+func (g *Game) separateFragments(fragments []*geometry.PolygonObject) {
+	var ships []*geometry.PolygonObject
+	for _, ship := range []*Player{g.player, g.partner} {
+		if ship != nil && !ship.Dead() {
+			ships = append(ships, ship.Collider())
+		}
+	}
+
+	for range separationSteps {
+		moved := false
+		for i, a := range fragments {
+			for _, b := range fragments[i+1:] {
+				if geometry.PolygonsCollide(a, b) {
+					pushAway(b, centerOf(a), separationPush/2)
+					pushAway(a, centerOf(b), separationPush/2)
+					moved = true
+				}
+			}
+			for _, ship := range ships {
+				if geometry.PolygonsCollide(ship, a) {
+					pushAway(a, ship.Position, separationPush)
+					moved = true
+				}
+			}
+		}
+		if !moved {
+			return
+		}
+	}
+}
+
+// centerOf returns the world position of a polygon's centroid
+func centerOf(p *geometry.PolygonObject) geometry.Vector2 {
+	return p.ToWorld(p.Centroid())
+}
+
+// pushAway moves p distance pixels directly away from point. If p is centered
+// on point it is pushed to the right.
+func pushAway(p *geometry.PolygonObject, point geometry.Vector2, distance float64) {
+	center := centerOf(p)
+	dx, dy := center.X-point.X, center.Y-point.Y
+	length := math.Hypot(dx, dy)
+	if length == 0 {
+		dx, dy, length = 1, 0, 1
+	}
+	p.Move(dx/length*distance, dy/length*distance)
+}
+
+// cutFragments cuts an asteroid's outline in two along the line through
+// point running along direction, such as a bullet's path, and returns the
+// two halves, each centered on its own centroid and moving apart from the
+// other across the cut. It returns nil if the cut fails or leaves a sliver.
+func cutFragments(asteroid *geometry.PolygonObject, point, direction geometry.Vector2) []*geometry.PolygonObject {
+	a, b, ok := geometry.CutPolygon(asteroid.TransformedVertices(), point, direction)
+	if !ok {
+		return nil
+	}
+	area := polygonMass(asteroid)
+	length := math.Hypot(direction.X, direction.Y)
+	fragments := make([]*geometry.PolygonObject, 0, 2)
+	for _, half := range [][]geometry.Vector2{a, b} {
+		fragment := geometry.CreatePolygon(half)
+		if fragment.Area() < cutMinFraction*area {
+			return nil
+		}
+		fragment.Recenter()
+		fragment.SetVertices(geometry.ScaleAbout(fragment.Vertices, geometry.Vector2{}, cutFragmentScale))
+		// Push the half away from the cut, on whichever side of it it is
+		offset := fragment.Position
+		side := direction.X*(offset.Y-point.Y) - direction.Y*(offset.X-point.X)
+		push := math.Copysign(cutSeparationSpeed, side) / length
+		fragment.SetVelocity(asteroid.Velocity.X-direction.Y*push, asteroid.Velocity.Y+direction.X*push)
+		fragments = append(fragments, fragment)
+	}
+	return fragments
+}
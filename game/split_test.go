@@ -0,0 +1,127 @@
+package game
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// fragmentsOf returns the live asteroids split off during the current tick
+func fragmentsOf(g *Game) []*Asteroid {
+	var fragments []*Asteroid
+	for _, e := range g.entities {
+		if a, ok := e.(*Asteroid); ok && !a.Dead() && a.spawnTick == g.survivalTicks {
+			fragments = append(fragments, a)
+		}
+	}
+	return fragments
+}
+
+func TestSplitFragmentsDontOverlap(t *testing.T) {
+	impacts := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		g := New(WithSeed(int64(i)))
+		g.Restart(RestartOptions{})
+		g.startPlaying()
+		g.entities = []Entity{g.player}
+
+		// A big rock hit while travelling at a random angle, with the ship
+		// parked somewhere near it
+		angle := impacts.Float64() * 2 * math.Pi
+		x := 100 + impacts.Float64()*600
+		y := 100 + impacts.Float64()*400
+		parent := placeAsteroid(g, 20+impacts.Float64()*25, x, y)
+		parent.polygon.SetRotation(angle)
+		parent.polygon.SetVelocity(math.Cos(angle)*2, math.Sin(angle)*2)
+		if i%2 == 0 {
+			g.player.polygon.SetPosition(x+(impacts.Float64()-0.5)*40, y+(impacts.Float64()-0.5)*40)
+		}
+
+		g.splitAsteroid(parent, OwnerPlayer)
+		fragments := fragmentsOf(g)
+		if len(fragments) != 2 {
+			t.Fatalf("Expected two fragments, got %d", len(fragments))
+		}
+		if geometry.PolygonsCollide(fragments[0].polygon, fragments[1].polygon) {
+			t.Errorf("Split %d: fragments overlap at %v and %v", i, fragments[0].polygon.Position, fragments[1].polygon.Position)
+		}
+		for _, f := range fragments {
+			if geometry.PolygonsCollide(g.player.Collider(), f.polygon) {
+				t.Errorf("Split %d: fragment at %v overlaps the ship at %v", i, f.polygon.Position, g.player.polygon.Position)
+			}
+		}
+	}
+}
+
+func TestSplitFragmentsStayClose(t *testing.T) {
+	g := New()
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+	g.splitAsteroid(placeAsteroid(g, 40, 100, 100), OwnerPlayer)
+
+	// Pushed apart only as far as needed, not scattered across the screen
+	for _, f := range fragmentsOf(g) {
+		if d := math.Hypot(f.polygon.Position.X-100, f.polygon.Position.Y-100); d > 60 {
+			t.Errorf("Expected the fragment to stay near the split, got %v away", d)
+		}
+	}
+}
+
+func TestShotCutsAsteroidOutline(t *testing.T) {
+	g := newWeaponsGame()
+	g.player.polygon.SetPosition(100, 100)
+	// A 60 wide square, shot straight down a third of the way across
+	square := geometry.CreatePolygon([]geometry.Vector2{{X: -30, Y: -30}, {X: 30, Y: -30}, {X: 30, Y: 30}, {X: -30, Y: 30}})
+	square.SetPosition(400, 300)
+	parent := newAsteroid(square)
+	g.addEntity(parent)
+	shot := newBullet(geometry.Vector2{X: 390, Y: 280}, geometry.Vector2{Y: 8})
+
+	g.splitAsteroidAlong(parent, OwnerPlayer, shot)
+	fragments := fragmentsOf(g)
+	if len(fragments) != 2 {
+		t.Fatalf("Expected two fragments, got %d", len(fragments))
+	}
+	var areas []float64
+	for _, f := range fragments {
+		// The halves of a square are rectangles, shrunk a little
+		if len(f.polygon.Vertices) != 4 {
+			t.Errorf("Expected a rectangular half, got %v", f.polygon.Vertices)
+		}
+		if c := f.polygon.Centroid(); math.Abs(c.X) > 1e-9 || math.Abs(c.Y) > 1e-9 {
+			t.Errorf("Expected the half to be centered on its centroid, got %v", c)
+		}
+		areas = append(areas, f.polygon.Area()/(cutFragmentScale*cutFragmentScale))
+	}
+	if math.Abs(math.Min(areas[0], areas[1])-1200) > 1e-6 || math.Abs(math.Max(areas[0], areas[1])-2400) > 1e-6 {
+		t.Errorf("Expected halves of 1200 and 2400 before shrinking, got %v", areas)
+	}
+	// Each half moves away from the cut
+	for _, f := range fragments {
+		if (f.polygon.Position.X < 390) != (f.polygon.Velocity.X < 0) {
+			t.Errorf("Expected the half at %v to move away from the cut, got %v", f.polygon.Position, f.polygon.Velocity)
+		}
+	}
+}
+
+func TestShotSplitFallsBackToGenerator(t *testing.T) {
+	g := newWeaponsGame()
+	g.player.polygon.SetPosition(100, 100)
+	parent := placeAsteroid(g, 40, 400, 300)
+	// Grazing the very edge would leave a sliver
+	shot := newBullet(geometry.Vector2{X: 439, Y: 280}, geometry.Vector2{Y: 8})
+
+	g.splitAsteroidAlong(parent, OwnerPlayer, shot)
+	fragments := fragmentsOf(g)
+	if len(fragments) != 2 {
+		t.Fatalf("Expected two fragments, got %d", len(fragments))
+	}
+	for _, f := range fragments {
+		if n := len(f.polygon.Vertices); n < 6 || n > 10 {
+			t.Errorf("Expected a generated fragment, got %d vertices", n)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package game
+
+import (
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+const (
+	// stationHP is how many asteroid impacts the station survives
+	stationHP = 10
+	// stationRadius is the size of the station
+	stationRadius = 45.0
+	// stationHPBonus is the bonus for each of the station's hit points left
+	// at the end of a wave
+	stationHPBonus = 10
+	// stationSpawnInterval is the time between asteroids within a wave
+	stationSpawnInterval = 3 * ticksPerSecond / 2
+	// stationAimSpread is the size of the area around the station that
+	// asteroids are aimed at, as a fraction of the screen
+	stationAimSpread = 0.15
+	// stationPlayerOffset is how far above the station the ship starts
+	stationPlayerOffset = 120.0
+)
+
+// Station is the space station defended in station mode. It sits in the
+// middle of the screen and never moves.
+type Station struct {
+	entityBase
+	hp    int
+	maxHP int
+}
+
+// newStation creates a station at position with a full set of hit points
+func newStation(position geometry.Vector2) *Station {
+	polygon := geometry.CreateCircle(stationRadius, 8)
+	polygon.SetPosition(position.X, position.Y)
+	polygon.LineWidth = 2
+	return &Station{
+		entityBase: entityBase{polygon: polygon, tag: TagStation, layer: LayerStation, collidesWith: LayerAsteroid},
+		hp:         stationHP,
+		maxHP:      stationHP,
+	}
+}
+
+// Update does nothing, as the station never moves
+func (s *Station) Update(ctx UpdateContext) {}
+
+// ApplyPalette colors the station like the player's ship
+func (s *Station) ApplyPalette(p *Palette) {
+	s.polygon.SetColor(p.Player)
+}
+
+// stationWaveSize returns how many asteroids are sent at the station in a wave
+func stationWaveSize(wave int) int {
+	return 4 + 2*(wave-1)
+}
+
+// startStation sets up a station mode run, with the station in the middle
+// of the screen and the ship just above it
+func (g *Game) startStation() {
+	center := geometry.Vector2{X: g.screenWidth / 2, Y: g.screenHeight / 2}
+	g.station = newStation(center)
+	g.addEntity(g.station)
+	g.player.polygon.SetPosition(center.X, center.Y-stationPlayerOffset)
+	if g.partner != nil {
+		g.partner.polygon.SetPosition(center.X+partnerOffset, center.Y-stationPlayerOffset)
+	}
+	g.startStationWave()
+}
+
+// startStationWave queues up the asteroids for the current wave
+func (g *Game) startStationWave() {
+	g.emit(WaveStarted{Wave: g.wave})
+	g.startWaveStats()
+	g.stationSpawnsLeft = stationWaveSize(g.wave)
+	g.spawnTimer = stationSpawnInterval
+}
+
+// updateStation sends the wave's asteroids in from the edges one at a time,
+// and starts the next wave once they have all been dealt with. Surviving a
+// wave earns a bonus for however much of the station is left.
+func (g *Game) updateStation() {
+	if g.stationSpawnsLeft > 0 {
+		g.spawnTimer--
+		if g.spawnTimer <= 0 {
+			g.spawnTimer = stationSpawnInterval
+			g.stationSpawnsLeft--
+			g.spawnAsteroidTowardsCenter(stationAimSpread)
+		}
+		return
+	}
+	if g.countEntities(TagAsteroid) > 0 {
+		return
+	}
+	if g.tally.running() {
+		return
+	}
+	g.emit(WaveCleared{Wave: g.wave})
+	g.finishWave()
+	g.startTally(func() {
+		g.wave++
+		g.rollWind()
+		g.startStationWave()
+	})
+}
+
+// stationHitByAsteroid destroys the asteroid and damages the station,
+// ending the run once the station has no hit points left
+func (g *Game) stationHitByAsteroid(station, asteroid Entity) bool {
+	s := station.(*Station)
+	asteroid.Kill()
+	g.emit(AsteroidDestroyed{Size: approximateRadius(asteroid.Collider()), Position: asteroid.Collider().Position})
+	if s.hp <= 0 {
+		return false
+	}
+	s.hp--
+	g.emit(StationDamaged{HP: s.hp})
+	if s.hp == 0 {
+		g.endRun("STATION DESTROYED")
+	}
+	return false
+}
+
+// playerHitsStation bounces the ship off the station
+func (g *Game) playerHitsStation(ship, station Entity) bool {
+	ship.(*Player).reflectOff(station.Collider())
+	return true
+}
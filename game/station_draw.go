@@ -0,0 +1,22 @@
+//go:build !headless
+
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// drawStationBar draws the station's hit points as a bar at the bottom
+// middle of the screen, clear of the power-up bars at the top
+func (g *Game) drawStationBar(screen *ebiten.Image) {
+	if g.mode != ModeStation || g.station == nil {
+		return
+	}
+	const width, height = 200, 10
+	x := float32(g.screenWidth)/2 - width/2
+	y := float32(g.screenHeight) - 20 - height
+	fraction := float32(g.station.hp) / float32(g.station.maxHP)
+	vector.FillRect(screen, x, y, width*fraction, height, g.palette.Player, false)
+	vector.StrokeRect(screen, x, y, width, height, 1, g.palette.UI, false)
+}
@@ -0,0 +1,143 @@
+package game
+
+import (
+	"testing"
+)
+
+// newStationGame returns a station mode game in play, holding just the
+// player and the station, with no asteroids left to send in the first wave
+func newStationGame(t *testing.T) *Game {
+	t.Helper()
+	g := New()
+	g.mode = ModeStation
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player, g.station}
+	g.stationSpawnsLeft = 0
+	// Deliver the start of the run before any test subscribes
+	g.dispatchEvents()
+	return g
+}
+
+func TestStationRestart(t *testing.T) {
+	g := newStationGame(t)
+	g.Restart(RestartOptions{})
+	if g.station == nil || g.station.hp != stationHP {
+		t.Fatalf("Expected a fresh station, got %+v", g.station)
+	}
+	if got := g.countEntities(TagAsteroid); got != 0 {
+		t.Errorf("Expected the field to start empty, got %d asteroids", got)
+	}
+	if g.stationSpawnsLeft != stationWaveSize(1) {
+		t.Errorf("Expected %d asteroids queued, got %d", stationWaveSize(1), g.stationSpawnsLeft)
+	}
+	if g.player.polygon.Position.Y >= g.station.polygon.Position.Y {
+		t.Errorf("Expected the ship to start above the station")
+	}
+}
+
+func TestStationDamagedByAsteroid(t *testing.T) {
+	g := newStationGame(t)
+	events := recordEvents(g)
+	center := g.station.polygon.Position
+	asteroid := placeAsteroid(g, 10, center.X+stationRadius, center.Y)
+	// Keep the wave from being cleared
+	placeAsteroid(g, 10, 50, 50)
+
+	if err := g.updatePlaying(); err != nil {
+		t.Fatal(err)
+	}
+	if !asteroid.Dead() {
+		t.Errorf("Expected the asteroid to be destroyed")
+	}
+	if g.station.hp != stationHP-1 {
+		t.Errorf("Expected the station to lose a hit point, has %d", g.station.hp)
+	}
+	found := false
+	for _, e := range *events {
+		if d, ok := e.(StationDamaged); ok && d.HP == stationHP-1 {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected a StationDamaged event, got %#v", *events)
+	}
+	if g.score != 0 {
+		t.Errorf("Expected no score for an asteroid hitting the station, got %d", g.score)
+	}
+}
+
+func TestStationDestroyed(t *testing.T) {
+	g := newStationGame(t)
+	g.station.hp = 1
+	center := g.station.polygon.Position
+	placeAsteroid(g, 10, center.X, center.Y+stationRadius)
+	placeAsteroid(g, 10, 50, 50)
+
+	if err := g.updatePlaying(); err != nil {
+		t.Fatal(err)
+	}
+	if g.state != GameStateGameOver || g.gameOverReason != "STATION DESTROYED" {
+		t.Errorf("Expected the run to end with the station, got state %v %q", g.state, g.gameOverReason)
+	}
+}
+
+func TestStationWaveBonus(t *testing.T) {
+	g := newStationGame(t)
+	g.station.hp = stationHP / 2
+	events := recordEvents(g)
+
+	// The next wave waits for the bonus to be tallied
+	for i := 0; i < 500 && g.wave == 1; i++ {
+		if err := g.updatePlaying(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var cleared *WaveCleared
+	tallied := 0
+	for _, e := range *events {
+		switch e := e.(type) {
+		case WaveCleared:
+			cleared = &e
+		case BonusTallied:
+			tallied += e.Points
+		}
+	}
+	if cleared == nil || cleared.Wave != 1 {
+		t.Fatalf("Expected wave 1 cleared, got %#v", cleared)
+	}
+	want := stationHP / 2 * stationHPBonus
+	if tallied != want || g.score != want {
+		t.Errorf("Expected a bonus of %d to be tallied and scored, got %d and %d", want, tallied, g.score)
+	}
+	if g.wave != 2 || g.stationSpawnsLeft != stationWaveSize(2) {
+		t.Errorf("Expected wave 2 to be queued, got wave %d with %d spawns", g.wave, g.stationSpawnsLeft)
+	}
+	if g.state != GameStatePlaying {
+		t.Errorf("Expected play to carry on, got state %v", g.state)
+	}
+}
+
+func TestStationSpawnsAimAtCenter(t *testing.T) {
+	g := newStationGame(t)
+	g.stationSpawnsLeft = 1
+	g.spawnTimer = 1
+
+	if err := g.updatePlaying(); err != nil {
+		t.Fatal(err)
+	}
+	if g.stationSpawnsLeft != 0 || g.countEntities(TagAsteroid) != 1 {
+		t.Fatalf("Expected one asteroid to be sent in")
+	}
+	for _, e := range g.entities {
+		a, ok := e.(*Asteroid)
+		if !ok {
+			continue
+		}
+		p, v := a.polygon.Position, a.polygon.Velocity
+		toCenter := (g.screenWidth/2-p.X)*v.X + (g.screenHeight/2-p.Y)*v.Y
+		if toCenter <= 0 {
+			t.Errorf("Expected the asteroid to head for the station, at %v moving %v", p, v)
+		}
+	}
+}
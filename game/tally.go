@@ -0,0 +1,98 @@
+package game
+
+import (
+	"fmt"
+)
+
+const (
+	// tallyCountTicks is how long each line of the tally takes to count up
+	tallyCountTicks = 40
+	// tallyPauseTicks is the pause after each line, and tallyHoldTicks how
+	// long the finished tally stays up before play carries on
+	tallyPauseTicks = 15
+	tallyHoldTicks  = 30
+	// hullBonus is the bonus for each hull point left at the end of a wave
+	hullBonus = 20
+)
+
+// tallyLine is a line of the end of wave tally: a bonus for count of
+// something left, each worth each
+type tallyLine struct {
+	label string
+	count int
+	each  int
+	// counted is how much of the bonus has been added to the score so far
+	counted int
+	shown   bool
+}
+
+// text returns the line as drawn, with the bonus counted so far
+func (l tallyLine) text() string {
+	return fmt.Sprintf("%s BONUS %d × %d +%s", l.label, l.count, l.each, formatScore(l.counted))
+}
+
+// tallyLines returns the bonuses for what the player has left at the end
+// of a wave
+func (g *Game) tallyLines() []*tallyLine {
+	var lines []*tallyLine
+	hull := 0
+	for _, p := range []*Player{g.player, g.partner} {
+		if p != nil {
+			hull += max(0, p.hull)
+		}
+	}
+	if hull > 0 {
+		lines = append(lines, &tallyLine{label: "HULL", count: hull, each: hullBonus})
+	}
+	if g.station != nil && g.station.hp > 0 {
+		lines = append(lines, &tallyLine{label: "STATION", count: g.station.hp, each: stationHPBonus})
+	}
+	return lines
+}
+
+// startTally counts up the end of wave bonuses into the score, one line
+// after another, then calls next. With nothing to tally next is called
+// straight away.
+func (g *Game) startTally(next func()) {
+	g.tally.clear()
+	g.tallyShown = g.tallyLines()
+	if len(g.tallyShown) == 0 {
+		next()
+		return
+	}
+	for _, line := range g.tallyShown {
+		total := line.count * line.each
+		// Add the points a tick at a time, so the score counts up with
+		// the line
+		count := func(points int) {
+			line.shown = true
+			line.counted += points
+			g.emit(BonusTallied{Label: line.label, Points: points})
+		}
+		g.tally.add(sequenceStep{
+			ticks: tallyCountTicks,
+			update: func(tick int) {
+				count(total*tick/tallyCountTicks - line.counted)
+			},
+			skip: func() { count(total - line.counted) },
+		})
+		g.tally.add(sequenceStep{ticks: tallyPauseTicks})
+	}
+	g.tally.add(sequenceStep{ticks: tallyHoldTicks})
+	g.tally.then(func() {
+		g.tallyShown = nil
+		next()
+	})
+}
+
+// updateTally plays the tally, which pressing fire skips to the end
+func (g *Game) updateTally() {
+	if !g.tally.running() {
+		return
+	}
+	if g.input.JustPressed(g.player.lastInput).Fire {
+		g.tally.skip()
+		return
+	}
+	g.tally.update()
+}
@@ -0,0 +1,23 @@
+//go:build !headless
+
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// drawTally draws the lines of the tally shown so far, in the middle of
+// the screen
+func (g *Game) drawTally(screen *ebiten.Image) {
+	const lineHeight = 40
+	y := float32(g.screenHeight)/2 - 60
+	for _, line := range g.tallyShown {
+		if !line.shown {
+			break
+		}
+		text := line.text()
+		x := float32(g.screenWidth)/2 - g.vectorFont.GetWidth(text)/2
+		g.vectorFont.DrawString(screen, text, x, y)
+		y += lineHeight
+	}
+}
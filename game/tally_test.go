@@ -0,0 +1,79 @@
+package game
+
+import (
+	"testing"
+)
+
+// newTallyGame returns a classic game whose last wave has just been
+// cleared, with a ship that has some hull left
+func newTallyGame(t *testing.T) *Game {
+	t.Helper()
+	g := New()
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+	g.wave = classicWaves
+	g.player.setHull(playerHull)
+	g.player.hull = 2
+	if err := g.updatePlaying(); err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestTallyCountsUp(t *testing.T) {
+	g := newTallyGame(t)
+	if !g.tally.running() || g.state != GameStatePlaying {
+		t.Fatalf("Expected the tally to hold off the end of the run")
+	}
+	want := 2 * hullBonus
+	last := g.score
+	for i := 0; i < tallyCountTicks; i++ {
+		if err := g.updatePlaying(); err != nil {
+			t.Fatal(err)
+		}
+		if g.score < last {
+			t.Fatalf("Expected the score to only count up, went from %d to %d", last, g.score)
+		}
+		last = g.score
+	}
+	if g.score != want || g.tallyShown[0].text() != "HULL BONUS 2 × 20 +40" {
+		t.Errorf("Expected %d tallied, got %d with %q", want, g.score, g.tallyShown[0].text())
+	}
+
+	for i := 0; i < tallyPauseTicks+tallyHoldTicks+1 && g.state == GameStatePlaying; i++ {
+		if err := g.updatePlaying(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if g.state != GameStateGameOver || g.gameOverReason != "YOU WIN!" || g.score != want {
+		t.Errorf("Expected the run to be won after the tally with %d, got %v %q %d", want, g.state, g.gameOverReason, g.score)
+	}
+}
+
+func TestTallySkippedByFire(t *testing.T) {
+	g := newTallyGame(t)
+	g.input = InputState{Fire: true}
+	g.player.lastInput = InputState{}
+	g.updateTally()
+	g.dispatchEvents()
+	if g.tally.running() || g.state != GameStateGameOver {
+		t.Fatalf("Expected fire to skip to the end of the tally")
+	}
+	if g.score != 2*hullBonus {
+		t.Errorf("Expected the whole bonus to be scored, got %d", g.score)
+	}
+}
+
+func TestNothingToTally(t *testing.T) {
+	g := New()
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+	if err := g.updatePlaying(); err != nil {
+		t.Fatal(err)
+	}
+	if g.tally.running() || g.state != GameStatePlaying || g.wave != 2 {
+		t.Errorf("Expected the next wave to start straight away without a hull, on wave %d", g.wave)
+	}
+}
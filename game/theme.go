@@ -0,0 +1,123 @@
+package game
+
+import (
+	"image/color"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+const (
+	// wavesPerTheme is how many waves are played in each theme before
+	// moving on to the next
+	wavesPerTheme = 3
+	// themeFadeTicks is how long the colors take to change to a new theme
+	themeFadeTicks = ticksPerSecond
+)
+
+// defaultThemes are the themes the waves cycle through, unless the config
+// has its own. A theme is a palette drawn over the chosen one: any color it
+// leaves unset keeps the chosen palette's, and the ship always does, so it
+// stays easy to pick out.
+var defaultThemes = []Palette{
+	{Name: "CLASSIC"},
+	{
+		Name:     "PHOSPHOR",
+		Bullet:   color.RGBA{180, 255, 180, 255},
+		Tracer:   color.RGBA{40, 140, 60, 255},
+		Asteroid: color.RGBA{51, 255, 102, 255},
+		Score:    color.RGBA{200, 255, 120, 255},
+		Comet:    color.RGBA{150, 255, 170, 255},
+		UI:       color.RGBA{51, 255, 102, 255},
+		UIDim:    color.RGBA{20, 100, 40, 255},
+	},
+	{
+		Name:     "AMBER",
+		Bullet:   color.RGBA{255, 220, 140, 255},
+		Tracer:   color.RGBA{150, 100, 20, 255},
+		Asteroid: color.RGBA{255, 176, 0, 255},
+		Score:    color.RGBA{255, 240, 160, 255},
+		Comet:    color.RGBA{255, 210, 120, 255},
+		UI:       color.RGBA{255, 176, 0, 255},
+		UIDim:    color.RGBA{110, 75, 0, 255},
+	},
+}
+
+// colors returns every role's color in the palette, for working on them
+// all at once. The ship's colors come first.
+func (p *Palette) colors() []*color.RGBA {
+	return []*color.RGBA{
+		&p.Player, &p.Flame,
+		&p.Bullet, &p.Tracer, &p.Asteroid, &p.FreshFragment, &p.Armored,
+		&p.Pickup, &p.Dilated, &p.PowerUp, &p.Hit, &p.Score, &p.Comet,
+		&p.UI, &p.UIDim,
+	}
+}
+
+// shipColors is how many of a palette's colors belong to the ship, which
+// themes leave alone
+const shipColors = 2
+
+// themed returns base drawn in theme. Colors the theme leaves unset, fully
+// transparent, keep base's, as do the ship's.
+func themed(base, theme Palette) Palette {
+	p := base
+	for i, c := range theme.colors()[shipColors:] {
+		if c.A != 0 {
+			*p.colors()[shipColors+i] = *c
+		}
+	}
+	return p
+}
+
+// blendPalettes returns the palette part way from a to b, progress being
+// from 0 at a to 1 at b
+func blendPalettes(a, b Palette, progress float64) Palette {
+	p := b
+	from, to := a.colors(), p.colors()
+	for i, c := range to {
+		*c = geometry.InterpolateColor(*from[i], *c, progress).(color.RGBA)
+	}
+	return p
+}
+
+// themes returns the themes the waves cycle through
+func (g *Game) themes() []Palette {
+	if len(g.config.Themes) > 0 {
+		return g.config.Themes
+	}
+	return defaultThemes
+}
+
+// waveTheme returns the theme for the current wave, or no theme if the
+// palette is kept fixed
+func (g *Game) waveTheme() Palette {
+	if g.config.FixedPalette {
+		return Palette{}
+	}
+	themes := g.themes()
+	return themes[(max(g.wave, 1)-1)/wavesPerTheme%len(themes)]
+}
+
+// targetPalette returns the palette to draw the current wave with
+func (g *Game) targetPalette() Palette {
+	return themed(paletteByName(g.config.Palette), g.waveTheme())
+}
+
+// handleThemes starts fading to the next theme when a wave starts in one
+func (g *Game) handleThemes(e Event) {
+	if _, ok := e.(WaveStarted); !ok || g.targetPalette() == g.palette {
+		return
+	}
+	g.themeFrom = g.palette
+	g.themeTicks = themeFadeTicks
+}
+
+// updateTheme moves the colors on towards the current wave's theme while a
+// change of theme is fading in
+func (g *Game) updateTheme() {
+	if g.themeTicks <= 0 {
+		return
+	}
+	g.themeTicks--
+	g.setPalette(blendPalettes(g.themeFrom, g.targetPalette(), 1-float64(g.themeTicks)/themeFadeTicks))
+}
@@ -0,0 +1,107 @@
+package game
+
+import (
+	"image/color"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWaveThemeCycles(t *testing.T) {
+	g := New()
+	for _, test := range []struct {
+		wave int
+		want string
+	}{
+		{1, "CLASSIC"}, {3, "CLASSIC"},
+		{4, "PHOSPHOR"}, {6, "PHOSPHOR"},
+		{7, "AMBER"}, {9, "AMBER"},
+		{10, "CLASSIC"},
+	} {
+		g.wave = test.wave
+		if got := g.waveTheme().Name; got != test.want {
+			t.Errorf("Wave %d: expected %s, got %s", test.wave, test.want, got)
+		}
+	}
+
+	g.config.FixedPalette = true
+	g.wave = 4
+	if g.targetPalette() != paletteByName(g.config.Palette) {
+		t.Errorf("Expected a fixed palette to ignore the wave's theme")
+	}
+}
+
+func TestThemeKeepsShipColor(t *testing.T) {
+	base := paletteByName("COLORBLIND")
+	theme := Palette{Player: color.RGBA{1, 2, 3, 255}, Asteroid: color.RGBA{0, 255, 0, 255}}
+	p := themed(base, theme)
+	if p.Player != base.Player {
+		t.Errorf("Expected the ship to keep its chosen color, got %v", p.Player)
+	}
+	if p.Asteroid != theme.Asteroid {
+		t.Errorf("Expected the theme's asteroid color, got %v", p.Asteroid)
+	}
+	if p.Pickup != base.Pickup {
+		t.Errorf("Expected colors the theme leaves unset to be kept, got %v", p.Pickup)
+	}
+}
+
+func TestThemeFadesInOnNewWave(t *testing.T) {
+	g := New()
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+	rock := placeAsteroid(g, 40, 100, 100)
+	classic := g.palette
+
+	g.wave = 4
+	g.emit(WaveStarted{Wave: g.wave})
+	g.dispatchEvents()
+	phosphor := g.targetPalette()
+	if g.palette != classic {
+		t.Fatalf("Expected the colors not to snap to the new theme")
+	}
+
+	for range themeFadeTicks / 2 {
+		g.updateTheme()
+	}
+	if got := rock.polygon.Color; got == classic.Asteroid || got == phosphor.Asteroid {
+		t.Errorf("Expected the asteroid part way between themes half way through, got %v", got)
+	}
+	for range themeFadeTicks / 2 {
+		g.updateTheme()
+	}
+	if g.palette != phosphor || rock.polygon.Color != phosphor.Asteroid {
+		t.Errorf("Expected the new theme once the fade finished, asteroid is %v", rock.polygon.Color)
+	}
+	if g.player.polygon.Color != classic.Player {
+		t.Errorf("Expected the ship to keep its color, got %v", g.player.polygon.Color)
+	}
+
+	// A new run goes straight back to the first theme
+	g.Restart(RestartOptions{})
+	if g.palette != classic {
+		t.Errorf("Expected a restart to go back to the first theme")
+	}
+}
+
+func TestThemesFromConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	data := `{"themes": [{"name": "RED"}, {"name": "BLUE", "asteroid": {"R": 0, "G": 0, "B": 255, "A": 255}}]}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := New(WithConfig(cfg))
+	g.wave = 4
+	if got := g.targetPalette().Asteroid; got != (color.RGBA{0, 0, 255, 255}) {
+		t.Errorf("Expected the config's second theme on wave 4, asteroid is %v", got)
+	}
+	g.wave = 7
+	if got := g.waveTheme().Name; got != "RED" {
+		t.Errorf("Expected the config's themes to cycle, got %s", got)
+	}
+}
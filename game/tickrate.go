@@ -0,0 +1,84 @@
+package game
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// ticksPerSecond is how many times a second the world is stepped, the same
+// rate the polygons in it are stepped at. Everything counted in ticks counts
+// these steps, whatever rate the game is updated at.
+const ticksPerSecond = geometry.TicksPerSecond
+
+// tickRates are the tick rates offered on the settings screen, in ticks a
+// second. The rate only changes how often the game updates, polling input
+// and catching the world up. The simulation doesn't follow it: the world
+// always moves in fixed steps of a ticksPerSecond'th of a second, so runs
+// play out the same at any rate and stay in step with run logs and network
+// games. A lower rate takes more than one step a tick, and a higher one
+// only steps on some ticks.
+var tickRates = []int{30, ticksPerSecond, 120}
+
+// nextTickRate returns the tick rate after current on the settings screen
+func nextTickRate(current int) int {
+	i := slices.Index(tickRates, current)
+	return tickRates[(i+1)%len(tickRates)]
+}
+
+// tickRateLabel returns how a tick rate is shown on the settings screen
+func tickRateLabel(rate int) string {
+	return fmt.Sprintf("%d TPS", rate)
+}
+
+// tickRate returns how many times a second the game updates and polls input:
+// the rate chosen in the config, or ticksPerSecond if it isn't one on offer
+func (c *Config) tickRate() int {
+	if !slices.Contains(tickRates, c.TickRate) {
+		return ticksPerSecond
+	}
+	return c.TickRate
+}
+
+// dueSteps moves the step clock on by a tick and returns how many steps of
+// the world fall in it
+func (g *Game) dueSteps() int {
+	rate := g.config.tickRate()
+	g.stepClock += ticksPerSecond
+	steps := g.stepClock / rate
+	g.stepClock -= steps * rate
+	return steps
+}
+
+// latchInput adds a tick's input to what the next step will take, so a
+// control pressed on a tick without a step of its own isn't lost.
+// thrustPressed is whether thrust was first pressed on the tick.
+func (g *Game) latchInput(in InputState, thrustPressed bool) {
+	g.latched = g.latched.Or(in)
+	g.thrustPressed = g.thrustPressed || thrustPressed
+}
+
+// stepRun moves the run on by this tick's steps, using the input latched
+// since the last step. One-off presses, such as a dash, only go to the
+// first step.
+func (g *Game) stepRun() error {
+	if g.steps == 0 {
+		return nil
+	}
+	for range g.steps {
+		in := g.readInput()
+		if g.net != nil {
+			if err := g.updateNet(in); err != nil {
+				return err
+			}
+		} else if err := g.Step(in); err != nil {
+			return err
+		}
+		if !g.InRun() {
+			break
+		}
+	}
+	g.latched = InputState{}
+	return nil
+}
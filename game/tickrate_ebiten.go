@@ -0,0 +1,11 @@
+//go:build !headless
+
+package game
+
+import "github.com/hajimehoshi/ebiten/v2"
+
+// applyTickRate has ebiten update the game at the configured rate, which
+// sets how often input is polled but not how fast the world moves
+func (g *Game) applyTickRate() {
+	ebiten.SetTPS(g.config.tickRate())
+}
@@ -0,0 +1,100 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+func TestDueSteps(t *testing.T) {
+	tests := []struct {
+		rate  int
+		steps []int
+	}{
+		{30, []int{2, 2, 2, 2}},
+		{60, []int{1, 1, 1, 1}},
+		{120, []int{0, 1, 0, 1}},
+		// A rate that isn't on offer falls back to the default
+		{45, []int{1, 1, 1, 1}},
+	}
+	for _, tt := range tests {
+		g := New(WithConfig(Config{TickRate: tt.rate}))
+		for i, want := range tt.steps {
+			if got := g.dueSteps(); got != want {
+				t.Errorf("%d TPS: expected %d steps on tick %d, got %d", tt.rate, want, i, got)
+			}
+		}
+	}
+}
+
+// playTrace plays a scripted run at the given tick rate, sampling the
+// controls from trace, which gives them at a time in seconds, once a tick
+func playTrace(t *testing.T, rate int, seconds float64, trace func(seconds float64) InputState) *Game {
+	t.Helper()
+	g := New(WithSeed(1), WithConfig(Config{TickRate: rate}))
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+	g.wind = geometry.Vector2{}
+	ship := g.player.polygon.Position
+	for _, offset := range []geometry.Vector2{{X: 0, Y: -150}, {X: 150, Y: 0}, {X: 0, Y: 150}} {
+		placeAsteroid(g, 12, ship.X+offset.X, ship.Y+offset.Y)
+	}
+
+	for tick := range int(seconds * float64(rate)) {
+		g.latchInput(trace(float64(tick)/float64(rate)), false)
+		g.steps = g.dueSteps()
+		if err := g.stepRun(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return g
+}
+
+func TestTickRatesPlayTheSame(t *testing.T) {
+	// Shoot, turn a quarter circle to the right, and repeat, changing the
+	// controls every 30th of a second so every rate sees each change
+	trace := func(seconds float64) InputState {
+		frame := int(seconds * 30)
+		return InputState{
+			Fire:  frame == 5 || frame == 15 || frame == 25,
+			Right: (frame >= 6 && frame < 14) || (frame >= 16 && frame < 24),
+		}
+	}
+	want := playTrace(t, ticksPerSecond, 3, trace)
+	if want.score == 0 {
+		t.Fatalf("Expected the scripted shots to score")
+	}
+	for _, rate := range []int{30, 120} {
+		g := playTrace(t, rate, 3, trace)
+		if g.score != want.score || g.survivalTicks != want.survivalTicks {
+			t.Errorf("%d TPS: expected a score of %d after %d steps, got %d after %d", rate, want.score, want.survivalTicks, g.score, g.survivalTicks)
+		}
+		if g.StateHash() != want.StateHash() {
+			t.Errorf("%d TPS: expected everything to be where it is at 60 TPS", rate)
+		}
+	}
+}
+
+func TestTickRatesRepeatMenusTheSame(t *testing.T) {
+	// Hold down on a menu for a while, which should repeat as often at any
+	// rate, as long as the hold doesn't end right on a repeat
+	hold := func(rate int) (downs int) {
+		g := New(WithConfig(Config{TickRate: rate}))
+		for range int(1.95 * float64(rate)) {
+			if g.menuInput.update(menuButtons{Down: true}, g.dueSteps()).Down {
+				downs++
+			}
+		}
+		return downs
+	}
+	want := hold(ticksPerSecond)
+	if want < 2 {
+		t.Fatalf("Expected holding down to repeat, got %d moves", want)
+	}
+	for _, rate := range []int{30, 120} {
+		if downs := hold(rate); downs != want {
+			t.Errorf("%d TPS: expected %d moves, as at 60 TPS, got %d", rate, want, downs)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package game
+
+import (
+	"time"
+)
+
+const (
+	// deathTimeScale is how slow the world runs after the player is hit
+	deathTimeScale = 0.3
+	// deathRampTicks is how long time takes to slow down after a death
+	deathRampTicks = 10
+	// respawnRampTicks is how long time takes to return to normal speed
+	// when the player respawns
+	respawnRampTicks = 30
+	// hitStopTicks is how long the world freezes when a large asteroid is destroyed
+	hitStopTicks = 3
+)
+
+// timeScale tracks how fast world time runs relative to real time
+type timeScale struct {
+	// current is the scale applied to the world this tick
+	current float64
+	// target is the scale current is ramping towards, by step per tick
+	// for rampTicks more ticks
+	target    float64
+	step      float64
+	rampTicks int
+	// frozenTicks counts down the remaining ticks of a hit-stop
+	frozenTicks int
+}
+
+// TimeScale returns how fast world time is currently running, where 1 is
+// normal speed and 0 is frozen
+func (g *Game) TimeScale() float64 {
+	if g.time.frozenTicks > 0 {
+		return 0
+	}
+	return g.time.current
+}
+
+// SetTimeScale smoothly changes the speed of world time to scale over the
+// given number of ticks. A ramp of 0 ticks changes it immediately.
+func (g *Game) SetTimeScale(scale float64, rampTicks int) {
+	g.time.target = scale
+	g.time.rampTicks = rampTicks
+	if rampTicks <= 0 {
+		g.time.current = scale
+		g.time.rampTicks = 0
+		return
+	}
+	g.time.step = (scale - g.time.current) / float64(rampTicks)
+}
+
+// ticksDuration returns how long the given number of ticks lasts at the
+// default tick rate
+func ticksDuration(ticks float64) time.Duration {
+	return time.Duration(ticks * float64(time.Second) / ticksPerSecond)
+}
+
+// hitStop freezes world time for the given number of ticks
+func (g *Game) hitStop(ticks int) {
+	g.time.frozenTicks = max(g.time.frozenTicks, ticks)
+}
+
+// advanceTime moves the time scale on by one tick and returns the scale to
+// apply to the world for this tick
+func (g *Game) advanceTime() float64 {
+	if g.time.frozenTicks > 0 {
+		g.time.frozenTicks--
+		return 0
+	}
+
+	t := &g.time
+	if t.rampTicks > 0 {
+		t.rampTicks--
+		t.current += t.step
+		if t.rampTicks == 0 {
+			// Land exactly on the target rather than accumulating rounding errors
+			t.current = t.target
+		}
+	}
+	return t.current
+}
+
+// handleTimeEffects slows time when the player dies and briefly freezes it
+// when a large asteroid is destroyed
+func (g *Game) handleTimeEffects(e Event) {
+	switch e := e.(type) {
+	case PlayerHit:
+		g.SetTimeScale(deathTimeScale, deathRampTicks)
+	case AsteroidDestroyed:
+		if e.Size >= largeAsteroidSize {
+			g.hitStop(hitStopTicks)
+		}
+	}
+}
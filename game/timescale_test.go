@@ -0,0 +1,65 @@
+package game
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTimeScaleSlowsOnDeath(t *testing.T) {
+	g := New()
+	g.state = GameStatePlaying
+
+	g.emit(PlayerHit{})
+	g.dispatchEvents()
+
+	for i := 0; i < deathRampTicks; i++ {
+		g.advanceTime()
+	}
+	if math.Abs(g.TimeScale()-deathTimeScale) > 1e-9 {
+		t.Errorf("Expected time scale %v after the death ramp, got %v", deathTimeScale, g.TimeScale())
+	}
+
+	// Restarting ramps time back up to normal
+	g.Restart(RestartOptions{})
+	for i := 0; i < respawnRampTicks; i++ {
+		g.advanceTime()
+	}
+	if g.TimeScale() != 1 {
+		t.Errorf("Expected normal time after respawning, got %v", g.TimeScale())
+	}
+}
+
+func TestTimeScaleHitStopFreezesWorld(t *testing.T) {
+	g := New()
+	g.state = GameStatePlaying
+	g.entities = []Entity{g.player}
+	asteroid := placeAsteroid(g, 40, 100, 100)
+	asteroid.polygon.SetVelocity(1, 0)
+
+	g.emit(AsteroidDestroyed{Size: largeAsteroidSize})
+	g.dispatchEvents()
+
+	start := asteroid.polygon.Position.X
+	for i := 0; i < hitStopTicks; i++ {
+		g.updatePlaying()
+	}
+	if asteroid.polygon.Position.X != start {
+		t.Errorf("Expected asteroid to stay still during the hit-stop")
+	}
+
+	g.updatePlaying()
+	if asteroid.polygon.Position.X == start {
+		t.Errorf("Expected asteroid to move again after the hit-stop")
+	}
+}
+
+func TestTimeScaleSmallAsteroidNoHitStop(t *testing.T) {
+	g := New()
+
+	g.emit(AsteroidDestroyed{Size: largeAsteroidSize - 1})
+	g.dispatchEvents()
+
+	if g.TimeScale() != 1 {
+		t.Errorf("Expected no hit-stop for a small asteroid, got time scale %v", g.TimeScale())
+	}
+}
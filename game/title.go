@@ -0,0 +1,72 @@
+package game
+
+import (
+	"runtime"
+)
+
+// gameModes lists the modes selectable on the title screen, in display order
+var gameModes = []GameMode{ModeClassic, ModeEndless, ModeStation, ModePractice}
+
+// titleItems returns the title screen menu: one entry per mode, then the
+// saved runs, records and settings
+func titleItems() []string {
+	items := make([]string, 0, len(gameModes)+3)
+	for _, mode := range gameModes {
+		items = append(items, mode.String())
+	}
+	return append(items, "CONTINUE", "RECORDS", "SETTINGS")
+}
+
+// updateBackground drifts the asteroids shown behind the menus and the game
+// over screen at the given time scale
+func (g *Game) updateBackground(timeScale float64) {
+	ctx := UpdateContext{ScreenWidth: g.screenWidth, ScreenHeight: g.screenHeight, TimeScale: timeScale}
+	for _, e := range g.entities {
+		if e.Tag() != TagPlayer {
+			g.updateEntity(e, ctx)
+		}
+	}
+}
+
+// updateTitle drifts the background asteroids and handles mode selection
+func (g *Game) updateTitle() error {
+	g.updateBackground(float64(g.steps))
+
+	items := len(titleItems())
+	in := g.readMenu()
+	if in.Up {
+		g.titleSelection = (g.titleSelection + items - 1) % items
+	}
+	if in.Down {
+		g.titleSelection = (g.titleSelection + 1) % items
+	}
+	// There is nothing to quit to in a browser
+	if keyJustPressed(keyEscape) && runtime.GOOS != "js" {
+		return g.quit()
+	}
+	if in.Confirm {
+		switch {
+		case g.titleSelection < len(gameModes):
+			// Pick the mutators for the run before starting
+			g.mode = gameModes[g.titleSelection]
+			g.mutatorSelection = len(mutatorList)
+			g.state = GameStateMutators
+		case g.titleSelection == len(gameModes):
+			g.openSlots()
+		case g.titleSelection == len(gameModes)+1:
+			g.state = GameStateRecords
+		default:
+			g.openSettings(GameStateTitle)
+		}
+	}
+	return nil
+}
+
+// inMenu reports whether one of the menu screens is showing, which hide the
+// player's ship
+func (g *Game) inMenu() bool {
+	if g.state == GameStateSettings {
+		return g.settingsReturn == GameStateTitle
+	}
+	return g.state == GameStateTitle || g.state == GameStateMutators || g.state == GameStateRecords || g.state == GameStateSlots
+}
@@ -0,0 +1,44 @@
+//go:build !headless
+
+package game
+
+import (
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/AndreRenaud/SpaceDebris/vectorfont"
+)
+
+// drawTitleScreen draws the game name and the mode selection menu, with
+// the wave of the latest run in progress beside the entry to continue it
+func (g *Game) drawTitleScreen(screen *ebiten.Image) {
+	centerX := float32(g.screenWidth / 2)
+	centerY := float32(g.screenHeight / 2)
+
+	title := "SPACE DEBRIS"
+	g.titleFont.DrawStringAligned(screen, title, centerX, centerY-140, vectorfont.AlignCenter)
+
+	items := titleItems()
+	if latest := g.latestSave(); latest >= 0 {
+		items[len(gameModes)] += fmt.Sprintf(" WAVE %d", g.slots[latest].run.Wave)
+	}
+	g.drawMenu(screen, items, g.titleSelection, centerY-60)
+
+	g.vectorFont.DrawStringAligned(screen, "PRESS ENTER TO START", centerX, float32(g.screenHeight)-50, vectorfont.AlignCenter)
+}
+
+// drawMenu draws a centered vertical list of items starting at y, with the
+// selected one highlighted
+func (g *Game) drawMenu(screen *ebiten.Image, items []string, selected int, y float32) {
+	centerX := float32(g.screenWidth / 2)
+	for i, item := range items {
+		if i == selected {
+			g.vectorFont.SetColor(g.palette.UI)
+		} else {
+			g.vectorFont.SetColor(g.palette.UIDim)
+		}
+		g.vectorFont.DrawStringAligned(screen, item, centerX, y+float32(i)*40, vectorfont.AlignCenter)
+	}
+	g.vectorFont.SetColor(g.palette.UI)
+}
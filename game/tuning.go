@@ -0,0 +1,149 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"time"
+)
+
+// Tuning holds the constants that decide how the game handles. It can be
+// loaded from a file and edited while the game runs, for balancing.
+type Tuning struct {
+	// Thrust is the ship's acceleration, in pixels per tick squared
+	Thrust float64 `json:"thrust"`
+	// RotationSpeed is how fast the ship turns, in radians per tick
+	RotationSpeed float64 `json:"rotation_speed"`
+	// ShipDrag is the fraction of the ship's velocity lost each tick
+	ShipDrag float64 `json:"ship_drag"`
+	// MaxSpeed is the fastest the ship can fly, in pixels per tick
+	MaxSpeed float64 `json:"max_speed"`
+	// AsteroidSpeed multiplies the speed of newly created asteroids
+	AsteroidSpeed float64 `json:"asteroid_speed"`
+	// AsteroidCount is how many asteroids each classic run starts with. Each
+	// wave after the first brings one more.
+	AsteroidCount int `json:"asteroid_count"`
+	// BulletCooldown is the number of ticks between shots
+	BulletCooldown int `json:"bullet_cooldown_ticks"`
+	// FireBuffer is how many ticks early fire can be pressed before the gun
+	// is ready and still fire as soon as it is
+	FireBuffer int `json:"fire_buffer_ticks"`
+	// AsteroidCap is how many asteroids the field can hold before the
+	// smallest fragments start merging when they touch. Zero turns merging
+	// off.
+	AsteroidCap int `json:"asteroid_cap"`
+}
+
+// DefaultTuning returns the game's standard handling
+func DefaultTuning() Tuning {
+	return Tuning{
+		Thrust:         0.2,
+		RotationSpeed:  0.1,
+		ShipDrag:       0.02,
+		MaxSpeed:       5,
+		AsteroidSpeed:  1,
+		AsteroidCount:  3,
+		BulletCooldown: ticksPerSecond / 10, // 100ms
+		FireBuffer:     4,
+		AsteroidCap:    40,
+	}
+}
+
+// rules returns the rules for a run before any mutators adjust them
+func (t Tuning) rules() rules {
+	return rules{shipDrag: t.ShipDrag, asteroidSpeed: t.AsteroidSpeed}
+}
+
+// toastTicks is how long a toast message stays on screen
+const toastTicks = 2 * ticksPerSecond
+
+// tuningWatch polls a tuning file for changes
+type tuningWatch struct {
+	path string
+	// base is the tuning the game started with. Each load is applied over
+	// it, so removing a value from the file puts it back.
+	base    Tuning
+	modTime time.Time
+	// nextCheck counts down the ticks until the file is polled again
+	nextCheck int
+}
+
+// WithTuningFile loads the handling constants from the JSON file at path,
+// and reloads them whenever the file changes. Values missing from the file
+// keep their defaults.
+func WithTuningFile(path string) Option {
+	return func(g *Game) {
+		g.tuningWatch = &tuningWatch{path: path}
+	}
+}
+
+// pollTuning reloads the tuning file, once a second, if it has changed
+func (g *Game) pollTuning() {
+	w := g.tuningWatch
+	if w == nil {
+		return
+	}
+	if w.nextCheck--; w.nextCheck > 0 {
+		return
+	}
+	w.nextCheck = ticksPerSecond
+	if !w.changed() {
+		return
+	}
+	if err := g.loadTuning(); err != nil {
+		log.Printf("Keeping the previous tuning: %v", err)
+		g.showToast("CONFIG ERROR")
+		return
+	}
+	g.showToast("CONFIG RELOADED")
+}
+
+// changed reports whether the file has been modified since it was last
+// checked. A file that can't be read is treated as unchanged.
+func (w *tuningWatch) changed() bool {
+	info, err := os.Stat(w.path)
+	if err != nil || info.ModTime().Equal(w.modTime) {
+		return false
+	}
+	w.modTime = info.ModTime()
+	return true
+}
+
+// loadTuning reads the tuning file and applies it
+func (g *Game) loadTuning() error {
+	w := g.tuningWatch
+	data, err := os.ReadFile(w.path)
+	if err != nil {
+		return err
+	}
+	tuning := w.base
+	if err := json.Unmarshal(data, &tuning); err != nil {
+		return fmt.Errorf("parsing %s: %w", w.path, err)
+	}
+	g.setTuning(tuning)
+	return nil
+}
+
+// setTuning changes the handling constants. The ship's handling changes
+// straight away, while asteroid speeds apply to new asteroids and the
+// asteroid count to the next wave.
+func (g *Game) setTuning(t Tuning) {
+	g.tuning = t
+	if g.player == nil {
+		return
+	}
+	g.applyMutatorRules()
+	for _, p := range []*Player{g.player, g.partner} {
+		if p != nil {
+			p.polygon.Drag = g.rules.shipDrag
+			p.polygon.MaxSpeed = t.MaxSpeed
+		}
+	}
+}
+
+// showToast shows a brief message at the bottom of the screen
+func (g *Game) showToast(text string) {
+	g.toast = text
+	g.toastTicks = toastTicks
+}
@@ -0,0 +1,16 @@
+//go:build !headless
+
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// drawToast draws the current toast message, if there is one
+func (g *Game) drawToast(screen *ebiten.Image) {
+	if g.toastTicks <= 0 {
+		return
+	}
+	x := float32(g.screenWidth)/2 - g.vectorFont.GetWidth(g.toast)/2
+	g.vectorFont.DrawString(screen, g.toast, x, float32(g.screenHeight)-70)
+}
@@ -0,0 +1,106 @@
+package game
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeTuning writes a tuning file with a modification time the given
+// number of seconds after the epoch, so changes are always noticed
+func writeTuning(t *testing.T, path, contents string, seconds int64) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	mtime := time.Unix(seconds, 0)
+	if err := os.Chtimes(path, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// pollNow polls the tuning file without waiting for the next check
+func pollNow(g *Game) {
+	g.tuningWatch.nextCheck = 0
+	g.pollTuning()
+}
+
+func TestTuningFileLoadedAtStart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tune.json")
+	writeTuning(t, path, `{"thrust": 0.5, "asteroid_count": 5}`, 1000)
+
+	g := New(WithTuningFile(path))
+	g.Restart(RestartOptions{})
+
+	if g.tuning.Thrust != 0.5 {
+		t.Errorf("Expected thrust 0.5, got %v", g.tuning.Thrust)
+	}
+	if got := g.countEntities(TagAsteroid); got != 5 {
+		t.Errorf("Expected 5 asteroids, got %d", got)
+	}
+	if g.tuning.ShipDrag != DefaultTuning().ShipDrag {
+		t.Errorf("Expected values missing from the file to keep their defaults")
+	}
+	if g.toastTicks != 0 {
+		t.Errorf("Expected no toast for the initial load, got %q", g.toast)
+	}
+}
+
+func TestTuningReloadAppliesToShip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tune.json")
+	writeTuning(t, path, `{}`, 1000)
+	g := New(WithTuningFile(path))
+	g.Restart(RestartOptions{})
+
+	writeTuning(t, path, `{"ship_drag": 0.1, "max_speed": 8, "bullet_cooldown_ticks": 2}`, 2000)
+	pollNow(g)
+
+	if g.toast != "CONFIG RELOADED" || g.toastTicks == 0 {
+		t.Errorf("Expected a reload toast, got %q", g.toast)
+	}
+	ship := g.player.polygon
+	if ship.Drag != 0.1 || ship.MaxSpeed != 8 {
+		t.Errorf("Expected the ship's handling to change immediately, drag %v max speed %v", ship.Drag, ship.MaxSpeed)
+	}
+	if g.tuning.BulletCooldown != 2 {
+		t.Errorf("Expected a 2 tick cooldown, got %d", g.tuning.BulletCooldown)
+	}
+
+	// Taking a value back out of the file restores its default
+	writeTuning(t, path, `{"max_speed": 8}`, 3000)
+	pollNow(g)
+	if ship.Drag != DefaultTuning().ShipDrag {
+		t.Errorf("Expected the default drag back, got %v", ship.Drag)
+	}
+}
+
+func TestTuningInvalidFileKeepsValues(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tune.json")
+	writeTuning(t, path, `{"thrust": 0.3}`, 1000)
+	g := New(WithTuningFile(path))
+
+	writeTuning(t, path, `{"thrust": `, 2000)
+	pollNow(g)
+
+	if g.tuning.Thrust != 0.3 {
+		t.Errorf("Expected the previous thrust to be kept, got %v", g.tuning.Thrust)
+	}
+	if g.toast != "CONFIG ERROR" {
+		t.Errorf("Expected an error toast, got %q", g.toast)
+	}
+}
+
+func TestTuningPolledOncePerSecond(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tune.json")
+	writeTuning(t, path, `{}`, 1000)
+	g := New(WithTuningFile(path))
+	g.tuningWatch.nextCheck = 0
+	g.pollTuning()
+
+	writeTuning(t, path, `{"thrust": 0.4}`, 2000)
+	g.pollTuning()
+	if g.tuning.Thrust == 0.4 {
+		t.Errorf("Expected the file not to be checked again so soon")
+	}
+}
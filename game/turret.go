@@ -0,0 +1,95 @@
+package game
+
+import (
+	"math"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+const (
+	// turretFirstWave is the first wave turret asteroids can appear in
+	turretFirstWave = 3
+	// turretChance is the chance of each asteroid in a wave being a turret
+	turretChance = 0.1
+	// turretFireTicks is the time between a turret's shots
+	turretFireTicks = 3 * ticksPerSecond
+	// turretTurnSpeed is how fast a turret's barrel turns, in radians per tick
+	turretTurnSpeed = 0.03
+	// turretBarrelLength is how far the barrel sticks out from the
+	// asteroid's centroid, relative to its size
+	turretBarrelLength = 1.3
+	// enemyBulletSpeed is how fast turret bullets travel, in pixels per tick
+	enemyBulletSpeed = 3.0
+)
+
+// turret is the gun mounted on a turret asteroid
+type turret struct {
+	// angle is the direction the barrel points, with 0 being up the screen
+	angle float64
+	// fireTicks counts down the ticks until the next shot
+	fireTicks float64
+}
+
+// makeTurret mounts a gun on the asteroid. The first shot comes after a
+// random part of the usual interval, so turrets in a wave don't fire
+// together.
+func (g *Game) makeTurret(a *Asteroid) {
+	a.turret = &turret{
+		angle:     g.rng.Float64() * 2 * math.Pi,
+		fireTicks: turretFireTicks * (0.5 + g.rng.Float64()/2),
+	}
+}
+
+// rollTurret decides whether an asteroid in the current wave should be a
+// turret. Early waves never have turrets.
+func (g *Game) rollTurret() bool {
+	return g.wave >= turretFirstWave && g.rng.Float64() < turretChance
+}
+
+// updateTurrets turns every turret towards the player, taking the shortest
+// way around the wrapped screen, and fires whenever one is ready
+func (g *Game) updateTurrets(timeScale float64) {
+	ship := g.player.polygon.Position
+	for _, e := range g.entities {
+		a, ok := e.(*Asteroid)
+		if !ok || a.turret == nil || a.Dead() {
+			continue
+		}
+		t := a.turret
+		muzzle := a.polygon.ToWorld(a.polygon.Centroid())
+		toShip := geometry.WrapDelta(muzzle, ship, g.screenWidth, g.screenHeight)
+		target := math.Atan2(toShip.X, -toShip.Y)
+		t.angle = turnTowards(t.angle, target, turretTurnSpeed*timeScale)
+
+		t.fireTicks -= timeScale
+		if t.fireTicks > 0 {
+			continue
+		}
+		t.fireTicks += turretFireTicks
+		velocity := geometry.Vector2{
+			X: math.Sin(t.angle) * enemyBulletSpeed,
+			Y: -math.Cos(t.angle) * enemyBulletSpeed,
+		}
+		g.addEntity(newEnemyBullet(a.barrelEnd(), velocity, OwnerTurret))
+	}
+}
+
+// turnTowards turns angle towards target by at most step, going whichever
+// way round is shorter
+func turnTowards(angle, target, step float64) float64 {
+	diff := math.Remainder(target-angle, 2*math.Pi)
+	if math.Abs(diff) <= step {
+		return geometry.NormalizeAngle(target)
+	}
+	return geometry.NormalizeAngle(angle + math.Copysign(step, diff))
+}
+
+// barrelEnd returns the tip of a turret asteroid's barrel
+func (a *Asteroid) barrelEnd() geometry.Vector2 {
+	center := a.polygon.ToWorld(a.polygon.Centroid())
+	length := approximateRadius(a.polygon) * turretBarrelLength
+	return geometry.Vector2{
+		X: center.X + math.Sin(a.turret.angle)*length,
+		Y: center.Y - math.Cos(a.turret.angle)*length,
+	}
+}
@@ -0,0 +1,18 @@
+//go:build !headless
+
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// drawBarrel draws a turret asteroid's gun as a line out from its centroid
+func (a *Asteroid) drawBarrel(screen *ebiten.Image, ctx DrawContext) {
+	center := a.polygon.ToWorld(a.polygon.Centroid())
+	end := a.barrelEnd()
+	vector.StrokeLine(screen,
+		float32(center.X), float32(center.Y),
+		float32(end.X), float32(end.Y),
+		2+ctx.LineWidthBoost, a.palette.Hit, true)
+}
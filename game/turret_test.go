@@ -0,0 +1,144 @@
+package game
+
+import (
+	"math"
+	"testing"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+func TestTurnTowards(t *testing.T) {
+	tests := []struct {
+		name                  string
+		angle, target, result float64
+	}{
+		{"Clockwise", 0, 1, 0.1},
+		{"Anticlockwise", 1, 0, 0.9},
+		{"Arrives", 1, 1.05, 1.05},
+		// The short way from just left of up to just right of up crosses 0
+		{"Across zero", 2*math.Pi - 0.2, 0.2, 2*math.Pi - 0.1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := turnTowards(tt.angle, tt.target, 0.1); math.Abs(got-tt.result) > 1e-9 {
+				t.Errorf("Expected %v, got %v", tt.result, got)
+			}
+		})
+	}
+}
+
+// newTurretGame returns a game in play with a single turret asteroid
+// directly above the ship, already aiming at it
+func newTurretGame(t *testing.T) (*Game, *Asteroid) {
+	t.Helper()
+	g := New()
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+	ship := g.player.polygon.Position
+	a := placeAsteroid(g, 20, ship.X, ship.Y-200)
+	g.makeTurret(a)
+	a.turret.angle = math.Pi
+	return g, a
+}
+
+func TestTurretTracksAndFires(t *testing.T) {
+	g, a := newTurretGame(t)
+	a.turret.angle = math.Pi / 2
+	a.turret.fireTicks = 100
+
+	for i := 0; i < 99; i++ {
+		g.updateTurrets(1)
+	}
+	if math.Abs(a.turret.angle-math.Pi) > 1e-9 {
+		t.Errorf("Expected the turret to turn to face the ship, angle %v", a.turret.angle)
+	}
+	if got := g.countEntities(TagEnemyBullet); got != 0 {
+		t.Fatalf("Expected no shots before the timer runs out, got %d", got)
+	}
+
+	g.updateTurrets(1)
+	if got := g.countEntities(TagEnemyBullet); got != 1 {
+		t.Fatalf("Expected a shot, got %d", got)
+	}
+	bullet := g.entities[len(g.entities)-1].Collider()
+	if bullet.Velocity.Y <= 0 || math.Abs(bullet.Velocity.X) > 1e-9 {
+		t.Errorf("Expected the bullet to head down towards the ship, velocity %v", bullet.Velocity)
+	}
+	if got := g.countEntities(TagBullet); got != 0 {
+		t.Errorf("Expected enemy bullets not to count as the player's, got %d", got)
+	}
+}
+
+func TestTurretAimsAcrossTheWrap(t *testing.T) {
+	g, a := newTurretGame(t)
+	// The ship is just over the left edge from a turret at the right edge
+	g.player.polygon.SetPosition(10, 300)
+	a.polygon.SetPosition(g.screenWidth-30, 300)
+	a.turret.angle = math.Pi / 2
+	for i := 0; i < 200; i++ {
+		g.updateTurrets(1)
+	}
+	if math.Abs(a.turret.angle-math.Pi/2) > 0.2 {
+		t.Errorf("Expected the turret to aim right, across the wrap, angle %v", a.turret.angle)
+	}
+}
+
+func TestEnemyBulletHitsPlayer(t *testing.T) {
+	g := New()
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+	g.addEntity(newEnemyBullet(g.player.polygon.Position, geometry.Vector2{}, OwnerTurret))
+
+	if err := g.updatePlaying(); err != nil {
+		t.Fatal(err)
+	}
+	if g.state != GameStateGameOver {
+		t.Errorf("Expected the shot to destroy the ship")
+	}
+
+	g = newHullGame(t)
+	// Keep an asteroid around so the wave isn't cleared
+	placeAsteroid(g, 10, 50, 50)
+	g.addEntity(newEnemyBullet(g.player.polygon.Position, geometry.Vector2{}, OwnerTurret))
+	if err := g.updatePlaying(); err != nil {
+		t.Fatal(err)
+	}
+	if g.state != GameStatePlaying || g.player.hull != playerHull-1 {
+		t.Errorf("Expected the shot to cost one hull point, state %v hull %d", g.state, g.player.hull)
+	}
+	if got := g.countEntities(TagEnemyBullet); got != 0 {
+		t.Errorf("Expected the bullet to be used up, got %d", got)
+	}
+}
+
+func TestTurretFragmentsAreOrdinary(t *testing.T) {
+	g, a := newTurretGame(t)
+	a.polygon.SetScale(2)
+	g.splitAsteroid(a, OwnerPlayer)
+	for _, e := range g.entities {
+		if fragment, ok := e.(*Asteroid); ok && !fragment.Dead() && fragment.turret != nil {
+			t.Errorf("Expected fragments to be ordinary asteroids")
+		}
+	}
+}
+
+func TestTurretsOnlyInLaterWaves(t *testing.T) {
+	g := New()
+	for i := 0; i < 100; i++ {
+		if g.rollTurret() {
+			t.Fatalf("Expected no turrets in wave %d", g.wave)
+		}
+	}
+	g.wave = turretFirstWave
+	turrets := 0
+	for i := 0; i < 1000; i++ {
+		if g.rollTurret() {
+			turrets++
+		}
+	}
+	if turrets == 0 || turrets > 200 {
+		t.Errorf("Expected turrets to be rare from wave %d, got %d in 1000", turretFirstWave, turrets)
+	}
+}
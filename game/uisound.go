@@ -0,0 +1,80 @@
+package game
+
+// uiSound is one of the short sounds the menus and controls make
+type uiSound int
+
+const (
+	// uiCursor is the blip of a menu's selection moving
+	uiCursor uiSound = iota
+	// uiSelect is the rising chirp of a menu item being chosen
+	uiSelect
+	// uiBack is the falling chirp of backing out of a menu
+	uiBack
+	// uiError is the buzz of something that can't be done, such as firing
+	// a weapon with no ammo left
+	uiError
+	// uiSoundCount is how many UI sounds there are
+	uiSoundCount
+)
+
+// uiSoundPeak is the loudest sample of any UI sound, as a fraction of full
+// scale, quiet enough to sit under the game's own sounds
+const uiSoundPeak = 0.3
+
+// synthesizeUISound makes a UI sound. Each is the same every time.
+func synthesizeUISound(s uiSound) []float64 {
+	var samples []float64
+	switch s {
+	case uiCursor:
+		samples = glide(1200, 1200, 0.03)
+		decayEnvelope(samples, 0.01)
+	case uiSelect:
+		samples = glide(660, 1320, 0.09)
+		decayEnvelope(samples, 0.04)
+	case uiBack:
+		samples = glide(880, 440, 0.09)
+		decayEnvelope(samples, 0.04)
+	default:
+		samples = squareWave(110, 0.18)
+		lowPass(samples, 2000)
+		decayEnvelope(samples, 0.12)
+	}
+	normalize(samples, uiSoundPeak)
+	return samples
+}
+
+// playUISound plays a UI sound on the UI channel, synthesizing it the first
+// time it is heard
+func (g *Game) playUISound(s uiSound) {
+	if !g.audible(ChannelUI) {
+		return
+	}
+	if g.uiSounds[s] == nil {
+		g.uiSounds[s] = toPCM(synthesizeUISound(s))
+	}
+	g.play(ChannelUI, g.uiSounds[s])
+}
+
+// readMenu reads the menu controls for this update, as every menu does,
+// blipping as the selection moves and chirping as a choice is made or
+// backed out of
+func (g *Game) readMenu() MenuActions {
+	in := g.menuInput.Read(g.steps)
+	switch {
+	case in.Back:
+		g.playUISound(uiBack)
+	case in.Confirm:
+		g.playUISound(uiSelect)
+	case in.Up || in.Down:
+		g.playUISound(uiCursor)
+	}
+	return in
+}
+
+// handleUISounds buzzes when this player's ship tries to fire a weapon it
+// has no ammo left for, which in a network game may be the partner's
+func (g *Game) handleUISounds(e Event) {
+	if e, ok := e.(WeaponEmpty); ok && e.Partner == g.localShip().partner {
+		g.playUISound(uiError)
+	}
+}
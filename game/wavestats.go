@@ -0,0 +1,79 @@
+package game
+
+const (
+	// waveSummaryTicks is how long the end of wave summary is shown for
+	waveSummaryTicks = 2 * ticksPerSecond
+	// accuracyBonusThreshold is the accuracy, in percent, above which a
+	// wave earns an accuracy bonus
+	accuracyBonusThreshold = 50
+	// accuracyBonusPerPercent is the bonus for each percent of accuracy
+	// above the threshold
+	accuracyBonusPerPercent = 10
+)
+
+// waveStats counts what the player did during the current wave
+type waveStats struct {
+	// startTick is the survival tick the wave started on
+	startTick int
+	destroyed int
+	shots     int
+	// hits counts the shots that hit a target. A bullet that passes
+	// through several only counts once.
+	hits int
+	// closeCalls counts the asteroids shot from up close
+	closeCalls int
+}
+
+// accuracy returns the percentage of shots that hit a target, or 0 if none
+// were fired
+func (s waveStats) accuracy() int {
+	if s.shots == 0 {
+		return 0
+	}
+	return 100 * s.hits / s.shots
+}
+
+// accuracyBonus returns the score bonus for finishing a wave with the given
+// accuracy
+func accuracyBonus(accuracy int) int {
+	return max(0, accuracy-accuracyBonusThreshold) * accuracyBonusPerPercent
+}
+
+// bulletLanded counts a bullet hitting a target towards the wave's accuracy
+func (g *Game) bulletLanded(b *Bullet) {
+	if b.landed {
+		return
+	}
+	b.landed = true
+	g.waveStats.hits++
+}
+
+// startWaveStats resets the counters for a new wave
+func (g *Game) startWaveStats() {
+	g.waveStats = waveStats{startTick: g.survivalTicks}
+}
+
+// finishWave sums up the wave just cleared, emitting a WaveSummary whose
+// accuracy bonus is scored along with the WaveCleared before it, before the
+// next wave starts
+func (g *Game) finishWave() {
+	accuracy := g.waveStats.accuracy()
+	g.bestWaveAccuracy = max(g.bestWaveAccuracy, accuracy)
+	g.emit(WaveSummary{
+		Wave:       g.wave,
+		Destroyed:  g.waveStats.destroyed,
+		Shots:      g.waveStats.shots,
+		Accuracy:   accuracy,
+		Ticks:      g.survivalTicks - g.waveStats.startTick,
+		Bonus:      accuracyBonus(accuracy),
+		CloseCalls: g.waveStats.closeCalls,
+	})
+}
+
+// handleWaveSummary shows the summary of each wave as it is cleared
+func (g *Game) handleWaveSummary(e Event) {
+	if e, ok := e.(WaveSummary); ok {
+		g.waveSummary = e
+		g.waveSummaryTicks = waveSummaryTicks
+	}
+}
@@ -0,0 +1,44 @@
+//go:build !headless
+
+package game
+
+import (
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// waveSummaryLines returns the lines of the end of wave summary
+func (g *Game) waveSummaryLines() []string {
+	s := g.waveSummary
+	lines := []string{
+		fmt.Sprintf("WAVE %d CLEAR", s.Wave),
+		fmt.Sprintf("DESTROYED %d", s.Destroyed),
+		fmt.Sprintf("SHOTS %d", s.Shots),
+		fmt.Sprintf("ACCURACY %d%%", s.Accuracy),
+		"TIME " + formatSurvivalTime(s.Ticks),
+	}
+	if s.CloseCalls > 0 {
+		lines = append(lines, fmt.Sprintf("CLOSE CALLS %d", s.CloseCalls))
+	}
+	if s.Bonus > 0 {
+		lines = append(lines, fmt.Sprintf("BONUS +%d", s.Bonus))
+	}
+	return lines
+}
+
+// drawWaveSummary draws the summary of the last wave below the middle of
+// the screen, out of the way of the next wave's banner, while it is showing
+func (g *Game) drawWaveSummary(screen *ebiten.Image) {
+	if g.waveSummaryTicks <= 0 {
+		return
+	}
+	const lineHeight = 30
+	lines := g.waveSummaryLines()
+	y := float32(g.screenHeight) - 40 - float32(len(lines))*lineHeight
+	for _, line := range lines {
+		x := float32(g.screenWidth)/2 - g.vectorFont.GetWidth(line)/2
+		g.vectorFont.DrawString(screen, line, x, y)
+		y += lineHeight
+	}
+}
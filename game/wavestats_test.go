@@ -0,0 +1,77 @@
+package game
+
+import "testing"
+
+func TestAccuracyBonus(t *testing.T) {
+	for accuracy, want := range map[int]int{0: 0, 50: 0, 51: 10, 75: 250, 100: 500} {
+		if got := accuracyBonus(accuracy); got != want {
+			t.Errorf("Expected %d%% accuracy to earn %d, got %d", accuracy, want, got)
+		}
+	}
+}
+
+func TestWaveSummary(t *testing.T) {
+	g := newStationGame(t)
+	events := recordEvents(g)
+	// Four shots, three of which hit, one of them twice
+	g.waveStats = waveStats{startTick: g.survivalTicks - 120, shots: 4}
+	bullets := []*Bullet{{}, {}, {}}
+	for _, b := range append(bullets, bullets[0]) {
+		g.bulletLanded(b)
+	}
+	g.waveStats.destroyed = 5
+
+	if err := g.updatePlaying(); err != nil {
+		t.Fatal(err)
+	}
+	var summary *WaveSummary
+	for _, e := range *events {
+		if s, ok := e.(WaveSummary); ok {
+			summary = &s
+		}
+	}
+	want := WaveSummary{Wave: 1, Destroyed: 5, Shots: 4, Accuracy: 75, Ticks: 121, Bonus: 250}
+	if summary == nil || *summary != want {
+		t.Fatalf("Expected %+v, got %+v", want, summary)
+	}
+	if g.score != want.Bonus {
+		t.Errorf("Expected the accuracy bonus to be scored, got %d", g.score)
+	}
+	// The station bonus is tallied before the next wave
+	g.tally.skip()
+	g.dispatchEvents()
+	if g.score != want.Bonus+stationHP*stationHPBonus {
+		t.Errorf("Expected the station bonus to be tallied, got %d", g.score)
+	}
+	if g.bestWaveAccuracy != 75 || g.waveSummaryTicks != waveSummaryTicks {
+		t.Errorf("Expected the summary to show and the best accuracy to be kept")
+	}
+	if g.waveStats.shots != 0 || g.waveStats.startTick != g.survivalTicks {
+		t.Errorf("Expected the next wave to start with fresh counters, got %+v", g.waveStats)
+	}
+
+	// A worse wave doesn't replace the best
+	g.waveStats = waveStats{shots: 10, hits: 1}
+	g.finishWave()
+	if g.bestWaveAccuracy != 75 {
+		t.Errorf("Expected the best accuracy to stay at 75%%, got %d", g.bestWaveAccuracy)
+	}
+}
+
+func TestWaveStatsCountShots(t *testing.T) {
+	g := New()
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+	target := placeAsteroid(g, 30, g.player.nose().X, g.player.nose().Y-40)
+	target.spawnTick = -1
+	g.createBullet(g.player)
+	for i := 0; i < 10 && !target.Dead(); i++ {
+		if err := g.updatePlaying(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if s := g.waveStats; s.shots != 1 || s.hits != 1 || s.destroyed != 1 {
+		t.Errorf("Expected one shot to hit and destroy, got %+v", s)
+	}
+}
@@ -0,0 +1,227 @@
+package game
+
+import (
+	"math"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+const (
+	// missileSpeed is how fast a missile flies, and missileTurnRate how far
+	// it can turn towards its target each tick, in radians
+	missileSpeed    = 5.0
+	missileTurnRate = 0.08
+	// missileSize and mineSize are the widths of a missile and a mine
+	missileSize = 5.0
+	mineSize    = 8.0
+	// missileLifetime and mineLifetime are how many ticks a missile flies
+	// for and a mine waits for, before they fizzle out
+	missileLifetime = 180
+	mineLifetime    = 900
+	// mineDropDistance is how far behind the ship's center a mine is left
+	mineDropDistance = 20.0
+	// bombRadius is how far from the ship a bomb splits asteroids
+	bombRadius = 150.0
+	// shakePerBomb is how hard a bomb shakes the screen
+	shakePerBomb = 6.0
+)
+
+// SecondaryWeapon is one of the ship's weapons with limited ammo, fired
+// with the secondary fire key and chosen with the radial menu
+type SecondaryWeapon int
+
+const (
+	// WeaponMissile fires a rocket that steers towards the nearest asteroid
+	WeaponMissile SecondaryWeapon = iota
+	// WeaponMine leaves a mine behind the ship, which splits the first
+	// asteroid to run into it
+	WeaponMine
+	// WeaponBomb splits every asteroid around the ship at once
+	WeaponBomb
+	secondaryWeapons
+)
+
+// weaponDef describes a secondary weapon
+type weaponDef struct {
+	name string
+	// ammo is how many shots of it a ship starts a run with
+	ammo int
+	// glyph is its icon, as lines joining points in a box from -1 to 1
+	glyph [][]geometry.Vector2
+	fire  func(g *Game, p *Player)
+}
+
+// weaponDefs are the secondary weapons, in the order they go around the
+// radial menu clockwise from the top
+var weaponDefs = [secondaryWeapons]weaponDef{
+	WeaponMissile: {
+		name: "MISSILE",
+		ammo: 3,
+		glyph: [][]geometry.Vector2{
+			{{X: 0, Y: -1}, {X: 0.35, Y: -0.5}, {X: 0.35, Y: 0.5}, {X: -0.35, Y: 0.5}, {X: -0.35, Y: -0.5}, {X: 0, Y: -1}},
+			{{X: 0.35, Y: 0.1}, {X: 0.75, Y: 0.9}, {X: 0.35, Y: 0.7}},
+			{{X: -0.35, Y: 0.1}, {X: -0.75, Y: 0.9}, {X: -0.35, Y: 0.7}},
+		},
+		fire: (*Game).fireMissile,
+	},
+	WeaponMine: {
+		name: "MINE",
+		ammo: 3,
+		glyph: [][]geometry.Vector2{
+			{{X: 0, Y: -0.55}, {X: 0.55, Y: 0}, {X: 0, Y: 0.55}, {X: -0.55, Y: 0}, {X: 0, Y: -0.55}},
+			{{X: 0, Y: -0.55}, {X: 0, Y: -1}},
+			{{X: 0.55, Y: 0}, {X: 1, Y: 0}},
+			{{X: 0, Y: 0.55}, {X: 0, Y: 1}},
+			{{X: -0.55, Y: 0}, {X: -1, Y: 0}},
+		},
+		fire: (*Game).dropMine,
+	},
+	WeaponBomb: {
+		name: "BOMB",
+		ammo: 1,
+		glyph: [][]geometry.Vector2{
+			glyphCircle(geometry.Vector2{X: -0.1, Y: 0.2}, 0.75, 10),
+			{{X: 0.4, Y: -0.35}, {X: 0.6, Y: -0.75}, {X: 0.9, Y: -0.9}},
+		},
+		fire: (*Game).dropBomb,
+	},
+}
+
+// glyphCircle returns a closed line around a circle, made of n straight
+// sides, for a glyph
+func glyphCircle(center geometry.Vector2, radius float64, n int) []geometry.Vector2 {
+	points := make([]geometry.Vector2, n+1)
+	for i := range points {
+		angle := 2 * math.Pi * float64(i) / float64(n)
+		points[i] = geometry.Vector2{
+			X: center.X + math.Sin(angle)*radius,
+			Y: center.Y - math.Cos(angle)*radius,
+		}
+	}
+	return points
+}
+
+// weaponInventory is the ammo a ship has left for each of its secondary
+// weapons, and which of them it fires
+type weaponInventory struct {
+	ammo     [secondaryWeapons]int
+	selected SecondaryWeapon
+	// highlighted is the weapon picked out on the radial menu while it is
+	// open, which becomes the selected one when it closes
+	highlighted SecondaryWeapon
+}
+
+// newWeaponInventory returns the weapons a ship starts a run with
+func newWeaponInventory() weaponInventory {
+	var w weaponInventory
+	for i, def := range weaponDefs {
+		w.ammo[i] = def.ammo
+	}
+	return w
+}
+
+// fireSecondary fires a ship's selected secondary weapon, if it has any
+// ammo left for it
+func (g *Game) fireSecondary(p *Player) {
+	w := &p.weapons
+	if w.ammo[w.selected] <= 0 {
+		g.emit(WeaponEmpty{Weapon: w.selected, Partner: p == g.partner})
+		return
+	}
+	w.ammo[w.selected]--
+	weaponDefs[w.selected].fire(g, p)
+}
+
+// newSecondaryShot makes a bullet for a secondary weapon, scaled up to size
+// from the usual 2x2. Secondary shots aren't counted towards accuracy.
+func newSecondaryShot(p *Player, position, velocity geometry.Vector2, size float64, lifetime float64) *Bullet {
+	b := newBullet(position, velocity)
+	b.owner = p.owner()
+	b.polygon.SetScale(size / 2)
+	b.lifetime.Remaining = lifetime
+	b.landed = true
+	return b
+}
+
+// fireMissile launches a missile from the ship's nose, which steers towards
+// the nearest asteroid
+func (g *Game) fireMissile(p *Player) {
+	ship := p.polygon
+	velocity := geometry.Vector2{
+		X: math.Sin(ship.Rotation)*missileSpeed + ship.Velocity.X,
+		Y: -math.Cos(ship.Rotation)*missileSpeed + ship.Velocity.Y,
+	}
+	m := newSecondaryShot(p, p.nose(), velocity, missileSize, missileLifetime)
+	m.onUpdate = func(g *Game) { g.steerMissile(m) }
+	g.addEntity(m)
+}
+
+// steerMissile turns a missile towards the nearest asteroid, by at most its
+// turn rate, and keeps it flying at its full speed
+func (g *Game) steerMissile(m *Bullet) {
+	position := m.polygon.Position
+	var target geometry.Vector2
+	closest := math.Inf(1)
+	for _, e := range g.entities {
+		a, ok := e.(*Asteroid)
+		if !ok || a.Dead() {
+			continue
+		}
+		delta := geometry.WrapDelta(position, centerOf(a.polygon), g.screenWidth, g.screenHeight)
+		if d := math.Hypot(delta.X, delta.Y); d < closest {
+			target, closest = delta, d
+		}
+	}
+	if math.IsInf(closest, 1) {
+		return
+	}
+
+	// Angles are measured clockwise from straight up the screen
+	velocity := m.polygon.Velocity
+	heading := math.Atan2(velocity.X, -velocity.Y)
+	turn := geometry.AngleDifference(heading, math.Atan2(target.X, -target.Y))
+	limit := missileTurnRate * g.TimeScale()
+	heading += math.Max(-limit, math.Min(limit, turn))
+	m.polygon.Velocity = geometry.Vector2{
+		X: math.Sin(heading) * missileSpeed,
+		Y: -math.Cos(heading) * missileSpeed,
+	}
+	m.polygon.SetRotation(heading)
+}
+
+// dropMine leaves a mine behind the ship, where it stays until an asteroid
+// runs into it or it fizzles out
+func (g *Game) dropMine(p *Player) {
+	ship := p.polygon
+	position := geometry.Vector2{
+		X: ship.Position.X - math.Sin(ship.Rotation)*mineDropDistance,
+		Y: ship.Position.Y + math.Cos(ship.Rotation)*mineDropDistance,
+	}
+	mine := newSecondaryShot(p, position, geometry.Vector2{}, mineSize, mineLifetime)
+	mine.polygon.RotationSpeed = 0.05
+	g.addEntity(mine)
+}
+
+// dropBomb splits every asteroid within bombRadius of the ship, whatever
+// its armor, with a shockwave the size of the blast
+func (g *Game) dropBomb(p *Player) {
+	center := p.polygon.Position
+	by := p.owner()
+	for _, e := range g.entities {
+		a, ok := e.(*Asteroid)
+		if !ok || a.Dead() {
+			continue
+		}
+		delta := geometry.WrapDelta(center, centerOf(a.polygon), g.screenWidth, g.screenHeight)
+		if math.Hypot(delta.X, delta.Y) > bombRadius+approximateRadius(a.polygon) {
+			continue
+		}
+		if a.orbit != nil {
+			g.shootMoonlet(a, by)
+			continue
+		}
+		g.splitAsteroid(a, by)
+	}
+	g.addEntity(newShockwave(center, bombRadius/shockwaveEndScale))
+	g.shake(shakePerBomb)
+}
@@ -0,0 +1,46 @@
+//go:build !headless
+
+package game
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+// drawGlyph draws a weapon's icon centered on (x, y), size wide
+func drawGlyph(screen *ebiten.Image, glyph [][]geometry.Vector2, x, y, size float32, c color.Color) {
+	half := size / 2
+	for _, line := range glyph {
+		for i := 1; i < len(line); i++ {
+			from, to := line[i-1], line[i]
+			vector.StrokeLine(screen,
+				x+float32(from.X)*half, y+float32(from.Y)*half,
+				x+float32(to.X)*half, y+float32(to.Y)*half,
+				1, c, true)
+		}
+	}
+}
+
+// drawWeaponSlot draws the local ship's selected secondary weapon and its
+// ammo at the bottom middle of the screen
+func (g *Game) drawWeaponSlot(screen *ebiten.Image) {
+	const size = 16
+	w := g.localShip().weapons
+	text := fmt.Sprintf("X%d", w.ammo[w.selected])
+	width := size + 8 + g.vectorFont.GetWidth(text)
+	x := (float32(g.screenWidth) - width) / 2
+	y := float32(g.screenHeight) - 20 - size
+	c := g.palette.UI
+	if w.ammo[w.selected] == 0 {
+		c = g.palette.UIDim
+	}
+	drawGlyph(screen, weaponDefs[w.selected].glyph, x+size/2, y+size/2, size, c)
+	g.vectorFont.SetColor(c)
+	g.vectorFont.DrawString(screen, text, x+size+8, y-4)
+	g.vectorFont.SetColor(g.palette.UI)
+}
@@ -0,0 +1,75 @@
+package game
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSecondaryFireUsesAmmo(t *testing.T) {
+	g := newWeaponsGame()
+	g.player.weapons.selected = WeaponMine
+	for range weaponDefs[WeaponMine].ammo + 1 {
+		g.input = InputState{Secondary: true}
+		stepTicks(t, g, 1)
+		g.input = InputState{}
+		stepTicks(t, g, 1)
+	}
+	if got := g.countEntities(TagBullet); got != weaponDefs[WeaponMine].ammo {
+		t.Errorf("Expected %d mines, got %d", weaponDefs[WeaponMine].ammo, got)
+	}
+	if g.player.weapons.ammo[WeaponMine] != 0 {
+		t.Errorf("Expected no mines left, got %d", g.player.weapons.ammo[WeaponMine])
+	}
+	if g.waveStats.shots != 0 {
+		t.Errorf("Expected secondary fire not to count as shots, got %d", g.waveStats.shots)
+	}
+}
+
+func TestMineStaysPut(t *testing.T) {
+	g := newWeaponsGame()
+	g.player.polygon.Velocity.X = 3
+	g.dropMine(g.player)
+	mine := g.entities[len(g.entities)-1].(*Bullet)
+	position := mine.polygon.Position
+
+	stepTicks(t, g, 10)
+	if mine.Dead() || mine.polygon.Position != position {
+		t.Errorf("Expected the mine to wait where it was dropped, got %v from %v", mine.polygon.Position, position)
+	}
+}
+
+func TestMissileSteersToAsteroid(t *testing.T) {
+	g := newWeaponsGame()
+	ship := g.player.polygon
+	// Off to the right of the ship, which faces up the screen
+	placeAsteroid(g, 10, ship.Position.X+150, ship.Position.Y)
+	g.fireMissile(g.player)
+	missile := g.entities[len(g.entities)-1].(*Bullet)
+
+	stepTicks(t, g, 20)
+	velocity := missile.polygon.Velocity
+	if velocity.X <= 0 {
+		t.Errorf("Expected the missile to turn towards the asteroid, got velocity %v", velocity)
+	}
+	if speed := math.Hypot(velocity.X, velocity.Y); math.Abs(speed-missileSpeed) > 1e-9 {
+		t.Errorf("Expected the missile to keep its speed, got %v", speed)
+	}
+}
+
+func TestBombSplitsNearbyAsteroids(t *testing.T) {
+	g := newWeaponsGame()
+	ship := g.player.polygon
+	near := placeAsteroid(g, 20, ship.Position.X+100, ship.Position.Y)
+	far := placeAsteroid(g, 20, ship.Position.X+bombRadius+50, ship.Position.Y)
+
+	g.dropBomb(g.player)
+	if !near.Dead() {
+		t.Errorf("Expected the asteroid in the blast to be split")
+	}
+	if far.Dead() {
+		t.Errorf("Expected the asteroid outside the blast to be left alone")
+	}
+	if g.countEntities(TagEffect) == 0 {
+		t.Errorf("Expected a shockwave from the blast")
+	}
+}
@@ -0,0 +1,82 @@
+package game
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+const (
+	// maxSolarWind is the strongest the solar wind blows, as an
+	// acceleration in pixels per frame squared
+	maxSolarWind = 0.01
+	// windTerminalSpeed is the speed along the wind at which it stops
+	// pushing, so a long wave doesn't leave everything racing downwind
+	windTerminalSpeed = 2.0
+	// dustCount is how many specks of dust show the wind
+	dustCount = 60
+	// dustDrift is how fast the dust moves for each unit of wind, in pixels
+	// per frame
+	dustDrift = 100.0
+)
+
+// rollWind picks the solar wind for a new wave: a random direction and
+// strength, or none on easy
+func (g *Game) rollWind() {
+	g.wind = geometry.Vector2{}
+	if g.config.Difficulty == DifficultyEasy {
+		return
+	}
+	angle := g.rng.Float64() * 2 * math.Pi
+	strength := g.rng.Float64() * maxSolarWind
+	g.wind = geometry.Vector2{X: math.Cos(angle) * strength, Y: math.Sin(angle) * strength}
+}
+
+// applyWind pushes the asteroids and bullets along with the solar wind,
+// until they are moving downwind at windTerminalSpeed. The ship is
+// shielded from it.
+func (g *Game) applyWind() {
+	if g.wind == (geometry.Vector2{}) {
+		return
+	}
+	for _, e := range g.entities {
+		switch e.Tag() {
+		case TagAsteroid, TagBullet, TagEnemyBullet:
+			g.blow(e.Collider())
+		}
+	}
+}
+
+// blow pushes a polygon along with the wind, unless it is already drifting
+// downwind at the wind's terminal speed
+func (g *Game) blow(p *geometry.PolygonObject) {
+	strength := math.Hypot(g.wind.X, g.wind.Y)
+	if strength == 0 {
+		return
+	}
+	downwind := (p.Velocity.X*g.wind.X + p.Velocity.Y*g.wind.Y) / strength
+	if downwind < windTerminalSpeed {
+		p.ApplyForce(g.wind.X, g.wind.Y)
+	}
+}
+
+// newDust scatters the specks of dust that show the wind over the screen.
+// It has its own random numbers, so it doesn't change how runs play out.
+func (g *Game) newDust() {
+	rng := rand.New(rand.NewSource(g.seed))
+	g.dust = make([]geometry.Vector2, dustCount)
+	for i := range g.dust {
+		g.dust[i] = geometry.Vector2{X: rng.Float64() * g.screenWidth, Y: rng.Float64() * g.screenHeight}
+	}
+}
+
+// updateDust blows the dust along with the wind, wrapping at the edges
+func (g *Game) updateDust(timeScale float64) {
+	for i := range g.dust {
+		d := &g.dust[i]
+		d.X += g.wind.X * dustDrift * timeScale
+		d.Y += g.wind.Y * dustDrift * timeScale
+		*d = geometry.WrapPosition(*d, g.screenWidth, g.screenHeight)
+	}
+}
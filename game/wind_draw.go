@@ -0,0 +1,26 @@
+//go:build !headless
+
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// drawDust draws the dust as faint specks behind everything else
+func (g *Game) drawDust(screen *ebiten.Image) {
+	for _, d := range g.dust {
+		vector.FillRect(screen, float32(d.X), float32(d.Y), 1, 1, g.palette.UIDim, false)
+	}
+}
+
+// drawWindArrow shows the solar wind on the debug overlay, as an arrow from
+// the middle of the screen scaled up to be visible
+func (g *Game) drawWindArrow(screen *ebiten.Image) {
+	const scale = 5000
+	x := float32(g.screenWidth / 2)
+	y := float32(g.screenHeight / 2)
+	dx, dy := float32(g.wind.X*scale), float32(g.wind.Y*scale)
+	vector.StrokeLine(screen, x, y, x+dx, y+dy, 1, debugVisualColor, true)
+	vector.StrokeCircle(screen, x+dx, y+dy, 3, 1, debugVisualColor, true)
+}
@@ -0,0 +1,56 @@
+package game
+
+import (
+	"math"
+	"testing"
+
+	"github.com/AndreRenaud/SpaceDebris/geometry"
+)
+
+func TestRollWind(t *testing.T) {
+	g := New(WithSeed(3))
+	for i := 0; i < 20; i++ {
+		g.rollWind()
+		if strength := math.Hypot(g.wind.X, g.wind.Y); strength > maxSolarWind {
+			t.Errorf("Expected the wind to be at most %v, got %v", maxSolarWind, strength)
+		}
+	}
+	if g.wind == (geometry.Vector2{}) {
+		t.Errorf("Expected some wind")
+	}
+
+	g.config.Difficulty = DifficultyEasy
+	g.rollWind()
+	if g.wind != (geometry.Vector2{}) {
+		t.Errorf("Expected no wind on easy, got %v", g.wind)
+	}
+}
+
+func TestWindPushesAsteroidsAndBullets(t *testing.T) {
+	g := New()
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.entities = []Entity{g.player}
+	g.wind = geometry.Vector2{X: maxSolarWind}
+	asteroid := placeAsteroid(g, 20, 100, 100)
+	bullet := newBullet(geometry.Vector2{X: 300, Y: 100}, geometry.Vector2{Y: -8})
+	g.addEntity(bullet)
+	speeding := placeAsteroid(g, 20, 500, 300)
+	speeding.polygon.SetVelocity(windTerminalSpeed, 0)
+
+	if err := g.updatePlaying(); err != nil {
+		t.Fatal(err)
+	}
+	if v := asteroid.polygon.Velocity; v.X <= 0 || v.Y != 0 {
+		t.Errorf("Expected the asteroid to be blown downwind, got velocity %v", v)
+	}
+	if v := bullet.polygon.Velocity; v.X <= 0 {
+		t.Errorf("Expected the bullet to curve downwind, got velocity %v", v)
+	}
+	if v := g.player.polygon.Velocity; v != (geometry.Vector2{}) {
+		t.Errorf("Expected the ship to be shielded from the wind, got velocity %v", v)
+	}
+	if v := speeding.polygon.Velocity; v.X != windTerminalSpeed {
+		t.Errorf("Expected no push past the terminal speed, got velocity %v", v)
+	}
+}
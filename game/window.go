@@ -0,0 +1,100 @@
+package game
+
+import (
+	"fmt"
+)
+
+// WindowState is the size, position and display mode of the game's window,
+// remembered in the config file so the next launch opens the same way
+type WindowState struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+	// X and Y are the window's position on the monitor, only meaningful if
+	// HasPosition is set. Browsers don't have one.
+	X           int  `json:"x,omitempty"`
+	Y           int  `json:"y,omitempty"`
+	HasPosition bool `json:"has_position,omitempty"`
+	Maximized   bool `json:"maximized,omitempty"`
+	Fullscreen  bool `json:"fullscreen,omitempty"`
+}
+
+// Clamp fits the window onto a monitor of the given size, shrinking it if
+// it is too big and moving it back on screen if it has wandered off. A
+// monitor size of zero means it isn't known, and leaves the window alone.
+func (w WindowState) Clamp(monitorWidth, monitorHeight int) WindowState {
+	if monitorWidth <= 0 || monitorHeight <= 0 {
+		return w
+	}
+	w.Width = min(w.Width, monitorWidth)
+	w.Height = min(w.Height, monitorHeight)
+	if w.HasPosition {
+		w.X = max(0, min(w.X, monitorWidth-w.Width))
+		w.Y = max(0, min(w.Y, monitorHeight-w.Height))
+	}
+	return w
+}
+
+// WithWindowPersistence saves the window's state to the config file when
+// the game quits
+func WithWindowPersistence() Option {
+	return func(g *Game) {
+		g.persistWindow = true
+	}
+}
+
+// WindowTitle is the game's window title when nothing more is shown in it
+const WindowTitle = "SpaceDebris"
+
+// windowTitleInterval is the fewest ticks between changes to the window
+// title. Changing it every frame is wasteful, and flickers with some window
+// managers.
+const windowTitleInterval = ticksPerSecond
+
+// WithLiveWindowTitle keeps the window title up to date with the wave and
+// score of the run being played, unless the config asks for a plain title
+func WithLiveWindowTitle() Option {
+	return func(g *Game) {
+		g.liveWindowTitle = true
+		g.windowTitle = WindowTitle
+	}
+}
+
+// windowTitleText returns what the window title should currently say
+func (g *Game) windowTitleText() string {
+	if g.config.PlainWindowTitle {
+		return WindowTitle
+	}
+	switch g.state {
+	case GameStatePaused:
+		return WindowTitle + " — PAUSED"
+	case GameStateSettings:
+		if g.settingsReturn == GameStatePaused {
+			return WindowTitle + " — PAUSED"
+		}
+	case GameStatePlaying, GameStateGetReady, GameStateDeathReview, GameStateGameOver:
+		// Endless mode has no waves, and is played for time instead
+		progress := fmt.Sprintf("Wave %d", g.wave)
+		if g.mode == ModeEndless {
+			progress = formatSurvivalTime(g.survivalTicks)
+		}
+		return WindowTitle + " — " + progress + " — " + formatScore(g.score) + " pts"
+	}
+	return WindowTitle
+}
+
+// updateWindowTitle changes the window title to match the game, at most
+// once every windowTitleInterval
+func (g *Game) updateWindowTitle() {
+	if !g.liveWindowTitle {
+		return
+	}
+	g.windowTitleTicks += g.steps
+	if g.windowTitleTicks < windowTitleInterval {
+		return
+	}
+	if title := g.windowTitleText(); title != g.windowTitle {
+		setWindowTitle(title)
+		g.windowTitle = title
+		g.windowTitleTicks = 0
+	}
+}
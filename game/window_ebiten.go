@@ -0,0 +1,46 @@
+//go:build !headless
+
+package game
+
+import (
+	"runtime"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// CurrentWindowState reads the state of the game's window. The size is the
+// windowed size, even while fullscreen.
+func CurrentWindowState() WindowState {
+	var w WindowState
+	w.Width, w.Height = ebiten.WindowSize()
+	if runtime.GOOS != "js" {
+		w.X, w.Y = ebiten.WindowPosition()
+		w.HasPosition = true
+	}
+	w.Maximized = ebiten.IsWindowMaximized()
+	w.Fullscreen = ebiten.IsFullscreen()
+	return w
+}
+
+// Apply sets up the game's window to match. It is meant to be called before
+// the game starts running.
+func (w WindowState) Apply() {
+	ebiten.SetWindowSize(w.Width, w.Height)
+	if w.HasPosition {
+		ebiten.SetWindowPosition(w.X, w.Y)
+	}
+	if w.Maximized {
+		ebiten.MaximizeWindow()
+	}
+	ebiten.SetFullscreen(w.Fullscreen)
+}
+
+// setWindowTitle changes the title of the game's window
+func setWindowTitle(title string) {
+	ebiten.SetWindowTitle(title)
+}
+
+// windowClosing reports whether the window is being closed
+func windowClosing() bool {
+	return ebiten.IsWindowBeingClosed()
+}
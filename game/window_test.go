@@ -0,0 +1,92 @@
+package game
+
+import "testing"
+
+func TestWindowClamp(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		in   WindowState
+		want WindowState
+	}{
+		{
+			name: "fits",
+			in:   WindowState{Width: 800, Height: 600, X: 100, Y: 50, HasPosition: true},
+			want: WindowState{Width: 800, Height: 600, X: 100, Y: 50, HasPosition: true},
+		},
+		{
+			name: "too big",
+			in:   WindowState{Width: 2560, Height: 1440, X: 10, Y: 10, HasPosition: true},
+			want: WindowState{Width: 1280, Height: 1024, HasPosition: true},
+		},
+		{
+			name: "off the edge",
+			in:   WindowState{Width: 800, Height: 600, X: 2000, Y: -300, HasPosition: true},
+			want: WindowState{Width: 800, Height: 600, X: 480, Y: 0, HasPosition: true},
+		},
+		{
+			name: "no position",
+			in:   WindowState{Width: 800, Height: 600, X: 2000, Fullscreen: true},
+			want: WindowState{Width: 800, Height: 600, X: 2000, Fullscreen: true},
+		},
+	} {
+		if got := test.in.Clamp(1280, 1024); got != test.want {
+			t.Errorf("%s: expected %+v, got %+v", test.name, test.want, got)
+		}
+	}
+
+	// An unknown monitor leaves the window alone
+	w := WindowState{Width: 5000, Height: 5000}
+	if got := w.Clamp(0, 0); got != w {
+		t.Errorf("Expected %+v, got %+v", w, got)
+	}
+}
+
+func TestWindowTitleText(t *testing.T) {
+	g := New()
+	if got := g.windowTitleText(); got != WindowTitle {
+		t.Errorf("Expected the plain title on the title screen, got %q", got)
+	}
+
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.wave = 3
+	g.score = 1240
+	if got, want := g.windowTitleText(), "SpaceDebris — Wave 3 — 1,240 pts"; got != want {
+		t.Errorf("Expected %q while playing, got %q", want, got)
+	}
+
+	g.pause()
+	if got, want := g.windowTitleText(), "SpaceDebris — PAUSED"; got != want {
+		t.Errorf("Expected %q while paused, got %q", want, got)
+	}
+
+	g.config.PlainWindowTitle = true
+	if got := g.windowTitleText(); got != WindowTitle {
+		t.Errorf("Expected the plain title when turned off, got %q", got)
+	}
+}
+
+func TestWindowTitleRateLimited(t *testing.T) {
+	g := New(WithLiveWindowTitle())
+	g.Restart(RestartOptions{})
+	g.startPlaying()
+	g.steps = 1
+	for i := 1; i < windowTitleInterval; i++ {
+		g.updateWindowTitle()
+	}
+	if g.windowTitle != WindowTitle {
+		t.Fatalf("Expected the title to wait, got %q", g.windowTitle)
+	}
+	g.updateWindowTitle()
+	want := g.windowTitleText()
+	if g.windowTitle != want {
+		t.Fatalf("Expected the title %q after a second, got %q", want, g.windowTitle)
+	}
+
+	// A change straight after has to wait again
+	g.score += 100
+	g.updateWindowTitle()
+	if g.windowTitle != want {
+		t.Errorf("Expected the title to be held for a second, got %q", g.windowTitle)
+	}
+}
@@ -0,0 +1,140 @@
+package geometry
+
+import (
+	"math"
+	"slices"
+)
+
+// lineCrossing is where a line crosses an edge of an outline
+type lineCrossing struct {
+	// edge is the index of the vertex the edge starts from
+	edge int
+	// t is how far along the line the crossing is, in multiples of its
+	// direction from its point
+	t     float64
+	point Vector2
+}
+
+// lineCrossings returns every point where the line through point, running
+// along direction, crosses the closed outline through vertices, in order
+// along the line. Vertices lying exactly on the line count as being on its
+// left, as if it were nudged a hair to the right, so a line through a
+// vertex crosses there once or not at all rather than twice.
+func lineCrossings(vertices []Vector2, point, direction Vector2) []lineCrossing {
+	side := func(v Vector2) float64 {
+		return direction.X*(v.Y-point.Y) - direction.Y*(v.X-point.X)
+	}
+	lengthSquared := direction.X*direction.X + direction.Y*direction.Y
+
+	var crossings []lineCrossing
+	for i, a := range vertices {
+		b := vertices[(i+1)%len(vertices)]
+		sa, sb := side(a), side(b)
+		if (sa >= 0) == (sb >= 0) {
+			continue
+		}
+		f := sa / (sa - sb)
+		at := Vector2{X: a.X + (b.X-a.X)*f, Y: a.Y + (b.Y-a.Y)*f}
+		t := ((at.X-point.X)*direction.X + (at.Y-point.Y)*direction.Y) / lengthSquared
+		crossings = append(crossings, lineCrossing{edge: i, t: t, point: at})
+	}
+	slices.SortFunc(crossings, func(a, b lineCrossing) int {
+		switch {
+		case a.t < b.t:
+			return -1
+		case a.t > b.t:
+			return 1
+		}
+		return 0
+	})
+	return crossings
+}
+
+// samePoint reports whether a and b are close enough to be one vertex
+func samePoint(a, b Vector2) bool {
+	return math.Abs(a.X-b.X) < 1e-9 && math.Abs(a.Y-b.Y) < 1e-9
+}
+
+// appendDistinct appends v to vertices unless it is where the last one
+// already is
+func appendDistinct(vertices []Vector2, v Vector2) []Vector2 {
+	if n := len(vertices); n > 0 && samePoint(vertices[n-1], v) {
+		return vertices
+	}
+	return append(vertices, v)
+}
+
+// outlineBetween returns the outline that runs from the crossing from, round
+// the vertices to the crossing to, and back along the cut
+func outlineBetween(vertices []Vector2, from, to lineCrossing) []Vector2 {
+	n := len(vertices)
+	piece := []Vector2{from.point}
+	for i := (from.edge + 1) % n; ; i = (i + 1) % n {
+		piece = appendDistinct(piece, vertices[i])
+		if i == to.edge {
+			break
+		}
+	}
+	piece = appendDistinct(piece, to.point)
+	// The outline closes back on its first point by itself
+	if len(piece) > 1 && samePoint(piece[0], piece[len(piece)-1]) {
+		piece = piece[:len(piece)-1]
+	}
+	return piece
+}
+
+// CutPolygon cuts the closed outline through vertices in two along the
+// line through point running along direction, and returns the outlines of
+// the two pieces, one either side of the cut. A line can cross a concave
+// outline more than twice, in which case it is cut along the stretch of the
+// line inside the outline around point, or the nearest one to it if point
+// is outside.
+//
+// ok is false if the line misses the outline or only grazes it, or if
+// either side isn't a simple polygon with at least 3 vertices and some
+// area, so the caller can fall back to something else.
+func CutPolygon(vertices []Vector2, point, direction Vector2) (a, b []Vector2, ok bool) {
+	if len(vertices) < 3 || (direction.X == 0 && direction.Y == 0) {
+		return nil, nil, false
+	}
+	crossings := lineCrossings(vertices, point, direction)
+	if len(crossings) < 2 || len(crossings)%2 != 0 {
+		return nil, nil, false
+	}
+
+	// Consecutive pairs of crossings bound the stretches of the line inside
+	// the outline. Pick the one around point, or else the nearest.
+	best, bestDistance := 0, math.Inf(1)
+	for i := 0; i < len(crossings); i += 2 {
+		distance := math.Max(0, math.Max(crossings[i].t, -crossings[i+1].t))
+		if distance < bestDistance {
+			best, bestDistance = i, distance
+		}
+	}
+	from, to := crossings[best], crossings[best+1]
+
+	a = outlineBetween(vertices, from, to)
+	b = outlineBetween(vertices, to, from)
+	for _, piece := range [][]Vector2{a, b} {
+		if !IsSimplePolygon(piece) || CreatePolygon(piece).Area() < 1e-9 {
+			return nil, nil, false
+		}
+	}
+	return a, b, true
+}
+
+// Recenter moves the polygon's origin to the centroid of its outline,
+// moving its Position to match so it stays where it is in the world
+func (p *PolygonObject) Recenter() {
+	centroid := p.Centroid()
+	p.Position = p.ToWorld(centroid)
+	for i := range p.Vertices {
+		p.Vertices[i].X -= centroid.X
+		p.Vertices[i].Y -= centroid.Y
+	}
+	for i := range p.CollisionVertices {
+		p.CollisionVertices[i].X -= centroid.X
+		p.CollisionVertices[i].Y -= centroid.Y
+	}
+	p.cacheValid = false
+}
@@ -0,0 +1,125 @@
+package geometry
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// outlineArea returns the area enclosed by an outline
+func outlineArea(vertices []Vector2) float64 {
+	return CreatePolygon(vertices).Area()
+}
+
+func TestCutPolygonConvex(t *testing.T) {
+	square := []Vector2{{X: -10, Y: -10}, {X: 10, Y: -10}, {X: 10, Y: 10}, {X: -10, Y: 10}}
+	tests := []struct {
+		name             string
+		point, direction Vector2
+		areas            [2]float64
+	}{
+		{"through the middle", Vector2{}, Vector2{Y: 1}, [2]float64{200, 200}},
+		{"off center", Vector2{X: 5}, Vector2{Y: -3}, [2]float64{100, 300}},
+		{"diagonal through corners", Vector2{}, Vector2{X: 1, Y: 1}, [2]float64{200, 200}},
+		{"from outside", Vector2{X: -5, Y: -40}, Vector2{Y: 1}, [2]float64{100, 300}},
+	}
+	for _, tt := range tests {
+		a, b, ok := CutPolygon(square, tt.point, tt.direction)
+		if !ok {
+			t.Errorf("%s: expected the square to be cut", tt.name)
+			continue
+		}
+		areas := [2]float64{outlineArea(a), outlineArea(b)}
+		if areas[0] > areas[1] {
+			areas[0], areas[1] = areas[1], areas[0]
+		}
+		if math.Abs(areas[0]-tt.areas[0]) > 1e-9 || math.Abs(areas[1]-tt.areas[1]) > 1e-9 {
+			t.Errorf("%s: expected pieces of area %v, got %v", tt.name, tt.areas, areas)
+		}
+	}
+}
+
+func TestCutPolygonMisses(t *testing.T) {
+	square := []Vector2{{X: -10, Y: -10}, {X: 10, Y: -10}, {X: 10, Y: 10}, {X: -10, Y: 10}}
+	for _, tt := range []struct {
+		name             string
+		point, direction Vector2
+	}{
+		{"clear of it", Vector2{X: 20}, Vector2{Y: 1}},
+		{"along an edge", Vector2{X: 10}, Vector2{Y: 1}},
+		{"through one corner", Vector2{X: 10, Y: -10}, Vector2{X: 1, Y: 1}},
+		{"without a direction", Vector2{}, Vector2{}},
+	} {
+		if _, _, ok := CutPolygon(square, tt.point, tt.direction); ok {
+			t.Errorf("%s: expected the cut to fail", tt.name)
+		}
+	}
+}
+
+func TestCutPolygonConcave(t *testing.T) {
+	// A U shape, open at the top, whose arms a horizontal line crosses
+	// twice each
+	u := []Vector2{
+		{X: -30, Y: -30}, {X: -10, Y: -30}, {X: -10, Y: 10}, {X: 10, Y: 10},
+		{X: 10, Y: -30}, {X: 30, Y: -30}, {X: 30, Y: 30}, {X: -30, Y: 30},
+	}
+	// Through the left arm, which is cut off on its own
+	a, b, ok := CutPolygon(u, Vector2{X: -20, Y: -20}, Vector2{X: 1})
+	if !ok {
+		t.Fatalf("Expected the U to be cut through its left arm")
+	}
+	areas := []float64{outlineArea(a), outlineArea(b)}
+	if math.Min(areas[0], areas[1]) != 200 || areas[0]+areas[1] != outlineArea(u) {
+		t.Errorf("Expected the tip of the arm, 200 in area, to be cut off, got %v", areas)
+	}
+	// Between the arms the nearest stretch inside is used, the right one
+	if _, _, ok := CutPolygon(u, Vector2{X: 4, Y: -20}, Vector2{X: 1}); !ok {
+		t.Errorf("Expected a cut from between the arms to use the nearer arm")
+	}
+	// Across the base, below the gap
+	a, b, ok = CutPolygon(u, Vector2{Y: 20}, Vector2{X: 1})
+	if !ok || outlineArea(a)+outlineArea(b) != outlineArea(u) {
+		t.Errorf("Expected the base to be cut off")
+	}
+}
+
+func TestCutPolygonPiecesAreSimple(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for range 1000 {
+		outline := CreateAsteroid(30, 10, 6+rng.Intn(8)).Vertices
+		point := Vector2{X: rng.Float64()*40 - 20, Y: rng.Float64()*40 - 20}
+		angle := rng.Float64() * 2 * math.Pi
+		a, b, ok := CutPolygon(outline, point, Vector2{X: math.Cos(angle), Y: math.Sin(angle)})
+		if !ok {
+			continue
+		}
+		if !IsSimplePolygon(a) || !IsSimplePolygon(b) {
+			t.Fatalf("Expected simple pieces cutting %v, got %v and %v", outline, a, b)
+		}
+		if total := outlineArea(a) + outlineArea(b); math.Abs(total-outlineArea(outline)) > 1e-6 {
+			t.Fatalf("Expected the pieces to add up to the whole, got %v of %v", total, outlineArea(outline))
+		}
+	}
+}
+
+func TestRecenter(t *testing.T) {
+	p := CreatePolygon([]Vector2{{X: 10, Y: 0}, {X: 20, Y: 0}, {X: 20, Y: 10}, {X: 10, Y: 10}})
+	p.SetPosition(100, 50)
+	p.SetRotation(math.Pi / 2)
+	p.SetScale(2)
+	before := append(DrawablePolygon(nil), p.TransformedVertices()...)
+
+	p.Recenter()
+	if c := p.Centroid(); math.Abs(c.X) > 1e-9 || math.Abs(c.Y) > 1e-9 {
+		t.Errorf("Expected the centroid at the origin, got %v", c)
+	}
+	// The centroid (15, 5), scaled, rotated a quarter turn and moved
+	if math.Abs(p.Position.X-90) > 1e-9 || math.Abs(p.Position.Y-80) > 1e-9 {
+		t.Errorf("Expected the position to move to (90, 80), got %v", p.Position)
+	}
+	for i, v := range p.TransformedVertices() {
+		if math.Abs(v.X-before[i].X) > 1e-9 || math.Abs(v.Y-before[i].Y) > 1e-9 {
+			t.Errorf("Vertex %d: expected it to stay at %v, got %v", i, before[i], v)
+		}
+	}
+}
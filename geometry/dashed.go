@@ -0,0 +1,42 @@
+package geometry
+
+import (
+	"math"
+)
+
+// segment is a straight line between two points
+type segment struct {
+	from, to Vector2
+}
+
+// dashes splits the line from one point to another into dashes of the given
+// length with gaps as long between them. phase is how far into the pattern
+// of dash and gap the line starts, and the phase the line ends at is
+// returned, so a path of several lines can carry its pattern on.
+func dashes(from, to Vector2, dash, phase float64) ([]segment, float64) {
+	length := math.Hypot(to.X-from.X, to.Y-from.Y)
+	if length == 0 || dash <= 0 {
+		return nil, phase
+	}
+	dx, dy := (to.X-from.X)/length, (to.Y-from.Y)/length
+	at := func(d float64) Vector2 {
+		return Vector2{X: from.X + dx*d, Y: from.Y + dy*d}
+	}
+
+	var out []segment
+	period := 2 * dash
+	phase = math.Mod(phase, period)
+	for d := 0.0; d < length; {
+		var next float64
+		if phase < dash {
+			// In a dash, which runs until the end of the dash or the line
+			next = math.Min(d+dash-phase, length)
+			out = append(out, segment{at(d), at(next)})
+		} else {
+			next = math.Min(d+period-phase, length)
+		}
+		phase = math.Mod(phase+next-d, period)
+		d = next
+	}
+	return out, phase
+}
@@ -0,0 +1,52 @@
+//go:build !headless
+
+package geometry
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// DrawDashedLine draws a line from one point to another as dashes of the
+// given length, with gaps as long between them
+func DrawDashedLine(screen *ebiten.Image, from, to Vector2, dash float64, width float32, c color.Color) {
+	segments, _ := dashes(from, to, dash, 0)
+	strokeSegments(screen, segments, width, c)
+}
+
+// DrawDashedPath draws a path through the points as dashes of the given
+// length, with gaps as long between them, the pattern carrying on from one
+// point to the next. A step between points that wraps around the screen is
+// drawn leaving one edge and coming in at the opposite one, rather than
+// across the whole screen.
+func DrawDashedPath(screen *ebiten.Image, points []Vector2, dash float64, width float32, c color.Color) {
+	bounds := screen.Bounds()
+	w, h := float64(bounds.Dx()), float64(bounds.Dy())
+	phase := 0.0
+	for i := 1; i < len(points); i++ {
+		from, to := points[i-1], points[i]
+		delta := WrapDelta(from, to, w, h)
+		// Where each end would be without the wrap
+		unwrappedTo := Vector2{X: from.X + delta.X, Y: from.Y + delta.Y}
+		segments, next := dashes(from, unwrappedTo, dash, phase)
+		if unwrappedTo != to {
+			unwrappedFrom := Vector2{X: to.X - delta.X, Y: to.Y - delta.Y}
+			incoming, _ := dashes(unwrappedFrom, to, dash, phase)
+			segments = append(segments, incoming...)
+		}
+		strokeSegments(screen, segments, width, c)
+		phase = next
+	}
+}
+
+// strokeSegments draws each segment as an antialiased line
+func strokeSegments(screen *ebiten.Image, segments []segment, width float32, c color.Color) {
+	for _, s := range segments {
+		vector.StrokeLine(screen,
+			float32(s.from.X), float32(s.from.Y),
+			float32(s.to.X), float32(s.to.Y),
+			width, c, true)
+	}
+}
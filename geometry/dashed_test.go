@@ -0,0 +1,67 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDashesSplitLine(t *testing.T) {
+	// 25 pixels of 4 pixel dashes and gaps: dashes start every 8 pixels,
+	// and the last is cut short by the end of the line
+	segments, phase := dashes(Vector2{X: 0, Y: 0}, Vector2{X: 25, Y: 0}, 4, 0)
+	want := []segment{
+		{Vector2{X: 0}, Vector2{X: 4}},
+		{Vector2{X: 8}, Vector2{X: 12}},
+		{Vector2{X: 16}, Vector2{X: 20}},
+		{Vector2{X: 24}, Vector2{X: 25}},
+	}
+	if len(segments) != len(want) {
+		t.Fatalf("Expected %d dashes, got %d: %v", len(want), len(segments), segments)
+	}
+	for i, s := range segments {
+		if math.Abs(s.from.X-want[i].from.X) > 1e-9 || math.Abs(s.to.X-want[i].to.X) > 1e-9 {
+			t.Errorf("Dash %d: expected %v, got %v", i, want[i], s)
+		}
+	}
+	if math.Abs(phase-1) > 1e-9 {
+		t.Errorf("Expected the line to end 1 pixel into a dash, got phase %v", phase)
+	}
+}
+
+func TestDashesCarryPhase(t *testing.T) {
+	// A line starting 6 pixels into the pattern is in a gap for 2 pixels
+	segments, phase := dashes(Vector2{X: 0, Y: 0}, Vector2{X: 0, Y: 10}, 4, 6)
+	if len(segments) != 1 {
+		t.Fatalf("Expected 1 dash, got %v", segments)
+	}
+	if math.Abs(segments[0].from.Y-2) > 1e-9 || math.Abs(segments[0].to.Y-6) > 1e-9 {
+		t.Errorf("Expected a dash from 2 to 6, got %v", segments[0])
+	}
+	if math.Abs(phase-0) > 1e-9 {
+		t.Errorf("Expected the line to end at the start of the pattern, got phase %v", phase)
+	}
+
+	// Splitting a line in two and carrying the phase across gives the same
+	// dashes as drawing it in one go
+	whole, _ := dashes(Vector2{}, Vector2{X: 30}, 3, 0)
+	first, mid := dashes(Vector2{}, Vector2{X: 13}, 3, 0)
+	second, _ := dashes(Vector2{X: 13}, Vector2{X: 30}, 3, mid)
+	var total float64
+	for _, s := range whole {
+		total += s.to.X - s.from.X
+	}
+	var split float64
+	for _, s := range append(first, second...) {
+		split += s.to.X - s.from.X
+	}
+	if math.Abs(total-split) > 1e-9 {
+		t.Errorf("Expected split line to have %v pixels of dashes, got %v", total, split)
+	}
+}
+
+func TestDashesEmptyLine(t *testing.T) {
+	segments, phase := dashes(Vector2{X: 5, Y: 5}, Vector2{X: 5, Y: 5}, 4, 3)
+	if segments != nil || phase != 3 {
+		t.Errorf("Expected no dashes and the phase unchanged, got %v and %v", segments, phase)
+	}
+}
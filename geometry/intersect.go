@@ -0,0 +1,106 @@
+package geometry
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// IntersectionHit is where a segment crosses an edge of a polygon
+type IntersectionHit struct {
+	Point Vector2
+	// T is how far along the segment the hit is, from 0 at its start to 1
+	// at its end
+	T float64
+	// Edge is the index of the vertex the edge that was hit starts from
+	Edge int
+}
+
+// segmentParameter returns how far along the segment from a to b the point
+// q, which must lie on it, is, from 0 at a to 1 at b. A zero-length segment
+// is all at 0.
+func segmentParameter(a, b, q Vector2) float64 {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	lengthSquared := dx*dx + dy*dy
+	if lengthSquared == 0 {
+		return 0
+	}
+	return math.Max(0, math.Min(1, ((q.X-a.X)*dx+(q.Y-a.Y)*dy)/lengthSquared))
+}
+
+// LineSegmentIntersection finds where the segment from p1 to p2 meets the
+// segment from p3 to p4. point is where they meet, t how far along the
+// first segment it is and u how far along the second, each from 0 at the
+// segment's start to 1 at its end. It meets the same segments as
+// LineSegmentsIntersect: end points count, so do collinear overlaps, and a
+// zero-length segment is a single point.
+//
+// Parallel segments only meet if they are collinear and overlap, in which
+// case point is the start of the overlap, the nearest point to p1 on both.
+func LineSegmentIntersection(p1, p2, p3, p4 Vector2) (ok bool, point Vector2, t, u float64) {
+	o1 := orientation(p1, p2, p3)
+	o2 := orientation(p1, p2, p4)
+	o3 := orientation(p3, p4, p1)
+	o4 := orientation(p3, p4, p2)
+
+	// Each segment's end points are on opposite sides of the other, so they
+	// cross at a single point. Rounding can put it a hair past an end.
+	r := Vector2{X: p2.X - p1.X, Y: p2.Y - p1.Y}
+	s := Vector2{X: p4.X - p3.X, Y: p4.Y - p3.Y}
+	if denominator := r.X*s.Y - r.Y*s.X; o1 != o2 && o3 != o4 && denominator != 0 {
+		q := Vector2{X: p3.X - p1.X, Y: p3.Y - p1.Y}
+		t = math.Max(0, math.Min(1, (q.X*s.Y-q.Y*s.X)/denominator))
+		u = math.Max(0, math.Min(1, (q.X*r.Y-q.Y*r.X)/denominator))
+		return true, Vector2{X: p1.X + r.X*t, Y: p1.Y + r.Y*t}, t, u
+	}
+
+	// Otherwise they can only meet where an end point lies on the other
+	// segment, which covers collinear overlaps and zero-length segments.
+	// Of those, keep the one nearest p1.
+	meet := func(q Vector2) {
+		qt := segmentParameter(p1, p2, q)
+		if !ok || qt < t {
+			ok, point, t, u = true, q, qt, segmentParameter(p3, p4, q)
+		}
+	}
+	if o1 == 0 && onSegment(p1, p3, p2) {
+		meet(p3)
+	}
+	if o2 == 0 && onSegment(p1, p4, p2) {
+		meet(p4)
+	}
+	if o3 == 0 && onSegment(p3, p1, p4) {
+		meet(p1)
+	}
+	if o4 == 0 && onSegment(p3, p2, p4) {
+		meet(p2)
+	}
+	return ok, point, t, u
+}
+
+// SegmentPolygonIntersections returns every point where the segment from a
+// to b meets an edge of the closed outline poly, nearest to a first. A hit
+// on a vertex is only reported once, on the edge starting from it, and a
+// segment running along an edge hits it where the overlap starts.
+func SegmentPolygonIntersections(a, b Vector2, poly DrawablePolygon) []IntersectionHit {
+	if len(poly) < 3 {
+		return nil
+	}
+	var hits []IntersectionHit
+	for i, start := range poly {
+		end := poly[(i+1)%len(poly)]
+		ok, point, t, _ := LineSegmentIntersection(a, b, start, end)
+		if !ok {
+			continue
+		}
+		// A hit at the end of this edge is at the start of the next
+		if samePoint(point, end) {
+			continue
+		}
+		hits = append(hits, IntersectionHit{Point: point, T: t, Edge: i})
+	}
+	slices.SortStableFunc(hits, func(x, y IntersectionHit) int {
+		return cmp.Compare(x.T, y.T)
+	})
+	return hits
+}
@@ -0,0 +1,109 @@
+package geometry
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// nearPoint reports whether a and b are within tolerance of each other
+func nearPoint(a, b Vector2, tolerance float64) bool {
+	return math.Abs(a.X-b.X) <= tolerance && math.Abs(a.Y-b.Y) <= tolerance
+}
+
+func TestLineSegmentIntersection(t *testing.T) {
+	tests := []struct {
+		name           string
+		p1, p2, p3, p4 Vector2
+		ok             bool
+		point          Vector2
+		t, u           float64
+	}{
+		{"crossing", Vector2{0, 0}, Vector2{10, 10}, Vector2{10, 0}, Vector2{0, 10}, true, Vector2{5, 5}, 0.5, 0.5},
+		{"crossing off center", Vector2{0, 0}, Vector2{8, 0}, Vector2{2, -1}, Vector2{2, 3}, true, Vector2{2, 0}, 0.25, 0.25},
+		{"disjoint", Vector2{0, 0}, Vector2{4, 4}, Vector2{10, 0}, Vector2{6, 4}, false, Vector2{}, 0, 0},
+		{"shared end point", Vector2{0, 0}, Vector2{10, 0}, Vector2{10, 0}, Vector2{10, 10}, true, Vector2{10, 0}, 1, 0},
+		{"shared start point", Vector2{0, 0}, Vector2{10, 0}, Vector2{0, 10}, Vector2{0, 0}, true, Vector2{0, 0}, 0, 1},
+		{"end point on other segment", Vector2{0, 0}, Vector2{10, 0}, Vector2{5, 0}, Vector2{5, 10}, true, Vector2{5, 0}, 0.5, 0},
+		{"end point short of other segment", Vector2{0, 0}, Vector2{10, 0}, Vector2{5, 1}, Vector2{5, 10}, false, Vector2{}, 0, 0},
+		{"parallel", Vector2{0, 0}, Vector2{10, 0}, Vector2{0, 1}, Vector2{10, 1}, false, Vector2{}, 0, 0},
+		{"collinear overlapping", Vector2{0, 0}, Vector2{10, 0}, Vector2{5, 0}, Vector2{15, 0}, true, Vector2{5, 0}, 0.5, 0},
+		{"collinear overlapping from inside", Vector2{10, 0}, Vector2{0, 0}, Vector2{5, 0}, Vector2{15, 0}, true, Vector2{10, 0}, 0, 0.5},
+		{"collinear contained", Vector2{0, 0}, Vector2{10, 10}, Vector2{3, 3}, Vector2{2, 2}, true, Vector2{2, 2}, 0.2, 1},
+		{"collinear containing", Vector2{2, 0}, Vector2{4, 0}, Vector2{0, 0}, Vector2{10, 0}, true, Vector2{2, 0}, 0, 0.2},
+		{"collinear touching", Vector2{0, 0}, Vector2{10, 0}, Vector2{10, 0}, Vector2{20, 0}, true, Vector2{10, 0}, 1, 0},
+		{"collinear apart", Vector2{0, 0}, Vector2{10, 0}, Vector2{11, 0}, Vector2{20, 0}, false, Vector2{}, 0, 0},
+		{"zero length on segment", Vector2{5, 5}, Vector2{5, 5}, Vector2{0, 0}, Vector2{10, 10}, true, Vector2{5, 5}, 0, 0.5},
+		{"zero length off segment", Vector2{5, 6}, Vector2{5, 6}, Vector2{0, 0}, Vector2{10, 10}, false, Vector2{}, 0, 0},
+		{"both zero length, same point", Vector2{3, 3}, Vector2{3, 3}, Vector2{3, 3}, Vector2{3, 3}, true, Vector2{3, 3}, 0, 0},
+		{"both zero length, apart", Vector2{3, 3}, Vector2{3, 3}, Vector2{4, 3}, Vector2{4, 3}, false, Vector2{}, 0, 0},
+		{"near parallel crossing", Vector2{0, 0}, Vector2{1e6, 1}, Vector2{0, 1}, Vector2{1e6, 0}, true, Vector2{5e5, 0.5}, 0.5, 0.5},
+		{"near parallel apart", Vector2{0, 0}, Vector2{10, 0}, Vector2{0, 1e-7}, Vector2{10, 2e-7}, false, Vector2{}, 0, 0},
+	}
+	for _, tt := range tests {
+		ok, point, pt, pu := LineSegmentIntersection(tt.p1, tt.p2, tt.p3, tt.p4)
+		if ok != tt.ok {
+			t.Errorf("%s: expected ok %v, got %v", tt.name, tt.ok, ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if !nearPoint(point, tt.point, 1e-6) || math.Abs(pt-tt.t) > 1e-9 || math.Abs(pu-tt.u) > 1e-9 {
+			t.Errorf("%s: expected %v at t %v u %v, got %v at t %v u %v", tt.name, tt.point, tt.t, tt.u, point, pt, pu)
+		}
+		if got := LineSegmentsIntersect(tt.p1, tt.p2, tt.p3, tt.p4); got != ok {
+			t.Errorf("%s: expected LineSegmentsIntersect to agree, got %v", tt.name, got)
+		}
+	}
+}
+
+func TestLineSegmentIntersectionOnBothSegments(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	point := func() Vector2 { return Vector2{X: rng.Float64() * 100, Y: rng.Float64() * 100} }
+	for range 10000 {
+		p1, p2, p3, p4 := point(), point(), point(), point()
+		ok, at, pt, pu := LineSegmentIntersection(p1, p2, p3, p4)
+		if !ok {
+			continue
+		}
+		onFirst := Vector2{X: p1.X + (p2.X-p1.X)*pt, Y: p1.Y + (p2.Y-p1.Y)*pt}
+		onSecond := Vector2{X: p3.X + (p4.X-p3.X)*pu, Y: p3.Y + (p4.Y-p3.Y)*pu}
+		if !nearPoint(at, onFirst, 1e-6) || !nearPoint(at, onSecond, 1e-6) {
+			t.Fatalf("Expected %v to be t %v along %v-%v and u %v along %v-%v", at, pt, p1, p2, pu, p3, p4)
+		}
+	}
+}
+
+func TestSegmentPolygonIntersections(t *testing.T) {
+	square := DrawablePolygon{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}}
+	tests := []struct {
+		name   string
+		a, b   Vector2
+		points []Vector2
+		edges  []int
+	}{
+		{"through", Vector2{-5, 5}, Vector2{15, 5}, []Vector2{{0, 5}, {10, 5}}, []int{3, 1}},
+		{"backwards", Vector2{15, 5}, Vector2{-5, 5}, []Vector2{{10, 5}, {0, 5}}, []int{1, 3}},
+		{"into", Vector2{5, -5}, Vector2{5, 5}, []Vector2{{5, 0}}, []int{0}},
+		{"inside", Vector2{2, 2}, Vector2{8, 8}, nil, nil},
+		{"outside", Vector2{20, 0}, Vector2{20, 10}, nil, nil},
+		{"through corners", Vector2{-5, -5}, Vector2{15, 15}, []Vector2{{0, 0}, {10, 10}}, []int{0, 2}},
+		{"along an edge", Vector2{-5, 0}, Vector2{5, 0}, []Vector2{{0, 0}}, []int{0}},
+	}
+	for _, tt := range tests {
+		hits := SegmentPolygonIntersections(tt.a, tt.b, square)
+		if len(hits) != len(tt.points) {
+			t.Errorf("%s: expected %d hits, got %v", tt.name, len(tt.points), hits)
+			continue
+		}
+		for i, hit := range hits {
+			if !nearPoint(hit.Point, tt.points[i], 1e-9) || hit.Edge != tt.edges[i] {
+				t.Errorf("%s: expected hit %d on edge %d at %v, got %+v", tt.name, i, tt.edges[i], tt.points[i], hit)
+			}
+			if i > 0 && hit.T < hits[i-1].T {
+				t.Errorf("%s: expected hits nearest the start first, got %v", tt.name, hits)
+			}
+		}
+	}
+}
@@ -0,0 +1,1104 @@
+// Package geometry provides the polygon objects, vector math and collision
+// detection used by SpaceDebris.
+package geometry
+
+import (
+	"image/color"
+	"math"
+	"slices"
+	"time"
+)
+
+// Vector2 represents a 2D point or vector
+type Vector2 struct {
+	X, Y float64
+}
+
+// PolygonObject represents a closed polygon that can be drawn
+type PolygonObject struct {
+	// Vertices relative to the object's origin (0,0)
+	Vertices []Vector2
+	// CollisionVertices is the outline used for collisions, relative to the
+	// origin like Vertices. It lets an object be harder to hit than it
+	// looks. nil means Vertices are used.
+	CollisionVertices []Vector2
+	// Position of the object's origin in world space
+	Position Vector2
+	// Velocity in pixels per frame
+	Velocity Vector2
+	// Acceleration in pixels per frame squared, added to the velocity on the
+	// next update and then cleared. Use ApplyForce to add to it.
+	Acceleration Vector2
+	// MaxSpeed caps the speed in pixels per frame. 0 means no limit.
+	MaxSpeed float64
+	// Drag is the fraction of the velocity lost each frame. 0 means no drag.
+	Drag float64
+	// Rotation angle in radians
+	Rotation float64
+	// Rotation speed in radians per frame
+	RotationSpeed float64
+	// Scale factor
+	Scale float64
+	// Boundary is what happens when the object reaches the screen edge.
+	// The zero value wraps it around to the opposite edge.
+	Boundary BoundaryBehavior
+	// Margin is how far past the screen edge a BoundaryDespawn object can
+	// go before it has left the world
+	Margin float64
+	// TimeScale multiplies how far the object moves and rotates each
+	// update, so it can be slowed without changing its velocity. 0 is
+	// treated as 1, so objects run at normal speed unless it is set.
+	TimeScale float64
+	// Color for drawing
+	Color color.Color
+	// Line width for drawing
+	LineWidth float32
+	// Color fading properties
+	FadeStartColor color.Color
+	FadeEndColor   color.Color
+	FadeProgress   float64 // 0.0 to 1.0, where 0 is start color and 1 is end color
+	FadeSpeed      float64 // How fast to fade (increment per tick)
+	IsFading       bool    // Whether the object is currently fading
+	FadeEasing     Easing  // How the fade progresses from start to end color
+
+	// Tweens animating the scale and line width, started by TweenScale and
+	// TweenLineWidth
+	scaleTween     tween
+	lineWidthTween tween
+
+	// The pose saved by SavePose, which drawing can interpolate from
+	previousPosition Vector2
+	previousRotation float64
+	hasPrevious      bool
+
+	// The world-space vertices and bounding box are cached, along with the
+	// transform they were computed for. Comparing the transform on each use
+	// means moving, turning or scaling the polygon through the exported
+	// fields can't leave the cache stale. Editing the vertices in place
+	// can, so outlines are replaced with SetVertices and
+	// SetCollisionVertices.
+	cacheValid       bool
+	cacheKey         transformKey
+	transformedCache DrawablePolygon
+	boundsCache      BoundingBox
+	// The collision outline is cached the same way, when there is one
+	collisionCache       DrawablePolygon
+	collisionBoundsCache BoundingBox
+}
+
+// transformKey is the part of a polygon's state its world-space vertices are
+// computed from
+type transformKey struct {
+	position Vector2
+	rotation float64
+	scale    float64
+	vertices int
+	// collisionVertices is -1 when there is no separate collision outline
+	collisionVertices int
+}
+
+// DrawablePolygon is a list of world-space vertices ready for drawing
+type DrawablePolygon []Vector2
+
+// CreateAsteroid creates an irregular asteroid-like polygon
+func CreateAsteroid(baseRadius float64, irregularity float64, numVertices int) *PolygonObject {
+	vertices := make([]Vector2, numVertices)
+	angleStep := 2 * math.Pi / float64(numVertices)
+
+	for i := 0; i < numVertices; i++ {
+		angle := float64(i) * angleStep
+		// Add some irregularity to the radius
+		radius := baseRadius + (math.Sin(angle*3)+math.Cos(angle*5))*irregularity
+		vertices[i] = Vector2{
+			X: math.Cos(angle) * radius,
+			Y: math.Sin(angle) * radius,
+		}
+	}
+	p := &PolygonObject{
+		Vertices:       vertices,
+		Position:       Vector2{X: 0, Y: 0},
+		Velocity:       Vector2{X: 0, Y: 0},
+		Rotation:       0,
+		RotationSpeed:  0,
+		Scale:          1.0,
+		Color:          color.White,
+		LineWidth:      1.0,
+		FadeStartColor: color.White,
+		FadeEndColor:   color.White,
+		FadeProgress:   0.0,
+		FadeSpeed:      0.0,
+		IsFading:       false,
+	}
+	p.NormalizeWinding()
+	return p
+}
+
+// playerHullScale is the size of the player's collision hull relative to
+// the drawn ship, so a rock only just clipping a wing tip doesn't count
+const playerHullScale = 0.7
+
+// CreatePlayer creates a spaceship polygon with wings and a divet at the
+// back. Its collision hull is a smaller copy of the outline, centered on the
+// cockpit.
+func CreatePlayer(size float64) *PolygonObject {
+	vertices := []Vector2{
+		{X: 0, Y: -size},                 // Nose (top vertex, pointing up)
+		{X: -size * 0.3, Y: -size * 0.2}, // Left side of nose
+		{X: -size * 0.7, Y: size * 0.3},  // Left wing tip
+		{X: -size * 0.4, Y: size * 0.6},  // Left wing inner
+		{X: -size * 0.2, Y: size * 0.8},  // Left back corner
+		{X: 0, Y: size * 0.6},            // Center back (creates divet)
+		{X: size * 0.2, Y: size * 0.8},   // Right back corner
+		{X: size * 0.4, Y: size * 0.6},   // Right wing inner
+		{X: size * 0.7, Y: size * 0.3},   // Right wing tip
+		{X: size * 0.3, Y: -size * 0.2},  // Right side of nose
+	}
+	p := &PolygonObject{
+		Vertices:       vertices,
+		Position:       Vector2{X: 0, Y: 0},
+		Velocity:       Vector2{X: 0, Y: 0},
+		Rotation:       0,
+		RotationSpeed:  0,
+		Scale:          1.0,
+		Color:          color.White,
+		LineWidth:      1.0,
+		FadeStartColor: color.White,
+		FadeEndColor:   color.White,
+		FadeProgress:   0.0,
+		FadeSpeed:      0.0,
+		IsFading:       false,
+	}
+	p.NormalizeWinding()
+	p.SetCollisionVertices(ScaleAbout(p.Vertices, p.Centroid(), playerHullScale))
+	return p
+}
+
+// ScaleAbout returns a copy of vertices scaled by factor towards or away
+// from center
+func ScaleAbout(vertices []Vector2, center Vector2, factor float64) []Vector2 {
+	scaled := make([]Vector2, len(vertices))
+	for i, v := range vertices {
+		scaled[i] = Vector2{
+			X: center.X + (v.X-center.X)*factor,
+			Y: center.Y + (v.Y-center.Y)*factor,
+		}
+	}
+	return scaled
+}
+
+// CreatePolygon creates a white polygon from the given outline, which is
+// copied so the caller may reuse it. Like all the constructors, it
+// normalizes the winding of the outline.
+func CreatePolygon(vertices []Vector2) *PolygonObject {
+	p := &PolygonObject{
+		Vertices:       append([]Vector2(nil), vertices...),
+		Scale:          1.0,
+		Color:          color.White,
+		LineWidth:      1.0,
+		FadeStartColor: color.White,
+		FadeEndColor:   color.White,
+	}
+	p.NormalizeWinding()
+	return p
+}
+
+// CreateCircle creates a regular polygon approximating a circle of the
+// given radius
+func CreateCircle(radius float64, segments int) *PolygonObject {
+	vertices := make([]Vector2, segments)
+	for i := range vertices {
+		angle := float64(i) * 2 * math.Pi / float64(segments)
+		vertices[i] = Vector2{X: math.Cos(angle) * radius, Y: math.Sin(angle) * radius}
+	}
+	return CreatePolygon(vertices)
+}
+
+// CreatePlayerFlame creates a crown-shaped engine flame
+func CreatePlayerFlame(size float64) *PolygonObject {
+	vertices := []Vector2{
+		{X: -size * 0.15, Y: size * 0.7}, // Left start
+		{X: -size * 0.25, Y: size * 1.1}, // Left point
+		{X: -size * 0.1, Y: size * 0.9},  // Left valley
+		{X: 0, Y: size * 1.3},            // Middle point
+		{X: size * 0.1, Y: size * 0.9},   // Right valley
+		{X: size * 0.25, Y: size * 1.1},  // Right point
+		{X: size * 0.15, Y: size * 0.7},  // Right end
+	}
+	p := &PolygonObject{
+		Vertices:       vertices,
+		Position:       Vector2{X: 0, Y: 0},
+		Velocity:       Vector2{X: 0, Y: 0},
+		Rotation:       0,
+		RotationSpeed:  0,
+		Scale:          1.0,
+		Color:          color.RGBA{255, 69, 0, 255}, // Orange-Red
+		LineWidth:      1.5,
+		FadeStartColor: color.RGBA{255, 69, 0, 255},
+		FadeEndColor:   color.RGBA{255, 69, 0, 255},
+		FadeProgress:   0.0,
+		FadeSpeed:      0.0,
+		IsFading:       false,
+	}
+	p.NormalizeWinding()
+	return p
+}
+
+// TransformedVertices returns the vertices transformed by position, rotation,
+// and scale. The result is cached until the transform changes, so it must
+// not be modified.
+func (p *PolygonObject) TransformedVertices() DrawablePolygon {
+	p.updateCache()
+	return p.transformedCache
+}
+
+// SetVertices replaces the polygon's outline, relative to its origin
+func (p *PolygonObject) SetVertices(vertices []Vector2) {
+	p.Vertices = vertices
+	p.cacheValid = false
+}
+
+// SetCollisionVertices replaces the outline used for collisions, relative to
+// the origin like the vertices. nil uses the vertices.
+func (p *PolygonObject) SetCollisionVertices(vertices []Vector2) {
+	p.CollisionVertices = vertices
+	p.cacheValid = false
+}
+
+// updateCache recomputes the world-space vertices and bounding boxes if the
+// transform has changed since they were last computed
+func (p *PolygonObject) updateCache() {
+	key := transformKey{
+		position:          p.Position,
+		rotation:          p.Rotation,
+		scale:             p.Scale,
+		vertices:          len(p.Vertices),
+		collisionVertices: -1,
+	}
+	if p.CollisionVertices != nil {
+		key.collisionVertices = len(p.CollisionVertices)
+	}
+	if p.cacheValid && p.cacheKey == key {
+		return
+	}
+
+	p.cacheValid = true
+	p.cacheKey = key
+	p.transformedCache = p.transform(p.Vertices)
+	p.boundsCache = boundingBox(p.transformedCache)
+	if p.CollisionVertices == nil {
+		p.collisionCache = p.transformedCache
+		p.collisionBoundsCache = p.boundsCache
+		return
+	}
+	p.collisionCache = p.transform(p.CollisionVertices)
+	p.collisionBoundsCache = boundingBox(p.collisionCache)
+}
+
+// transform returns vertices moved from the polygon's own coordinates into
+// world coordinates by its position, rotation and scale
+func (p *PolygonObject) transform(vertices []Vector2) DrawablePolygon {
+	return p.transformPose(vertices, p.Position, p.Rotation)
+}
+
+// transformPose is transform with the polygon at the given position and
+// rotation instead of its own
+func (p *PolygonObject) transformPose(vertices []Vector2, position Vector2, rotation float64) DrawablePolygon {
+	transformed := make([]Vector2, len(vertices))
+	cos := math.Cos(rotation)
+	sin := math.Sin(rotation)
+
+	for i, vertex := range vertices {
+		// Scale
+		scaledX := vertex.X * p.Scale
+		scaledY := vertex.Y * p.Scale
+
+		// Rotate
+		rotatedX := scaledX*cos - scaledY*sin
+		rotatedY := scaledX*sin + scaledY*cos
+
+		// Translate
+		transformed[i] = Vector2{
+			X: rotatedX + position.X,
+			Y: rotatedY + position.Y,
+		}
+	}
+	return transformed
+}
+
+// TransformedCollisionVertices returns the collision outline transformed
+// like TransformedVertices, which is the visual outline when there isn't a
+// separate one. It is cached the same way, so it must not be modified.
+func (p *PolygonObject) TransformedCollisionVertices() DrawablePolygon {
+	p.updateCache()
+	return p.collisionCache
+}
+
+// ToWorld transforms a point from the polygon's own coordinates into world
+// coordinates, the same way as its vertices
+func (p *PolygonObject) ToWorld(local Vector2) Vector2 {
+	cos := math.Cos(p.Rotation)
+	sin := math.Sin(p.Rotation)
+	x, y := local.X*p.Scale, local.Y*p.Scale
+	return Vector2{
+		X: x*cos - y*sin + p.Position.X,
+		Y: x*sin + y*cos + p.Position.Y,
+	}
+}
+
+// ToLocal transforms a point from world coordinates into the polygon's own
+// coordinates, undoing ToWorld
+func (p *PolygonObject) ToLocal(world Vector2) Vector2 {
+	cos := math.Cos(p.Rotation)
+	sin := math.Sin(p.Rotation)
+	x, y := world.X-p.Position.X, world.Y-p.Position.Y
+	return Vector2{
+		X: (x*cos + y*sin) / p.Scale,
+		Y: (y*cos - x*sin) / p.Scale,
+	}
+}
+
+// TransformedPoints transforms points from the polygon's own coordinates
+// into world coordinates the same way as its vertices, rewound towards the
+// pose saved by SavePose like DrawOptions.Rewind. It isn't cached.
+func (p *PolygonObject) TransformedPoints(points []Vector2, rewind float64) DrawablePolygon {
+	if rewind > 0 && p.hasPrevious {
+		position, rotation := p.InterpolatedPose(1 - rewind)
+		return p.transformPose(points, position, rotation)
+	}
+	return p.transform(points)
+}
+
+// DrawOptions adjusts how a polygon is drawn without changing the object itself
+type DrawOptions struct {
+	// ExtraLineWidth is added to the object's LineWidth
+	ExtraLineWidth float32
+	// NoWrap skips the wrapped copies drawn at the opposite screen edges
+	NoWrap bool
+	// Rewind draws the polygon this fraction of the way back from its
+	// current pose towards the one saved by SavePose, for smooth motion
+	// between ticks. 0 draws the current pose.
+	Rewind float64
+	// HiddenEdges leaves out the edges whose bits are set, bit i being the
+	// edge from vertex i to the next. Only the first 64 edges can be hidden.
+	HiddenEdges uint64
+}
+
+// edgeHidden reports whether the i'th edge is set in the mask hidden
+func edgeHidden(hidden uint64, i int) bool {
+	return i < 64 && hidden&(1<<i) != 0
+}
+
+// SavePose remembers the current position and rotation, so drawing can
+// interpolate from them until the next time they are saved. It is meant to be
+// called at the start of each tick.
+func (p *PolygonObject) SavePose() {
+	p.previousPosition = p.Position
+	p.previousRotation = p.Rotation
+	p.hasPrevious = true
+}
+
+// MatchPose moves the polygon to the same position and rotation as other,
+// including the pose it interpolates from, so the two are drawn together
+func (p *PolygonObject) MatchPose(other *PolygonObject) {
+	p.Position = other.Position
+	p.Rotation = other.Rotation
+	p.previousPosition = other.previousPosition
+	p.previousRotation = other.previousRotation
+	p.hasPrevious = other.hasPrevious
+}
+
+// InterpolatedPose returns the position and rotation alpha of the way from
+// the pose saved by SavePose to the current one. Rotation goes the shorter
+// way round. Without a saved pose it returns the current one.
+func (p *PolygonObject) InterpolatedPose(alpha float64) (Vector2, float64) {
+	if !p.hasPrevious {
+		return p.Position, p.Rotation
+	}
+	position := Vector2{
+		X: p.previousPosition.X + (p.Position.X-p.previousPosition.X)*alpha,
+		Y: p.previousPosition.Y + (p.Position.Y-p.previousPosition.Y)*alpha,
+	}
+	turn := math.Remainder(p.Rotation-p.previousRotation, 2*math.Pi)
+	return position, NormalizeAngle(p.previousRotation + turn*alpha)
+}
+
+// BoundingBox represents a rectangular bounding box
+type BoundingBox struct {
+	MinX, MinY, MaxX, MaxY float64
+}
+
+// GetBoundingBox returns the axis-aligned bounding box of the polygon
+func (p *PolygonObject) GetBoundingBox() BoundingBox {
+	p.updateCache()
+	return p.boundsCache
+}
+
+// GetCollisionBoundingBox returns the axis-aligned bounding box of the
+// polygon's collision outline
+func (p *PolygonObject) GetCollisionBoundingBox() BoundingBox {
+	p.updateCache()
+	return p.collisionBoundsCache
+}
+
+// boundingBox returns the axis-aligned bounding box of a set of vertices
+func boundingBox(transformedVertices []Vector2) BoundingBox {
+	if len(transformedVertices) == 0 {
+		return BoundingBox{0, 0, 0, 0}
+	}
+
+	minX, minY := transformedVertices[0].X, transformedVertices[0].Y
+	maxX, maxY := minX, minY
+
+	for _, vertex := range transformedVertices[1:] {
+		if vertex.X < minX {
+			minX = vertex.X
+		}
+		if vertex.X > maxX {
+			maxX = vertex.X
+		}
+		if vertex.Y < minY {
+			minY = vertex.Y
+		}
+		if vertex.Y > maxY {
+			maxY = vertex.Y
+		}
+	}
+
+	return BoundingBox{minX, minY, maxX, maxY}
+}
+
+func (b BoundingBox) Overlaps(other BoundingBox) bool {
+	return b.MinX <= other.MaxX && b.MaxX >= other.MinX &&
+		b.MinY <= other.MaxY && b.MaxY >= other.MinY
+}
+
+// PointInPolygon checks if a point is inside a polygon using ray casting
+// algorithm. Points exactly on an edge or vertex count as inside, so a
+// polygon's own outline is part of it.
+func PointInPolygon(point Vector2, vertices []Vector2) bool {
+	if len(vertices) < 3 {
+		return false
+	}
+
+	// The ray cast is unreliable right on the outline, so check that first
+	j := len(vertices) - 1
+	for i := 0; i < len(vertices); i++ {
+		if orientation(vertices[j], vertices[i], point) == 0 && onSegment(vertices[j], point, vertices[i]) {
+			return true
+		}
+		j = i
+	}
+
+	inside := false
+	j = len(vertices) - 1
+
+	for i := 0; i < len(vertices); i++ {
+		xi, yi := vertices[i].X, vertices[i].Y
+		xj, yj := vertices[j].X, vertices[j].Y
+
+		if ((yi > point.Y) != (yj > point.Y)) &&
+			(point.X < (xj-xi)*(point.Y-yi)/(yj-yi)+xi) {
+			inside = !inside
+		}
+		j = i
+	}
+
+	return inside
+}
+
+// orientation returns which side of the line from a to b the point c is on,
+// as the sign of the cross product of b-a and c-a. It is 0 if the three
+// points are collinear.
+func orientation(a, b, c Vector2) int {
+	cross := (b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)
+	switch {
+	case cross > 1e-10:
+		return 1
+	case cross < -1e-10:
+		return -1
+	}
+	return 0
+}
+
+// onSegment reports whether q, which must be collinear with a and b, lies
+// within the bounding box of the segment from a to b
+func onSegment(a, q, b Vector2) bool {
+	return q.X <= math.Max(a.X, b.X) && q.X >= math.Min(a.X, b.X) &&
+		q.Y <= math.Max(a.Y, b.Y) && q.Y >= math.Min(a.Y, b.Y)
+}
+
+// LineSegmentsIntersect checks if the segment from p1 to p2 intersects the
+// segment from p3 to p4. Segments include their end points, so segments that
+// only touch intersect, as do collinear segments that overlap. A zero-length
+// segment is treated as a single point.
+func LineSegmentsIntersect(p1, p2, p3, p4 Vector2) bool {
+	ok, _, _, _ := LineSegmentIntersection(p1, p2, p3, p4)
+	return ok
+}
+
+// ClosestApproach returns the smallest distance between two points moving
+// in straight lines at steady speeds over the same time, one from a0 to a1
+// and the other from b0 to b1. Small, fast objects can pass straight
+// through each other between two ticks, so testing their swept paths this
+// way catches hits that comparing their positions would miss.
+func ClosestApproach(a0, a1, b0, b1 Vector2) float64 {
+	// Work in the frame of the first point, where the second moves from d0
+	// to d1, and find the closest point on that path to the origin
+	d0 := Vector2{X: b0.X - a0.X, Y: b0.Y - a0.Y}
+	d1 := Vector2{X: b1.X - a1.X, Y: b1.Y - a1.Y}
+	motion := Vector2{X: d1.X - d0.X, Y: d1.Y - d0.Y}
+	t := 0.0
+	if lengthSquared := motion.X*motion.X + motion.Y*motion.Y; lengthSquared > 0 {
+		t = math.Max(0, math.Min(1, -(d0.X*motion.X+d0.Y*motion.Y)/lengthSquared))
+	}
+	return math.Hypot(d0.X+motion.X*t, d0.Y+motion.Y*t)
+}
+
+// IsSimplePolygon reports whether the closed outline through vertices has
+// at least 3 vertices and no edges that cross each other
+func IsSimplePolygon(vertices []Vector2) bool {
+	n := len(vertices)
+	if n < 3 {
+		return false
+	}
+	for i := 0; i < n; i++ {
+		a1, a2 := vertices[i], vertices[(i+1)%n]
+		// Adjacent edges always share a vertex, so only check the edges
+		// that aren't next to this one
+		for j := i + 2; j < n; j++ {
+			if i == 0 && j == n-1 {
+				continue
+			}
+			if LineSegmentsIntersect(a1, a2, vertices[j], vertices[(j+1)%n]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// PolygonsCollide checks if two polygons collide, using their collision
+// outlines. Polygons that only touch along an edge or at a vertex collide,
+// and the result doesn't depend on the order of the arguments.
+func PolygonsCollide(poly1, poly2 *PolygonObject) bool {
+	// Fast bounding box check first
+	box1 := poly1.GetCollisionBoundingBox()
+	box2 := poly2.GetCollisionBoundingBox()
+
+	if !box1.Overlaps(box2) {
+		return false // No collision possible if bounding boxes don't overlap
+	}
+
+	// Get transformed vertices for both polygons
+	vertices1 := poly1.TransformedCollisionVertices()
+	vertices2 := poly2.TransformedCollisionVertices()
+
+	if len(vertices1) < 3 || len(vertices2) < 3 {
+		return false
+	}
+
+	// Check if any vertex of polygon1 is inside polygon2
+	for _, vertex := range vertices1 {
+		if PointInPolygon(vertex, vertices2) {
+			return true
+		}
+	}
+
+	// Check if any vertex of polygon2 is inside polygon1
+	for _, vertex := range vertices2 {
+		if PointInPolygon(vertex, vertices1) {
+			return true
+		}
+	}
+
+	// Check if any edge of polygon1 intersects any edge of polygon2
+	for i := 0; i < len(vertices1); i++ {
+		edge1Start := vertices1[i]
+		edge1End := vertices1[(i+1)%len(vertices1)]
+
+		for j := 0; j < len(vertices2); j++ {
+			edge2Start := vertices2[j]
+			edge2End := vertices2[(j+1)%len(vertices2)]
+
+			if LineSegmentsIntersect(edge1Start, edge1End, edge2Start, edge2End) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// WrapDelta returns the shortest displacement from one point to another on
+// a playfield that wraps around at the screen edges, which may cross an
+// edge rather than going the long way around. Points exactly half the
+// screen apart are left going the direct way.
+func WrapDelta(from, to Vector2, screenWidth, screenHeight float64) Vector2 {
+	return Vector2{
+		X: wrapAxis(to.X-from.X, screenWidth),
+		Y: wrapAxis(to.Y-from.Y, screenHeight),
+	}
+}
+
+// WrapDistance returns the length of the shortest path between two points
+// on a playfield that wraps around at the screen edges
+func WrapDistance(from, to Vector2, screenWidth, screenHeight float64) float64 {
+	delta := WrapDelta(from, to, screenWidth, screenHeight)
+	return math.Hypot(delta.X, delta.Y)
+}
+
+// WrapPosition returns the point on the screen equivalent to p, wherever p
+// is, on a playfield that wraps around at the screen edges
+func WrapPosition(p Vector2, screenWidth, screenHeight float64) Vector2 {
+	return Vector2{X: wrapCoordinate(p.X, screenWidth), Y: wrapCoordinate(p.Y, screenHeight)}
+}
+
+// wrapCoordinate returns x moved into [0, size) by whole multiples of size
+func wrapCoordinate(x, size float64) float64 {
+	if size <= 0 {
+		return x
+	}
+	x = math.Mod(x, size)
+	if x < 0 {
+		x += size
+	}
+	// Adding size to a tiny negative coordinate can round up to exactly size
+	if x >= size {
+		x = 0
+	}
+	return x
+}
+
+// wrapAxis returns the shortest equivalent of the offset d along an axis
+// that wraps every size pixels
+func wrapAxis(d, size float64) float64 {
+	if size <= 0 {
+		return d
+	}
+	d = math.Mod(d, size)
+	if d > size/2 {
+		d -= size
+	} else if d < -size/2 {
+		d += size
+	}
+	return d
+}
+
+// SignedArea returns the area enclosed by the polygon's outline, before
+// scaling. It is positive if the vertices wind counter-clockwise in the
+// usual mathematical sense, which looks clockwise on screen where y points
+// down, and negative otherwise.
+func (p *PolygonObject) SignedArea() float64 {
+	area := 0.0
+	for i, v := range p.Vertices {
+		next := p.Vertices[(i+1)%len(p.Vertices)]
+		area += v.X*next.Y - next.X*v.Y
+	}
+	return area / 2
+}
+
+// Area returns the area enclosed by the polygon's outline, before scaling,
+// whichever way it winds
+func (p *PolygonObject) Area() float64 {
+	return math.Abs(p.SignedArea())
+}
+
+// Centroid returns the center of mass of the area enclosed by the polygon's
+// outline, relative to its origin and before scaling. Outlines with no area
+// use the average of their vertices instead.
+func (p *PolygonObject) Centroid() Vector2 {
+	area := p.SignedArea()
+	if math.Abs(area) < 1e-10 {
+		var sum Vector2
+		for _, v := range p.Vertices {
+			sum.X += v.X
+			sum.Y += v.Y
+		}
+		if len(p.Vertices) > 0 {
+			sum.X /= float64(len(p.Vertices))
+			sum.Y /= float64(len(p.Vertices))
+		}
+		return sum
+	}
+
+	var centroid Vector2
+	for i, v := range p.Vertices {
+		next := p.Vertices[(i+1)%len(p.Vertices)]
+		cross := v.X*next.Y - next.X*v.Y
+		centroid.X += (v.X + next.X) * cross
+		centroid.Y += (v.Y + next.Y) * cross
+	}
+	centroid.X /= 6 * area
+	centroid.Y /= 6 * area
+	return centroid
+}
+
+// NormalizeWinding reorders the vertices if needed so that the signed area is
+// positive. The first vertex stays first, so it can still be used as a
+// reference point such as the ship's nose.
+func (p *PolygonObject) NormalizeWinding() {
+	if p.SignedArea() >= 0 {
+		return
+	}
+	slices.Reverse(p.Vertices[1:])
+	p.cacheValid = false
+}
+
+// CollisionNormal returns the unit vector pointing from the center of poly2
+// towards the center of poly1, which is the direction poly1 should be pushed
+// to separate them. If the centers coincide it points up the screen.
+func CollisionNormal(poly1, poly2 *PolygonObject) Vector2 {
+	dx := poly1.Position.X - poly2.Position.X
+	dy := poly1.Position.Y - poly2.Position.Y
+	length := math.Sqrt(dx*dx + dy*dy)
+	if length == 0 {
+		return Vector2{X: 0, Y: -1}
+	}
+	return Vector2{X: dx / length, Y: dy / length}
+}
+
+// SetPosition sets the world position of the polygon
+func (p *PolygonObject) SetPosition(x, y float64) {
+	p.Position.X = x
+	p.Position.Y = y
+}
+
+// NormalizeAngle returns the equivalent of angle in the range [0, 2π),
+// however many turns it is away from it
+func NormalizeAngle(angle float64) float64 {
+	angle = math.Mod(angle, 2*math.Pi)
+	if angle < 0 {
+		angle += 2 * math.Pi
+	}
+	// Adding 2π to a tiny negative angle can round up to exactly 2π
+	if angle >= 2*math.Pi {
+		angle = 0
+	}
+	return angle
+}
+
+// AngleDifference returns the signed turn from one angle to another, taking
+// the shorter way around, in the range [-π, π). Angles exactly half a turn
+// apart are -π.
+func AngleDifference(from, to float64) float64 {
+	return NormalizeAngle(to-from+math.Pi) - math.Pi
+}
+
+// SetRotation sets the rotation angle in radians
+func (p *PolygonObject) SetRotation(angle float64) {
+	p.Rotation = NormalizeAngle(angle)
+}
+
+// SetScale sets the scale factor
+func (p *PolygonObject) SetScale(scale float64) {
+	p.Scale = scale
+}
+
+// SetColor sets the drawing color
+func (p *PolygonObject) SetColor(c color.Color) {
+	p.Color = c
+	p.IsFading = false // Stop fading when color is set directly
+}
+
+// Rotate rotates the polygon by the given angle in radians
+func (p *PolygonObject) Rotate(angle float64) {
+	p.Rotation = NormalizeAngle(p.Rotation + angle)
+}
+
+// Move translates the polygon by the given offset
+func (p *PolygonObject) Move(dx, dy float64) {
+	p.Position.X += dx
+	p.Position.Y += dy
+}
+
+// SetVelocity sets the velocity of the polygon
+func (p *PolygonObject) SetVelocity(vx, vy float64) {
+	p.Velocity.X = vx
+	p.Velocity.Y = vy
+}
+
+// ApplyForce adds an acceleration, in pixels per frame squared, to be
+// applied on the next update
+func (p *PolygonObject) ApplyForce(fx, fy float64) {
+	p.Acceleration.X += fx
+	p.Acceleration.Y += fy
+}
+
+// SetRotationSpeed sets the rotation speed in radians per frame
+func (p *PolygonObject) SetRotationSpeed(speed float64) {
+	p.RotationSpeed = speed
+}
+
+// BoundaryBehavior is what happens to an object when it reaches the edge of
+// the screen
+type BoundaryBehavior int
+
+const (
+	// BoundaryWrap moves the object to the opposite edge. It is the zero
+	// value, so objects wrap unless told otherwise.
+	BoundaryWrap BoundaryBehavior = iota
+	// BoundaryDespawn leaves the object alone until it is more than Margin
+	// beyond an edge, and then reports that it has left the world
+	BoundaryDespawn
+	// BoundaryBounce reflects the object back into the screen
+	BoundaryBounce
+	// BoundaryClamp stops the object at the edge, losing its speed into it
+	BoundaryClamp
+)
+
+// Edge is what happened to an object at the screen edge during a step
+type Edge int
+
+const (
+	// EdgeNone means the object didn't cross an edge
+	EdgeNone Edge = iota
+	// EdgeWrapped means the object moved to the opposite edge
+	EdgeWrapped
+	// EdgeBounced means the object was reflected back into the screen
+	EdgeBounced
+	// EdgeClamped means the object was stopped at the edge
+	EdgeClamped
+	// EdgeLeft means a despawning object has left the world, and its owner
+	// should remove it
+	EdgeLeft
+)
+
+// Update advances the polygon by one frame. See Step.
+func (p *PolygonObject) Update(screenWidth, screenHeight float64) Edge {
+	return p.Step(1, screenWidth, screenHeight)
+}
+
+// Step advances the polygon by dt frames, which may be fractional, and then
+// applies its Boundary behavior. It reports what happened at the edge.
+func (p *PolygonObject) Step(dt, screenWidth, screenHeight float64) Edge {
+	motion := dt
+	if p.TimeScale != 0 {
+		motion *= p.TimeScale
+	}
+
+	p.stepVelocity(motion)
+
+	// Update position based on velocity
+	if p.Velocity.X != 0 {
+		p.Position.X += p.Velocity.X * motion
+	}
+	if p.Velocity.Y != 0 {
+		p.Position.Y += p.Velocity.Y * motion
+	}
+
+	// Update rotation based on rotation speed
+	p.Rotation += p.RotationSpeed * motion
+
+	// Keep rotation in the range [0, 2π) for cleaner values
+	p.Rotation = NormalizeAngle(p.Rotation)
+
+	// Update color fading and any other animated properties
+	p.updateFade(dt)
+	p.updateTweens(dt)
+
+	switch p.Boundary {
+	case BoundaryDespawn:
+		return p.despawn(screenWidth, screenHeight)
+	case BoundaryBounce:
+		return p.bounce(screenWidth, screenHeight)
+	case BoundaryClamp:
+		return p.clamp(screenWidth, screenHeight)
+	}
+	return p.wrap(screenWidth, screenHeight)
+}
+
+// wrap moves the polygon to the opposite edge if it has crossed one
+func (p *PolygonObject) wrap(screenWidth, screenHeight float64) Edge {
+	unwrapped := p.Position
+	if p.Position.X < 0 {
+		p.Position.X += screenWidth
+	} else if p.Position.X > screenWidth {
+		p.Position.X -= screenWidth
+	}
+
+	if p.Position.Y < 0 {
+		p.Position.Y += screenHeight
+	} else if p.Position.Y > screenHeight {
+		p.Position.Y -= screenHeight
+	}
+
+	if p.Position == unwrapped {
+		return EdgeNone
+	}
+	// Don't interpolate the whole way across the screen
+	p.previousPosition = p.Position
+	return EdgeWrapped
+}
+
+// despawn reports whether the polygon is more than Margin beyond any edge
+func (p *PolygonObject) despawn(screenWidth, screenHeight float64) Edge {
+	pos, m := p.Position, p.Margin
+	if pos.X < -m || pos.X > screenWidth+m || pos.Y < -m || pos.Y > screenHeight+m {
+		return EdgeLeft
+	}
+	return EdgeNone
+}
+
+// bounce reflects the polygon back into the screen if it has crossed an
+// edge, by mirroring its position and negating the velocity normal to the
+// edge
+func (p *PolygonObject) bounce(screenWidth, screenHeight float64) Edge {
+	edge := EdgeNone
+	if p.Position.X < 0 || p.Position.X > screenWidth {
+		p.Position.X = math.Max(0, math.Min(screenWidth, p.Position.X))*2 - p.Position.X
+		p.Velocity.X = -p.Velocity.X
+		edge = EdgeBounced
+	}
+	if p.Position.Y < 0 || p.Position.Y > screenHeight {
+		p.Position.Y = math.Max(0, math.Min(screenHeight, p.Position.Y))*2 - p.Position.Y
+		p.Velocity.Y = -p.Velocity.Y
+		edge = EdgeBounced
+	}
+	return edge
+}
+
+// clamp holds the polygon at the edge it has crossed, dropping the part of
+// its velocity heading out of the screen
+func (p *PolygonObject) clamp(screenWidth, screenHeight float64) Edge {
+	edge := EdgeNone
+	if p.Position.X < 0 || p.Position.X > screenWidth {
+		if (p.Position.X < 0) == (p.Velocity.X < 0) {
+			p.Velocity.X = 0
+		}
+		p.Position.X = math.Max(0, math.Min(screenWidth, p.Position.X))
+		edge = EdgeClamped
+	}
+	if p.Position.Y < 0 || p.Position.Y > screenHeight {
+		if (p.Position.Y < 0) == (p.Velocity.Y < 0) {
+			p.Velocity.Y = 0
+		}
+		p.Position.Y = math.Max(0, math.Min(screenHeight, p.Position.Y))
+		edge = EdgeClamped
+	}
+	return edge
+}
+
+// stepVelocity applies the pending acceleration, drag and speed limit over
+// motion frames
+func (p *PolygonObject) stepVelocity(motion float64) {
+	p.Velocity.X += p.Acceleration.X * motion
+	p.Velocity.Y += p.Acceleration.Y * motion
+	p.Acceleration = Vector2{}
+
+	if p.Drag != 0 {
+		retained := math.Pow(1-p.Drag, motion)
+		p.Velocity.X *= retained
+		p.Velocity.Y *= retained
+	}
+
+	if p.MaxSpeed != 0 {
+		speed := math.Hypot(p.Velocity.X, p.Velocity.Y)
+		if speed > p.MaxSpeed {
+			p.Velocity.X = p.Velocity.X / speed * p.MaxSpeed
+			p.Velocity.Y = p.Velocity.Y / speed * p.MaxSpeed
+		}
+	}
+}
+
+// InterpolateColor interpolates between two colors based on progress (0.0 to 1.0)
+func InterpolateColor(startColor, endColor color.Color, progress float64) color.Color {
+	// Clamp progress to [0, 1]
+	if progress < 0 {
+		progress = 0
+	} else if progress > 1 {
+		progress = 1
+	}
+
+	// Convert colors to RGBA
+	sr, sg, sb, sa := startColor.RGBA()
+	er, eg, eb, ea := endColor.RGBA()
+
+	// Interpolate each component
+	r := uint8((float64(sr>>8)*(1-progress) + float64(er>>8)*progress))
+	g := uint8((float64(sg>>8)*(1-progress) + float64(eg>>8)*progress))
+	b := uint8((float64(sb>>8)*(1-progress) + float64(eb>>8)*progress))
+	a := uint8((float64(sa>>8)*(1-progress) + float64(ea>>8)*progress))
+
+	return color.RGBA{r, g, b, a}
+}
+
+// Easing shapes how a fade progresses over its duration
+type Easing int
+
+const (
+	// EaseLinear changes color at a steady rate
+	EaseLinear Easing = iota
+	// EaseIn starts slowly and speeds up towards the end
+	EaseIn
+	// EaseOut starts quickly and slows down towards the end
+	EaseOut
+	// EaseInOut starts and ends slowly, changing fastest in the middle
+	EaseInOut
+)
+
+// Apply maps the linear progress t, from 0 to 1, to eased progress. The
+// ends are kept fixed, so every easing starts at 0 and finishes at 1.
+func (e Easing) Apply(t float64) float64 {
+	switch e {
+	case EaseIn:
+		return t * t
+	case EaseOut:
+		return 1 - (1-t)*(1-t)
+	case EaseInOut:
+		if t < 0.5 {
+			return 2 * t * t
+		}
+		return 1 - 2*(1-t)*(1-t)
+	}
+	return t
+}
+
+// StartFade begins a color fade from current color to target color
+func (p *PolygonObject) StartFade(targetColor color.Color, duration float64) {
+	p.StartEasedFade(targetColor, duration, EaseLinear)
+}
+
+// StartEasedFade begins a color fade from current color to target color,
+// over duration frames, progressing according to easing
+func (p *PolygonObject) StartEasedFade(targetColor color.Color, duration float64, easing Easing) {
+	p.FadeStartColor = p.Color
+	p.FadeEndColor = targetColor
+	p.FadeProgress = 0.0
+	p.FadeSpeed = 1.0 / duration // duration in frames (60 FPS)
+	p.FadeEasing = easing
+	p.IsFading = true
+}
+
+// Flash switches the polygon to c, then fades it back evenly over d to the
+// color it had. If it was already fading, such as part way through another flash,
+// it fades back to where that fade was going instead, so retriggering a
+// flash never leaves it on an in-between color.
+func (p *PolygonObject) Flash(c color.Color, d time.Duration) {
+	restore := p.Color
+	if p.IsFading {
+		restore = p.FadeEndColor
+	}
+	p.Color = c
+	p.StartEasedFade(restore, d.Seconds()*TicksPerSecond, EaseLinear)
+}
+
+// updateFade advances the fade progress by dt frames and updates the color
+// (called internally by Step)
+func (p *PolygonObject) updateFade(dt float64) {
+	if !p.IsFading {
+		return
+	}
+
+	p.FadeProgress += p.FadeSpeed * dt
+
+	// Allow for rounding, so a fade of n frames finishes after n steps
+	if p.FadeProgress >= 1.0-1e-9 {
+		// Fade complete
+		p.FadeProgress = 1.0
+		p.Color = p.FadeEndColor
+		p.IsFading = false
+	} else {
+		// Update color based on current progress
+		p.Color = InterpolateColor(p.FadeStartColor, p.FadeEndColor, p.FadeEasing.Apply(p.FadeProgress))
+	}
+}
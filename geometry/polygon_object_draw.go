@@ -0,0 +1,137 @@
+//go:build !headless
+
+package geometry
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// whiteImage is a 1x1 white image used for drawing colored shapes
+var (
+	whiteImage = ebiten.NewImage(3, 3)
+)
+
+func init() {
+	whiteImage.Fill(color.White)
+}
+
+func (d DrawablePolygon) Draw(screen *ebiten.Image, lineWidth float32, color color.Color) {
+	d.drawWrapped(screen, lineWidth, color, 0)
+}
+
+// drawWrapped draws the polygon outline, and the wrapped copies of it at the
+// opposite edges of the screen that it crosses, leaving out the edges set
+// in hidden
+func (d DrawablePolygon) drawWrapped(screen *ebiten.Image, lineWidth float32, color color.Color, hidden uint64) {
+	if len(d) < 3 {
+		return // Can't draw a polygon with less than 3 vertices
+	}
+
+	bounds := screen.Bounds()
+	sw, sh := float64(bounds.Dx()), float64(bounds.Dy())
+
+	// Find bounding box of the polygon to see if it needs wrapping
+	minX, minY := d[0].X, d[0].Y
+	maxX, maxY := minX, minY
+	for _, v := range d[1:] {
+		if v.X < minX {
+			minX = v.X
+		}
+		if v.X > maxX {
+			maxX = v.X
+		}
+		if v.Y < minY {
+			minY = v.Y
+		}
+		if v.Y > maxY {
+			maxY = v.Y
+		}
+	}
+
+	// Determine which offsets we need to draw at for screen wrapping
+	dxs := []float64{0}
+	if minX < 0 {
+		dxs = append(dxs, sw)
+	}
+	if maxX > sw {
+		dxs = append(dxs, -sw)
+	}
+
+	dys := []float64{0}
+	if minY < 0 {
+		dys = append(dys, sh)
+	}
+	if maxY > sh {
+		dys = append(dys, -sh)
+	}
+
+	// Draw the polygon outline for each required wrap position
+	for _, dx := range dxs {
+		for _, dy := range dys {
+			d.drawAt(screen, dx, dy, lineWidth, color, hidden)
+		}
+	}
+}
+
+// DrawNoWrap draws the polygon outline only at its actual position, without
+// the wrapped copies Draw adds when the polygon crosses a screen edge
+func (d DrawablePolygon) DrawNoWrap(screen *ebiten.Image, lineWidth float32, color color.Color) {
+	if len(d) < 3 {
+		return // Can't draw a polygon with less than 3 vertices
+	}
+	d.drawAt(screen, 0, 0, lineWidth, color, 0)
+}
+
+// drawAt draws the polygon outline offset by (dx, dy), leaving out the edges
+// set in hidden
+func (d DrawablePolygon) drawAt(screen *ebiten.Image, dx, dy float64, lineWidth float32, color color.Color, hidden uint64) {
+	for i := 0; i < len(d); i++ {
+		if edgeHidden(hidden, i) {
+			continue
+		}
+		start := d[i]
+		end := d[(i+1)%len(d)]
+
+		vector.StrokeLine(
+			screen,
+			float32(start.X+dx), float32(start.Y+dy),
+			float32(end.X+dx), float32(end.Y+dy),
+			lineWidth,
+			color,
+			true, // antialiasing
+		)
+	}
+}
+
+// Draw renders the polygon to the screen with antialiased lines
+func (p *PolygonObject) Draw(screen *ebiten.Image) {
+	p.DrawWithOptions(screen, DrawOptions{})
+}
+
+// DrawNoWrap renders the polygon without drawing wrapped copies at the
+// opposite screen edges, for objects that are not wrapping
+func (p *PolygonObject) DrawNoWrap(screen *ebiten.Image) {
+	p.DrawWithOptions(screen, DrawOptions{NoWrap: true})
+}
+
+// DrawWithOptions renders the polygon with the given drawing adjustments
+func (p *PolygonObject) DrawWithOptions(screen *ebiten.Image, opts DrawOptions) {
+	if len(p.Vertices) < 3 {
+		return // Can't draw a polygon with less than 3 vertices
+	}
+
+	transformedVertices := p.TransformedVertices()
+	if opts.Rewind > 0 && p.hasPrevious {
+		position, rotation := p.InterpolatedPose(1 - opts.Rewind)
+		transformedVertices = p.transformPose(p.Vertices, position, rotation)
+	}
+	lineWidth := p.LineWidth + opts.ExtraLineWidth
+	if opts.NoWrap {
+		transformedVertices.drawAt(screen, 0, 0, lineWidth, p.Color, opts.HiddenEdges)
+	} else {
+		transformedVertices.drawWrapped(screen, lineWidth, p.Color, opts.HiddenEdges)
+	}
+}
@@ -0,0 +1,22 @@
+//go:build !headless
+
+package geometry
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestInterpolatedDrawKeepsCollisionOutline(t *testing.T) {
+	// The collision outline stays on the tick's position
+	p := CreateCircle(5, 8)
+	p.SetPosition(100, 100)
+	p.SavePose()
+	p.SetPosition(120, 100)
+	before := p.GetCollisionBoundingBox()
+	p.DrawWithOptions(ebiten.NewImage(10, 10), DrawOptions{Rewind: 0.5})
+	if p.GetCollisionBoundingBox() != before {
+		t.Errorf("Expected drawing not to move the collision outline")
+	}
+}
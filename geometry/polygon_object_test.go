@@ -0,0 +1,949 @@
+package geometry
+
+import (
+	"image/color"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestVector2(t *testing.T) {
+	v := Vector2{X: 10, Y: 20}
+	if v.X != 10 || v.Y != 20 {
+		t.Errorf("Expected {10, 20}, got {%v, %v}", v.X, v.Y)
+	}
+}
+
+func TestPolygonObject_SetPosition(t *testing.T) {
+	p := &PolygonObject{}
+	p.SetPosition(100, 200)
+	if p.Position.X != 100 || p.Position.Y != 200 {
+		t.Errorf("Expected position {100, 200}, got {%v, %v}", p.Position.X, p.Position.Y)
+	}
+}
+
+func TestPolygonObject_Rotate(t *testing.T) {
+	p := &PolygonObject{Rotation: 0}
+	p.Rotate(math.Pi / 2)
+	if math.Abs(p.Rotation-math.Pi/2) > 1e-9 {
+		t.Errorf("Expected rotation %v, got %v", math.Pi/2, p.Rotation)
+	}
+}
+
+func TestPolygonObject_TimeScale(t *testing.T) {
+	p := CreatePolygon([]Vector2{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1}})
+	p.SetVelocity(2, 0)
+	p.SetRotationSpeed(0.2)
+
+	p.TimeScale = 0.5
+	p.Update(800, 600)
+	if p.Position.X != 1 || math.Abs(p.Rotation-0.1) > 1e-9 {
+		t.Errorf("Expected half speed movement, got position %v rotation %v", p.Position, p.Rotation)
+	}
+	if p.Velocity.X != 2 {
+		t.Errorf("Expected velocity to be unchanged, got %v", p.Velocity.X)
+	}
+
+	p.TimeScale = 0
+	p.Update(800, 600)
+	if p.Position.X != 3 {
+		t.Errorf("Expected a zero time scale to run at normal speed, got position %v", p.Position)
+	}
+}
+
+func TestBoundingBoxOverlap(t *testing.T) {
+	box1 := BoundingBox{MinX: 0, MinY: 0, MaxX: 10, MaxY: 10}
+	box2 := BoundingBox{MinX: 5, MinY: 5, MaxX: 15, MaxY: 15}
+	box3 := BoundingBox{MinX: 20, MinY: 20, MaxX: 30, MaxY: 30}
+
+	if !box1.Overlaps(box2) {
+		t.Errorf("Expected box1 and box2 to overlap")
+	}
+	if box1.Overlaps(box3) {
+		t.Errorf("Expected box1 and box3 to not overlap")
+	}
+}
+
+func TestPointInPolygon(t *testing.T) {
+	// Square polygon
+	vertices := []Vector2{
+		{X: 0, Y: 0},
+		{X: 10, Y: 0},
+		{X: 10, Y: 10},
+		{X: 0, Y: 10},
+	}
+
+	insidePoint := Vector2{X: 5, Y: 5}
+	outsidePoint := Vector2{X: 15, Y: 5}
+
+	if !PointInPolygon(insidePoint, vertices) {
+		t.Errorf("Expected point {%v, %v} to be inside polygon", insidePoint.X, insidePoint.Y)
+	}
+	if PointInPolygon(outsidePoint, vertices) {
+		t.Errorf("Expected point {%v, %v} to be outside polygon", outsidePoint.X, outsidePoint.Y)
+	}
+}
+
+func TestLineSegmentsIntersect(t *testing.T) {
+	if !LineSegmentsIntersect(Vector2{X: 0, Y: 0}, Vector2{X: 10, Y: 10}, Vector2{X: 10, Y: 0}, Vector2{X: 0, Y: 10}) {
+		t.Errorf("Expected crossing diagonals to intersect")
+	}
+	if LineSegmentsIntersect(Vector2{X: 0, Y: 0}, Vector2{X: 4, Y: 4}, Vector2{X: 10, Y: 0}, Vector2{X: 6, Y: 4}) {
+		t.Errorf("Expected segments that stop short of each other not to intersect")
+	}
+}
+
+func TestIsSimplePolygon(t *testing.T) {
+	square := []Vector2{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}}
+	if !IsSimplePolygon(square) {
+		t.Errorf("Expected square to be simple")
+	}
+
+	bowtie := []Vector2{{X: 0, Y: 0}, {X: 10, Y: 10}, {X: 10, Y: 0}, {X: 0, Y: 10}}
+	if IsSimplePolygon(bowtie) {
+		t.Errorf("Expected bowtie to be self-intersecting")
+	}
+
+	if IsSimplePolygon(square[:2]) {
+		t.Errorf("Expected a 2 vertex outline to be rejected")
+	}
+
+	if !IsSimplePolygon(CreatePlayer(20).Vertices) {
+		t.Errorf("Expected the built-in player ship to be simple")
+	}
+}
+
+func TestInterpolateColor(t *testing.T) {
+	c1 := color.RGBA{0, 0, 0, 255}
+	c2 := color.RGBA{255, 255, 255, 255}
+
+	mid := InterpolateColor(c1, c2, 0.5)
+	r, _, _, a := mid.RGBA()
+
+	// RGBA() returns 0-65535
+	if uint8(r>>8) < 120 || uint8(r>>8) > 135 {
+		t.Errorf("Expected red around 127, got %v", uint8(r>>8))
+	}
+	if uint8(a>>8) != 255 {
+		t.Errorf("Expected alpha 255, got %v", uint8(a>>8))
+	}
+}
+
+func TestEasing(t *testing.T) {
+	for _, e := range []Easing{EaseLinear, EaseIn, EaseOut, EaseInOut} {
+		if e.Apply(0) != 0 || e.Apply(1) != 1 {
+			t.Errorf("Easing %d: expected the ends to stay fixed, got %v and %v", e, e.Apply(0), e.Apply(1))
+		}
+		previous := 0.0
+		for i := 1; i <= 100; i++ {
+			v := e.Apply(float64(i) / 100)
+			if v < previous {
+				t.Errorf("Easing %d: went backwards at %d%%", e, i)
+			}
+			previous = v
+		}
+	}
+	if EaseIn.Apply(0.5) >= 0.5 || EaseOut.Apply(0.5) <= 0.5 || EaseInOut.Apply(0.5) != 0.5 {
+		t.Errorf("Expected ease in to lag, ease out to lead and ease in-out to meet in the middle")
+	}
+}
+
+func TestEasedFade(t *testing.T) {
+	black := color.RGBA{0, 0, 0, 255}
+	white := color.RGBA{255, 255, 255, 255}
+	linear := CreateCircle(1, 4)
+	eased := CreateCircle(1, 4)
+	for _, p := range []*PolygonObject{linear, eased} {
+		p.SetColor(black)
+	}
+	linear.StartFade(white, 10)
+	eased.StartEasedFade(white, 10, EaseIn)
+	for i := 0; i < 5; i++ {
+		linear.Update(800, 600)
+		eased.Update(800, 600)
+	}
+	lr, _, _, _ := linear.Color.RGBA()
+	er, _, _, _ := eased.Color.RGBA()
+	if er >= lr {
+		t.Errorf("Expected an ease in fade to be behind a linear one half way, got %v and %v", er>>8, lr>>8)
+	}
+	for i := 0; i < 5; i++ {
+		eased.Update(800, 600)
+	}
+	if eased.Color != color.Color(white) || eased.IsFading {
+		t.Errorf("Expected the eased fade to finish on the target, got %v", eased.Color)
+	}
+}
+
+func TestFlashRestoresColor(t *testing.T) {
+	original := color.RGBA{10, 20, 30, 255}
+	red := color.RGBA{255, 0, 0, 255}
+	p := CreateCircle(1, 4)
+	p.SetColor(original)
+
+	p.Flash(red, time.Second)
+	if p.Color != color.Color(red) {
+		t.Fatalf("Expected the flash color straight away, got %v", p.Color)
+	}
+	// Retrigger part way through, while the color is in between
+	for i := 0; i < 30; i++ {
+		p.Update(800, 600)
+	}
+	// The flash fades back evenly, so half way it is half way between
+	if r, _, _, _ := p.Color.RGBA(); r>>8 < 130 || r>>8 > 134 {
+		t.Errorf("Expected the flash to be half faded after half its duration, got %v", p.Color)
+	}
+	p.Flash(red, time.Second)
+	for i := 0; i < 59; i++ {
+		p.Update(800, 600)
+	}
+	if !p.IsFading {
+		t.Errorf("Expected the retriggered flash to last its full duration")
+	}
+	p.Update(800, 600)
+	if p.IsFading || p.Color != color.Color(original) {
+		t.Errorf("Expected the color to return exactly to %v, got %v", original, p.Color)
+	}
+}
+
+func TestCollisionDetection(t *testing.T) {
+	polygon1 := &PolygonObject{
+		Vertices: []Vector2{
+			{X: 0, Y: 0},
+			{X: 10, Y: 0},
+			{X: 10, Y: 10},
+			{X: 0, Y: 10},
+		},
+		Position: Vector2{X: 0, Y: 0},
+		Scale:    1.0,
+	}
+
+	polygon2 := &PolygonObject{
+		Vertices: []Vector2{
+			{X: 5, Y: 5},
+			{X: 15, Y: 5},
+			{X: 15, Y: 15},
+			{X: 5, Y: 15},
+		},
+		Position: Vector2{X: 0, Y: 0},
+		Scale:    1.0,
+	}
+
+	polygon3 := &PolygonObject{
+		Vertices: []Vector2{
+			{X: 20, Y: 20},
+			{X: 30, Y: 20},
+			{X: 30, Y: 30},
+			{X: 20, Y: 30},
+		},
+		Position: Vector2{X: 0, Y: 0},
+		Scale:    1.0,
+	}
+
+	if !PolygonsCollide(polygon1, polygon2) {
+		t.Errorf("Expected polygon1 and polygon2 to collide")
+	}
+	if PolygonsCollide(polygon1, polygon3) {
+		t.Errorf("Expected polygon1 and polygon3 to not collide")
+	}
+}
+
+func TestPlayerCollisionHull(t *testing.T) {
+	ship := CreatePlayer(20)
+	ship.SetPosition(100, 100)
+	visual := ship.GetBoundingBox()
+	hull := ship.GetCollisionBoundingBox()
+	if hull.MinX <= visual.MinX || hull.MaxX >= visual.MaxX || hull.MinY <= visual.MinY || hull.MaxY >= visual.MaxY {
+		t.Errorf("Expected the collision hull %v inside the drawn ship %v", hull, visual)
+	}
+	if got := len(ship.TransformedVertices()); got != len(ship.Vertices) {
+		t.Errorf("Expected drawing to use all %d visual vertices, got %d", len(ship.Vertices), got)
+	}
+
+	// A rock just clipping the right wing tip, at (114, 106), misses
+	rock := CreateCircle(3, 8)
+	rock.SetPosition(115, 106)
+	if PolygonsCollide(ship, rock) || PolygonsCollide(rock, ship) {
+		t.Errorf("Expected a rock clipping the wing tip not to hit")
+	}
+	// Without the smaller hull it would have
+	ship.CollisionVertices = nil
+	if !PolygonsCollide(ship, rock) {
+		t.Errorf("Expected the rock to touch the drawn wing tip")
+	}
+
+	// A rock over the cockpit still hits
+	ship = CreatePlayer(20)
+	ship.SetPosition(100, 100)
+	rock.SetPosition(100, 95)
+	if !PolygonsCollide(ship, rock) {
+		t.Errorf("Expected a rock over the cockpit to hit")
+	}
+}
+
+func TestCollisionVerticesDefault(t *testing.T) {
+	p := CreateCircle(10, 8)
+	p.SetPosition(5, 5)
+	if p.GetCollisionBoundingBox() != p.GetBoundingBox() {
+		t.Errorf("Expected the collision box to match the drawn box without a collision outline")
+	}
+	p.CollisionVertices = ScaleAbout(p.Vertices, Vector2{}, 0.5)
+	if got := p.GetCollisionBoundingBox(); math.Abs(got.MaxX-10) > 1e-9 {
+		t.Errorf("Expected setting a collision outline to update the box, got %v", got)
+	}
+}
+
+func TestCollisionNormal(t *testing.T) {
+	a := CreateCircle(10, 8)
+	b := CreateCircle(10, 8)
+	a.SetPosition(13, 4)
+	b.SetPosition(10, 0)
+
+	normal := CollisionNormal(a, b)
+	if math.Abs(normal.X-0.6) > 1e-9 || math.Abs(normal.Y-0.8) > 1e-9 {
+		t.Errorf("Expected normal {0.6, 0.8}, got %v", normal)
+	}
+
+	b.SetPosition(13, 4)
+	if normal := CollisionNormal(a, b); normal != (Vector2{X: 0, Y: -1}) {
+		t.Errorf("Expected an upward normal for coincident centers, got %v", normal)
+	}
+}
+
+func TestTransformCacheInvalidation(t *testing.T) {
+	square := CreatePolygon([]Vector2{{X: -1, Y: -1}, {X: 1, Y: -1}, {X: 1, Y: 1}, {X: -1, Y: 1}})
+	if box := square.GetBoundingBox(); box != (BoundingBox{-1, -1, 1, 1}) {
+		t.Fatalf("Unexpected initial bounding box %v", box)
+	}
+
+	square.SetPosition(10, 20)
+	if box := square.GetBoundingBox(); box != (BoundingBox{9, 19, 11, 21}) {
+		t.Errorf("Expected the bounding box to follow SetPosition, got %v", box)
+	}
+
+	square.SetScale(2)
+	if box := square.GetBoundingBox(); box != (BoundingBox{8, 18, 12, 22}) {
+		t.Errorf("Expected the bounding box to follow SetScale, got %v", box)
+	}
+
+	square.Rotate(math.Pi / 4)
+	box := square.GetBoundingBox()
+	want := 10 - 2*math.Sqrt2
+	if math.Abs(box.MinX-want) > 1e-9 {
+		t.Errorf("Expected the bounding box to follow Rotate, got %v", box)
+	}
+	vertex := square.TransformedVertices()[0]
+	if math.Abs(vertex.X-10) > 1e-9 || math.Abs(vertex.Y-(20-2*math.Sqrt2)) > 1e-9 {
+		t.Errorf("Expected the vertices to follow Rotate, got %v", vertex)
+	}
+
+	// Changes made by Step and by writing the fields directly count too
+	square.SetRotation(0)
+	square.SetRotationSpeed(math.Pi / 2)
+	square.Step(1, 800, 600)
+	vertex = square.TransformedVertices()[0]
+	if math.Abs(vertex.X-12) > 1e-9 || math.Abs(vertex.Y-18) > 1e-9 {
+		t.Errorf("Expected the vertices to follow a rotation step, got %v", vertex)
+	}
+	square.Position.X = 100
+	if box := square.GetBoundingBox(); box.MinX != 98 {
+		t.Errorf("Expected the bounding box to follow a direct position change, got %v", box)
+	}
+
+	// A new outline with as many vertices as the old one, as when a
+	// fragment is shrunk, has to be picked up
+	square.SetVertices(ScaleAbout(square.Vertices, Vector2{}, 0.5))
+	if box := square.GetBoundingBox(); box.MinX != 99 {
+		t.Errorf("Expected the bounding box to follow SetVertices, got %v", box)
+	}
+	square.SetCollisionVertices(ScaleAbout(square.Vertices, Vector2{}, 0.5))
+	if box := square.GetCollisionBoundingBox(); box.MinX != 99.5 {
+		t.Errorf("Expected the collision bounding box to follow SetCollisionVertices, got %v", box)
+	}
+}
+
+// BenchmarkCollisionFrame simulates frames of a busy field of fragments,
+// each moving, tested against every other and then drawn
+func BenchmarkCollisionFrame(b *testing.B) {
+	asteroids := make([]*PolygonObject, 60)
+	for i := range asteroids {
+		asteroids[i] = CreateAsteroid(12, 3, 8)
+		asteroids[i].SetPosition(float64(i%10)*20, float64(i/10)*20)
+		asteroids[i].SetVelocity(0.5, 0.25)
+		asteroids[i].SetRotationSpeed(0.02)
+	}
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for _, a := range asteroids {
+			a.Update(800, 600)
+		}
+		for i, a := range asteroids {
+			for _, other := range asteroids[i+1:] {
+				PolygonsCollide(a, other)
+			}
+		}
+		for _, a := range asteroids {
+			a.TransformedVertices()
+		}
+	}
+}
+
+func TestLineSegmentsIntersectEdgeCases(t *testing.T) {
+	tests := []struct {
+		name           string
+		p1, p2, p3, p4 Vector2
+		want           bool
+	}{
+		{"crossing", Vector2{0, 0}, Vector2{10, 10}, Vector2{10, 0}, Vector2{0, 10}, true},
+		{"disjoint", Vector2{0, 0}, Vector2{4, 4}, Vector2{10, 0}, Vector2{6, 4}, false},
+		{"shared end point", Vector2{0, 0}, Vector2{10, 0}, Vector2{10, 0}, Vector2{10, 10}, true},
+		{"end point on other segment", Vector2{0, 0}, Vector2{10, 0}, Vector2{5, 0}, Vector2{5, 10}, true},
+		{"end point short of other segment", Vector2{0, 0}, Vector2{10, 0}, Vector2{5, 1}, Vector2{5, 10}, false},
+		{"collinear overlapping", Vector2{0, 0}, Vector2{10, 0}, Vector2{5, 0}, Vector2{15, 0}, true},
+		{"collinear contained", Vector2{0, 0}, Vector2{10, 10}, Vector2{2, 2}, Vector2{3, 3}, true},
+		{"collinear touching", Vector2{0, 0}, Vector2{10, 0}, Vector2{10, 0}, Vector2{20, 0}, true},
+		{"collinear apart", Vector2{0, 0}, Vector2{10, 0}, Vector2{11, 0}, Vector2{20, 0}, false},
+		{"parallel", Vector2{0, 0}, Vector2{10, 0}, Vector2{0, 1}, Vector2{10, 1}, false},
+		{"zero length on segment", Vector2{5, 5}, Vector2{5, 5}, Vector2{0, 0}, Vector2{10, 10}, true},
+		{"zero length off segment", Vector2{5, 6}, Vector2{5, 6}, Vector2{0, 0}, Vector2{10, 10}, false},
+		{"zero length beyond segment", Vector2{11, 11}, Vector2{11, 11}, Vector2{0, 0}, Vector2{10, 10}, false},
+		{"both zero length, same point", Vector2{3, 3}, Vector2{3, 3}, Vector2{3, 3}, Vector2{3, 3}, true},
+		{"both zero length, apart", Vector2{3, 3}, Vector2{3, 3}, Vector2{4, 3}, Vector2{4, 3}, false},
+	}
+	for _, tt := range tests {
+		if got := LineSegmentsIntersect(tt.p1, tt.p2, tt.p3, tt.p4); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestClosestApproach(t *testing.T) {
+	tests := []struct {
+		name           string
+		a0, a1, b0, b1 Vector2
+		want           float64
+	}{
+		{"passing through each other", Vector2{0, 0}, Vector2{10, 0}, Vector2{10, 0}, Vector2{0, 0}, 0},
+		{"passing side by side", Vector2{0, 0}, Vector2{10, 0}, Vector2{10, 3}, Vector2{0, 3}, 3},
+		{"closing but not meeting", Vector2{0, 0}, Vector2{2, 0}, Vector2{10, 0}, Vector2{8, 0}, 6},
+		{"moving apart", Vector2{5, 0}, Vector2{0, 0}, Vector2{6, 0}, Vector2{11, 0}, 1},
+		{"moving together", Vector2{0, 0}, Vector2{5, 5}, Vector2{0, 4}, Vector2{5, 9}, 4},
+		{"both still", Vector2{1, 1}, Vector2{1, 1}, Vector2{4, 5}, Vector2{4, 5}, 5},
+	}
+	for _, tt := range tests {
+		if got := ClosestApproach(tt.a0, tt.a1, tt.b0, tt.b1); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestPointInPolygonBoundary(t *testing.T) {
+	square := []Vector2{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}}
+	tests := []struct {
+		name  string
+		point Vector2
+		want  bool
+	}{
+		{"inside", Vector2{5, 5}, true},
+		{"outside", Vector2{15, 5}, false},
+		{"on left edge", Vector2{0, 5}, true},
+		{"on right edge", Vector2{10, 5}, true},
+		{"on top edge", Vector2{5, 0}, true},
+		{"on bottom edge", Vector2{5, 10}, true},
+		{"on vertex", Vector2{10, 10}, true},
+		{"in line with an edge", Vector2{15, 0}, false},
+		{"in line with a vertex", Vector2{-5, 10}, false},
+	}
+	for _, tt := range tests {
+		if got := PointInPolygon(tt.point, square); got != tt.want {
+			t.Errorf("%s: expected %v for %v, got %v", tt.name, tt.want, tt.point, got)
+		}
+	}
+}
+
+// polygonFromInts builds a polygon at the origin from pairs of small integer
+// coordinates, which keeps all the collision arithmetic exact
+func polygonFromInts(coords []int8) *PolygonObject {
+	vertices := make([]Vector2, len(coords)/2)
+	for i := range vertices {
+		vertices[i] = Vector2{X: float64(coords[2*i]), Y: float64(coords[2*i+1])}
+	}
+	return CreatePolygon(vertices)
+}
+
+// checkCollisionProperties reports any broken PolygonsCollide invariant for
+// the two polygons
+func checkCollisionProperties(t *testing.T, a, b *PolygonObject, dx, dy float64) {
+	t.Helper()
+	ab := PolygonsCollide(a, b)
+	if ba := PolygonsCollide(b, a); ab != ba {
+		t.Errorf("Asymmetric collision for %v and %v: %v vs %v", a.Vertices, b.Vertices, ab, ba)
+	}
+	if len(a.Vertices) >= 3 && !PolygonsCollide(a, a) {
+		t.Errorf("Expected %v to collide with itself", a.Vertices)
+	}
+
+	ax, ay := a.Position.X, a.Position.Y
+	bx, by := b.Position.X, b.Position.Y
+	a.SetPosition(ax+dx, ay+dy)
+	b.SetPosition(bx+dx, by+dy)
+	if moved := PolygonsCollide(a, b); moved != ab {
+		t.Errorf("Collision of %v and %v changed from %v to %v when moved by {%v, %v}", a.Vertices, b.Vertices, ab, moved, dx, dy)
+	}
+	a.SetPosition(ax, ay)
+	b.SetPosition(bx, by)
+}
+
+func TestPolygonsCollideProperties(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	randomPolygon := func() *PolygonObject {
+		coords := make([]int8, 2*(3+rng.Intn(6)))
+		for i := range coords {
+			coords[i] = int8(rng.Intn(21) - 10)
+		}
+		return polygonFromInts(coords)
+	}
+
+	for i := 0; i < 2000; i++ {
+		a, b := randomPolygon(), randomPolygon()
+		b.SetPosition(float64(rng.Intn(21)-10), float64(rng.Intn(21)-10))
+		checkCollisionProperties(t, a, b, float64(rng.Intn(200)-100), float64(rng.Intn(200)-100))
+	}
+
+	// Squares sharing just an edge or a corner touch
+	square := polygonFromInts([]int8{0, 0, 10, 0, 10, 10, 0, 10})
+	neighbour := polygonFromInts([]int8{0, 0, 10, 0, 10, 10, 0, 10})
+	for _, offset := range []Vector2{{X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: -10}} {
+		neighbour.SetPosition(offset.X, offset.Y)
+		if !PolygonsCollide(square, neighbour) {
+			t.Errorf("Expected squares touching at offset %v to collide", offset)
+		}
+	}
+	neighbour.SetPosition(10.5, 0)
+	if PolygonsCollide(square, neighbour) {
+		t.Errorf("Expected separated squares not to collide")
+	}
+}
+
+func FuzzLineSegmentsIntersect(f *testing.F) {
+	f.Add(int8(0), int8(0), int8(10), int8(10), int8(10), int8(0), int8(0), int8(10))
+	f.Add(int8(0), int8(0), int8(10), int8(0), int8(5), int8(0), int8(15), int8(0))
+	f.Add(int8(5), int8(5), int8(5), int8(5), int8(0), int8(0), int8(10), int8(10))
+	f.Fuzz(func(t *testing.T, x1, y1, x2, y2, x3, y3, x4, y4 int8) {
+		p1 := Vector2{X: float64(x1), Y: float64(y1)}
+		p2 := Vector2{X: float64(x2), Y: float64(y2)}
+		p3 := Vector2{X: float64(x3), Y: float64(y3)}
+		p4 := Vector2{X: float64(x4), Y: float64(y4)}
+
+		got := LineSegmentsIntersect(p1, p2, p3, p4)
+		if LineSegmentsIntersect(p3, p4, p1, p2) != got ||
+			LineSegmentsIntersect(p2, p1, p3, p4) != got ||
+			LineSegmentsIntersect(p1, p2, p4, p3) != got {
+			t.Errorf("Intersection of %v-%v and %v-%v depends on argument order", p1, p2, p3, p4)
+		}
+		if !LineSegmentsIntersect(p1, p2, p1, p2) {
+			t.Errorf("Expected %v-%v to intersect itself", p1, p2)
+		}
+		if !LineSegmentsIntersect(p1, p2, p2, p3) {
+			t.Errorf("Expected %v-%v to touch %v-%v", p1, p2, p2, p3)
+		}
+	})
+}
+
+func FuzzPolygonsCollide(f *testing.F) {
+	f.Add([]byte{0, 0, 10, 0, 10, 10, 0, 10}, []byte{10, 0, 20, 0, 20, 10, 10, 10}, int8(3), int8(-7))
+	f.Add([]byte{0, 0, 10, 10, 10, 0, 0, 10}, []byte{5, 5, 6, 5, 5, 6}, int8(0), int8(0))
+	f.Fuzz(func(t *testing.T, first, second []byte, dx, dy int8) {
+		toCoords := func(data []byte) []int8 {
+			coords := make([]int8, len(data)&^1)
+			for i := range coords {
+				coords[i] = int8(data[i])
+			}
+			return coords
+		}
+		a := polygonFromInts(toCoords(first))
+		b := polygonFromInts(toCoords(second))
+		checkCollisionProperties(t, a, b, float64(dx), float64(dy))
+	})
+}
+
+func TestNormalizeWinding(t *testing.T) {
+	// Built directly, so the constructors don't normalize it first
+	clockwise := &PolygonObject{
+		Vertices: []Vector2{{X: 0, Y: 0}, {X: 0, Y: 10}, {X: 10, Y: 10}, {X: 10, Y: 0}},
+		Position: Vector2{X: 50, Y: 50},
+		Scale:    1,
+	}
+	other := CreatePolygon([]Vector2{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 4}})
+	other.SetPosition(58, 58)
+
+	if area := clockwise.SignedArea(); area != -100 {
+		t.Fatalf("Expected a signed area of -100, got %v", area)
+	}
+	areaBefore := clockwise.Area()
+	centroidBefore := clockwise.Centroid()
+	collidesBefore := PolygonsCollide(clockwise, other)
+
+	clockwise.NormalizeWinding()
+
+	if area := clockwise.SignedArea(); area != 100 {
+		t.Errorf("Expected a signed area of 100 after normalizing, got %v", area)
+	}
+	if clockwise.Vertices[0] != (Vector2{X: 0, Y: 0}) {
+		t.Errorf("Expected the first vertex to stay first, got %v", clockwise.Vertices[0])
+	}
+	if area := clockwise.Area(); area != areaBefore || area != 100 {
+		t.Errorf("Expected the area to stay 100, got %v then %v", areaBefore, area)
+	}
+	if centroid := clockwise.Centroid(); centroid != centroidBefore || centroid != (Vector2{X: 5, Y: 5}) {
+		t.Errorf("Expected the centroid to stay {5, 5}, got %v then %v", centroidBefore, centroid)
+	}
+	if collides := PolygonsCollide(clockwise, other); collides != collidesBefore || !collides {
+		t.Errorf("Expected the collision to be unchanged, got %v then %v", collidesBefore, collides)
+	}
+
+	for name, polygon := range map[string]*PolygonObject{
+		"player":   CreatePlayer(15),
+		"asteroid": CreateAsteroid(30, 5, 8),
+		"flame":    CreatePlayerFlame(25),
+		"circle":   CreateCircle(10, 12),
+	} {
+		if polygon.SignedArea() <= 0 {
+			t.Errorf("Expected the %s to be normalized, signed area %v", name, polygon.SignedArea())
+		}
+	}
+	if nose := CreatePlayer(15).Vertices[0]; nose != (Vector2{X: 0, Y: -15}) {
+		t.Errorf("Expected the ship's nose to stay first, got %v", nose)
+	}
+}
+
+func TestNormalizeAngle(t *testing.T) {
+	tests := []struct {
+		angle, want float64
+	}{
+		{0, 0},
+		{math.Pi, math.Pi},
+		{2 * math.Pi, 0},
+		{7 * math.Pi, math.Pi},
+		{-7 * math.Pi, math.Pi},
+		{-math.Pi / 2, 3 * math.Pi / 2},
+		{-1e-18, 0},
+	}
+	for _, tt := range tests {
+		got := NormalizeAngle(tt.angle)
+		if math.Abs(got-tt.want) > 1e-9 || got < 0 || got >= 2*math.Pi {
+			t.Errorf("NormalizeAngle(%v): expected %v, got %v", tt.angle, tt.want, got)
+		}
+	}
+}
+
+func TestAngleDifference(t *testing.T) {
+	cases := []struct {
+		from, to, want float64
+	}{
+		{0, 0.1, 0.1},
+		{0.1, 0, -0.1},
+		// Across the 0/2π seam
+		{2*math.Pi - 0.1, 0.1, 0.2},
+		{0.1, 2*math.Pi - 0.1, -0.2},
+		// Either side of ±π, the shorter way round
+		{0, math.Pi - 0.01, math.Pi - 0.01},
+		{0, math.Pi + 0.01, -math.Pi + 0.01},
+		{math.Pi - 0.05, -math.Pi + 0.05, 0.1},
+		{-math.Pi + 0.05, math.Pi - 0.05, -0.1},
+		// Exactly half a turn is -π, whichever way it is asked
+		{0, math.Pi, -math.Pi},
+		{math.Pi, 0, -math.Pi},
+		// Whole extra turns don't matter
+		{0, 6*math.Pi + 0.3, 0.3},
+		{-4 * math.Pi, 0.3, 0.3},
+	}
+	for _, c := range cases {
+		if got := AngleDifference(c.from, c.to); math.Abs(got-c.want) > 1e-9 {
+			t.Errorf("AngleDifference(%v, %v) = %v, expected %v", c.from, c.to, got, c.want)
+		}
+	}
+}
+
+func TestRotationStaysInRange(t *testing.T) {
+	p := CreatePolygon([]Vector2{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1}})
+
+	p.Rotate(7 * math.Pi)
+	if math.Abs(p.Rotation-math.Pi) > 1e-9 {
+		t.Errorf("Expected Rotate(7π) to land on π, got %v", p.Rotation)
+	}
+	p.SetRotation(-7 * math.Pi)
+	if math.Abs(p.Rotation-math.Pi) > 1e-9 {
+		t.Errorf("Expected SetRotation(-7π) to land on π, got %v", p.Rotation)
+	}
+
+	for _, speed := range []float64{1, -1, 20} {
+		p.SetRotationSpeed(speed)
+		for i := 0; i < 1000; i++ {
+			p.Update(800, 600)
+			if p.Rotation < 0 || p.Rotation >= 2*math.Pi {
+				t.Fatalf("Rotation left [0, 2π) at speed %v after %d frames: %v", speed, i+1, p.Rotation)
+			}
+		}
+	}
+}
+
+func TestMaxSpeedUnderSustainedForce(t *testing.T) {
+	p := CreatePolygon([]Vector2{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1}})
+	p.MaxSpeed = 5
+
+	for i := 0; i < 500; i++ {
+		p.ApplyForce(0.3, -0.2)
+		p.Update(800, 600)
+		if speed := math.Hypot(p.Velocity.X, p.Velocity.Y); speed > 5+1e-9 {
+			t.Fatalf("Speed %v exceeded the maximum after %d frames", speed, i+1)
+		}
+	}
+	if speed := math.Hypot(p.Velocity.X, p.Velocity.Y); math.Abs(speed-5) > 1e-9 {
+		t.Errorf("Expected to reach the maximum speed, got %v", speed)
+	}
+}
+
+func TestForceAndDrag(t *testing.T) {
+	p := CreatePolygon([]Vector2{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1}})
+
+	p.ApplyForce(1, 0)
+	p.ApplyForce(1, 2)
+	p.Update(800, 600)
+	if p.Velocity != (Vector2{X: 2, Y: 2}) || p.Position != (Vector2{X: 2, Y: 2}) {
+		t.Errorf("Expected forces to add up before moving, got velocity %v position %v", p.Velocity, p.Position)
+	}
+	if p.Acceleration != (Vector2{}) {
+		t.Errorf("Expected the acceleration to be cleared, got %v", p.Acceleration)
+	}
+
+	// Without drag the velocity is kept, with it a fraction is lost
+	p.Update(800, 600)
+	if p.Velocity != (Vector2{X: 2, Y: 2}) {
+		t.Errorf("Expected no drag by default, got velocity %v", p.Velocity)
+	}
+	p.Drag = 0.5
+	p.Update(800, 600)
+	if p.Velocity != (Vector2{X: 1, Y: 1}) {
+		t.Errorf("Expected drag to halve the velocity, got %v", p.Velocity)
+	}
+
+	// Drag over two half steps matches one full step
+	p.Step(0.5, 800, 600)
+	p.Step(0.5, 800, 600)
+	if math.Abs(p.Velocity.X-0.5) > 1e-9 {
+		t.Errorf("Expected drag to be frame rate independent, got %v", p.Velocity)
+	}
+}
+
+// movingSquare creates a small square at position, moving with velocity and
+// handling the screen edge with boundary
+func movingSquare(boundary BoundaryBehavior, position, velocity Vector2) *PolygonObject {
+	p := CreatePolygon([]Vector2{{X: -1, Y: -1}, {X: 1, Y: -1}, {X: 1, Y: 1}, {X: -1, Y: 1}})
+	p.Boundary = boundary
+	p.Position = position
+	p.Velocity = velocity
+	return p
+}
+
+func TestBoundaryWrap(t *testing.T) {
+	p := movingSquare(BoundaryWrap, Vector2{X: 798, Y: 300}, Vector2{X: 4, Y: 0})
+	if edge := p.Update(800, 600); edge != EdgeWrapped || p.Position != (Vector2{X: 2, Y: 300}) {
+		t.Errorf("Expected to wrap to the left edge, got %v at %v", edge, p.Position)
+	}
+	if edge := p.Update(800, 600); edge != EdgeNone {
+		t.Errorf("Expected nothing to happen inside the screen, got %v", edge)
+	}
+
+	// Wrapping is the default
+	if CreateAsteroid(10, 1, 8).Boundary != BoundaryWrap {
+		t.Errorf("Expected new polygons to wrap")
+	}
+}
+
+func TestBoundaryDespawn(t *testing.T) {
+	p := movingSquare(BoundaryDespawn, Vector2{X: 400, Y: 598}, Vector2{X: 0, Y: 4})
+	p.Margin = 10
+
+	// Within the margin the object carries on off the screen
+	for i := 0; i < 3; i++ {
+		if edge := p.Update(800, 600); edge != EdgeNone {
+			t.Fatalf("Expected no edge within the margin at %v, got %v", p.Position, edge)
+		}
+	}
+	if edge := p.Update(800, 600); edge != EdgeLeft {
+		t.Errorf("Expected to leave the world at %v, got %v", p.Position, edge)
+	}
+	if p.Position.Y != 614 {
+		t.Errorf("Expected the position to be left alone, got %v", p.Position)
+	}
+}
+
+func TestBoundaryBounce(t *testing.T) {
+	p := movingSquare(BoundaryBounce, Vector2{X: 2, Y: 597}, Vector2{X: -4, Y: 5})
+	if edge := p.Update(800, 600); edge != EdgeBounced {
+		t.Fatalf("Expected to bounce, got %v", edge)
+	}
+	// Mirrored back in off both edges of the corner
+	if p.Position != (Vector2{X: 2, Y: 598}) || p.Velocity != (Vector2{X: 4, Y: -5}) {
+		t.Errorf("Expected to be reflected into the corner, got position %v velocity %v", p.Position, p.Velocity)
+	}
+}
+
+func TestBoundaryClamp(t *testing.T) {
+	p := movingSquare(BoundaryClamp, Vector2{X: 797, Y: 3}, Vector2{X: 5, Y: -2})
+	if edge := p.Update(800, 600); edge != EdgeClamped {
+		t.Fatalf("Expected to be clamped, got %v", edge)
+	}
+	if p.Position != (Vector2{X: 800, Y: 1}) || p.Velocity != (Vector2{X: 0, Y: -2}) {
+		t.Errorf("Expected to stop at the right edge and keep sliding, got position %v velocity %v", p.Position, p.Velocity)
+	}
+
+	// Speed back into the screen is kept
+	p.Position = Vector2{X: -5, Y: 300}
+	p.Velocity = Vector2{X: 3, Y: 0}
+	p.Update(800, 600)
+	if p.Position.X != 0 || p.Velocity.X != 3 {
+		t.Errorf("Expected to be held at the edge heading back in, got position %v velocity %v", p.Position, p.Velocity)
+	}
+}
+
+func TestWrapDelta(t *testing.T) {
+	const w, h = 800, 600
+	tests := []struct {
+		name     string
+		from, to Vector2
+		want     Vector2
+	}{
+		{"same point", Vector2{400, 300}, Vector2{400, 300}, Vector2{0, 0}},
+		{"direct", Vector2{100, 100}, Vector2{150, 80}, Vector2{50, -20}},
+		{"across right edge", Vector2{790, 300}, Vector2{10, 300}, Vector2{20, 0}},
+		{"across left edge", Vector2{10, 300}, Vector2{790, 300}, Vector2{-20, 0}},
+		{"across bottom edge", Vector2{400, 590}, Vector2{400, 5}, Vector2{0, 15}},
+		{"across top edge", Vector2{400, 5}, Vector2{400, 590}, Vector2{0, -15}},
+		{"across a corner", Vector2{795, 595}, Vector2{5, 5}, Vector2{10, 10}},
+		{"across the opposite corner", Vector2{5, 595}, Vector2{795, 5}, Vector2{-10, 10}},
+		{"just under half way", Vector2{0, 0}, Vector2{399, 299}, Vector2{399, 299}},
+		{"just over half way", Vector2{0, 0}, Vector2{401, 301}, Vector2{-399, -299}},
+		{"exactly half way", Vector2{0, 0}, Vector2{400, 300}, Vector2{400, 300}},
+		{"off screen", Vector2{-10, 300}, Vector2{810, 300}, Vector2{20, 0}},
+		{"several screens away", Vector2{0, 0}, Vector2{2*w + 10, -3*h - 10}, Vector2{10, -10}},
+	}
+	for _, tt := range tests {
+		got := WrapDelta(tt.from, tt.to, w, h)
+		if math.Abs(got.X-tt.want.X) > 1e-9 || math.Abs(got.Y-tt.want.Y) > 1e-9 {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestWrapDistance(t *testing.T) {
+	if d := WrapDistance(Vector2{X: 797, Y: 598}, Vector2{X: 0, Y: 2}, 800, 600); math.Abs(d-5) > 1e-9 {
+		t.Errorf("Expected a distance of 5 across the corner, got %v", d)
+	}
+	a, b := Vector2{X: 100, Y: 500}, Vector2{X: 700, Y: 50}
+	if WrapDistance(a, b, 800, 600) != WrapDistance(b, a, 800, 600) {
+		t.Errorf("Expected the wrapped distance to be symmetric")
+	}
+}
+
+func TestWrapPosition(t *testing.T) {
+	const w, h = 800, 600
+	tests := []struct {
+		name string
+		p    Vector2
+		want Vector2
+	}{
+		{"on screen", Vector2{100, 200}, Vector2{100, 200}},
+		{"past the right edge", Vector2{830, 300}, Vector2{30, 300}},
+		{"past the left edge", Vector2{-30, 300}, Vector2{770, 300}},
+		{"past the bottom edge", Vector2{400, 650}, Vector2{400, 50}},
+		{"past the top edge", Vector2{400, -50}, Vector2{400, 550}},
+		{"past a corner", Vector2{-10, 610}, Vector2{790, 10}},
+		{"several screens away", Vector2{2*w + 10, -3*h - 10}, Vector2{10, 590}},
+		{"on the far edge", Vector2{w, h}, Vector2{0, 0}},
+	}
+	for _, tt := range tests {
+		got := WrapPosition(tt.p, w, h)
+		if math.Abs(got.X-tt.want.X) > 1e-9 || math.Abs(got.Y-tt.want.Y) > 1e-9 {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestInterpolatedPose(t *testing.T) {
+	p := CreateCircle(5, 8)
+	p.SetPosition(100, 100)
+	if pos, _ := p.InterpolatedPose(0.5); pos != p.Position {
+		t.Errorf("Expected the current pose before any is saved, got %v", pos)
+	}
+
+	p.SetRotation(2*math.Pi - 0.1)
+	p.SavePose()
+	p.SetVelocity(10, -4)
+	p.SetRotationSpeed(0.2)
+	p.Update(800, 600)
+
+	pos, rot := p.InterpolatedPose(0.5)
+	if math.Abs(pos.X-105) > 1e-9 || math.Abs(pos.Y-98) > 1e-9 {
+		t.Errorf("Expected to be half way at (105, 98), got %v", pos)
+	}
+	// Half way from just under a full turn to just over it is zero
+	if math.Abs(math.Remainder(rot, 2*math.Pi)) > 1e-9 {
+		t.Errorf("Expected the rotation to go the short way round through 0, got %v", rot)
+	}
+	if pos, rot := p.InterpolatedPose(1); pos != p.Position || math.Abs(rot-p.Rotation) > 1e-9 {
+		t.Errorf("Expected the current pose at the end, got %v %v", pos, rot)
+	}
+}
+
+func TestInterpolationSnapsOnWrap(t *testing.T) {
+	p := CreateCircle(5, 8)
+	p.SetPosition(798, 300)
+	p.SavePose()
+	p.SetVelocity(5, 0)
+	p.Update(800, 600)
+	if pos, _ := p.InterpolatedPose(0.5); pos != p.Position {
+		t.Errorf("Expected to snap to %v after wrapping, got %v", p.Position, pos)
+	}
+}
+
+func TestToWorldMatchesVertices(t *testing.T) {
+	p := CreateAsteroid(20, 5, 8)
+	p.SetPosition(100, 50)
+	p.SetRotation(1.2)
+	p.SetScale(1.5)
+	for i, vertex := range p.TransformedVertices() {
+		world := p.ToWorld(p.Vertices[i])
+		if math.Abs(world.X-vertex.X) > 1e-9 || math.Abs(world.Y-vertex.Y) > 1e-9 {
+			t.Errorf("Vertex %d: expected %v, got %v", i, vertex, world)
+		}
+	}
+}
+
+func TestToLocalUndoesToWorld(t *testing.T) {
+	p := CreateAsteroid(20, 5, 8)
+	p.SetPosition(100, 50)
+	p.SetRotation(1.2)
+	p.SetScale(1.5)
+	for i, vertex := range p.TransformedPoints(p.Vertices, 0) {
+		local := p.ToLocal(vertex)
+		if math.Abs(local.X-p.Vertices[i].X) > 1e-9 || math.Abs(local.Y-p.Vertices[i].Y) > 1e-9 {
+			t.Errorf("Vertex %d: expected %v, got %v", i, p.Vertices[i], local)
+		}
+	}
+}
+
+func TestEdgeHidden(t *testing.T) {
+	hidden := uint64(1<<0 | 1<<3)
+	for i, want := range []bool{true, false, false, true, false} {
+		if got := edgeHidden(hidden, i); got != want {
+			t.Errorf("Edge %d: expected hidden %v, got %v", i, want, got)
+		}
+	}
+	if edgeHidden(^uint64(0), 64) {
+		t.Errorf("Expected edges past the mask to be drawn")
+	}
+}
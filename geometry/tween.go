@@ -0,0 +1,87 @@
+package geometry
+
+import (
+	"time"
+)
+
+// TicksPerSecond is how many times a second polygons are stepped, which is
+// ebiten's default tick rate. Durations are turned into steps at this rate.
+const TicksPerSecond = 60
+
+// tween animates a single property of a polygon from one value to another
+type tween struct {
+	from, to float64
+	// progress runs from 0 to 1, advancing by speed each frame
+	progress float64
+	speed    float64
+	easing   Easing
+	active   bool
+	// done is called once the tween finishes, if it is set
+	done func()
+}
+
+// start begins animating from one value to another over d
+func (t *tween) start(from, to float64, d time.Duration, easing Easing, done func()) {
+	frames := d.Seconds() * TicksPerSecond
+	*t = tween{from: from, to: to, easing: easing, active: true, done: done}
+	if frames > 0 {
+		t.speed = 1 / frames
+	} else {
+		// A zero length tween finishes on the next step
+		t.progress = 1
+	}
+}
+
+// value returns the property's value at the tween's current progress
+func (t *tween) value() float64 {
+	return t.from + (t.to-t.from)*t.easing.Apply(t.progress)
+}
+
+// step advances the tween by dt frames, setting the property through set.
+// Once the tween finishes, the property is left exactly on its end value
+// and the completion callback is run.
+func (t *tween) step(dt float64, set func(float64)) {
+	if !t.active {
+		return
+	}
+	t.progress += t.speed * dt
+	// Allow for rounding, as with fades
+	if t.progress < 1-1e-9 {
+		set(t.value())
+		return
+	}
+	t.progress = 1
+	t.active = false
+	set(t.to)
+	if t.done != nil {
+		t.done()
+	}
+}
+
+// TweenScale animates the scale from one value to another over d,
+// progressing according to easing. done, which may be nil, is called once it
+// finishes. Only one scale tween runs at a time, so starting another
+// replaces it without calling its done.
+func (p *PolygonObject) TweenScale(from, to float64, d time.Duration, easing Easing, done func()) {
+	p.scaleTween.start(from, to, d, easing, done)
+	p.Scale = from
+}
+
+// TweenLineWidth animates the line width from one value to another over d,
+// the same way as TweenScale
+func (p *PolygonObject) TweenLineWidth(from, to float32, d time.Duration, easing Easing, done func()) {
+	p.lineWidthTween.start(float64(from), float64(to), d, easing, done)
+	p.LineWidth = from
+}
+
+// Tweening reports whether any of the polygon's properties are being tweened
+func (p *PolygonObject) Tweening() bool {
+	return p.scaleTween.active || p.lineWidthTween.active
+}
+
+// updateTweens advances the active tweens by dt frames (called internally
+// by Step)
+func (p *PolygonObject) updateTweens(dt float64) {
+	p.scaleTween.step(dt, func(v float64) { p.Scale = v })
+	p.lineWidthTween.step(dt, func(v float64) { p.LineWidth = float32(v) })
+}
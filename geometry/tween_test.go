@@ -0,0 +1,87 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestTweenScaleMidpoint(t *testing.T) {
+	// Half a second is 30 frames, so the midpoint is after 15
+	tests := []struct {
+		easing Easing
+		want   float64
+	}{
+		{EaseLinear, 2},
+		{EaseIn, 1.5},
+		{EaseOut, 2.5},
+		{EaseInOut, 2},
+	}
+	for _, test := range tests {
+		p := CreateCircle(10, 8)
+		p.TweenScale(1, 3, time.Second/2, test.easing, nil)
+		for i := 0; i < 15; i++ {
+			p.Update(800, 600)
+		}
+		if math.Abs(p.Scale-test.want) > 1e-9 {
+			t.Errorf("Easing %d: expected scale %v at the midpoint, got %v", test.easing, test.want, p.Scale)
+		}
+	}
+}
+
+func TestTweenLineWidthMidpoint(t *testing.T) {
+	tests := []struct {
+		easing Easing
+		want   float32
+	}{
+		{EaseLinear, 3},
+		{EaseIn, 2},
+		{EaseOut, 4},
+		{EaseInOut, 3},
+	}
+	for _, test := range tests {
+		p := CreateCircle(10, 8)
+		p.TweenLineWidth(1, 5, time.Second/2, test.easing, nil)
+		for i := 0; i < 15; i++ {
+			p.Update(800, 600)
+		}
+		if math.Abs(float64(p.LineWidth-test.want)) > 1e-5 {
+			t.Errorf("Easing %d: expected line width %v at the midpoint, got %v", test.easing, test.want, p.LineWidth)
+		}
+	}
+}
+
+func TestTweenFinishes(t *testing.T) {
+	p := CreateCircle(10, 8)
+	done := 0
+	p.TweenScale(0.5, 2, time.Second/2, EaseInOut, func() { done++ })
+	if p.Scale != 0.5 {
+		t.Errorf("Expected the tween to start at its from value, got %v", p.Scale)
+	}
+	for i := 0; i < 29; i++ {
+		p.Update(800, 600)
+	}
+	if done != 0 || !p.Tweening() {
+		t.Fatalf("Expected the tween to still be running")
+	}
+	p.Update(800, 600)
+	if done != 1 || p.Tweening() || p.Scale != 2 {
+		t.Errorf("Expected the tween to finish exactly on 2 and call done once, got %v after %d calls", p.Scale, done)
+	}
+	p.Update(800, 600)
+	if done != 1 {
+		t.Errorf("Expected done not to be called again")
+	}
+}
+
+func TestTweenReplaced(t *testing.T) {
+	p := CreateCircle(10, 8)
+	first, second := false, false
+	p.TweenScale(1, 2, time.Second, EaseLinear, func() { first = true })
+	p.Update(800, 600)
+	p.TweenScale(3, 4, time.Second/60, EaseLinear, func() { second = true })
+	p.Update(800, 600)
+	if first || !second || p.Scale != 4 {
+		t.Errorf("Expected only the replacement tween to run, got scale %v, first %v, second %v", p.Scale, first, second)
+	}
+}
@@ -7,6 +7,7 @@ require github.com/hajimehoshi/ebiten/v2 v2.9.6
 require (
 	github.com/ebitengine/gomobile v0.0.0-20250923094054-ea854a63cce1 // indirect
 	github.com/ebitengine/hideconsole v1.0.0 // indirect
+	github.com/ebitengine/oto/v3 v3.4.0 // indirect
 	github.com/ebitengine/purego v0.9.1 // indirect
 	github.com/jezek/xgb v1.2.0 // indirect
 	golang.org/x/sync v0.19.0 // indirect
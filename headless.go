@@ -0,0 +1,15 @@
+//go:build headless
+
+package main
+
+import (
+	"errors"
+
+	"github.com/AndreRenaud/SpaceDebris/game"
+)
+
+// runWindow plays the game in a window, which a headless build doesn't
+// have, so it can only run -headless-ticks, -replay or -serve
+func runWindow(opts options, cfg game.Config, gameOpts []game.Option) error {
+	return errors.New("this build has no window; it can only run -headless-ticks, -replay or -serve")
+}
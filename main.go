@@ -1,488 +1,358 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
-	"image/color"
+	"io"
 	"log"
-	"math"
-	"math/rand"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"strconv"
+	"strings"
 	"time"
 
-	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/AndreRenaud/SpaceDebris/game"
 )
 
-// GameState represents the current state of the game
-type GameState int
-
-const (
-	GameStatePlaying GameState = iota
-	GameStateGameOver
-)
-
-// Bullet represents a projectile fired by the player
-type Bullet struct {
-	polygon *PolygonObject
+// screenSize is a flag value for a size written as WxH
+type screenSize struct {
+	width, height int
 }
 
-// Game implements ebiten.Game interface.
-type Game struct {
-	asteroids          []*PolygonObject
-	player             *PolygonObject
-	playerFlame        *PolygonObject
-	playerAccelerating bool
-	bullets            []*Bullet
-	screenWidth        float64
-	screenHeight       float64
-	lastBulletTime     time.Time
-	bulletCooldown     time.Duration
-	score              int
-	vectorFont         *VectorFont
-	state              GameState
-	gameOverReason     string
-
-	// We keep the last frame's screen for phosphor ghosting effect
-	phosphorGhost      *ebiten.Image
-	phosphorGhostAlpha float32
+func (s *screenSize) String() string {
+	return fmt.Sprintf("%dx%d", s.width, s.height)
 }
 
-// Update proceeds the game state.
-// Update is called every tick (1/60 [s] by default).
-func (g *Game) Update() error {
-	g.phosphorGhostAlpha *= 0.9
-	switch g.state {
-	case GameStatePlaying:
-		return g.updatePlaying()
-	case GameStateGameOver:
-		return g.updateGameOver()
+func (s *screenSize) Set(value string) error {
+	w, h, ok := strings.Cut(value, "x")
+	if !ok {
+		return errors.New("expected a size like 800x600")
 	}
-	return nil
-}
-
-// updatePlaying handles the game logic when playing
-func (g *Game) updatePlaying() error {
-	// Handle player input
-	g.handlePlayerInput()
-
-	// Update player with wrapping
-	g.player.Update(g.screenWidth, g.screenHeight, true)
-
-	// Update player flame position and rotation to match player
-	g.playerFlame.SetPosition(g.player.Position.X, g.player.Position.Y)
-	g.playerFlame.SetRotation(g.player.Rotation)
-
-	// Update all asteroids with wrapping
-	for _, asteroid := range g.asteroids {
-		asteroid.Update(g.screenWidth, g.screenHeight, true)
+	width, err := strconv.Atoi(w)
+	if err != nil || width <= 0 {
+		return fmt.Errorf("invalid width %q", w)
 	}
-
-	// Update bullets
-	g.updateBullets()
-
-	// Check collisions
-	g.checkCollisions()
-
-	// Check win condition (all asteroids destroyed)
-	if len(g.asteroids) == 0 {
-		g.state = GameStateGameOver
-		g.gameOverReason = "YOU WIN!"
+	height, err := strconv.Atoi(h)
+	if err != nil || height <= 0 {
+		return fmt.Errorf("invalid height %q", h)
 	}
-
+	s.width, s.height = width, height
 	return nil
 }
 
-// updateGameOver handles the game logic when in game over state
-func (g *Game) updateGameOver() error {
-	// Check for restart input
-	if ebiten.IsKeyPressed(ebiten.KeyEnter) {
-		g.Restart()
-		g.state = GameStatePlaying
-		g.gameOverReason = ""
-	}
-	return nil
+// options holds the command line flags. Flags that weren't given leave the
+// config file's setting, or the default, alone.
+type options struct {
+	seed          int64
+	seedSet       bool
+	fullscreen    bool
+	mute          bool
+	muteSet       bool
+	difficulty    game.Difficulty
+	configPath    string
+	logPath       string
+	tunePath      string
+	size          screenSize
+	sizeSet       bool
+	headlessTicks int
+	interpolate   bool
+	// host and join are the addresses to host or join a network game on,
+	// and mode is the mode a hosted game is played in
+	host string
+	join string
+	mode game.GameMode
+	// serve is the address to run the stats server on
+	serve string
+	// recordAlways records every run beside the config file, and replay is
+	// a recorded run to play again
+	recordAlways bool
+	replay       string
+	// events prints the game's notifications as they happen
+	events bool
+	// pprof is the address to serve profiles of the running game on
+	pprof string
 }
 
-// handlePlayerInput processes keyboard input for player movement
-func (g *Game) handlePlayerInput() {
-	const rotationSpeed = 0.1 // radians per frame
-	const acceleration = 0.2  // pixels per frame squared
-	const maxSpeed = 5.0      // maximum speed
-	const friction = 0.98     // velocity decay factor
-
-	// Rotation controls
-	if ebiten.IsKeyPressed(ebiten.KeyArrowLeft) {
-		g.player.SetRotation(g.player.Rotation - rotationSpeed)
+// parseFlags parses the command line arguments, not including the program
+// name. Any problems are explained on output as well as returned.
+func parseFlags(args []string, output io.Writer) (options, error) {
+	opts := options{
+		configPath: game.DefaultConfigPath(),
+		size:       screenSize{800, 600},
 	}
-	if ebiten.IsKeyPressed(ebiten.KeyArrowRight) {
-		g.player.SetRotation(g.player.Rotation + rotationSpeed)
+	var difficulty, mode string
+
+	flags := flag.NewFlagSet("SpaceDebris", flag.ContinueOnError)
+	flags.SetOutput(output)
+	flags.Int64Var(&opts.seed, "seed", 0, "seed the random number generator, for repeatable runs")
+	flags.BoolVar(&opts.fullscreen, "fullscreen", false, "start in fullscreen")
+	flags.BoolVar(&opts.interpolate, "interpolate", true, "smooth motion by drawing between ticks")
+	flags.BoolVar(&opts.mute, "mute", false, "turn off sound")
+	flags.StringVar(&difficulty, "difficulty", "", "difficulty: easy, normal or hard")
+	flags.StringVar(&opts.configPath, "config", opts.configPath, "path to the config file")
+	flags.StringVar(&opts.logPath, "log", "", "write every game event to this file as JSON lines")
+	flags.StringVar(&opts.tunePath, "tune", "", "load the handling constants from this JSON file, reloading it whenever it changes")
+	flags.Var(&opts.size, "size", "playfield size, as WxH")
+	flags.IntVar(&opts.headlessTicks, "headless-ticks", 0, "run this many ticks with the demo AI and no window, then print the score")
+	flags.StringVar(&opts.host, "host", "", "host a two-player network game on this address, such as :7777")
+	flags.StringVar(&opts.join, "join", "", "join the two-player network game hosted at this address")
+	flags.StringVar(&mode, "mode", "classic", "mode of a hosted network game: classic, endless, station or practice")
+	flags.StringVar(&opts.serve, "serve", "", "run a stats server on this address, such as :8080, verifying the scores of posted run logs")
+	flags.BoolVar(&opts.recordAlways, "record-always", false, "record the last few runs beside the config file, to replay for bug reports")
+	flags.StringVar(&opts.replay, "replay", "", "replay the run recorded in this file without a window, and print how it turned out")
+	flags.BoolVar(&opts.events, "events", false, "print score changes, waves, deaths and game overs to standard output as they happen, for stream overlays")
+	flags.StringVar(&opts.pprof, "pprof", "", "serve CPU and heap profiles of the running game on this address, such as :6060")
+	if err := flags.Parse(args); err != nil {
+		// The flag set has already explained what went wrong
+		return opts, err
 	}
-
-	// Forward/backward thrust
-	g.playerAccelerating = ebiten.IsKeyPressed(ebiten.KeyArrowUp)
-	if ebiten.IsKeyPressed(ebiten.KeyArrowUp) {
-		// Accelerate in the direction the ship is facing
-		thrustX := math.Sin(g.player.Rotation) * acceleration
-		thrustY := -math.Cos(g.player.Rotation) * acceleration
-		g.player.Velocity.X += thrustX
-		g.player.Velocity.Y += thrustY
-	}
-	/*
-		if ebiten.IsKeyPressed(ebiten.KeyArrowDown) {
-			// Decelerate (reverse thrust)
-			thrustX := math.Sin(g.player.Rotation) * acceleration * 0.5
-			thrustY := -math.Cos(g.player.Rotation) * acceleration * 0.5
-			g.player.Velocity.X -= thrustX
-			g.player.Velocity.Y -= thrustY
-		}
-	*/
-
-	// Apply friction to gradually slow down the ship
-	g.player.Velocity.X *= friction
-	g.player.Velocity.Y *= friction
-
-	// Limit maximum speed
-	speed := math.Sqrt(g.player.Velocity.X*g.player.Velocity.X + g.player.Velocity.Y*g.player.Velocity.Y)
-	if speed > maxSpeed {
-		g.player.Velocity.X = (g.player.Velocity.X / speed) * maxSpeed
-		g.player.Velocity.Y = (g.player.Velocity.Y / speed) * maxSpeed
+	fail := func(err error) (options, error) {
+		fmt.Fprintln(output, err)
+		return opts, err
+	}
+	if flags.NArg() > 0 {
+		return fail(fmt.Errorf("unexpected arguments: %v", flags.Args()))
 	}
 
-	// Shooting
-	if ebiten.IsKeyPressed(ebiten.KeySpace) {
-		now := time.Now()
-		if now.Sub(g.lastBulletTime) > g.bulletCooldown {
-			g.createBullet()
-			g.lastBulletTime = now
+	flags.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "seed":
+			opts.seedSet = true
+		case "mute":
+			opts.muteSet = true
+		case "size":
+			opts.sizeSet = true
+		}
+	})
+	if difficulty != "" {
+		d, err := game.ParseDifficulty(difficulty)
+		if err != nil {
+			return fail(err)
 		}
+		opts.difficulty = d
+	}
+	if opts.headlessTicks < 0 {
+		return fail(fmt.Errorf("-headless-ticks must not be negative, got %d", opts.headlessTicks))
+	}
+	m, err := game.ParseGameMode(mode)
+	if err != nil {
+		return fail(err)
+	}
+	opts.mode = m
+	if opts.host != "" && opts.join != "" {
+		return fail(errors.New("-host and -join can't be used together"))
+	}
+	if (opts.host != "" || opts.join != "") && opts.headlessTicks > 0 {
+		return fail(errors.New("network games can't be run headless"))
 	}
+	if opts.serve != "" && (opts.host != "" || opts.join != "" || opts.headlessTicks > 0) {
+		return fail(errors.New("-serve can't be combined with playing a game"))
+	}
+	if opts.replay != "" && (opts.serve != "" || opts.host != "" || opts.join != "" || opts.headlessTicks > 0) {
+		return fail(errors.New("-replay can't be combined with playing a game"))
+	}
+	if opts.events && (opts.headlessTicks > 0 || opts.serve != "" || opts.replay != "") {
+		return fail(errors.New("-events needs a game to be played in a window"))
+	}
+	return opts, nil
 }
 
-// createBullet creates a new bullet at the tip of the player ship
-func (g *Game) createBullet() {
-	// Calculate the tip position of the player triangle
-	tipOffset := 15.0 // Same as triangle size
-	tipX := g.player.Position.X + math.Sin(g.player.Rotation)*tipOffset
-	tipY := g.player.Position.Y - math.Cos(g.player.Rotation)*tipOffset
-
-	// Create a small rectangle for the bullet (2x2)
-	bulletPolygon := &PolygonObject{
-		Vertices: []Vector2{
-			{X: -1, Y: -1}, // Top left
-			{X: 1, Y: -1},  // Top right
-			{X: 1, Y: 1},   // Bottom right
-			{X: -1, Y: 1},  // Bottom left
-		},
-		Position:      Vector2{X: tipX, Y: tipY},
-		Velocity:      Vector2{X: 0, Y: 0},
-		Rotation:      0,
-		RotationSpeed: 0,
-		Scale:         1.0,
-		Color:         color.White,
-		LineWidth:     1.0,
+// loadConfig reads the config file and the custom shapes beside it, falling
+// back to the defaults for anything that can't be read
+func (o options) loadConfig() (game.Config, game.Shapes) {
+	cfg := game.DefaultConfig()
+	var shapes game.Shapes
+	if o.configPath != "" {
+		var err error
+		if cfg, err = game.LoadConfig(o.configPath); err != nil {
+			log.Printf("Using default settings: %v", err)
+		}
+		if shapes, err = game.LoadShapes(game.ShapesPath(o.configPath)); err != nil {
+			log.Printf("Using built-in shapes: %v", err)
+		}
 	}
-
-	// Set bullet velocity in the direction the player is facing
-	const bulletSpeed = 8.0
-	bulletPolygon.Velocity.X = math.Sin(g.player.Rotation) * bulletSpeed
-	bulletPolygon.Velocity.Y = -math.Cos(g.player.Rotation) * bulletSpeed
-
-	// Add player's velocity to bullet (inherit momentum)
-	bulletPolygon.Velocity.X += g.player.Velocity.X
-	bulletPolygon.Velocity.Y += g.player.Velocity.Y
-
-	bullet := &Bullet{polygon: bulletPolygon}
-	g.bullets = append(g.bullets, bullet)
+	return cfg, shapes
 }
 
-// updateBullets updates all bullets and removes those that have left the screen
-func (g *Game) updateBullets() {
-	// Update bullet positions
-	for _, bullet := range g.bullets {
-		bullet.polygon.Update(g.screenWidth, g.screenHeight, false)
+// gameOptions returns the options to create the game with, applying the flags
+// over the config file
+func (o options) gameOptions(cfg game.Config, shapes game.Shapes, headless bool) []game.Option {
+	if o.difficulty != "" {
+		cfg.Difficulty = o.difficulty
+	}
+	if o.muteSet {
+		cfg.Muted = o.mute
 	}
 
-	// Remove bullets that are off-screen (with some margin for safety)
-	margin := 50.0
-	var activeBullets []*Bullet
-	for _, bullet := range g.bullets {
-		pos := bullet.polygon.Position
-		if pos.X >= -margin && pos.X <= g.screenWidth+margin &&
-			pos.Y >= -margin && pos.Y <= g.screenHeight+margin {
-			activeBullets = append(activeBullets, bullet)
-		}
+	gameOpts := []game.Option{
+		game.WithConfig(cfg),
+		game.WithShapes(shapes),
+		game.WithScreenSize(o.size.width, o.size.height),
+		game.WithInterpolation(o.interpolate),
+	}
+	// Headless runs are for testing, so they don't record high scores
+	if !headless {
+		gameOpts = append(gameOpts, game.WithConfigPath(o.configPath))
+	}
+	if o.seedSet {
+		gameOpts = append(gameOpts, game.WithSeed(o.seed))
+	}
+	if o.tunePath != "" {
+		gameOpts = append(gameOpts, game.WithTuningFile(o.tunePath))
 	}
-	g.bullets = activeBullets
+	if !headless && o.configPath != "" {
+		gameOpts = append(gameOpts,
+			game.WithRecordsPath(game.RecordsPath(o.configPath)),
+			game.WithSaveSlots(game.SavesDir(o.configPath)))
+	}
+	if o.recordAlways && !headless && o.configPath != "" {
+		gameOpts = append(gameOpts, game.WithRunRecording(game.RecordingsDir(o.configPath)))
+	}
+	return gameOpts
 }
 
-// checkCollisions handles all collision detection in the game
-func (g *Game) checkCollisions() {
-	// Check bullet-asteroid collisions
-	for i := len(g.bullets) - 1; i >= 0; i-- {
-		bullet := g.bullets[i]
-		bulletHit := false
-
-		for j := len(g.asteroids) - 1; j >= 0; j-- {
-			asteroid := g.asteroids[j]
-
-			if PolygonsCollide(bullet.polygon, asteroid) {
-				// Remove the bullet
-				g.bullets = append(g.bullets[:i], g.bullets[i+1:]...)
-
-				// Increment score for hitting an asteroid
-				g.score++
-
-				// Split the asteroid or remove it if too small
-				g.splitAsteroid(j)
-
-				bulletHit = true
-				break
-			}
-		}
-
-		if bulletHit {
-			break // Move to next bullet since this one was removed
+// runHeadless plays a run with the demo AI for up to ticks ticks, without
+// creating a window, and returns the final score
+func runHeadless(g *game.Game, ticks int) (int, error) {
+	g.Restart(game.RestartOptions{})
+	for i := 0; i < ticks && g.InRun(); i++ {
+		if err := game.Simulate(g, []game.InputState{g.DemoInput()}, 1); err != nil {
+			return g.Score(), err
 		}
 	}
+	return g.Score(), nil
+}
 
-	// Check player-asteroid collisions
-	for _, asteroid := range g.asteroids {
-		if PolygonsCollide(g.player, asteroid) {
-			// Set game over state
-			g.state = GameStateGameOver
-			g.gameOverReason = "GAME OVER"
-
-			// Start a red flash fade effect for 1 second (60 frames)
-			redFlash := color.RGBA{255, 50, 50, 255}
-			blue := color.RGBA{0, 0, 255, 255} // Blue color
-			g.player.SetColor(redFlash)
-			g.player.StartFade(blue, 60)
+// replay plays a recorded run again and prints how it turned out
+func replay(path string, output io.Writer) error {
+	l, err := game.LoadRunLog(path)
+	if err != nil {
+		return err
+	}
+	result, err := game.ReplayRun(l)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(output, "score %d (recorded %d), wave %d, %d of %d ticks played\n",
+		result.Score, l.Score, result.Wave, result.Ticks, len(l.Inputs))
+	return nil
+}
 
-			break
+// printEvents writes each notification from the game to output, one per
+// line, for as long as the program runs. The game never closes the channel,
+// so it only returns if the channel it is given is closed.
+func printEvents(events <-chan game.GameEvent, output io.Writer) {
+	for e := range events {
+		line := fmt.Sprintf("%s %s tick=%d score=%d wave=%d",
+			e.Time.Format(time.RFC3339Nano), e.Kind, e.Tick, e.Score, e.Wave)
+		if e.Partner {
+			line += " partner"
 		}
+		if e.Reason != "" {
+			line += fmt.Sprintf(" reason=%q", e.Reason)
+		}
+		fmt.Fprintln(output, line)
 	}
 }
 
-// splitAsteroid splits an asteroid into two smaller ones or removes it if too small
-func (g *Game) splitAsteroid(asteroidIndex int) {
-	asteroid := g.asteroids[asteroidIndex]
-
-	// Calculate current size (approximate radius)
-	bbox := asteroid.GetBoundingBox()
-	currentSize := (bbox.MaxX - bbox.MinX + bbox.MaxY - bbox.MinY) / 4 // Average of width and height, divided by 2
-
-	const minSize = 15.0 // Minimum size threshold
-
-	if currentSize < minSize {
-		// Remove asteroid if too small
-		g.asteroids = append(g.asteroids[:asteroidIndex], g.asteroids[asteroidIndex+1:]...)
-		return
+// servePprof serves net/http/pprof's profiles on addr until the listener
+// it returns is closed. They are registered on a mux of their own, only
+// once profiling has been asked for, so they never turn up on the stats
+// server or anything else serving the default mux.
+func servePprof(addr string) (net.Listener, error) {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
 	}
-
-	// Create two smaller asteroids
-	newSize := currentSize * 0.6    // Make them 60% of original size
-	irregularity := newSize * 0.3   // Proportional irregularity
-	numVertices := 6 + rand.Intn(5) // 6-10 vertices
-
-	// Create first smaller asteroid
-	asteroid1 := CreateAsteroid(newSize, irregularity, numVertices)
-	asteroid1.SetPosition(asteroid.Position.X-newSize*0.5, asteroid.Position.Y-newSize*0.5)
-	asteroid1.SetColor(asteroid.Color)
-
-	// Give it some velocity based on original velocity plus some random spread
-	vel1X := asteroid.Velocity.X + (rand.Float64()-0.5)*2
-	vel1Y := asteroid.Velocity.Y + (rand.Float64()-0.5)*2
-	asteroid1.SetVelocity(vel1X, vel1Y)
-	asteroid1.SetRotationSpeed((rand.Float64() - 0.5) * 0.15)
-
-	// Start a fade from white to red over 2 seconds (120 frames at 60 FPS)
-	redColor := color.RGBA{255, 100, 100, 255}
-	asteroid1.SetColor(redColor)
-	asteroid1.StartFade(color.White, 120)
-
-	// Create second smaller asteroid
-	asteroid2 := CreateAsteroid(newSize, irregularity, numVertices)
-	asteroid2.SetPosition(asteroid.Position.X+newSize*0.5, asteroid.Position.Y+newSize*0.5)
-	asteroid2.SetColor(asteroid.Color)
-
-	// Give it velocity in roughly opposite direction
-	vel2X := asteroid.Velocity.X + (rand.Float64()-0.5)*2
-	vel2Y := asteroid.Velocity.Y + (rand.Float64()-0.5)*2
-	asteroid2.SetVelocity(vel2X, vel2Y)
-	asteroid2.SetRotationSpeed((rand.Float64() - 0.5) * 0.15)
-
-	// Start Pulse red
-	asteroid2.SetColor(redColor)
-	asteroid2.StartFade(color.White, 120)
-
-	// Remove the original asteroid
-	g.asteroids = append(g.asteroids[:asteroidIndex], g.asteroids[asteroidIndex+1:]...)
-
-	// Add the two new asteroids
-	g.asteroids = append(g.asteroids, asteroid1, asteroid2)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	go http.Serve(l, mux)
+	return l, nil
 }
 
-// Draw draws the game screen.
-// Draw is called every frame (typically 1/60[s] for 60Hz display).
-func (g *Game) Draw(screen *ebiten.Image) {
-	// Draw player ship
-	g.player.Draw(screen)
-
-	// Draw player flame if accelerating
-	if g.playerAccelerating {
-		g.playerFlame.Draw(screen)
+// run plays the game, in a window or headless, logging events to the file
+// given by -log if there is one, and serving profiles on the address given
+// by -pprof. With -serve it runs the stats server instead, and with -replay
+// it replays a recorded run.
+func run(opts options) (err error) {
+	if opts.pprof != "" {
+		l, err := servePprof(opts.pprof)
+		if err != nil {
+			return err
+		}
+		defer l.Close()
+		log.Printf("Serving profiles on http://%s/debug/pprof/", l.Addr())
 	}
-
-	// Draw all asteroids
-	for _, asteroid := range g.asteroids {
-		asteroid.Draw(screen)
+	if opts.serve != "" {
+		log.Printf("Serving run verification on %s", opts.serve)
+		return http.ListenAndServe(opts.serve, newStatsHandler())
 	}
-
-	// Draw all bullets
-	for _, bullet := range g.bullets {
-		bullet.polygon.Draw(screen)
+	if opts.replay != "" {
+		return replay(opts.replay, os.Stdout)
 	}
 
-	// Draw score in top-right corner
-	scoreStr := fmt.Sprintf("%d", g.score)
-	scoreWidth := g.vectorFont.GetWidth(scoreStr)
-	scoreX := float32(g.screenWidth) - scoreWidth - 20 // 20 pixels from right edge
-	scoreY := float32(20)                              // 20 pixels from top
-	g.vectorFont.DrawString(screen, scoreStr, scoreX, scoreY)
-
-	// Draw game over screen if in game over state
-	if g.state == GameStateGameOver {
-		g.drawGameOverScreen(screen)
+	headless := opts.headlessTicks > 0
+	cfg, shapes := opts.loadConfig()
+	gameOpts := opts.gameOptions(cfg, shapes, headless)
+	if opts.logPath != "" {
+		f, err := os.Create(opts.logPath)
+		if err != nil {
+			return err
+		}
+		eventLog := game.NewEventLog(f)
+		defer func() {
+			err = errors.Join(err, eventLog.Close(), f.Close())
+		}()
+		gameOpts = append(gameOpts, game.WithEventLog(eventLog))
 	}
 
-	if g.phosphorGhost != nil {
-		op := &ebiten.DrawImageOptions{}
-		op.ColorScale.ScaleAlpha(g.phosphorGhostAlpha)
-		screen.DrawImage(g.phosphorGhost, op)
-		g.phosphorGhostAlpha = 1
-	}
-	// Capture current screen for next frame's trail
-	snapshot := ebiten.NewImageFromImage(screen)
-	g.phosphorGhost = snapshot
-
-}
-
-// Layout takes the outside size (e.g., the window size) and returns the (logical) screen size.
-// If you don't have to adjust the screen size with the outside size, just return a fixed size.
-func (g *Game) Layout(outsideWidth, outsideHeight int) (screenWidth, screenHeight int) {
-	return int(g.screenWidth), int(g.screenHeight)
-}
-
-// NewGame creates a new game instance with initialized asteroids and player
-func NewGame() *Game {
-	rand.Seed(time.Now().UnixNano())
-
-	game := &Game{
-		screenWidth:    800,
-		screenHeight:   600,
-		bulletCooldown: 100 * time.Millisecond,                // 100ms cooldown
-		vectorFont:     NewVectorFont(16, 24, 3, color.White), // 16x24 digit size, 2px line width, white color
+	if headless {
+		score, err := runHeadless(game.New(gameOpts...), opts.headlessTicks)
+		if err != nil {
+			return err
+		}
+		fmt.Println(score)
+		return nil
 	}
 
-	// Use Restart to initialize the game state
-	game.Restart()
-
-	return game
+	return runWindow(opts, cfg, gameOpts)
 }
 
-// Restart resets the game state to initial conditions
-func (g *Game) Restart() {
-	// Reset game state
-	g.state = GameStatePlaying
-	g.gameOverReason = ""
-
-	// Reset score
-	g.score = 0
-
-	// Clear all bullets and asteroids
-	g.bullets = nil
-	g.asteroids = nil
-
-	// Reset bullet timing
-	g.lastBulletTime = time.Now()
-
-	// Create player ship
-	g.player = CreatePlayer(20)
-	g.player.SetPosition(g.screenWidth/2, g.screenHeight/2) // Center of screen
-	blue := color.RGBA{0, 0, 255, 255}                      // Blue color
-	g.player.SetColor(blue)
-
-	// Create player flame
-	g.playerFlame = CreatePlayerFlame(25)
-	g.playerFlame.SetPosition(g.player.Position.X, g.player.Position.Y)
-	g.playerFlame.SetRotation(g.player.Rotation)
-
-	// Create 3 random asteroids
-	for i := 0; i < 3; i++ {
-		// Random base radius between 20 and 50
-		baseRadius := 20.0 + rand.Float64()*30.0
-		// Random irregularity between 5 and 15
-		irregularity := 5.0 + rand.Float64()*10.0
-		// Random number of vertices between 6 and 12
-		numVertices := 6 + rand.Intn(7)
-
-		asteroid := CreateAsteroid(baseRadius, irregularity, numVertices)
-
-		// Random position within the screen bounds (with some margin)
-		asteroid.SetPosition(
-			50+rand.Float64()*(g.screenWidth-100),  // X between 50 and 750
-			50+rand.Float64()*(g.screenHeight-100), // Y between 50 and 550
-		)
-
-		// Random rotation
-		asteroid.SetRotation(rand.Float64() * 6.28) // 0 to 2π radians
-
-		// Random velocity (pixels per frame)
-		vx := (rand.Float64() - 0.5) * 4 // -2 to 2 pixels per frame
-		vy := (rand.Float64() - 0.5) * 4 // -2 to 2 pixels per frame
-		asteroid.SetVelocity(vx, vy)
-
-		// Random rotation speed (radians per frame)
-		rotSpeed := (rand.Float64() - 0.5) * 0.1 // -0.05 to 0.05 radians per frame
-		asteroid.SetRotationSpeed(rotSpeed)
-
-		// Set color to white
-		asteroid.SetColor(color.White)
-
-		g.asteroids = append(g.asteroids, asteroid)
+// connect hosts or joins the network game given by -host or -join, waiting
+// for the other player. A hosted game is played on a width x height
+// playfield, with friendly fire if cfg turns it on. It returns nil if there
+// is no network game.
+func (o options) connect(cfg game.Config, width, height int) (*game.NetSession, error) {
+	switch {
+	case o.host != "":
+		seed := time.Now().UnixNano()
+		if o.seedSet {
+			seed = o.seed
+		}
+		log.Printf("Waiting for another player to join on %s", o.host)
+		return game.Host(o.host, seed, o.mode, o.difficulty, width, height, cfg.FriendlyFire)
+	case o.join != "":
+		return game.Join(o.join)
 	}
-}
-
-// drawGameOverScreen draws the game over screen with score and restart instruction
-func (g *Game) drawGameOverScreen(screen *ebiten.Image) {
-	centerX := float32(g.screenWidth / 2)
-	centerY := float32(g.screenHeight / 2)
-
-	// Draw game over reason (GAME OVER or YOU WIN!)
-	reasonWidth := g.vectorFont.GetWidth(g.gameOverReason)
-	reasonX := centerX - (reasonWidth / 2)
-	reasonY := centerY - 60
-	g.vectorFont.DrawString(screen, g.gameOverReason, reasonX, reasonY)
-
-	// Draw final score
-	scoreText := fmt.Sprintf("SCORE: %d", g.score)
-	scoreWidth := g.vectorFont.GetWidth(scoreText)
-	scoreX := centerX - (scoreWidth / 2)
-	scoreY := centerY - 20
-	g.vectorFont.DrawString(screen, scoreText, scoreX, scoreY)
-
-	// Draw restart instruction
-	restartText := "PRESS ENTER TO RESTART"
-	restartWidth := g.vectorFont.GetWidth(restartText)
-	restartX := centerX - (restartWidth / 2)
-	restartY := centerY + 40
-	g.vectorFont.DrawString(screen, restartText, restartX, restartY)
+	return nil, nil
 }
 
 func main() {
-	ebiten.SetWindowSize(800, 600)
-	ebiten.SetWindowTitle("Asteroids Game")
-
-	game := NewGame()
-	if err := ebiten.RunGame(game); err != nil {
+	opts, err := parseFlags(os.Args[1:], os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		os.Exit(2)
+	}
+	if err := run(opts); err != nil {
 		log.Fatal(err)
 	}
 }
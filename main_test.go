@@ -0,0 +1,147 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AndreRenaud/SpaceDebris/game"
+)
+
+func TestParseFlags(t *testing.T) {
+	opts, err := parseFlags([]string{"-seed", "7", "-difficulty", "hard", "-size", "640x480", "-headless-ticks", "100", "-mute"}, io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !opts.seedSet || opts.seed != 7 {
+		t.Errorf("Expected seed 7, got %d (set %v)", opts.seed, opts.seedSet)
+	}
+	if opts.difficulty != game.DifficultyHard {
+		t.Errorf("Expected hard difficulty, got %q", opts.difficulty)
+	}
+	if opts.size != (screenSize{640, 480}) {
+		t.Errorf("Expected a 640x480 screen, got %v", opts.size)
+	}
+	if opts.headlessTicks != 100 || !opts.mute || !opts.muteSet {
+		t.Errorf("Unexpected options %+v", opts)
+	}
+
+	defaults, err := parseFlags(nil, io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if defaults.seedSet || defaults.muteSet || defaults.difficulty != "" || defaults.size != (screenSize{800, 600}) || !defaults.interpolate {
+		t.Errorf("Expected no overrides by default, got %+v", defaults)
+	}
+}
+
+func TestParseFlagsErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{"-difficulty", "medium"},
+		{"-size", "800"},
+		{"-size", "0x600"},
+		{"-size", "800xlots"},
+		{"-headless-ticks", "-1"},
+		{"-seed", "abc"},
+		{"-mode", "arcade"},
+		{"-host", ":7777", "-join", "localhost:7777"},
+		{"-join", "localhost:7777", "-headless-ticks", "10"},
+		{"-serve", ":8080", "-host", ":7777"},
+		{"-replay", "run-1.json", "-headless-ticks", "10"},
+		{"-events", "-headless-ticks", "10"},
+		{"stray"},
+	} {
+		if _, err := parseFlags(args, io.Discard); err == nil {
+			t.Errorf("Expected an error for %v", args)
+		}
+	}
+}
+
+func TestRunHeadless(t *testing.T) {
+	opts := options{size: screenSize{800, 600}, seed: 1, seedSet: true}
+	first, err := runHeadless(game.New(opts.gameOptions(game.DefaultConfig(), game.Shapes{}, true)...), 2000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := runHeadless(game.New(opts.gameOptions(game.DefaultConfig(), game.Shapes{}, true)...), 2000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != second {
+		t.Errorf("Expected the same seed to give the same score, got %d and %d", first, second)
+	}
+	if first == 0 {
+		t.Errorf("Expected the demo AI to score something")
+	}
+}
+
+func TestPrintEvents(t *testing.T) {
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	events := make(chan game.GameEvent, 2)
+	events <- game.GameEvent{Kind: game.EventScore, Time: at, Tick: 90, Score: 12, Wave: 2}
+	events <- game.GameEvent{Kind: game.EventGameOver, Time: at, Tick: 95, Score: 12, Wave: 2, Reason: "GAME OVER"}
+	close(events)
+
+	var output strings.Builder
+	printEvents(events, &output)
+	want := "2024-05-01T12:00:00Z score tick=90 score=12 wave=2\n" +
+		"2024-05-01T12:00:00Z game_over tick=95 score=12 wave=2 reason=\"GAME OVER\"\n"
+	if output.String() != want {
+		t.Errorf("Expected\n%s\ngot\n%s", want, output.String())
+	}
+}
+
+func TestServePprof(t *testing.T) {
+	l, err := servePprof("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	for _, path := range []string{"/debug/pprof/", "/debug/pprof/cmdline", "/debug/pprof/heap"} {
+		resp, err := http.Get("http://" + l.Addr().String() + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("Expected %s to be served, got %s", path, resp.Status)
+		}
+	}
+
+	// The stats server has a mux of its own, without the profiles
+	rec := httptest.NewRecorder()
+	newStatsHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/debug/pprof/cmdline", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected the stats server not to serve profiles, got %d", rec.Code)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	l := cannedRunLog(t)
+	body, err := json.Marshal(l)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "run.json")
+	if err := os.WriteFile(path, body, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var output strings.Builder
+	if err := replay(path, &output); err != nil {
+		t.Fatal(err)
+	}
+	score := fmt.Sprintf("score %d (recorded %d), ", l.Score, l.Score)
+	ticks := fmt.Sprintf(", %d of %d ticks played\n", len(l.Inputs), len(l.Inputs))
+	if got := output.String(); !strings.HasPrefix(got, score) || !strings.HasSuffix(got, ticks) {
+		t.Errorf("Expected the recorded score over every tick, got %q", got)
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/AndreRenaud/SpaceDebris/game"
+)
+
+// maxRunLogSize is the largest run log the stats server will accept, in
+// bytes
+const maxRunLogSize = 1 << 20
+
+// verifyResponse is the stats server's verdict on a run log
+type verifyResponse struct {
+	// Verified is set if replaying the run gave the score it claimed
+	Verified bool `json:"verified"`
+	Claimed  int  `json:"claimed"`
+	// Assisted is set if the run was played with aim assist, so its score
+	// can be ranked apart from unassisted ones
+	Assisted bool `json:"assisted,omitempty"`
+	game.RunResult
+}
+
+// errorResponse explains why a run log couldn't be checked
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// newStatsHandler returns the stats server's HTTP handler. Run logs are
+// POSTed as JSON to /verify, replayed, and answered with a verifyResponse.
+// Replaying needs no ebiten, so a server without a display runs the
+// SpaceDebris-headless build.
+func newStatsHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /verify", handleVerify)
+	return mux
+}
+
+// handleVerify replays a submitted run log and reports whether its score
+// holds up. Logs that replay to a different score are answered with 422
+// Unprocessable Entity.
+func handleVerify(w http.ResponseWriter, r *http.Request) {
+	var l game.RunLog
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRunLogSize)).Decode(&l); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+		return
+	}
+	result, err := game.ReplayRun(l)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+		return
+	}
+	response := verifyResponse{Verified: result.Score == l.Score, Claimed: l.Score, Assisted: l.AimAssist > 0, RunResult: result}
+	status := http.StatusOK
+	if !response.Verified {
+		status = http.StatusUnprocessableEntity
+	}
+	writeJSON(w, status, response)
+}
+
+// writeJSON answers a request with v encoded as JSON
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/AndreRenaud/SpaceDebris/game"
+)
+
+// cannedRunLog records a run played by the demo AI
+func cannedRunLog(t *testing.T) game.RunLog {
+	t.Helper()
+	dir := t.TempDir()
+	g := game.New(game.WithSeed(1), game.WithRunRecording(dir))
+	g.Restart(game.RestartOptions{})
+	for i := 0; i < 2000 && g.InRun(); i++ {
+		if err := g.Step(g.DemoInput()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Starting another run saves the one just played, if it is still going
+	g.Restart(game.RestartOptions{})
+	l, err := game.LoadRunLog(filepath.Join(dir, "run-1.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l
+}
+
+// postRunLog submits body to the stats server's verify endpoint
+func postRunLog(t *testing.T, body []byte) (*httptest.ResponseRecorder, verifyResponse) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/verify", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	newStatsHandler().ServeHTTP(rec, req)
+	var response verifyResponse
+	if rec.Code != http.StatusBadRequest {
+		if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Expected a JSON response, got %q: %v", rec.Body.String(), err)
+		}
+	}
+	return rec, response
+}
+
+func TestVerifyRunLog(t *testing.T) {
+	l := cannedRunLog(t)
+	if l.Score == 0 {
+		t.Fatalf("Expected the demo AI to score something")
+	}
+	body, err := json.Marshal(l)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec, response := postRunLog(t, body)
+	if rec.Code != http.StatusOK || !response.Verified {
+		t.Fatalf("Expected the run to verify, got %d %+v", rec.Code, response)
+	}
+	if response.Score != l.Score || response.Claimed != l.Score || response.Ticks != len(l.Inputs) {
+		t.Errorf("Expected a score of %d over %d ticks, got %+v", l.Score, len(l.Inputs), response)
+	}
+}
+
+func TestVerifyTamperedRunLog(t *testing.T) {
+	l := cannedRunLog(t)
+	l.Score += 1000
+	body, err := json.Marshal(l)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec, response := postRunLog(t, body)
+	if rec.Code != http.StatusUnprocessableEntity || response.Verified {
+		t.Fatalf("Expected the inflated score to be rejected, got %d %+v", rec.Code, response)
+	}
+	if response.Score != l.Score-1000 {
+		t.Errorf("Expected the real score of %d, got %d", l.Score-1000, response.Score)
+	}
+}
+
+func TestVerifyBadRequests(t *testing.T) {
+	for _, body := range []string{
+		"not json",
+		`{"seed": 1, "mode": "arcade"}`,
+	} {
+		if rec, _ := postRunLog(t, []byte(body)); rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected %q to be a bad request, got %d", body, rec.Code)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/verify", nil)
+	rec := httptest.NewRecorder()
+	newStatsHandler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected only POST to be allowed, got %d", rec.Code)
+	}
+}
@@ -1,14 +1,13 @@
-package main
+// Package vectorfont draws text using simple vector line segments, in the
+// style of a seven-segment display.
+package vectorfont
 
 import (
 	"image/color"
-
-	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/vector"
 )
 
-// VectorFont represents a font made of vector lines for drawing digits
-type VectorFont struct {
+// Font represents a font made of vector lines for drawing digits
+type Font struct {
 	runeWidth  float32
 	runeHeight float32
 	lineWidth  float32
@@ -19,9 +18,9 @@ type LineSegment struct {
 	X1, Y1, X2, Y2 float32 // Start and end points of the line segment
 }
 
-// NewVectorFont creates a new vector font with specified dimensions
-func NewVectorFont(width, height, lineWidth float32, c color.Color) *VectorFont {
-	return &VectorFont{
+// New creates a new vector font with specified dimensions
+func New(width, height, lineWidth float32, c color.Color) *Font {
+	return &Font{
 		runeWidth:  width,
 		runeHeight: height,
 		lineWidth:  lineWidth,
@@ -30,16 +29,19 @@ func NewVectorFont(width, height, lineWidth float32, c color.Color) *VectorFont
 }
 
 // SetColor changes the color of the font
-func (vf *VectorFont) SetColor(c color.Color) {
+func (vf *Font) SetColor(c color.Color) {
 	vf.color = c
 }
 
-// drawLine draws a line from (x1, y1) to (x2, y2) on the screen
-func (vf *VectorFont) drawLine(screen *ebiten.Image, x1, y1, x2, y2 float32) {
-	vector.StrokeLine(screen,
-		x1, y1,
-		x2, y2,
-		vf.lineWidth, vf.color, true)
+// SetSize changes the width and height of each character
+func (vf *Font) SetSize(width, height float32) {
+	vf.runeWidth = width
+	vf.runeHeight = height
+}
+
+// SetLineWidth changes the width of the lines the font is drawn with
+func (vf *Font) SetLineWidth(width float32) {
+	vf.lineWidth = width
 }
 
 var charMaps = map[rune][]LineSegment{
@@ -129,12 +131,30 @@ var charMaps = map[rune][]LineSegment{
 		{1, 1, 0.5, 0},
 		{0.2, 0.6, 0.8, 0.6},
 	},
+	'B': {
+		{0, 0, 0, 1},        // Left vertical (full height)
+		{0, 0, 0.8, 0},      // Top
+		{0.8, 0, 1, 0.25},   // Upper bowl top
+		{1, 0.25, 0.8, 0.5}, // Upper bowl bottom
+		{0, 0.5, 0.8, 0.5},  // Middle
+		{0.8, 0.5, 1, 0.75}, // Lower bowl top
+		{1, 0.75, 0.8, 1},   // Lower bowl bottom
+		{0.8, 1, 0, 1},      // Bottom
+	},
 	'C': {
 		{0, 0, 1, 0},   // A (top)
 		{0, 0, 0, 0.5}, // F (top left)
 		{0, 0.5, 0, 1}, // E (bottom left)
 		{0, 1, 1, 1},   // D (bottom)
 	},
+	'D': {
+		{0, 0, 0, 1},     // Left vertical (full height)
+		{0, 0, 0.7, 0},   // Top
+		{0.7, 0, 1, 0.3}, // Top right corner
+		{1, 0.3, 1, 0.7}, // Right vertical
+		{1, 0.7, 0.7, 1}, // Bottom right corner
+		{0.7, 1, 0, 1},   // Bottom
+	},
 	'E': {
 		{0, 0, 1, 0},       // A (top)
 		{0, 0, 0, 0.5},     // F (top left)
@@ -142,6 +162,12 @@ var charMaps = map[rune][]LineSegment{
 		{0, 0.5, 0, 1},     // E (bottom left)
 		{0, 1, 1, 1},       // D (bottom)
 	},
+	'F': {
+		{0, 0, 1, 0},       // A (top)
+		{0, 0, 0, 0.5},     // F (top left)
+		{0, 0.5, 0.7, 0.5}, // G (middle, shortened)
+		{0, 0.5, 0, 1},     // E (bottom left)
+	},
 	'G': {
 		{0, 0, 1, 0},       // A (top)
 		{0, 0, 0, 0.5},     // F (top left)
@@ -150,6 +176,25 @@ var charMaps = map[rune][]LineSegment{
 		{1, 0.5, 1, 1},     // C (bottom right)
 		{0.5, 0.5, 1, 0.5}, // G (middle, from center to right)
 	},
+	'H': {
+		{0, 0, 0, 1},     // Left vertical (full height)
+		{1, 0, 1, 1},     // Right vertical (full height)
+		{0, 0.5, 1, 0.5}, // G (middle)
+	},
+	'J': {
+		{1, 0, 1, 1},   // Right vertical (full height)
+		{1, 1, 0, 1},   // D (bottom)
+		{0, 1, 0, 0.5}, // E (bottom left)
+	},
+	'K': {
+		{0, 0, 0, 1},   // Left vertical (full height)
+		{0, 0.5, 1, 0}, // Upper diagonal
+		{0, 0.5, 1, 1}, // Lower diagonal
+	},
+	'L': {
+		{0, 0, 0, 1}, // Left vertical (full height)
+		{0, 1, 1, 1}, // D (bottom)
+	},
 	'M': {
 		{0, 1, 0, 0},     // Left vertical (full height)
 		{0, 0, 0.5, 0.5}, // Left diagonal to center
@@ -176,6 +221,15 @@ var charMaps = map[rune][]LineSegment{
 		{0, 0, 0, 0.5},   // F (top left)
 		{0, 0.5, 0, 1},   // E (bottom left)
 	},
+	'Q': {
+		{0, 0, 1, 0},     // A (top)
+		{1, 0, 1, 0.5},   // B (top right)
+		{1, 0.5, 1, 1},   // C (bottom right)
+		{1, 1, 0, 1},     // D (bottom)
+		{0, 1, 0, 0.5},   // E (bottom left)
+		{0, 0.5, 0, 0},   // F (top left)
+		{0.6, 0.6, 1, 1}, // Tail
+	},
 	'R': {
 		{0, 0, 1, 0},     // A (top)
 		{1, 0, 1, 0.5},   // B (top right)
@@ -213,11 +267,20 @@ var charMaps = map[rune][]LineSegment{
 		{0.67, 0.5, 1, 1},      // Right diagonal from second center
 		{1, 1, 1, 0},           // Right vertical (full height)
 	},
+	'X': {
+		{0, 0, 1, 1}, // Diagonal from top-left to bottom-right
+		{1, 0, 0, 1}, // Diagonal from top-right to bottom-left
+	},
 	'Y': {
 		{0, 0, 0.5, 0.5},   // Left diagonal from top-left to center
 		{1, 0, 0.5, 0.5},   // Right diagonal from top-right to center
 		{0.5, 0.5, 0.5, 1}, // Center vertical from middle to bottom
 	},
+	'Z': {
+		{0, 0, 1, 0}, // A (top)
+		{1, 0, 0, 1}, // Diagonal from top-right to bottom-left
+		{0, 1, 1, 1}, // D (bottom)
+	},
 	'I': {
 		{0, 0, 1, 0},     // A (top horizontal)
 		{0.5, 0, 0.5, 1}, // Center vertical line
@@ -228,40 +291,110 @@ var charMaps = map[rune][]LineSegment{
 		{0.4, 0.8, 0.6, 0.8}, // Dot (top part)
 		{0.4, 0.9, 0.6, 0.9}, // Dot (bottom part)
 	},
+	'?': {
+		{0, 0, 1, 0},         // A (top)
+		{1, 0, 1, 0.4},       // B (top right)
+		{1, 0.4, 0.5, 0.5},   // Diagonal in to the middle
+		{0.5, 0.5, 0.5, 0.7}, // Stem
+		{0.4, 0.9, 0.6, 0.9}, // Dot
+	},
+	'/': {
+		{1, 0, 0, 1}, // Diagonal from top-right to bottom-left
+	},
+	'[': {
+		{0.7, 0, 0.3, 0}, // Top
+		{0.3, 0, 0.3, 1}, // Left vertical (full height)
+		{0.3, 1, 0.7, 1}, // Bottom
+	},
+	']': {
+		{0.3, 0, 0.7, 0}, // Top
+		{0.7, 0, 0.7, 1}, // Right vertical (full height)
+		{0.7, 1, 0.3, 1}, // Bottom
+	},
+	',': {
+		{0.15, 0.8, 0.15, 0.9}, // Dot
+		{0.15, 0.9, 0.05, 1},   // Tail
+	},
+	'.': {
+		{0.4, 0.9, 0.6, 0.9}, // Dot (top part)
+		{0.4, 1, 0.6, 1},     // Dot (bottom part)
+	},
 	':': {
 		{0.4, 0.3, 0.6, 0.3}, // Top dot (top part)
 		{0.4, 0.4, 0.6, 0.4}, // Top dot (bottom part)
 		{0.4, 0.6, 0.6, 0.6}, // Bottom dot (top part)
 		{0.4, 0.7, 0.6, 0.7}, // Bottom dot (bottom part)
 	},
+	'×': {
+		{0.25, 0.25, 0.75, 0.75}, // Diagonal from top-left to bottom-right
+		{0.75, 0.25, 0.25, 0.75}, // Diagonal from top-right to bottom-left
+	},
+	'+': {
+		{0.5, 0.2, 0.5, 0.8}, // Vertical
+		{0.2, 0.5, 0.8, 0.5}, // Horizontal
+	},
+	'%': {
+		{1, 0, 0, 1},       // Diagonal from top-right to bottom-left
+		{0, 0, 0.3, 0},     // Top left circle (top)
+		{0.3, 0, 0.3, 0.3}, // Top left circle (right)
+		{0.3, 0.3, 0, 0.3}, // Top left circle (bottom)
+		{0, 0.3, 0, 0},     // Top left circle (left)
+		{0.7, 0.7, 1, 0.7}, // Bottom right circle (top)
+		{1, 0.7, 1, 1},     // Bottom right circle (right)
+		{1, 1, 0.7, 1},     // Bottom right circle (bottom)
+		{0.7, 1, 0.7, 0.7}, // Bottom right circle (left)
+	},
 }
 
-// DrawDigit draws a single digit at the specified position
-func (vf *VectorFont) DrawRune(screen *ebiten.Image, ch rune, x, y float32) {
-	segments, ok := charMaps[ch]
-	if !ok {
-		return // No segments defined for this digit
-	}
-	// Draw each segment of the digit
-	for _, seg := range segments {
-		vf.drawLine(screen, x+seg.X1*vf.runeWidth, y+seg.Y1*vf.runeHeight,
-			x+seg.X2*vf.runeWidth, y+seg.Y2*vf.runeHeight)
-	}
+// runeGap is the small gap left between characters
+const runeGap = 4
+
+// narrowRunes are the characters drawn in a fraction of the full width, so
+// separators don't spread numbers out
+var narrowRunes = map[rune]float32{
+	',': 0.3,
 }
 
-// DrawNumber draws a multi-digit number at the specified position
-func (vf *VectorFont) DrawString(screen *ebiten.Image, str string, x, y float32) {
-	// Draw each digit with spacing
-	spacing := vf.runeWidth + 4 // Small gap between digits
-	currentX := x
+// advance returns how far along the string a character moves the next one
+func (vf *Font) advance(ch rune) float32 {
+	if fraction, ok := narrowRunes[ch]; ok {
+		return vf.runeWidth*fraction + runeGap
+	}
+	return vf.runeWidth + runeGap
+}
 
+// GetTextWidth calculates the width of a number when drawn
+func (vf *Font) GetWidth(str string) float32 {
+	if str == "" {
+		return -runeGap
+	}
+	var width float32
 	for _, ch := range str {
-		vf.DrawRune(screen, ch, currentX, y)
-		currentX += spacing
+		width += vf.advance(ch)
 	}
+	return width - runeGap
 }
 
-// GetTextWidth calculates the width of a number when drawn
-func (vf *VectorFont) GetWidth(str string) float32 {
-	return vf.runeWidth*float32(len(str)) + 4*float32(len(str)-1)
+// Align is how a string sits against the x position it is drawn at
+type Align int
+
+const (
+	// AlignLeft starts the string at x
+	AlignLeft Align = iota
+	// AlignCenter centers the string on x
+	AlignCenter
+	// AlignRight ends the string at x
+	AlignRight
+)
+
+// AlignedX returns where to start drawing str so it sits against x as
+// align says
+func (vf *Font) AlignedX(str string, x float32, align Align) float32 {
+	switch align {
+	case AlignCenter:
+		return x - vf.GetWidth(str)/2
+	case AlignRight:
+		return x - vf.GetWidth(str)
+	}
+	return x
 }
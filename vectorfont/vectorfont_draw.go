@@ -0,0 +1,43 @@
+//go:build !headless
+
+package vectorfont
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// drawLine draws a line from (x1, y1) to (x2, y2) on the screen
+func (vf *Font) drawLine(screen *ebiten.Image, x1, y1, x2, y2 float32) {
+	vector.StrokeLine(screen,
+		x1, y1,
+		x2, y2,
+		vf.lineWidth, vf.color, true)
+}
+
+// DrawDigit draws a single digit at the specified position
+func (vf *Font) DrawRune(screen *ebiten.Image, ch rune, x, y float32) {
+	segments, ok := charMaps[ch]
+	if !ok {
+		return // No segments defined for this digit
+	}
+	// Draw each segment of the digit
+	for _, seg := range segments {
+		vf.drawLine(screen, x+seg.X1*vf.runeWidth, y+seg.Y1*vf.runeHeight,
+			x+seg.X2*vf.runeWidth, y+seg.Y2*vf.runeHeight)
+	}
+}
+
+// DrawNumber draws a multi-digit number at the specified position
+func (vf *Font) DrawString(screen *ebiten.Image, str string, x, y float32) {
+	currentX := x
+	for _, ch := range str {
+		vf.DrawRune(screen, ch, currentX, y)
+		currentX += vf.advance(ch)
+	}
+}
+
+// DrawStringAligned draws a string at y, sitting against x as align says
+func (vf *Font) DrawStringAligned(screen *ebiten.Image, str string, x, y float32, align Align) {
+	vf.DrawString(screen, str, vf.AlignedX(str, x, align), y)
+}
@@ -0,0 +1,40 @@
+package vectorfont
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestGetWidth(t *testing.T) {
+	vf := New(10, 20, 1, color.White)
+	for _, test := range []struct {
+		str  string
+		want float32
+	}{
+		{"0", 10},
+		{"999", 38},
+		// The comma only takes 3 of the usual 10
+		{"1,000", 10 + 4 + 3 + 4 + 10 + 4 + 10 + 4 + 10},
+	} {
+		if got := vf.GetWidth(test.str); got != test.want {
+			t.Errorf("GetWidth(%q) = %v, expected %v", test.str, got, test.want)
+		}
+	}
+}
+
+func TestAlignedX(t *testing.T) {
+	vf := New(10, 20, 1, color.White)
+	// "999" is 38 wide
+	for _, test := range []struct {
+		align Align
+		want  float32
+	}{
+		{AlignLeft, 100},
+		{AlignCenter, 81},
+		{AlignRight, 62},
+	} {
+		if got := vf.AlignedX("999", 100, test.align); got != test.want {
+			t.Errorf("AlignedX with align %d = %v, expected %v", test.align, got, test.want)
+		}
+	}
+}
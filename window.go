@@ -0,0 +1,69 @@
+//go:build !headless
+
+package main
+
+import (
+	"errors"
+	"os"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/AndreRenaud/SpaceDebris/game"
+)
+
+// runWindow plays the game in a window, in a network game if the flags ask
+// for one, until it is quit
+func runWindow(opts options, cfg game.Config, gameOpts []game.Option) error {
+	window := opts.window(cfg)
+	// The window isn't resizable, so the playfield is the size of the window
+	gameOpts = append(gameOpts,
+		game.WithScreenSize(window.Width, window.Height),
+		game.WithWindowPersistence(),
+		game.WithLiveWindowTitle(),
+		game.WithSpeaker(game.NewAudioSpeaker()),
+	)
+	session, err := opts.connect(cfg, window.Width, window.Height)
+	if err != nil {
+		return err
+	}
+	if session != nil {
+		defer session.Close()
+		gameOpts = append(gameOpts, game.WithNetSession(session))
+		window.Width, window.Height = session.Size()
+	}
+
+	window.Apply()
+	ebiten.SetWindowTitle(game.WindowTitle)
+	// Let the game ask before closing the window in the middle of a run
+	ebiten.SetWindowClosingHandled(true)
+
+	g := game.New(gameOpts...)
+	if opts.events {
+		go printEvents(g.Events(), os.Stdout)
+	}
+	err = ebiten.RunGame(g)
+	// Quitting from the game is a normal exit
+	if errors.Is(err, game.ErrQuit) {
+		return nil
+	}
+	return err
+}
+
+// window returns how to open the window: as it was left last time, unless
+// -size or -fullscreen say otherwise, and fitted onto the current monitor
+func (o options) window(cfg game.Config) game.WindowState {
+	window := game.WindowState{Width: o.size.width, Height: o.size.height}
+	if cfg.Window != nil && cfg.Window.Width > 0 && cfg.Window.Height > 0 {
+		window = *cfg.Window
+		if o.sizeSet {
+			window.Width, window.Height = o.size.width, o.size.height
+		}
+	}
+	if o.fullscreen {
+		window.Fullscreen = true
+	}
+	if m := ebiten.Monitor(); m != nil {
+		window = window.Clamp(m.Size())
+	}
+	return window
+}